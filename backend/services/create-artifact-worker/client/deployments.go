@@ -30,7 +30,11 @@ import (
 )
 
 const (
-	uriInternalUpload = "/api/internal/v1/deployments/tenants/{id}/artifacts"
+	uriInternalUpload      = "/api/internal/v1/deployments/tenants/{id}/artifacts"
+	uriInternalDeltaStatus = "/api/internal/v1/deployments/tenants/{id}/deltas/{delta}/status"
+
+	DeltaStatusReady  = "ready"
+	DeltaStatusFailed = "failed"
 )
 
 var (
@@ -39,6 +43,7 @@ var (
 
 type Deployments interface {
 	UploadArtifactInternal(ctx context.Context, path, aid, tid, desc string) error
+	UpdateDeltaStatusInternal(ctx context.Context, did, tid, status string, size int64) error
 }
 
 type deployments struct {
@@ -135,6 +140,61 @@ func (d *deployments) UploadArtifactInternal(
 	return nil
 }
 
+func (d *deployments) UpdateDeltaStatusInternal(
+	ctx context.Context,
+	did,
+	tid,
+	status string,
+	size int64,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(struct {
+		Status string `json:"status"`
+		Size   int64  `json:"size,omitempty"`
+	}{
+		Status: status,
+		Size:   size,
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot create delta status request")
+	}
+
+	if tid == "" {
+		tid = "default"
+	}
+
+	url, err := join(d.deplUrl, uriInternalDeltaStatus, map[string]string{
+		"id":    tid,
+		"delta": did,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create delta status request")
+	}
+
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := d.c.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status of delta %s", did)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return errors.Wrapf(apiErr(res), "failed to update status of delta %s", did)
+	}
+
+	return nil
+}
+
 func apiErr(r *http.Response) error {
 	e := struct {
 		Reqid string `json:"request_id"`
@@ -155,3 +155,79 @@ func restErr(t *testing.T, msg string) []byte {
 
 	return b
 }
+
+func TestDeploymentsUpdateDeltaStatusInternal(t *testing.T) {
+	t.Parallel()
+
+	tc := map[string]struct {
+		tenantId string
+		status   string
+		size     int64
+
+		uri  string
+		body string
+
+		code   int
+		errmsg string
+		err    error
+	}{
+		"ok": {
+			tenantId: "1",
+			status:   DeltaStatusReady,
+			size:     10,
+
+			uri:  "/api/internal/v1/deployments/tenants/1/deltas/delta/status",
+			body: `{"status":"ready","size":10}`,
+			code: http.StatusNoContent,
+		},
+		"ok, no tenant": {
+			status: DeltaStatusFailed,
+
+			uri:  "/api/internal/v1/deployments/tenants/default/deltas/delta/status",
+			body: `{"status":"failed"}`,
+			code: http.StatusNoContent,
+		},
+		"error": {
+			tenantId: "1",
+			status:   DeltaStatusFailed,
+
+			uri:    "/api/internal/v1/deployments/tenants/1/deltas/delta/status",
+			body:   `{"status":"failed"}`,
+			code:   http.StatusNotFound,
+			errmsg: "not found",
+			err: errors.New("failed to update status of delta delta: " +
+				"http 404, reqid: 1234, msg: not found"),
+		},
+	}
+
+	for name := range tc {
+		tc := tc[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPut, req.Method)
+				assert.Equal(t, tc.uri, req.URL.Path)
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tc.body, string(b))
+
+				rw.WriteHeader(tc.code)
+				if tc.err != nil {
+					_, _ = rw.Write(restErr(t, tc.errmsg))
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewDeployments(server.URL, true)
+			assert.NoError(t, err)
+
+			err = c.UpdateDeltaStatusInternal(context.TODO(), "delta", tc.tenantId, tc.status, tc.size)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
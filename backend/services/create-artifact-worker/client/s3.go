@@ -27,6 +27,7 @@ import (
 type Storage interface {
 	Download(ctx context.Context, url, path string) error
 	Delete(ctx context.Context, url string) error
+	Upload(ctx context.Context, url string, header map[string]string, path string) error
 }
 
 type storage struct {
@@ -131,3 +132,61 @@ func (s *storage) Delete(ctx context.Context, url string) error {
 
 	return nil
 }
+
+func (s *storage) Upload(
+	ctx context.Context,
+	url string,
+	header map[string]string,
+	path string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	stat, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, in)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = stat.Size()
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	req = req.WithContext(ctx)
+
+	res, err := s.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		var body string
+
+		bbody, err := io.ReadAll(res.Body)
+		if err != nil {
+			body = "<failed to read body>"
+		} else {
+			body = string(bbody)
+		}
+
+		return errors.New(fmt.Sprintf(
+			"failed to upload artifact to url %s, http %d, response: \n %s",
+			url,
+			res.StatusCode,
+			body,
+		))
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mendersoftware/mender-server/services/create-artifact-worker/client"
+	"github.com/mendersoftware/mender-server/services/create-artifact-worker/config"
+	mlog "github.com/mendersoftware/mender-server/services/create-artifact-worker/log"
+)
+
+const (
+	argDeltaId           = "delta-id"
+	argSourceArtifactUri = "source-artifact-uri"
+	argTargetArtifactUri = "target-artifact-uri"
+	argPutDeltaUri       = "put-delta-uri"
+	argPutDeltaHeader    = "put-delta-header"
+
+	deltaGeneratorPath = "/usr/bin/mender-binary-delta-generator"
+)
+
+var deltaCmd = &cobra.Command{
+	Use:   "delta",
+	Short: "Generate a binary delta artifact between two rootfs-image artifacts.",
+	Long: "\nBesides command line args, supports the following env vars:\n\n" +
+		"CREATE_ARTIFACT_SKIPVERIFY skip ssl verification (default: false)\n" +
+		"CREATE_ARTIFACT_WORKDIR working dir for processing (default: /var)\n" +
+		"CREATE_ARTIFACT_DEPLOYMENTS_URL internal deployments service url\n",
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := NewDeltaCmd(cmd, args)
+		if err != nil {
+			mlog.Error(err.Error())
+			os.Exit(1)
+		}
+
+		err = c.Run()
+		if err != nil {
+			mlog.Error(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	deltaCmd.Flags().String(argDeltaId, "", "delta artifact id")
+	_ = deltaCmd.MarkFlagRequired(argDeltaId)
+
+	deltaCmd.Flags().String(argTenantId, "", "tenant id")
+	_ = deltaCmd.MarkFlagRequired(argTenantId)
+
+	deltaCmd.Flags().String(
+		argSourceArtifactUri,
+		"",
+		"pre-signed url to the artifact installed on the device (GET)",
+	)
+	_ = deltaCmd.MarkFlagRequired(argSourceArtifactUri)
+
+	deltaCmd.Flags().String(
+		argTargetArtifactUri,
+		"",
+		"pre-signed url to the artifact to update the device to (GET)",
+	)
+	_ = deltaCmd.MarkFlagRequired(argTargetArtifactUri)
+
+	deltaCmd.Flags().String(
+		argPutDeltaUri,
+		"",
+		"pre-signed url to upload the delta artifact to (PUT)",
+	)
+	_ = deltaCmd.MarkFlagRequired(argPutDeltaUri)
+
+	deltaCmd.Flags().String(
+		argPutDeltaHeader,
+		"",
+		"headers required by the upload url in json form: {<NAME>:<VALUE>}",
+	)
+}
+
+type DeltaCmd struct {
+	DeploymentsUrl string
+	SkipVerify     bool
+	Workdir        string
+
+	DeltaId           string
+	TenantId          string
+	SourceArtifactUri string
+	TargetArtifactUri string
+	PutDeltaUri       string
+	PutDeltaHeader    map[string]string
+}
+
+func NewDeltaCmd(cmd *cobra.Command, args []string) (*DeltaCmd, error) {
+	c := &DeltaCmd{}
+
+	if err := c.init(cmd); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *DeltaCmd) init(cmd *cobra.Command) error {
+	c.DeploymentsUrl = viper.GetString(config.CfgDeploymentsUrl)
+	c.SkipVerify = viper.GetBool(config.CfgSkipVerify)
+	c.Workdir = viper.GetString(config.CfgWorkDir)
+
+	var err error
+	for flag, value := range map[string]*string{
+		argDeltaId:           &c.DeltaId,
+		argTenantId:          &c.TenantId,
+		argSourceArtifactUri: &c.SourceArtifactUri,
+		argTargetArtifactUri: &c.TargetArtifactUri,
+		argPutDeltaUri:       &c.PutDeltaUri,
+	} {
+		*value, err = cmd.Flags().GetString(flag)
+		if err != nil {
+			return err
+		}
+	}
+
+	header, err := cmd.Flags().GetString(argPutDeltaHeader)
+	if err != nil {
+		return err
+	}
+	if header != "" {
+		err = json.Unmarshal([]byte(header), &c.PutDeltaHeader)
+		if err != nil {
+			return errors.Wrapf(err, "can't parse '%s'", argPutDeltaHeader)
+		}
+	}
+
+	return nil
+}
+
+func (c *DeltaCmd) Validate() error {
+	if err := config.ValidAbsPath(c.Workdir); err != nil {
+		return errors.Wrap(err, "invalid workdir")
+	}
+
+	// the delta id names the output file
+	if _, err := uuid.Parse(c.DeltaId); err != nil {
+		return errors.Wrap(err, "invalid delta-id")
+	}
+
+	for name, value := range map[string]string{
+		argSourceArtifactUri: c.SourceArtifactUri,
+		argTargetArtifactUri: c.TargetArtifactUri,
+		argPutDeltaUri:       c.PutDeltaUri,
+	} {
+		if err := config.ValidUrl(value); err != nil {
+			return errors.Wrapf(err, "invalid %s", name)
+		}
+	}
+
+	return nil
+}
+
+func (c *DeltaCmd) Run() error {
+	mlog.Info("running delta artifact generation:\n%s", c.dumpArgs())
+	mlog.Info("config:\n%s", config.Dump())
+
+	cd, err := client.NewDeployments(c.DeploymentsUrl, c.SkipVerify)
+	if err != nil {
+		return errors.New("failed to configure 'deployments' client")
+	}
+
+	ctx := context.Background()
+
+	size, err := c.generate(ctx)
+	if err != nil {
+		if e := cd.UpdateDeltaStatusInternal(
+			ctx, c.DeltaId, c.TenantId, client.DeltaStatusFailed, 0,
+		); e != nil {
+			mlog.Error("failed to report delta generation failure: %s", e.Error())
+		}
+		return err
+	}
+
+	mlog.Verbose("reporting delta artifact ready")
+	err = cd.UpdateDeltaStatusInternal(
+		ctx, c.DeltaId, c.TenantId, client.DeltaStatusReady, size,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to report delta artifact ready")
+	}
+
+	return nil
+}
+
+// generate generates and uploads the delta artifact, returning its size
+func (c *DeltaCmd) generate(ctx context.Context) (int64, error) {
+	cs3 := client.NewStorage(c.SkipVerify)
+
+	mlog.Verbose("creating temp dir at", c.Workdir)
+
+	downloadDir, err := os.MkdirTemp(c.Workdir, "delta")
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to create temp dir under workdir %s", c.Workdir)
+	}
+	defer func() {
+		if err := os.RemoveAll(downloadDir); err != nil {
+			mlog.Error("failed to remove temp working dir %s: %v", downloadDir, err.Error())
+		}
+	}()
+
+	sourceFile := filepath.Join(downloadDir, "source.mender")
+	targetFile := filepath.Join(downloadDir, "target.mender")
+	outfile := filepath.Join(downloadDir, c.DeltaId+"-delta.mender")
+
+	mlog.Verbose("downloading source artifact to %s", sourceFile)
+	err = cs3.Download(ctx, c.SourceArtifactUri, sourceFile)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to download source artifact at %s",
+			c.SourceArtifactUri)
+	}
+
+	mlog.Verbose("downloading target artifact to %s", targetFile)
+	err = cs3.Download(ctx, c.TargetArtifactUri, targetFile)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to download target artifact at %s",
+			c.TargetArtifactUri)
+	}
+
+	mlog.Verbose("generating delta artifact %s", outfile)
+
+	cmd := exec.Command(deltaGeneratorPath, "-o", outfile, sourceFile, targetFile)
+
+	std, err := cmd.CombinedOutput()
+	mlog.Info(string(std))
+	if err != nil {
+		return 0, errors.Wrapf(err, "mender-binary-delta-generator exited with error %s", std)
+	}
+
+	stat, err := os.Stat(outfile)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read generated delta artifact")
+	}
+
+	mlog.Verbose("uploading generated delta artifact")
+	err = cs3.Upload(ctx, c.PutDeltaUri, c.PutDeltaHeader, outfile)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload generated delta artifact")
+	}
+
+	return stat.Size(), nil
+}
+
+func (c *DeltaCmd) dumpArgs() string {
+	return dumpArg(argDeltaId, c.DeltaId) +
+		dumpArg(argTenantId, c.TenantId) +
+		dumpArg(argSourceArtifactUri, c.SourceArtifactUri) +
+		dumpArg(argTargetArtifactUri, c.TargetArtifactUri) +
+		dumpArg(argPutDeltaUri, c.PutDeltaUri)
+}
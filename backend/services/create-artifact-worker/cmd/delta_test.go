@@ -0,0 +1,74 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaValidate(t *testing.T) {
+	valid := DeltaCmd{
+		Workdir:           "/var",
+		DeltaId:           "6a1cfdd8-2a0b-4d4f-9b32-2b4a0d5b7c10",
+		SourceArtifactUri: "https://s3.example.com/source",
+		TargetArtifactUri: "https://s3.example.com/target",
+		PutDeltaUri:       "https://s3.example.com/delta",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *DeltaCmd)
+		wantErr string
+	}{
+		{
+			name:   "valid command",
+			modify: func(c *DeltaCmd) {},
+		},
+		{
+			name:    "invalid workdir",
+			modify:  func(c *DeltaCmd) { c.Workdir = "var" },
+			wantErr: "invalid workdir",
+		},
+		{
+			name:    "path traversal in delta id",
+			modify:  func(c *DeltaCmd) { c.DeltaId = "../../etc/shadow" },
+			wantErr: "invalid delta-id",
+		},
+		{
+			name:    "invalid source uri",
+			modify:  func(c *DeltaCmd) { c.SourceArtifactUri = "source" },
+			wantErr: "invalid source-artifact-uri",
+		},
+		{
+			name:    "missing upload uri",
+			modify:  func(c *DeltaCmd) { c.PutDeltaUri = "" },
+			wantErr: "invalid put-delta-uri",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := valid
+			tt.modify(&cmd)
+			err := cmd.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
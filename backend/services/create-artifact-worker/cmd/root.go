@@ -79,6 +79,7 @@ func Execute() {
 func init() {
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(singleFileCmd)
+	rootCmd.AddCommand(deltaCmd)
 	config.Init()
 	mlog.Init(viper.GetBool(config.CfgVerbose))
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deployments/app"
 	dconfig "github.com/mendersoftware/mender-server/services/deployments/config"
 	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/store"
 	"github.com/mendersoftware/mender-server/services/deployments/utils"
 )
@@ -81,6 +82,8 @@ const (
 	ParamSort         = "sort"
 	ParamID           = "id"
 	ParamReleaseKind  = "kind"
+	ParamObjectPath   = "object_path"
+	ParamPartNumber   = "part_number"
 )
 
 const Redacted = "REDACTED"
@@ -150,6 +153,10 @@ type Config struct {
 	// related to releases; helpful in performing long-running maintenance and data
 	// migrations on the artifacts and releases collections.
 	DisableNewReleasesFeature bool
+
+	// ObjectStorage serves the objects behind the signed links when
+	// using the filesystem storage, nil otherwise.
+	ObjectStorage storage.ObjectStorage
 }
 
 func NewConfig() *Config {
@@ -212,6 +219,11 @@ func (conf *Config) SetMaxRequestSize(size int64) *Config {
 	return conf
 }
 
+func (conf *Config) SetObjectStorage(objStore storage.ObjectStorage) *Config {
+	conf.ObjectStorage = objStore
+	return conf
+}
+
 type DeploymentsApiHandlers struct {
 	view   RESTView
 	store  store.DataStore
@@ -254,6 +266,9 @@ func NewDeploymentsApiHandlers(
 		conf.DisableNewReleasesFeature = c.DisableNewReleasesFeature
 		conf.EnableDirectUpload = c.EnableDirectUpload
 		conf.EnableDirectUploadSkipVerify = c.EnableDirectUploadSkipVerify
+		if c.ObjectStorage != nil {
+			conf.ObjectStorage = c.ObjectStorage
+		}
 	}
 	return &DeploymentsApiHandlers{
 		store:  store,
@@ -590,13 +605,94 @@ func (d *DeploymentsApiHandlers) CompleteUpload(c *gin.Context) {
 		}
 	}
 
-	err := d.app.CompleteUpload(ctx, artifactID, d.config.EnableDirectUploadSkipVerify, metadata)
+	err := d.app.CompleteUpload(ctx, artifactID, metadata)
 	switch errors.Cause(err) {
 	case nil:
-		// c.Writer.Header().Set("Link", "FEAT: Upload status API")
+		location := fmt.Sprintf("%s/%s",
+			ApiUrlManagement+ApiUrlManagementArtifactsDirectUpload, artifactID)
+		c.Writer.Header().Add("Location", location)
 		c.Status(http.StatusAccepted)
 	case app.ErrUploadNotFound:
 		d.view.RenderErrorNotFound(c)
+	case app.ErrUploadNoParts, app.ErrUploadPartMissing, app.ErrUploadPartSize:
+		d.view.RenderError(c, err, http.StatusConflict)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+// GetUploadStatus reports the processing status of a direct upload
+func (d *DeploymentsApiHandlers) GetUploadStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	status, err := d.app.GetUploadStatus(ctx, c.Param(ParamID))
+	switch errors.Cause(err) {
+	case nil:
+		d.view.RenderSuccessGet(c, status)
+	case app.ErrUploadNotFound:
+		d.view.RenderErrorNotFound(c)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+// MultipartUploadLink starts a direct upload receiving the artifact in parts
+func (d *DeploymentsApiHandlers) MultipartUploadLink(c *gin.Context) {
+	expireSeconds := config.Config.GetInt(dconfig.SettingsStorageUploadExpireSeconds)
+	link, err := d.app.MultipartUploadLink(
+		c.Request.Context(),
+		time.Duration(expireSeconds)*time.Second,
+		d.config.EnableDirectUploadSkipVerify,
+	)
+	switch errors.Cause(err) {
+	case nil:
+		d.view.RenderSuccessGet(c, &model.MultipartUploadLink{
+			ArtifactID: link.ArtifactID,
+			Expire:     link.Expire,
+		})
+	case app.ErrMultipartNotSupported:
+		d.view.RenderError(c, err, http.StatusNotImplemented)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+// UploadPartLink returns the link uploading a part of a multipart upload
+func (d *DeploymentsApiHandlers) UploadPartLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	partNumber, err := strconv.Atoi(c.Param(ParamPartNumber))
+	if err != nil {
+		d.view.RenderError(c, app.ErrUploadPartNumber, http.StatusBadRequest)
+		return
+	}
+	link, err := d.app.UploadPartLink(ctx, c.Param(ParamID), partNumber)
+	switch errors.Cause(err) {
+	case nil:
+		d.view.RenderSuccessGet(c, link)
+	case app.ErrUploadPartNumber:
+		d.view.RenderError(c, err, http.StatusBadRequest)
+	case app.ErrUploadNotFound:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrUploadNotMultipart:
+		d.view.RenderError(c, err, http.StatusConflict)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+// ListUploadParts lists the parts received for a multipart upload
+func (d *DeploymentsApiHandlers) ListUploadParts(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	parts, err := d.app.ListUploadParts(ctx, c.Param(ParamID))
+	switch errors.Cause(err) {
+	case nil:
+		d.view.RenderSuccessGet(c, parts)
+	case app.ErrUploadNotFound:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrUploadNotMultipart:
+		d.view.RenderError(c, err, http.StatusConflict)
 	default:
 		d.view.RenderInternalError(c, err)
 	}
@@ -623,29 +719,11 @@ func (d *DeploymentsApiHandlers) DownloadConfiguration(c *gin.Context) {
 		err      error
 	)
 	tenantID = q.Get(ParamTenantID)
-	sig := model.NewRequestSignature(c.Request, d.config.PresignSecret)
-	if err = sig.Validate(); err != nil {
-		switch cause := errors.Cause(err); cause {
-		case model.ErrLinkExpired:
-			d.view.RenderError(c, cause, http.StatusForbidden)
-		default:
-			d.view.RenderError(c,
-				errors.Wrap(err, "invalid request parameters"),
-				http.StatusBadRequest,
-			)
-		}
-		return
-	}
-
-	if !sig.VerifyHMAC256() {
-		d.view.RenderError(c,
-			errors.New("signature invalid"),
-			http.StatusForbidden,
-		)
+	// Validate request signature
+	if !d.verifyRequestSignature(c) {
 		return
 	}
 
-	// Validate request signature
 	ctx := identity.WithContext(c.Request.Context(), &identity.Identity{
 		Subject:  deviceID,
 		Tenant:   tenantID,
@@ -687,6 +765,33 @@ func (d *DeploymentsApiHandlers) DownloadConfiguration(c *gin.Context) {
 	}
 }
 
+// verifyRequestSignature validates the signature of a pre-signed request,
+// rendering the error response and returning false if it is not valid.
+func (d *DeploymentsApiHandlers) verifyRequestSignature(c *gin.Context) bool {
+	sig := model.NewRequestSignature(c.Request, d.config.PresignSecret)
+	if err := sig.Validate(); err != nil {
+		switch cause := errors.Cause(err); cause {
+		case model.ErrLinkExpired:
+			d.view.RenderError(c, cause, http.StatusForbidden)
+		default:
+			d.view.RenderError(c,
+				errors.Wrap(err, "invalid request parameters"),
+				http.StatusBadRequest,
+			)
+		}
+		return false
+	}
+
+	if !sig.VerifyHMAC256() {
+		d.view.RenderError(c,
+			errors.New("signature invalid"),
+			http.StatusForbidden,
+		)
+		return false
+	}
+	return true
+}
+
 func (d *DeploymentsApiHandlers) DeleteImage(c *gin.Context) {
 
 	id := c.Param("id")
@@ -830,7 +935,8 @@ func (d *DeploymentsApiHandlers) newImageWithContext(
 	default:
 		d.view.RenderInternalError(c, err)
 		return
-	case app.ErrModelArtifactNotUnique:
+	case app.ErrModelArtifactNotUnique, app.ErrModelArtifactNotSigned,
+		app.ErrModelArtifactSignatureInvalid:
 		d.view.RenderError(c, cause, http.StatusUnprocessableEntity)
 		return
 	case app.ErrModelParsingArtifactFailed:
@@ -1119,7 +1225,7 @@ func (d *DeploymentsApiHandlers) createDeployment(
 		location := fmt.Sprintf("%s/%s", ApiUrlManagement+ApiUrlManagementDeployments, id)
 		c.Writer.Header().Add("Location", location)
 		c.Status(http.StatusCreated)
-	case app.ErrNoArtifact:
+	case app.ErrNoArtifact, app.ErrModelArtifactNotSigned:
 		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
 	case app.ErrNoDevices:
 		d.view.RenderError(c, err, http.StatusBadRequest)
@@ -1146,6 +1252,49 @@ func (d *DeploymentsApiHandlers) DeployToGroup(c *gin.Context) {
 	d.createDeployment(c, ctx, group)
 }
 
+func (d *DeploymentsApiHandlers) previewDeployment(
+	c *gin.Context,
+	ctx context.Context,
+	group string,
+) {
+	constructor, err := d.getDeploymentConstructorFromBody(c, group)
+	if err != nil {
+		d.view.RenderError(
+			c,
+			errors.Wrap(err, "Validating request body"),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	preview, err := d.app.PreviewDeployment(ctx, constructor)
+	switch err {
+	case nil:
+		d.view.RenderSuccessGet(c, preview)
+	case app.ErrNoArtifact:
+		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+func (d *DeploymentsApiHandlers) PreviewDeployment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	d.previewDeployment(c, ctx, "")
+}
+
+func (d *DeploymentsApiHandlers) PreviewGroupDeployment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	group := c.Param("name")
+	if len(group) < 1 {
+		d.view.RenderError(c, ErrMissingGroupName, http.StatusBadRequest)
+		return
+	}
+	d.previewDeployment(c, ctx, group)
+}
+
 // parseDeviceConfigurationDeploymentPathParams parses expected params
 // and check if the params are not empty
 func parseDeviceConfigurationDeploymentPathParams(c *gin.Context) (string, string, string, error) {
@@ -1353,7 +1502,7 @@ func (d *DeploymentsApiHandlers) AbortDeployment(c *gin.Context) {
 
 	// receive request body
 	var status struct {
-		Status model.DeviceDeploymentStatus
+		Status string
 	}
 
 	err := c.ShouldBindJSON(&status)
@@ -1361,13 +1510,19 @@ func (d *DeploymentsApiHandlers) AbortDeployment(c *gin.Context) {
 		d.view.RenderError(c, err, http.StatusBadRequest)
 		return
 	}
-	// "aborted" is the only supported status
-	if status.Status != model.DeviceDeploymentStatusAborted {
+	// "aborted", "paused" and "resumed" are supported for all deployments,
+	// "finished" for the continuous deployments only
+	switch model.DeploymentStatus(status.Status) {
+	case model.DeviceDeploymentStatusAbortedStr,
+		model.DeploymentStatusFinished,
+		model.DeploymentStatusPaused,
+		model.DeploymentStatusResumed:
+	default:
 		d.view.RenderError(c, ErrUnexpectedDeploymentStatus, http.StatusBadRequest)
+		return
 	}
 
 	l := log.FromContext(ctx)
-	l.Infof("Abort deployment: %s", id)
 
 	// Check if deployment is finished
 	isDeploymentFinished, err := d.app.IsDeploymentFinished(ctx, id)
@@ -1380,12 +1535,34 @@ func (d *DeploymentsApiHandlers) AbortDeployment(c *gin.Context) {
 		return
 	}
 
-	// Abort deployments for devices and update deployment stats
-	if err := d.app.AbortDeployment(ctx, id); err != nil {
+	switch model.DeploymentStatus(status.Status) {
+	case model.DeploymentStatusFinished:
+		l.Infof("Finish deployment: %s", id)
+		err = d.app.FinishDeployment(ctx, id)
+	case model.DeploymentStatusPaused:
+		l.Infof("Pause deployment: %s", id)
+		err = d.app.PauseDeployment(ctx, id)
+	case model.DeploymentStatusResumed:
+		l.Infof("Resume deployment: %s", id)
+		err = d.app.ResumeDeployment(ctx, id)
+	default:
+		l.Infof("Abort deployment: %s", id)
+		// Abort deployments for devices and update deployment stats
+		err = d.app.AbortDeployment(ctx, id)
+	}
+	switch err {
+	case nil:
+		d.view.RenderEmptySuccessResponse(c)
+	case app.ErrDeploymentNotContinuous, app.ErrDeploymentFinished:
+		d.view.RenderError(c, err, http.StatusUnprocessableEntity)
+	case app.ErrDeploymentPaused, app.ErrDeploymentNotPaused,
+		app.ErrDeploymentStatusChanged:
+		d.view.RenderError(c, err, http.StatusConflict)
+	case app.ErrModelDeploymentNotFound:
+		d.view.RenderError(c, err, http.StatusNotFound)
+	default:
 		d.view.RenderInternalError(c, err)
 	}
-
-	d.view.RenderEmptySuccessResponse(c)
 }
 
 func (d *DeploymentsApiHandlers) GetDeploymentForDevice(c *gin.Context) {
@@ -1530,7 +1707,8 @@ func (d *DeploymentsApiHandlers) PutDeploymentStatusForDevice(
 			SubState: report.SubState,
 		}); err != nil {
 
-		if err == app.ErrDeploymentAborted || err == app.ErrDeviceDecommissioned {
+		if err == app.ErrDeploymentAborted || err == app.ErrDeviceDecommissioned ||
+			err == app.ErrIllegalStatusTransition {
 			d.view.RenderError(c, err, http.StatusConflict)
 		} else if err == app.ErrStorageNotFound {
 			d.view.RenderErrorNotFound(c)
@@ -1673,6 +1851,8 @@ func ParseLookupQuery(vals url.Values) (model.Query, error) {
 		query.Status = model.StatusQueryPending
 	case "aborted":
 		query.Status = model.StatusQueryAborted
+	case "paused":
+		query.Status = model.StatusQueryPaused
 	case "":
 		query.Status = model.StatusQueryAny
 	default:
@@ -1928,6 +2108,29 @@ func (d *DeploymentsApiHandlers) GetDeploymentLogForDevice(c *gin.Context) {
 	d.view.RenderDeploymentLog(c, *depl)
 }
 
+// DownloadDeploymentLogForDevice downloads the raw text of the device's
+// deployment log.
+func (d *DeploymentsApiHandlers) DownloadDeploymentLogForDevice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	did := c.Param("id")
+	devid := c.Param("devid")
+
+	depl, err := d.app.GetDeviceDeploymentLog(ctx, devid, did)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+
+	if depl == nil {
+		d.view.RenderErrorNotFound(c)
+		return
+	}
+
+	d.view.RenderDeploymentLogDownload(c, *depl,
+		fmt.Sprintf("deployment-%s-%s.log", did, devid))
+}
+
 func (d *DeploymentsApiHandlers) AbortDeviceDeployments(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -2076,6 +2279,37 @@ func (d *DeploymentsApiHandlers) AbortDeviceDeploymentsInternal(c *gin.Context)
 	}
 }
 
+// PutDeltaStatusInternal records the outcome of the generation of a delta
+// artifact, reported by the generation job.
+func (d *DeploymentsApiHandlers) PutDeltaStatusInternal(c *gin.Context) {
+	ctx := c.Request.Context()
+	if tenantID := c.Param("tenant"); tenantID != "default" {
+		ctx = identity.WithContext(ctx, &identity.Identity{
+			Tenant: tenantID,
+		})
+	}
+
+	var update model.DeltaStatusUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err := update.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.UpdateDeltaStatus(ctx, c.Param("id"), update)
+	switch err {
+	case nil:
+		d.view.RenderEmptySuccessResponse(c)
+	case app.ErrDeltaNotFound:
+		d.view.RenderErrorNotFound(c)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
 // tenants
 
 func (d *DeploymentsApiHandlers) ProvisionTenantsHandler(c *gin.Context) {
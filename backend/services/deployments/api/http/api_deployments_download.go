@@ -0,0 +1,79 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) GetDownloadSettings(c *gin.Context) {
+	settings, err := d.app.GetDownloadSettings(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, settings)
+}
+
+func (d *DeploymentsApiHandlers) PutDownloadSettings(c *gin.Context) {
+	var settings model.DownloadSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err := settings.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.SetDownloadSettings(c.Request.Context(), settings)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessPut(c)
+}
+
+// GetDownloadLinkForDevice returns a new download link for the artifact of
+// an ongoing deployment of the device, leaving the deployment status as is.
+func (d *DeploymentsApiHandlers) GetDownloadLinkForDevice(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	idata := identity.FromContext(ctx)
+	if idata == nil {
+		d.view.RenderError(c, ErrMissingIdentity, http.StatusBadRequest)
+		return
+	}
+
+	link, err := d.app.GetDeviceDownloadLink(ctx, idata.Subject, c.Param("id"))
+	switch err {
+	case nil:
+		d.view.RenderSuccessGet(c, link)
+	case app.ErrStorageNotFound, app.ErrNoArtifact:
+		d.view.RenderErrorNotFound(c)
+	case app.ErrDeploymentAborted, app.ErrDeviceDecommissioned,
+		app.ErrDeviceDeploymentFinished:
+		d.view.RenderError(c, err, http.StatusConflict)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+func TestDownloadSettings(t *testing.T) {
+	t.Parallel()
+
+	settingsURL := ApiUrlManagement + ApiUrlManagementDownloadSettings
+
+	testCases := map[string]struct {
+		method string
+		body   string
+		setup  func(appMock *mapp.App)
+
+		statusCode int
+	}{
+		"ok, get settings": {
+			method: http.MethodGet,
+			setup: func(appMock *mapp.App) {
+				appMock.On("GetDownloadSettings", contextMatcher()).
+					Return(&model.DownloadSettings{}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		"error, get settings": {
+			method: http.MethodGet,
+			setup: func(appMock *mapp.App) {
+				appMock.On("GetDownloadSettings", contextMatcher()).
+					Return(nil, errors.New("internal error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		"ok, set settings": {
+			method: http.MethodPut,
+			body:   `{"min_bandwidth":16384,"groups":[{"group":"cellular","min_bandwidth":8192}]}`,
+			setup: func(appMock *mapp.App) {
+				appMock.On("SetDownloadSettings", contextMatcher(),
+					model.DownloadSettings{
+						MinBandwidth: 16384,
+						Groups: []model.GroupDownloadSettings{{
+							Group:        "cellular",
+							MinBandwidth: 8192,
+						}},
+					}).
+					Return(nil)
+			},
+			statusCode: http.StatusNoContent,
+		},
+		"error, set settings malformed": {
+			method:     http.MethodPut,
+			body:       `{"min_bandwidth":"fast"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		"error, set settings invalid": {
+			method:     http.MethodPut,
+			body:       `{"min_bandwidth":-1}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.setup != nil {
+				tc.setup(appMock)
+			}
+			handlers := NewDeploymentsApiHandlers(nil, &view.RESTView{}, appMock)
+			router := setUpTestRouter()
+			router.GET(settingsURL, handlers.GetDownloadSettings)
+			router.PUT(settingsURL, handlers.PutDownloadSettings)
+
+			req, _ := http.NewRequest(tc.method, "http://localhost"+settingsURL,
+				strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestGetDownloadLinkForDevice(t *testing.T) {
+	t.Parallel()
+
+	const (
+		deviceID     = "b1d2d1e4-6e7d-4c1a-8d6e-0d1c1b0e9f3a"
+		deploymentID = "2e5d9f16-4d5b-4f4b-9c2f-8b0a4f6d3e21"
+	)
+	link := &model.Link{
+		Uri:    "https://bucket/artifact",
+		Expire: time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	}
+
+	testCases := map[string]struct {
+		identity *identity.Identity
+		link     *model.Link
+		err      error
+
+		statusCode int
+	}{
+		"ok": {
+			identity:   &identity.Identity{Subject: deviceID, IsDevice: true},
+			link:       link,
+			statusCode: http.StatusOK,
+		},
+		"error, missing identity": {
+			statusCode: http.StatusBadRequest,
+		},
+		"error, not found": {
+			identity:   &identity.Identity{Subject: deviceID, IsDevice: true},
+			err:        app.ErrStorageNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		"error, finished": {
+			identity:   &identity.Identity{Subject: deviceID, IsDevice: true},
+			err:        app.ErrDeviceDeploymentFinished,
+			statusCode: http.StatusConflict,
+		},
+		"error, internal": {
+			identity:   &identity.Identity{Subject: deviceID, IsDevice: true},
+			err:        errors.New("internal error"),
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.identity != nil {
+				appMock.On("GetDeviceDownloadLink", contextMatcher(),
+					deviceID, deploymentID).
+					Return(tc.link, tc.err)
+			}
+			handlers := NewDeploymentsApiHandlers(nil, &view.RESTView{}, appMock)
+			router := setUpTestRouter()
+			router.GET(ApiUrlDevices+ApiUrlDevicesDeploymentLink,
+				handlers.GetDownloadLinkForDevice)
+
+			ctx := context.Background()
+			if tc.identity != nil {
+				ctx = identity.WithContext(ctx, tc.identity)
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet,
+				"http://localhost"+ApiUrlDevices+
+					strings.Replace(ApiUrlDevicesDeploymentLink, ":id", deploymentID, 1),
+				nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+			if tc.link != nil {
+				assert.JSONEq(t,
+					`{"uri":"https://bucket/artifact","expire":"`+
+						tc.link.Expire.Format(time.RFC3339)+`"}`,
+					w.Body.String())
+			}
+		})
+	}
+}
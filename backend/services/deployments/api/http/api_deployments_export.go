@@ -0,0 +1,164 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+
+	contentTypeCSV    = "text/csv"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+var ErrInvalidExportFormat = errors.New(
+	"Invalid export format, supported formats: csv, ndjson",
+)
+
+// reportWriter writes the device deployment reports in the export format
+type reportWriter interface {
+	Write(report *model.DeviceDeploymentReport) error
+	Flush() error
+}
+
+type csvReportWriter struct {
+	*csv.Writer
+}
+
+func (w csvReportWriter) Write(report *model.DeviceDeploymentReport) error {
+	return w.Writer.Write(report.Record())
+}
+
+func (w csvReportWriter) Flush() error {
+	w.Writer.Flush()
+	return w.Writer.Error()
+}
+
+type ndjsonReportWriter struct {
+	*json.Encoder
+}
+
+func (w ndjsonReportWriter) Write(report *model.DeviceDeploymentReport) error {
+	return w.Encode(report)
+}
+
+func (w ndjsonReportWriter) Flush() error {
+	return nil
+}
+
+func newReportWriter(format string, w io.Writer) reportWriter {
+	if format == ExportFormatNDJSON {
+		return ndjsonReportWriter{Encoder: json.NewEncoder(w)}
+	}
+	cw := csv.NewWriter(w)
+	// the header is buffered, write errors are reported on flush
+	_ = cw.Write(model.DeviceDeploymentReportHeader)
+	return csvReportWriter{Writer: cw}
+}
+
+// ExportDevicesForDeployment streams the results of the deployment for all
+// its devices as CSV or NDJSON, straight from the database cursor
+func (d *DeploymentsApiHandlers) ExportDevicesForDeployment(c *gin.Context) {
+	ctx := c.Request.Context()
+	l := log.FromContext(ctx)
+
+	did := c.Param("id")
+	if !govalidator.IsUUID(did) {
+		d.view.RenderError(c, ErrIDNotUUID, http.StatusBadRequest)
+		return
+	}
+
+	format := c.Query("format")
+	contentType := contentTypeCSV
+	switch format {
+	case "", ExportFormatCSV:
+		format = ExportFormatCSV
+	case ExportFormatNDJSON:
+		contentType = contentTypeNDJSON
+	default:
+		d.view.RenderError(c, ErrInvalidExportFormat, http.StatusBadRequest)
+		return
+	}
+
+	lq := store.ListQuery{
+		DeploymentID: did,
+	}
+	if status := c.Query("status"); status != "" {
+		lq.Status = &status
+	}
+	if err := lq.ValidateFilter(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	it, err := d.app.ExportDevicesForDeployment(ctx, lq)
+	switch err {
+	case nil:
+	case app.ErrModelDeploymentNotFound:
+		d.view.RenderError(c, err, http.StatusNotFound)
+		return
+	default:
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	defer it.Close(ctx)
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=\"deployment-%s-devices.%s\"", did, format))
+	c.Status(http.StatusOK)
+
+	// the status is sent already: errors can only be logged from now on,
+	// leaving the client with a truncated report
+	w := newReportWriter(format, c.Writer)
+	for {
+		next, err := it.Next(ctx)
+		if err != nil {
+			l.Errorf("failed to export the devices of deployment %s: %s", did, err)
+			break
+		} else if !next {
+			break
+		}
+		var dd model.DeviceDeployment
+		if err = it.Decode(&dd); err != nil {
+			l.Errorf("failed to export the devices of deployment %s: %s", did, err)
+			break
+		}
+		if err = w.Write(model.NewDeviceDeploymentReport(&dd)); err != nil {
+			l.Errorf("failed to export the devices of deployment %s: %s", did, err)
+			break
+		}
+	}
+	if err := w.Flush(); err != nil {
+		l.Errorf("failed to export the devices of deployment %s: %s", did, err)
+	}
+}
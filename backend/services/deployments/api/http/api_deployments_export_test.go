@@ -0,0 +1,276 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+type sliceIterator[T interface{}] struct {
+	items []T
+	idx   int
+	err   error
+}
+
+func (it *sliceIterator[T]) Next(ctx context.Context) (bool, error) {
+	if it.idx >= len(it.items) {
+		return false, it.err
+	}
+	it.idx++
+	return true, nil
+}
+
+func (it *sliceIterator[T]) Decode(value *T) error {
+	*value = it.items[it.idx-1]
+	return nil
+}
+
+func (it *sliceIterator[T]) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestExportDevicesForDeployment(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "b532b01a-9313-404f-8d19-e7fcbe5cc347"
+
+	created := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	finished := created.Add(time.Hour)
+	devices := []model.DeviceDeployment{{
+		DeviceId: "device-1",
+		Status:   model.DeviceDeploymentStatusSuccess,
+		Created:  &created,
+		Started:  &created,
+		Finished: &finished,
+		Image: &model.Image{
+			Id:           "artifact-id",
+			ArtifactMeta: &model.ArtifactMeta{Name: "artifact"},
+		},
+		Attempts: 2,
+		Retries:  1,
+	}, {
+		DeviceId: "device-2",
+		Status:   model.DeviceDeploymentStatusPending,
+		SubState: "waiting",
+		Created:  &created,
+	}}
+
+	testCases := map[string]struct {
+		query string
+
+		status  *string
+		callApp bool
+		iterErr error
+		appErr  error
+
+		code        int
+		contentType string
+		body        string
+		err         error
+	}{
+		"ok, csv": {
+			callApp:     true,
+			code:        http.StatusOK,
+			contentType: contentTypeCSV,
+			body: "device_id,status,substate,created,started,finished," +
+				"artifact_id,artifact_name,attempts,retries\n" +
+				"device-1,success,,2026-10-14T12:00:00Z,2026-10-14T12:00:00Z," +
+				"2026-10-14T13:00:00Z,artifact-id,artifact,2,1\n" +
+				"device-2,pending,waiting,2026-10-14T12:00:00Z,,,,,0,0\n",
+		},
+		"ok, ndjson, status filter": {
+			query:       "?format=ndjson&status=finished",
+			status:      &[]string{"finished"}[0],
+			callApp:     true,
+			code:        http.StatusOK,
+			contentType: contentTypeNDJSON,
+			body: `{"device_id":"device-1","status":"success",` +
+				`"created":"2026-10-14T12:00:00Z","started":"2026-10-14T12:00:00Z",` +
+				`"finished":"2026-10-14T13:00:00Z","artifact_id":"artifact-id",` +
+				`"artifact_name":"artifact","attempts":2,"retries":1}` + "\n" +
+				`{"device_id":"device-2","status":"pending","substate":"waiting",` +
+				`"created":"2026-10-14T12:00:00Z","attempts":0,"retries":0}` + "\n",
+		},
+		"ok, truncated on iterator error": {
+			query:       "?format=csv",
+			callApp:     true,
+			iterErr:     errors.New("cursor error"),
+			code:        http.StatusOK,
+			contentType: contentTypeCSV,
+			body: "device_id,status,substate,created,started,finished," +
+				"artifact_id,artifact_name,attempts,retries\n" +
+				"device-1,success,,2026-10-14T12:00:00Z,2026-10-14T12:00:00Z," +
+				"2026-10-14T13:00:00Z,artifact-id,artifact,2,1\n" +
+				"device-2,pending,waiting,2026-10-14T12:00:00Z,,,,,0,0\n",
+		},
+		"error, invalid format": {
+			query: "?format=xml",
+			code:  http.StatusBadRequest,
+			err:   ErrInvalidExportFormat,
+		},
+		"error, invalid status": {
+			query: "?status=dummy",
+			code:  http.StatusBadRequest,
+			err:   errors.New("status: must be a valid value"),
+		},
+		"error, deployment not found": {
+			callApp: true,
+			appErr:  app.ErrModelDeploymentNotFound,
+			code:    http.StatusNotFound,
+			err:     app.ErrModelDeploymentNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.callApp {
+				var it store.Iterator[model.DeviceDeployment]
+				if tc.appErr == nil {
+					it = &sliceIterator[model.DeviceDeployment]{
+						items: devices,
+						err:   tc.iterErr,
+					}
+				}
+				appMock.On("ExportDevicesForDeployment",
+					mock.AnythingOfType("*context.valueCtx"),
+					store.ListQuery{
+						DeploymentID: deploymentID,
+						Status:       tc.status,
+					}).
+					Return(it, tc.appErr)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementDeploymentsDevicesExport, d.ExportDevicesForDeployment)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementDeploymentsDevicesExport, ":id", deploymentID, 1) +
+					tc.query,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			if tc.err != nil {
+				assert.JSONEq(t,
+					`{"error":"`+tc.err.Error()+`","request_id":"test"}`,
+					recorded.Recorder.Body.String())
+			} else {
+				assert.Equal(t, tc.contentType, recorded.Recorder.Header().Get("Content-Type"))
+				assert.Equal(t, tc.body, recorded.Recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestDownloadDeploymentLogForDevice(t *testing.T) {
+	t.Parallel()
+
+	const (
+		deploymentID = "b532b01a-9313-404f-8d19-e7fcbe5cc347"
+		deviceID     = "device-1"
+	)
+	now := time.Now()
+
+	testCases := map[string]struct {
+		dlog   *model.DeploymentLog
+		appErr error
+
+		code int
+		body string
+		err  error
+	}{
+		"ok": {
+			dlog: &model.DeploymentLog{
+				Messages: []model.LogMessage{
+					{Timestamp: &now, Level: "info", Message: "foo"},
+					{Timestamp: &now, Level: "info", Message: "bar\n"},
+				},
+			},
+			code: http.StatusOK,
+			body: "foo\nbar\n",
+		},
+		"error, not found": {
+			code: http.StatusNotFound,
+			err:  view.ErrNotFound,
+		},
+		"error, internal": {
+			appErr: errors.New("internal error"),
+			code:   http.StatusInternalServerError,
+			err:    errors.New("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			appMock.On("GetDeviceDeploymentLog",
+				mock.AnythingOfType("*context.valueCtx"),
+				deviceID, deploymentID).
+				Return(tc.dlog, tc.appErr)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementDeploymentsLogDownload, d.DownloadDeploymentLogForDevice)
+
+			path := strings.NewReplacer(":id", deploymentID, ":devid", deviceID).
+				Replace(ApiUrlManagementDeploymentsLogDownload)
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "GET",
+				Path:   "http://localhost" + path,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			if tc.err != nil {
+				assert.JSONEq(t,
+					`{"error":"`+tc.err.Error()+`","request_id":"test"}`,
+					recorded.Recorder.Body.String())
+			} else {
+				assert.Equal(t, "text/plain", recorded.Recorder.Header().Get("Content-Type"))
+				assert.Equal(t,
+					`attachment; filename="deployment-`+deploymentID+`-`+deviceID+`.log"`,
+					recorded.Recorder.Header().Get("Content-Disposition"))
+				assert.Equal(t, tc.body, recorded.Recorder.Body.String())
+			}
+		})
+	}
+}
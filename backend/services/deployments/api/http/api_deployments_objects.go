@@ -0,0 +1,108 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
+)
+
+// The following handlers serve the signed links generated by the filesystem
+// object storage; the request signature is the only authorization.
+
+func (d *DeploymentsApiHandlers) DownloadObject(c *gin.Context) {
+	if !d.verifyRequestSignature(c) {
+		return
+	}
+	objectPath := strings.TrimPrefix(c.Param(ParamObjectPath), "/")
+	obj, err := d.config.ObjectStorage.GetObject(c.Request.Context(), objectPath)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		d.view.RenderErrorNotFound(c)
+		return
+	} else if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	defer obj.Close()
+
+	// serve the object in ranges so that interrupted downloads resume
+	content, ok := obj.(io.ReadSeeker)
+	if !ok {
+		d.view.RenderInternalError(c, errors.New("object storage is not seekable"))
+		return
+	}
+	var modTime time.Time
+	if r, ok := obj.(interface{ ModTime() time.Time }); ok {
+		modTime = r.ModTime()
+	}
+	// the filename is part of the request signature
+	filename := c.Query(filesystem.ParamFilename)
+	if filename != "" {
+		c.Header("Content-Disposition", mime.FormatMediaType(
+			"attachment", map[string]string{"filename": filename},
+		))
+	}
+	c.Header("Content-Type", app.ArtifactContentType)
+	http.ServeContent(c.Writer, c.Request, filename, modTime, content)
+}
+
+func (d *DeploymentsApiHandlers) UploadObject(c *gin.Context) {
+	if !d.verifyRequestSignature(c) {
+		return
+	}
+	objectPath := strings.TrimPrefix(c.Param(ParamObjectPath), "/")
+	err := d.config.ObjectStorage.PutObject(
+		c.Request.Context(), objectPath, c.Request.Body,
+	)
+	if err != nil {
+		var errTooLarge *http.MaxBytesError
+		if errors.As(err, &errTooLarge) {
+			d.view.RenderError(c,
+				ErrModelArtifactFileTooLarge,
+				http.StatusRequestEntityTooLarge,
+			)
+		} else {
+			d.view.RenderInternalError(c, err)
+		}
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+func (d *DeploymentsApiHandlers) DeleteObject(c *gin.Context) {
+	if !d.verifyRequestSignature(c) {
+		return
+	}
+	objectPath := strings.TrimPrefix(c.Param(ParamObjectPath), "/")
+	err := d.config.ObjectStorage.DeleteObject(c.Request.Context(), objectPath)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		d.view.RenderErrorNotFound(c)
+		return
+	} else if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessDelete(c)
+}
@@ -0,0 +1,283 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
+	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+type testObjectReader struct {
+	io.ReadSeeker
+	length int64
+}
+
+func (r testObjectReader) Length() int64 {
+	return r.length
+}
+
+func (r testObjectReader) Close() error {
+	return nil
+}
+
+func newObjectRequest(
+	method, objectPath, filename string,
+	expire time.Time,
+	body io.Reader,
+) *http.Request {
+	req, _ := http.NewRequest(
+		method,
+		"http://localhost"+ApiUrlDevices+ApiUrlDevicesDownloadObjects+"/"+objectPath,
+		body,
+	)
+	if filename != "" {
+		q := req.URL.Query()
+		q.Set(filesystem.ParamFilename, filename)
+		req.URL.RawQuery = q.Encode()
+	}
+	sig := model.NewRequestSignature(req, []byte("test"))
+	sig.SetExpire(expire)
+	sig.PresignURL()
+	return req
+}
+
+func TestObjects(t *testing.T) {
+	t.Parallel()
+
+	const content = "*Just imagine an artifact here*"
+	validUntil := time.Now().Add(time.Minute)
+
+	testCases := map[string]struct {
+		request *http.Request
+		setup   func(objStore *fs_mocks.ObjectStorage)
+
+		statusCode int
+		body       string
+		headers    map[string]string
+	}{
+		"ok, download": {
+			request: newObjectRequest(http.MethodGet,
+				"tenant/artifact", "artifact.mender", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("GetObject", contextMatcher(), "tenant/artifact").
+					Return(testObjectReader{
+						ReadSeeker: strings.NewReader(content),
+						length:     int64(len(content)),
+					}, nil)
+			},
+			statusCode: http.StatusOK,
+			body:       content,
+			headers: map[string]string{
+				"Content-Disposition": `attachment; filename=artifact.mender`,
+				"Content-Type":        app.ArtifactContentType,
+				"Content-Length":      "31",
+				"Accept-Ranges":       "bytes",
+			},
+		},
+		"ok, download range": {
+			request: func() *http.Request {
+				req := newObjectRequest(http.MethodGet,
+					"tenant/artifact", "artifact.mender", validUntil, nil)
+				req.Header.Set("Range", "bytes=6-")
+				return req
+			}(),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("GetObject", contextMatcher(), "tenant/artifact").
+					Return(testObjectReader{
+						ReadSeeker: strings.NewReader(content),
+						length:     int64(len(content)),
+					}, nil)
+			},
+			statusCode: http.StatusPartialContent,
+			body:       content[6:],
+			headers: map[string]string{
+				"Content-Type":   app.ArtifactContentType,
+				"Content-Length": "25",
+				"Content-Range":  "bytes 6-30/31",
+			},
+		},
+		"error, download filename not signed": {
+			request: func() *http.Request {
+				req := newObjectRequest(http.MethodGet,
+					"tenant/artifact", "", validUntil, nil)
+				q := req.URL.Query()
+				q.Set(filesystem.ParamFilename, "evil.exe")
+				req.URL.RawQuery = q.Encode()
+				return req
+			}(),
+			statusCode: http.StatusForbidden,
+		},
+		"error, download not seekable": {
+			request: newObjectRequest(http.MethodGet,
+				"tenant/artifact", "", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("GetObject", contextMatcher(), "tenant/artifact").
+					Return(io.NopCloser(strings.NewReader(content)), nil)
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		"error, download not found": {
+			request: newObjectRequest(http.MethodGet,
+				"tenant/artifact", "", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("GetObject", contextMatcher(), "tenant/artifact").
+					Return(nil, errors.WithMessage(storage.ErrObjectNotFound, "filesystem"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+		"error, download internal error": {
+			request: newObjectRequest(http.MethodGet,
+				"tenant/artifact", "", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("GetObject", contextMatcher(), "tenant/artifact").
+					Return(nil, errors.New("internal error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		"error, download link expired": {
+			request: newObjectRequest(http.MethodGet,
+				"tenant/artifact", "", time.Now().Add(-time.Minute), nil),
+			statusCode: http.StatusForbidden,
+		},
+		"error, download not signed": {
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet,
+					"http://localhost"+ApiUrlDevices+
+						ApiUrlDevicesDownloadObjects+"/tenant/artifact",
+					nil)
+				return req
+			}(),
+			statusCode: http.StatusBadRequest,
+		},
+		"error, signed for another object": {
+			request: func() *http.Request {
+				req := newObjectRequest(http.MethodGet,
+					"tenant/artifact", "", validUntil, nil)
+				req.URL.Path = ApiUrlDevices + ApiUrlDevicesDownloadObjects +
+					"/tenant/other"
+				return req
+			}(),
+			statusCode: http.StatusForbidden,
+		},
+		"ok, upload": {
+			request: newObjectRequest(http.MethodPut,
+				"tenant/upload", "", validUntil, strings.NewReader(content)),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("PutObject", contextMatcher(), "tenant/upload",
+					mock.MatchedBy(func(r io.Reader) bool {
+						b, _ := io.ReadAll(r)
+						return string(b) == content
+					})).
+					Return(nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		"error, upload signed for download": {
+			request: func() *http.Request {
+				req := newObjectRequest(http.MethodGet,
+					"tenant/upload", "", validUntil, strings.NewReader(content))
+				req.Method = http.MethodPut
+				return req
+			}(),
+			statusCode: http.StatusForbidden,
+		},
+		"error, upload internal error": {
+			request: newObjectRequest(http.MethodPut,
+				"tenant/upload", "", validUntil, strings.NewReader(content)),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("PutObject", contextMatcher(), "tenant/upload",
+					mock.Anything).
+					Return(errors.New("internal error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		"ok, delete": {
+			request: newObjectRequest(http.MethodDelete,
+				"tenant/artifact", "", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("DeleteObject", contextMatcher(), "tenant/artifact").
+					Return(nil)
+			},
+			statusCode: http.StatusNoContent,
+		},
+		"error, delete not found": {
+			request: newObjectRequest(http.MethodDelete,
+				"tenant/artifact", "", validUntil, nil),
+			setup: func(objStore *fs_mocks.ObjectStorage) {
+				objStore.On("DeleteObject", contextMatcher(), "tenant/artifact").
+					Return(storage.ErrObjectNotFound)
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			objStore := fs_mocks.NewObjectStorage(t)
+			if tc.setup != nil {
+				tc.setup(objStore)
+			}
+			config := NewConfig().
+				SetPresignSecret([]byte("test")).
+				SetObjectStorage(objStore)
+			handlers := NewDeploymentsApiHandlers(nil, &view.RESTView{}, nil, config)
+			router := setUpTestRouter()
+			NewObjectsResourceRoutes(router.Group("."), handlers, config)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.request)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+			if tc.body != "" {
+				assert.Equal(t, tc.body, w.Body.String())
+			}
+			for key, value := range tc.headers {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestObjectsNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	handlers := NewDeploymentsApiHandlers(nil, &view.RESTView{}, nil, NewConfig())
+	router := setUpTestRouter()
+	NewObjectsResourceRoutes(router.Group("."), handlers, NewConfig())
+
+	req := newObjectRequest(http.MethodGet,
+		"tenant/artifact", "", time.Now().Add(time.Minute), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -360,7 +361,7 @@ func TestCompleteUpload(t *testing.T) {
 		ID: sampleID,
 		App: func(t *testing.T) *mapp.App {
 			app := new(mapp.App)
-			app.On("CompleteUpload", contextMatcher(), sampleID, false, mock.AnythingOfType("*model.DirectUploadMetadata")).
+			app.On("CompleteUpload", contextMatcher(), sampleID, mock.AnythingOfType("*model.DirectUploadMetadata")).
 				Return(nil)
 			return app
 		},
@@ -375,7 +376,7 @@ func TestCompleteUpload(t *testing.T) {
 		ID: sampleID,
 		App: func(t *testing.T) *mapp.App {
 			app := new(mapp.App)
-			app.On("CompleteUpload", contextMatcher(), sampleID, false, mock.AnythingOfType("*model.DirectUploadMetadata")).
+			app.On("CompleteUpload", contextMatcher(), sampleID, mock.AnythingOfType("*model.DirectUploadMetadata")).
 				Return(errors.New("internal error"))
 
 			return app
@@ -395,7 +396,7 @@ func TestCompleteUpload(t *testing.T) {
 		ID: sampleID,
 		App: func(t *testing.T) *mapp.App {
 			mockApp := new(mapp.App)
-			mockApp.On("CompleteUpload", contextMatcher(), sampleID, false, mock.AnythingOfType("*model.DirectUploadMetadata")).
+			mockApp.On("CompleteUpload", contextMatcher(), sampleID, mock.AnythingOfType("*model.DirectUploadMetadata")).
 				Return(app.ErrUploadNotFound)
 			return mockApp
 		},
@@ -404,6 +405,44 @@ func TestCompleteUpload(t *testing.T) {
 		BodyAssertionFunc: func(t *testing.T, body string) bool {
 			return true
 		},
+	}, {
+		Name: "error/no parts uploaded",
+
+		ID: sampleID,
+		App: func(t *testing.T) *mapp.App {
+			mockApp := new(mapp.App)
+			mockApp.On("CompleteUpload", contextMatcher(), sampleID, mock.AnythingOfType("*model.DirectUploadMetadata")).
+				Return(app.ErrUploadNoParts)
+			return mockApp
+		},
+
+		StatusCode: http.StatusConflict,
+		BodyAssertionFunc: func(t *testing.T, body string) bool {
+			return assert.Regexp(t,
+				`"error":"no parts have been uploaded"`,
+				string(body),
+				"unexpected error response body",
+			)
+		},
+	}, {
+		Name: "error/missing part",
+
+		ID: sampleID,
+		App: func(t *testing.T) *mapp.App {
+			mockApp := new(mapp.App)
+			mockApp.On("CompleteUpload", contextMatcher(), sampleID, mock.AnythingOfType("*model.DirectUploadMetadata")).
+				Return(errors.Wrap(app.ErrUploadPartMissing, "part 2 is missing"))
+			return mockApp
+		},
+
+		StatusCode: http.StatusConflict,
+		BodyAssertionFunc: func(t *testing.T, body string) bool {
+			return assert.Regexp(t,
+				`"error":"part 2 is missing: the parts must be numbered from 1 without gaps"`,
+				string(body),
+				"unexpected error response body",
+			)
+		},
 	}}
 	pathGen := func(id string) string {
 		return strings.ReplaceAll(
@@ -437,11 +476,295 @@ func TestCompleteUpload(t *testing.T) {
 			apiHandler.ServeHTTP(w, req)
 
 			assert.Equal(t, tc.StatusCode, w.Code, "Unexpected HTTP status code")
+			if tc.StatusCode == http.StatusAccepted {
+				assert.Equal(t,
+					ApiUrlManagement+ApiUrlManagementArtifactsDirectUpload+"/"+tc.ID,
+					w.Header().Get("Location"))
+			}
 			tc.BodyAssertionFunc(t, w.Body.String())
 		})
 	}
 }
 
+func TestGetUploadStatus(t *testing.T) {
+	t.Parallel()
+
+	const sampleID = "a5522c47-3c99-459b-ae6b-6049c744db7f"
+
+	testCases := map[string]struct {
+		status *model.UploadLinkStatus
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			status: &model.UploadLinkStatus{
+				ArtifactID: sampleID,
+				Status:     model.LinkStatusFailed | model.LinkStatusProcessedBit,
+				Retries:    3,
+			},
+			code: http.StatusOK,
+			body: `{"id":"` + sampleID + `","status":"failed","retries":3}`,
+		},
+		"error, not found": {
+			appErr: app.ErrUploadNotFound,
+			code:   http.StatusNotFound,
+			body:   `{"error":"Resource not found","request_id":"test"}`,
+		},
+		"error, internal": {
+			appErr: errors.New("internal error"),
+			code:   http.StatusInternalServerError,
+			body:   `{"error":"internal error","request_id":"test"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			appMock.On("GetUploadStatus",
+				mock.AnythingOfType("*context.valueCtx"), sampleID).
+				Return(tc.status, tc.appErr)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementArtifactsDirectUploadId, d.GetUploadStatus)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodGet,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementArtifactsDirectUploadId, ":id", sampleID, 1),
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			assert.JSONEq(t, tc.body, recorded.Recorder.Body.String())
+		})
+	}
+}
+
+func TestMultipartUploadLink(t *testing.T) {
+	t.Parallel()
+
+	const sampleID = "a5522c47-3c99-459b-ae6b-6049c744db7f"
+	expire := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		link   *model.UploadLink
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			link: &model.UploadLink{
+				ArtifactID: sampleID,
+				Link:       model.Link{Expire: expire},
+				UploadID:   "upload-id",
+			},
+			code: http.StatusOK,
+			body: `{"id":"` + sampleID + `","expire":"2026-01-01T12:00:00Z"}`,
+		},
+		"error, not supported": {
+			appErr: app.ErrMultipartNotSupported,
+			code:   http.StatusNotImplemented,
+			body: `{"error":"the storage does not support multipart uploads",` +
+				`"request_id":"test"}`,
+		},
+		"error, internal": {
+			appErr: errors.New("internal error"),
+			code:   http.StatusInternalServerError,
+			body:   `{"error":"internal error","request_id":"test"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			appMock.On("MultipartUploadLink",
+				mock.AnythingOfType("*context.valueCtx"),
+				mock.AnythingOfType("time.Duration"),
+				false).
+				Return(tc.link, tc.appErr)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementArtifactsMultipartUpload, d.MultipartUploadLink)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost" + ApiUrlManagementArtifactsMultipartUpload,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			assert.JSONEq(t, tc.body, recorded.Recorder.Body.String())
+		})
+	}
+}
+
+func TestUploadPartLink(t *testing.T) {
+	t.Parallel()
+
+	const sampleID = "a5522c47-3c99-459b-ae6b-6049c744db7f"
+	expire := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		partNumber string
+		link       *model.Link
+		appErr     error
+
+		code int
+		body string
+	}{
+		"ok": {
+			partNumber: "2",
+			link: &model.Link{
+				Uri:    "https://storage/part",
+				Method: http.MethodPut,
+				Expire: expire,
+			},
+			code: http.StatusOK,
+			body: `{"uri":"https://storage/part","method":"PUT",` +
+				`"expire":"2026-01-01T12:00:00Z"}`,
+		},
+		"error, part number not a number": {
+			partNumber: "two",
+			code:       http.StatusBadRequest,
+			body: `{"error":"part number must be between 1 and 10000",` +
+				`"request_id":"test"}`,
+		},
+		"error, part number out of range": {
+			partNumber: "0",
+			appErr:     app.ErrUploadPartNumber,
+			code:       http.StatusBadRequest,
+			body: `{"error":"part number must be between 1 and 10000",` +
+				`"request_id":"test"}`,
+		},
+		"error, not found": {
+			partNumber: "2",
+			appErr:     app.ErrUploadNotFound,
+			code:       http.StatusNotFound,
+			body:       `{"error":"Resource not found","request_id":"test"}`,
+		},
+		"error, not multipart": {
+			partNumber: "2",
+			appErr:     app.ErrUploadNotMultipart,
+			code:       http.StatusConflict,
+			body: `{"error":"the upload is not a multipart upload",` +
+				`"request_id":"test"}`,
+		},
+		"error, internal": {
+			partNumber: "2",
+			appErr:     errors.New("internal error"),
+			code:       http.StatusInternalServerError,
+			body:       `{"error":"internal error","request_id":"test"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if partNumber, err := strconv.Atoi(tc.partNumber); err == nil {
+				appMock.On("UploadPartLink",
+					mock.AnythingOfType("*context.valueCtx"), sampleID, partNumber).
+					Return(tc.link, tc.appErr)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementArtifactsUploadPart, d.UploadPartLink)
+
+			path := strings.NewReplacer(
+				":id", sampleID,
+				":part_number", tc.partNumber,
+			).Replace(ApiUrlManagementArtifactsUploadPart)
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodGet,
+				Path:   "http://localhost" + path,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			assert.JSONEq(t, tc.body, recorded.Recorder.Body.String())
+		})
+	}
+}
+
+func TestListUploadParts(t *testing.T) {
+	t.Parallel()
+
+	const sampleID = "a5522c47-3c99-459b-ae6b-6049c744db7f"
+
+	testCases := map[string]struct {
+		parts  []model.UploadPart
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			parts: []model.UploadPart{
+				{Number: 1, Size: 1024, ETag: "etag-1"},
+				{Number: 3, Size: 512},
+			},
+			code: http.StatusOK,
+			body: `[{"number":1,"size":1024,"etag":"etag-1"},{"number":3,"size":512}]`,
+		},
+		"error, not found": {
+			appErr: app.ErrUploadNotFound,
+			code:   http.StatusNotFound,
+			body:   `{"error":"Resource not found","request_id":"test"}`,
+		},
+		"error, not multipart": {
+			appErr: app.ErrUploadNotMultipart,
+			code:   http.StatusConflict,
+			body: `{"error":"the upload is not a multipart upload",` +
+				`"request_id":"test"}`,
+		},
+		"error, internal": {
+			appErr: errors.New("internal error"),
+			code:   http.StatusInternalServerError,
+			body:   `{"error":"internal error","request_id":"test"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			appMock.On("ListUploadParts",
+				mock.AnythingOfType("*context.valueCtx"), sampleID).
+				Return(tc.parts, tc.appErr)
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.GET(ApiUrlManagementArtifactsUploadParts, d.ListUploadParts)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodGet,
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementArtifactsUploadParts, ":id", sampleID, 1),
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			assert.Equal(t, tc.code, recorded.Recorder.Code)
+			assert.JSONEq(t, tc.body, recorded.Recorder.Body.String())
+		})
+	}
+}
+
 func TestPostDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -703,6 +1026,130 @@ func TestPostDeploymentToGroup(t *testing.T) {
 	}
 }
 
+func TestPreviewDeployment(t *testing.T) {
+	t.Parallel()
+
+	preview := &model.DeploymentPreview{
+		TargetDevices:     10,
+		CompatibleDevices: 8,
+		AlreadyInstalled:  2,
+	}
+
+	testCases := []struct {
+		Name       string
+		InputBody  interface{}
+		InputGroup string
+
+		CallApp      bool
+		AppError     error
+		ResponseCode int
+		ResponseBody interface{}
+	}{{
+		Name: "ok",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			AllDevices:   true,
+		},
+		CallApp:      true,
+		ResponseCode: http.StatusOK,
+		ResponseBody: preview,
+	}, {
+		Name: "ok, group",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+		},
+		InputGroup:   "baz",
+		CallApp:      true,
+		ResponseCode: http.StatusOK,
+		ResponseBody: preview,
+	}, {
+		Name: "error: invalid constructor",
+		InputBody: &model.DeploymentConstructor{
+			Name: "foo",
+		},
+		ResponseCode: http.StatusBadRequest,
+		ResponseBody: rest.Error{
+			Err:       "Validating request body: artifact_name: cannot be blank.",
+			RequestID: "test",
+		},
+	}, {
+		Name: "error: no artifact",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			AllDevices:   true,
+		},
+		CallApp:      true,
+		AppError:     app.ErrNoArtifact,
+		ResponseCode: http.StatusUnprocessableEntity,
+		ResponseBody: rest.Error{
+			Err:       app.ErrNoArtifact.Error(),
+			RequestID: "test",
+		},
+	}, {
+		Name: "error: app error",
+		InputBody: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "bar",
+			AllDevices:   true,
+		},
+		CallApp:      true,
+		AppError:     errors.New("some error"),
+		ResponseCode: http.StatusInternalServerError,
+		ResponseBody: rest.Error{
+			Err:       "internal error",
+			RequestID: "test",
+		},
+	}}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.CallApp {
+				constructor := *tc.InputBody.(*model.DeploymentConstructor)
+				constructor.Group = tc.InputGroup
+				result := preview
+				if tc.AppError != nil {
+					result = nil
+				}
+				appMock.On("PreviewDeployment",
+					mock.AnythingOfType("*context.valueCtx"),
+					&constructor,
+				).Return(result, tc.AppError)
+			}
+			d := NewDeploymentsApiHandlers(
+				nil,
+				new(view.RESTView),
+				appMock,
+				NewConfig(),
+			)
+			router := setUpTestRouter()
+			router.POST(ApiUrlManagementDeploymentsPreview, d.PreviewDeployment)
+			router.POST(ApiUrlManagementDeploymentsGroupPreview, d.PreviewGroupDeployment)
+
+			path := ApiUrlManagementDeploymentsPreview
+			if tc.InputGroup != "" {
+				path = strings.Replace(
+					ApiUrlManagementDeploymentsGroupPreview, ":name", tc.InputGroup, 1)
+			}
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "POST",
+				Path:   "http://localhost" + path,
+				Body:   tc.InputBody,
+			})
+
+			recorded := restutil.RunRequest(t, router, req)
+
+			checker := mt.NewJSONResponse(tc.ResponseCode, nil, tc.ResponseBody)
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
+
 func TestControllerPostConfigurationDeployment(t *testing.T) {
 
 	t.Parallel()
@@ -1852,6 +2299,117 @@ func TestPutTenantStorageSettings(t *testing.T) {
 	}
 }
 
+func TestPutDeltaStatusInternal(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tenant string
+		body   interface{}
+		update *model.DeltaStatusUpdate
+		appErr error
+
+		httpStatus int
+	}{
+		"ok": {
+			body: map[string]interface{}{
+				"status": "ready",
+				"size":   123,
+			},
+			update: &model.DeltaStatusUpdate{
+				Status: model.DeltaStatusReady,
+				Size:   123,
+			},
+			httpStatus: http.StatusNoContent,
+		},
+		"ok, default tenant": {
+			tenant: "default",
+			body: map[string]interface{}{
+				"status": "ready",
+				"size":   123,
+			},
+			update: &model.DeltaStatusUpdate{
+				Status: model.DeltaStatusReady,
+				Size:   123,
+			},
+			httpStatus: http.StatusNoContent,
+		},
+		"error, not found": {
+			body: map[string]interface{}{
+				"status": "failed",
+			},
+			update: &model.DeltaStatusUpdate{
+				Status: model.DeltaStatusFailed,
+			},
+			appErr:     app.ErrDeltaNotFound,
+			httpStatus: http.StatusNotFound,
+		},
+		"error, invalid status": {
+			body: map[string]interface{}{
+				"status": "pending",
+			},
+			httpStatus: http.StatusBadRequest,
+		},
+		"error, malformed body": {
+			body:       "foo",
+			httpStatus: http.StatusBadRequest,
+		},
+		"error, internal": {
+			body: map[string]interface{}{
+				"status": "failed",
+			},
+			update: &model.DeltaStatusUpdate{
+				Status: model.DeltaStatusFailed,
+			},
+			appErr:     errors.New("internal error"),
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.tenant == "" {
+				tc.tenant = "tenant"
+			}
+			app := mapp.NewApp(t)
+			if tc.update != nil {
+				app.On("UpdateDeltaStatus",
+					mock.MatchedBy(func(ctx context.Context) bool {
+						id := identity.FromContext(ctx)
+						if tc.tenant == "default" {
+							return id == nil
+						}
+						return id != nil && id.Tenant == tc.tenant
+					}),
+					"delta",
+					*tc.update,
+				).Return(tc.appErr)
+			}
+
+			restView := new(view.RESTView)
+			d := NewDeploymentsApiHandlers(nil, restView, app)
+			router := setUpTestRouter()
+			router.PUT(ApiUrlInternalTenantDeltaStatus, d.PutDeltaStatusInternal)
+
+			url := strings.NewReplacer(
+				":tenant", tc.tenant,
+				":id", "delta",
+			).Replace(ApiUrlInternalTenantDeltaStatus)
+			body, _ := json.Marshal(tc.body)
+			req, _ := http.NewRequest(
+				http.MethodPut,
+				"http://localhost"+url,
+				bytes.NewBuffer(body),
+			)
+
+			recorded := restutil.RunRequest(t, router, req)
+			assert.Equal(t, tc.httpStatus, recorded.Recorder.Code)
+		})
+	}
+}
+
 func TestLookupDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -2706,3 +3264,136 @@ func TestNewConfig(t *testing.T) {
 	conf.SetDisableNewReleasesFeature(true)
 	assert.True(t, conf.DisableNewReleasesFeature)
 }
+
+func TestPutDeploymentStatus(t *testing.T) {
+	t.Parallel()
+
+	const deploymentID = "b532b01a-9313-404f-8d19-e7fcbe5cc347"
+
+	testCases := map[string]struct {
+		body interface{}
+
+		finished bool
+		abort    bool
+		finish   bool
+		pause    bool
+		resume   bool
+		appErr   error
+
+		code int
+		err  error
+	}{
+		"ok, aborted": {
+			body:  map[string]string{"status": "aborted"},
+			abort: true,
+			code:  http.StatusNoContent,
+		},
+		"ok, finished": {
+			body:   map[string]string{"status": "finished"},
+			finish: true,
+			code:   http.StatusNoContent,
+		},
+		"error, finished, not continuous": {
+			body:   map[string]string{"status": "finished"},
+			finish: true,
+			appErr: app.ErrDeploymentNotContinuous,
+			code:   http.StatusUnprocessableEntity,
+			err:    app.ErrDeploymentNotContinuous,
+		},
+		"ok, paused": {
+			body:  map[string]string{"status": "paused"},
+			pause: true,
+			code:  http.StatusNoContent,
+		},
+		"error, paused, already paused": {
+			body:   map[string]string{"status": "paused"},
+			pause:  true,
+			appErr: app.ErrDeploymentPaused,
+			code:   http.StatusConflict,
+			err:    app.ErrDeploymentPaused,
+		},
+		"error, paused, not found": {
+			body:   map[string]string{"status": "paused"},
+			pause:  true,
+			appErr: app.ErrModelDeploymentNotFound,
+			code:   http.StatusNotFound,
+			err:    app.ErrModelDeploymentNotFound,
+		},
+		"ok, resumed": {
+			body:   map[string]string{"status": "resumed"},
+			resume: true,
+			code:   http.StatusNoContent,
+		},
+		"error, resumed, not paused": {
+			body:   map[string]string{"status": "resumed"},
+			resume: true,
+			appErr: app.ErrDeploymentNotPaused,
+			code:   http.StatusConflict,
+			err:    app.ErrDeploymentNotPaused,
+		},
+		"error, already finished": {
+			body:     map[string]string{"status": "finished"},
+			finished: true,
+			code:     http.StatusUnprocessableEntity,
+			err:      ErrDeploymentAlreadyFinished,
+		},
+		"error, unexpected status": {
+			body: map[string]string{"status": "success"},
+			code: http.StatusBadRequest,
+			err:  ErrUnexpectedDeploymentStatus,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.abort || tc.finish || tc.pause || tc.resume || tc.finished {
+				appMock.On("IsDeploymentFinished",
+					mock.AnythingOfType("*context.valueCtx"), deploymentID).
+					Return(tc.finished, nil)
+			}
+			if tc.abort {
+				appMock.On("AbortDeployment",
+					mock.AnythingOfType("*context.valueCtx"), deploymentID).
+					Return(nil)
+			}
+			if tc.finish {
+				appMock.On("FinishDeployment",
+					mock.AnythingOfType("*context.valueCtx"), deploymentID).
+					Return(tc.appErr)
+			}
+			if tc.pause {
+				appMock.On("PauseDeployment",
+					mock.AnythingOfType("*context.valueCtx"), deploymentID).
+					Return(tc.appErr)
+			}
+			if tc.resume {
+				appMock.On("ResumeDeployment",
+					mock.AnythingOfType("*context.valueCtx"), deploymentID).
+					Return(tc.appErr)
+			}
+
+			d := NewDeploymentsApiHandlers(nil, new(view.RESTView), appMock, NewConfig())
+			router := setUpTestRouter()
+			router.PUT(ApiUrlManagementDeploymentsStatus, d.AbortDeployment)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: "PUT",
+				Path: "http://localhost" + strings.Replace(
+					ApiUrlManagementDeploymentsStatus, ":id", deploymentID, 1),
+				Body: tc.body,
+			})
+			recorded := restutil.RunRequest(t, router, req)
+
+			var body interface{}
+			if tc.err != nil {
+				body = rest.Error{Err: tc.err.Error(), RequestID: "test"}
+			}
+			checker := mt.NewJSONResponse(tc.code, nil, body)
+			mt.CheckHTTPResponse(t, checker, recorded)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func (d *DeploymentsApiHandlers) ListVerificationKeys(c *gin.Context) {
+	keys, err := d.app.ListVerificationKeys(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, keys)
+}
+
+func (d *DeploymentsApiHandlers) AddVerificationKey(c *gin.Context) {
+	var key model.NewVerificationKey
+	if err := c.ShouldBindJSON(&key); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+	if err := key.Validate(); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := d.app.AddVerificationKey(c.Request.Context(), key)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessPost(c, id)
+}
+
+func (d *DeploymentsApiHandlers) DeleteVerificationKey(c *gin.Context) {
+	err := d.app.DeleteVerificationKey(c.Request.Context(), c.Param("id"))
+	switch err {
+	case nil:
+		d.view.RenderSuccessDelete(c)
+	case app.ErrVerificationKeyNotFound:
+		d.view.RenderError(c, err, http.StatusNotFound)
+	default:
+		d.view.RenderInternalError(c, err)
+	}
+}
+
+func (d *DeploymentsApiHandlers) GetArtifactVerificationSettings(c *gin.Context) {
+	settings, err := d.app.GetArtifactVerificationSettings(c.Request.Context())
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessGet(c, settings)
+}
+
+func (d *DeploymentsApiHandlers) PutArtifactVerificationSettings(c *gin.Context) {
+	var settings model.ArtifactVerificationSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		d.view.RenderError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	err := d.app.SetArtifactVerificationSettings(c.Request.Context(), settings)
+	if err != nil {
+		d.view.RenderInternalError(c, err)
+		return
+	}
+	d.view.RenderSuccessPut(c)
+}
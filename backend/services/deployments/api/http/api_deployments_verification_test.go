@@ -0,0 +1,181 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/deployments/app"
+	mapp "github.com/mendersoftware/mender-server/services/deployments/app/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/utils/restutil/view"
+)
+
+func TestArtifactVerification(t *testing.T) {
+	t.Parallel()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	newKeyBody := func(name, key string) string {
+		b, _ := json.Marshal(model.NewVerificationKey{Name: name, PublicKey: key})
+		return string(b)
+	}
+
+	keysURL := ApiUrlManagement + ApiUrlManagementVerificationKeys
+	settingsURL := ApiUrlManagement + ApiUrlManagementVerificationSettings
+
+	testCases := map[string]struct {
+		method string
+		path   string
+		body   string
+		setup  func(appMock *mapp.App)
+
+		statusCode int
+		location   string
+	}{
+		"ok, list keys": {
+			method: http.MethodGet,
+			path:   keysURL,
+			setup: func(appMock *mapp.App) {
+				appMock.On("ListVerificationKeys", contextMatcher()).
+					Return([]model.VerificationKey{{Id: "key"}}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		"error, list keys": {
+			method: http.MethodGet,
+			path:   keysURL,
+			setup: func(appMock *mapp.App) {
+				appMock.On("ListVerificationKeys", contextMatcher()).
+					Return(nil, errors.New("internal error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		"ok, add key": {
+			method: http.MethodPost,
+			path:   keysURL,
+			body:   newKeyBody("release", publicKey),
+			setup: func(appMock *mapp.App) {
+				appMock.On("AddVerificationKey", contextMatcher(),
+					model.NewVerificationKey{Name: "release", PublicKey: publicKey}).
+					Return("key", nil)
+			},
+			statusCode: http.StatusCreated,
+			location:   keysURL + "/key",
+		},
+		"error, add invalid key": {
+			method:     http.MethodPost,
+			path:       keysURL,
+			body:       newKeyBody("release", "not a key"),
+			statusCode: http.StatusBadRequest,
+		},
+		"error, add key without name": {
+			method:     http.MethodPost,
+			path:       keysURL,
+			body:       newKeyBody("", publicKey),
+			statusCode: http.StatusBadRequest,
+		},
+		"ok, delete key": {
+			method: http.MethodDelete,
+			path:   keysURL + "/key",
+			setup: func(appMock *mapp.App) {
+				appMock.On("DeleteVerificationKey", contextMatcher(), "key").
+					Return(nil)
+			},
+			statusCode: http.StatusNoContent,
+		},
+		"error, delete key not found": {
+			method: http.MethodDelete,
+			path:   keysURL + "/key",
+			setup: func(appMock *mapp.App) {
+				appMock.On("DeleteVerificationKey", contextMatcher(), "key").
+					Return(app.ErrVerificationKeyNotFound)
+			},
+			statusCode: http.StatusNotFound,
+		},
+		"ok, get settings": {
+			method: http.MethodGet,
+			path:   settingsURL,
+			setup: func(appMock *mapp.App) {
+				appMock.On("GetArtifactVerificationSettings", contextMatcher()).
+					Return(&model.ArtifactVerificationSettings{}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		"ok, set settings": {
+			method: http.MethodPut,
+			path:   settingsURL,
+			body:   `{"require_signature":true}`,
+			setup: func(appMock *mapp.App) {
+				appMock.On("SetArtifactVerificationSettings", contextMatcher(),
+					model.ArtifactVerificationSettings{RequireSignature: true}).
+					Return(nil)
+			},
+			statusCode: http.StatusNoContent,
+		},
+		"error, set settings malformed": {
+			method:     http.MethodPut,
+			path:       settingsURL,
+			body:       `{"require_signature":"yes"}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			appMock := mapp.NewApp(t)
+			if tc.setup != nil {
+				tc.setup(appMock)
+			}
+			handlers := NewDeploymentsApiHandlers(nil, &view.RESTView{}, appMock)
+			router := setUpTestRouter()
+			NewVerificationResourceRoutes(router.Group("."), handlers)
+
+			req, _ := http.NewRequest(tc.method, "http://localhost"+tc.path,
+				strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.statusCode, w.Code)
+			if tc.location != "" {
+				assert.Equal(t, tc.location, w.Header().Get("Location"))
+			}
+		})
+	}
+}
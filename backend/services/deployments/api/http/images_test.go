@@ -167,6 +167,37 @@ func TestPostArtifacts(t *testing.T) {
 			appCreateImageResponse: "24436884-a710-4d20-aec4-82c89fbfe29e",
 			appCreateImageError:    testConflictError,
 		},
+		{
+			requestBodyObject: []h.Part{
+				{
+					FieldName:  "id",
+					FieldValue: "5e2fbcf6a6a7eca56cbc9476",
+				},
+				{
+					FieldName:  "artifact_id",
+					FieldValue: "24436884-a710-4d20-aec4-82c89fbfe29e",
+				},
+				{
+					FieldName:  "description",
+					FieldValue: "description",
+				},
+				{
+					FieldName:  "size",
+					FieldValue: strconv.Itoa(len(imageBody)),
+				},
+				{
+					FieldName:   "artifact",
+					ContentType: "application/octet-stream",
+					ImageData:   imageBody,
+				},
+			},
+			requestContentType:     "multipart/form-data",
+			responseCode:           http.StatusUnprocessableEntity,
+			responseBody:           app.ErrModelArtifactSignatureInvalid.Error(),
+			appCreateImage:         true,
+			appCreateImageResponse: "24436884-a710-4d20-aec4-82c89fbfe29e",
+			appCreateImageError:    app.ErrModelArtifactSignatureInvalid,
+		},
 	}
 
 	store := &store_mocks.DataStore{}
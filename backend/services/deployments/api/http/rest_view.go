@@ -29,6 +29,7 @@ type RESTView interface {
 	RenderEmptySuccessResponse(c *gin.Context)
 	RenderErrorNotFound(c *gin.Context)
 	RenderDeploymentLog(c *gin.Context, dlog model.DeploymentLog)
+	RenderDeploymentLogDownload(c *gin.Context, dlog model.DeploymentLog, filename string)
 	RenderSuccessDelete(c *gin.Context)
 	RenderSuccessPut(c *gin.Context)
 }
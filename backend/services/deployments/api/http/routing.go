@@ -49,27 +49,45 @@ const (
 	ApiUrlManagementArtifactsDirectUpload   = "/artifacts/directupload"
 	ApiUrlManagementArtifactsCompleteUpload = ApiUrlManagementArtifactsDirectUpload +
 		"/:id/complete"
+	ApiUrlManagementArtifactsDirectUploadId = ApiUrlManagementArtifactsDirectUpload +
+		"/:id"
+	ApiUrlManagementArtifactsMultipartUpload = ApiUrlManagementArtifactsDirectUpload +
+		"/multipart"
+	ApiUrlManagementArtifactsUploadParts = ApiUrlManagementArtifactsDirectUpload +
+		"/:id/parts"
+	ApiUrlManagementArtifactsUploadPart = ApiUrlManagementArtifactsDirectUpload +
+		"/:id/parts/:part_number"
 	ApiUrlManagementArtifactsId         = "/artifacts/:id"
 	ApiUrlManagementArtifactsIdDownload = "/artifacts/:id/download"
 
 	ApiUrlManagementDeployments                   = "/deployments"
 	ApiUrlManagementMultipleDeploymentsStatistics = "/deployments/statistics/list"
 	ApiUrlManagementDeploymentsGroup              = "/deployments/group/:name"
+	ApiUrlManagementDeploymentsPreview            = "/deployments/preview"
+	ApiUrlManagementDeploymentsGroupPreview       = "/deployments/group/:name/preview"
 	ApiUrlManagementDeploymentsId                 = "/deployments/:id"
 	ApiUrlManagementDeploymentsStatistics         = "/deployments/:id/statistics"
 	ApiUrlManagementDeploymentsStatus             = "/deployments/:id/status"
 	ApiUrlManagementDeploymentsDevices            = "/deployments/:id/devices"
 	ApiUrlManagementDeploymentsDevicesList        = "/deployments/:id/devices/list"
+	ApiUrlManagementDeploymentsDevicesExport      = "/deployments/:id/devices/export"
 	ApiUrlManagementDeploymentsLog                = "/deployments/:id/devices/:devid/log"
+	ApiUrlManagementDeploymentsLogDownload        = "/deployments/:id/devices/:devid/log/download"
 	ApiUrlManagementDeploymentsDeviceId           = "/deployments/devices/:id"
 	ApiUrlManagementDeploymentsDeviceHistory      = "/deployments/devices/:id/history"
 	ApiUrlManagementDeploymentsDeviceList         = "/deployments/:id/device_list"
 
+	ApiUrlManagementDownloadSettings = "/deployments/settings/download"
+
 	ApiUrlManagementReleases     = "/deployments/releases"
 	ApiUrlManagementReleasesList = "/deployments/releases/list"
 
 	ApiUrlManagementLimitsName = "/limits/:name"
 
+	ApiUrlManagementVerificationKeys     = "/artifacts/verification/keys"
+	ApiUrlManagementVerificationKeysId   = ApiUrlManagementVerificationKeys + "/:id"
+	ApiUrlManagementVerificationSettings = "/artifacts/verification/settings"
+
 	ApiUrlManagementV2                      = "/api/management/v2/deployments"
 	ApiUrlManagementV2Releases              = "/deployments/releases"
 	ApiUrlManagementV2ReleasesName          = ApiUrlManagementV2Releases + "/:name"
@@ -81,8 +99,13 @@ const (
 	ApiUrlDevicesDeploymentsNext  = "/device/deployments/next"
 	ApiUrlDevicesDeploymentStatus = "/device/deployments/:id/status"
 	ApiUrlDevicesDeploymentsLog   = "/device/deployments/:id/log"
+	ApiUrlDevicesDeploymentLink   = "/device/deployments/:id/link"
 	ApiUrlDevicesDownloadConfig   = "/download/configuration" +
 		"/:deployment_id/:device_type/:device_id"
+	// ApiUrlDevicesDownloadObjects is the base path of the signed object
+	// links generated by the filesystem storage.
+	ApiUrlDevicesDownloadObjects   = "/download/objects"
+	ApiUrlDevicesDownloadObjectsId = ApiUrlDevicesDownloadObjects + "/*object_path"
 
 	ApiUrlInternalAlive                          = "/alive"
 	ApiUrlInternalHealth                         = "/health"
@@ -92,6 +115,7 @@ const (
 	ApiUrlInternalTenantDeploymentsDevice        = "/tenants/:tenant/deployments/devices/:id"
 	ApiUrlInternalTenantArtifacts                = "/tenants/:tenant/artifacts"
 	ApiUrlInternalTenantStorageSettings          = "/tenants/:tenant/storage/settings"
+	ApiUrlInternalTenantDeltaStatus              = "/tenants/:tenant/deltas/:id/status"
 	ApiUrlInternalDeviceConfigurationDeployments = "/tenants/:tenant/configuration/deployments" +
 		"/:deployment_id/devices/:device_id"
 	ApiUrlInternalDeviceDeploymentLastStatusDeployments = "/tenants/:tenant/devices/deployments" +
@@ -136,6 +160,7 @@ func NewRouter(
 	withAuth.Use(identity.Middleware())
 
 	NewImagesResourceRoutes(withAuth, deploymentsHandlers, cfg)
+	NewObjectsResourceRoutes(publicAPIs, deploymentsHandlers, cfg)
 
 	// The rest of the public APIs does not need custom request size limits
 	publicAPIs.Use(requestsize.Middleware(cfg.MaxRequestSize))
@@ -143,6 +168,7 @@ func NewRouter(
 
 	NewDeploymentsResourceRoutes(publicAPIs, deploymentsHandlers)
 	NewLimitsResourceRoutes(withAuth, deploymentsHandlers)
+	NewVerificationResourceRoutes(withAuth, deploymentsHandlers)
 	InternalRoutes(internalAPIs, deploymentsHandlers)
 	ReleasesRoutes(withAuth, deploymentsHandlers)
 
@@ -213,9 +239,33 @@ func NewImagesResourceRoutes(router *gin.RouterGroup,
 		mgmtV1.Group(".").Use(contenttype.CheckJSON()).
 			POST(ApiUrlManagementArtifactsDirectUpload,
 				controller.UploadLink).
+			POST(ApiUrlManagementArtifactsMultipartUpload,
+				controller.MultipartUploadLink).
 			POST(ApiUrlManagementArtifactsCompleteUpload,
 				controller.CompleteUpload)
+		mgmtV1.GET(ApiUrlManagementArtifactsDirectUploadId,
+			controller.GetUploadStatus).
+			GET(ApiUrlManagementArtifactsUploadParts,
+				controller.ListUploadParts).
+			GET(ApiUrlManagementArtifactsUploadPart,
+				controller.UploadPartLink)
+	}
+}
+
+// NewObjectsResourceRoutes serves the objects of the filesystem storage
+// behind the signed links, if configured.
+func NewObjectsResourceRoutes(router *gin.RouterGroup,
+	controller *DeploymentsApiHandlers, cfg *Config) {
+
+	if controller == nil || controller.config.ObjectStorage == nil {
+		return
 	}
+	devices := router.Group(ApiUrlDevices)
+
+	devices.GET(ApiUrlDevicesDownloadObjectsId, controller.DownloadObject)
+	devices.PUT(ApiUrlDevicesDownloadObjectsId,
+		requestsize.Middleware(cfg.MaxImageSize), controller.UploadObject)
+	devices.DELETE(ApiUrlDevicesDownloadObjectsId, controller.DeleteObject)
 }
 
 func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
@@ -236,12 +286,17 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 		controller.GetDeviceStatusesForDeployment)
 	mgmtV1.GET(ApiUrlManagementDeploymentsDevicesList,
 		controller.GetDevicesListForDeployment)
+	mgmtV1.GET(ApiUrlManagementDeploymentsDevicesExport,
+		controller.ExportDevicesForDeployment)
 	mgmtV1.GET(ApiUrlManagementDeploymentsLog,
 		controller.GetDeploymentLogForDevice)
+	mgmtV1.GET(ApiUrlManagementDeploymentsLogDownload,
+		controller.DownloadDeploymentLogForDevice)
 	mgmtV1.GET(ApiUrlManagementDeploymentsDeviceId,
 		controller.ListDeviceDeployments)
 	mgmtV1.GET(ApiUrlManagementDeploymentsDeviceList,
 		controller.GetDeploymentDeviceList)
+	mgmtV1.GET(ApiUrlManagementDownloadSettings, controller.GetDownloadSettings)
 
 	mgmtV1.DELETE(ApiUrlManagementDeploymentsDeviceId,
 		controller.AbortDeviceDeployments)
@@ -251,9 +306,13 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
 		POST(ApiUrlManagementDeployments, controller.PostDeployment).
 		POST(ApiUrlManagementDeploymentsGroup, controller.DeployToGroup).
+		POST(ApiUrlManagementDeploymentsPreview, controller.PreviewDeployment).
+		POST(ApiUrlManagementDeploymentsGroupPreview,
+			controller.PreviewGroupDeployment).
 		POST(ApiUrlManagementMultipleDeploymentsStatistics,
 			controller.GetDeploymentsStats).
-		PUT(ApiUrlManagementDeploymentsStatus, controller.AbortDeployment)
+		PUT(ApiUrlManagementDeploymentsStatus, controller.AbortDeployment).
+		PUT(ApiUrlManagementDownloadSettings, controller.PutDownloadSettings)
 
 	// Devices
 	devices := router.Group(ApiUrlDevices)
@@ -264,6 +323,7 @@ func NewDeploymentsResourceRoutes(router *gin.RouterGroup, controller *Deploymen
 	devices.Use(identity.Middleware())
 
 	devices.GET(ApiUrlDevicesDeploymentsNext, controller.GetDeploymentForDevice)
+	devices.GET(ApiUrlDevicesDeploymentLink, controller.GetDownloadLinkForDevice)
 	devices.Group(".").Use(contenttype.CheckJSON()).
 		POST(ApiUrlDevicesDeploymentsNext,
 			controller.GetDeploymentForDevice).
@@ -285,6 +345,23 @@ func NewLimitsResourceRoutes(router *gin.RouterGroup, controller *DeploymentsApi
 
 }
 
+func NewVerificationResourceRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
+
+	if controller == nil {
+		return
+	}
+	mgmtV1 := router.Group(ApiUrlManagement)
+
+	mgmtV1.GET(ApiUrlManagementVerificationKeys, controller.ListVerificationKeys)
+	mgmtV1.GET(ApiUrlManagementVerificationSettings,
+		controller.GetArtifactVerificationSettings)
+	mgmtV1.DELETE(ApiUrlManagementVerificationKeysId, controller.DeleteVerificationKey)
+	mgmtV1.Group(".").Use(contenttype.CheckJSON()).
+		POST(ApiUrlManagementVerificationKeys, controller.AddVerificationKey).
+		PUT(ApiUrlManagementVerificationSettings,
+			controller.PutArtifactVerificationSettings)
+}
+
 func InternalRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers) {
 	if controller == nil {
 		return
@@ -322,6 +399,9 @@ func InternalRoutes(router *gin.RouterGroup, controller *DeploymentsApiHandlers)
 	router.GET(ApiUrlInternalTenantStorageSettings, controller.GetTenantStorageSettingsHandler)
 	router.PUT(ApiUrlInternalTenantStorageSettings, controller.PutTenantStorageSettingsHandler)
 
+	// delta artifacts (internal)
+	router.PUT(ApiUrlInternalTenantDeltaStatus, controller.PutDeltaStatusInternal)
+
 	// Configuration deployments (internal)
 	router.POST(ApiUrlInternalDeviceConfigurationDeployments,
 		controller.PostDeviceConfigurationDeployment)
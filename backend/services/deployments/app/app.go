@@ -17,12 +17,15 @@ package app
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -54,6 +57,7 @@ const (
 	ArtifactConfigureProvidesCleared = "data-partition.mender-configure.*"
 
 	DefaultUpdateDownloadLinkExpire  = 24 * time.Hour
+	MaxUpdateDownloadLinkExpire      = 7 * 24 * time.Hour
 	DefaultImageGenerationLinkExpire = 7 * 24 * time.Hour
 	PerPageInventoryDevices          = 500
 	InventoryGroupScope              = "system"
@@ -62,11 +66,16 @@ const (
 	InventoryGroupAttributeName      = "group"
 	InventoryStatusAttributeName     = "status"
 	InventoryIdAttributeName         = "id"
+	InventoryDeviceTypeAttributeName = "device_type"
+	InventoryArtifactAttributeName   = "artifact_name"
 	InventoryStatusAccepted          = "accepted"
 
 	fileSuffixTmp = ".tmp"
 
 	inprogressIdleTime = time.Hour
+	// maxUploadRetries is the number of times the processing of an upload
+	// is resumed before giving up
+	maxUploadRetries = 3
 )
 
 var (
@@ -86,27 +95,41 @@ var (
 		"Image is used in active deployment and cannot be removed",
 	)
 	ErrModelImageUsedInAnyDeployment = errors.New("Image has already been used in deployment")
+	ErrModelArtifactDuplicate        = errors.New("Artifact with the same checksum already exists")
+	ErrModelArtifactNotSigned        = errors.New("Artifact is not signed")
 	ErrModelParsingArtifactFailed    = errors.New("Cannot parse artifact file")
 	ErrUploadNotFound                = errors.New("artifact object not found")
+	ErrDeltaNotFound                 = errors.New("delta artifact not found")
+	ErrVerificationKeyNotFound       = errors.New("verification key not found")
 	ErrEmptyArtifact                 = errors.New("artifact cannot be nil")
+	ErrModelArtifactSignatureInvalid = errors.New(
+		"Artifact signature cannot be verified with any of the verification keys",
+	)
 
 	ErrMsgArtifactConflict = "An artifact with the same name has conflicting dependencies"
 
 	// deployments
-	ErrModelMissingInput       = errors.New("Missing input deployment data")
-	ErrModelInvalidDeviceID    = errors.New("Invalid device ID")
-	ErrModelDeploymentNotFound = errors.New("Deployment not found")
-	ErrModelInternal           = errors.New("Internal error")
-	ErrStorageInvalidLog       = errors.New("Invalid deployment log")
-	ErrStorageNotFound         = errors.New("Not found")
-	ErrDeploymentAborted       = errors.New("Deployment aborted")
-	ErrDeviceDecommissioned    = errors.New("Device decommissioned")
-	ErrNoArtifact              = errors.New("No artifact for the deployment")
-	ErrNoDevices               = errors.New("No devices for the deployment")
-	ErrDuplicateDeployment     = errors.New("Deployment with given ID already exists")
-	ErrInvalidDeploymentID     = errors.New("Deployment ID must be a valid UUID")
-	ErrConflictingRequestData  = errors.New("Device provided conflicting request data")
-	ErrConflictingDeployment   = errors.New(
+	ErrModelMissingInput        = errors.New("Missing input deployment data")
+	ErrModelInvalidDeviceID     = errors.New("Invalid device ID")
+	ErrModelDeploymentNotFound  = errors.New("Deployment not found")
+	ErrModelInternal            = errors.New("Internal error")
+	ErrStorageInvalidLog        = errors.New("Invalid deployment log")
+	ErrStorageNotFound          = errors.New("Not found")
+	ErrDeploymentAborted        = errors.New("Deployment aborted")
+	ErrDeviceDecommissioned     = errors.New("Device decommissioned")
+	ErrNoArtifact               = errors.New("No artifact for the deployment")
+	ErrNoDevices                = errors.New("No devices for the deployment")
+	ErrDuplicateDeployment      = errors.New("Deployment with given ID already exists")
+	ErrInvalidDeploymentID      = errors.New("Deployment ID must be a valid UUID")
+	ErrConflictingRequestData   = errors.New("Device provided conflicting request data")
+	ErrDeploymentNotContinuous  = errors.New("Only continuous deployments can be finished")
+	ErrDeploymentPaused         = errors.New("Deployment is already paused")
+	ErrDeploymentNotPaused      = errors.New("Deployment is not paused")
+	ErrDeploymentFinished       = errors.New("Deployment already finished")
+	ErrDeploymentStatusChanged  = errors.New("Deployment status changed concurrently")
+	ErrIllegalStatusTransition  = errors.New("Illegal device deployment status transition")
+	ErrDeviceDeploymentFinished = errors.New("Device deployment already finished")
+	ErrConflictingDeployment    = errors.New(
 		"Invalid deployment definition: there is already an active deployment with " +
 			"the same parameters",
 	)
@@ -125,6 +148,22 @@ type App interface {
 	GetStorageSettings(ctx context.Context) (*model.StorageSettings, error)
 	SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error
 
+	// artifact verification
+	ListVerificationKeys(ctx context.Context) ([]model.VerificationKey, error)
+	AddVerificationKey(ctx context.Context, key model.NewVerificationKey) (string, error)
+	DeleteVerificationKey(ctx context.Context, id string) error
+	GetArtifactVerificationSettings(
+		ctx context.Context,
+	) (*model.ArtifactVerificationSettings, error)
+	SetArtifactVerificationSettings(
+		ctx context.Context,
+		settings model.ArtifactVerificationSettings,
+	) error
+
+	// download links
+	GetDownloadSettings(ctx context.Context) (*model.DownloadSettings, error)
+	SetDownloadSettings(ctx context.Context, settings model.DownloadSettings) error
+
 	// images
 	ListImages(
 		ctx context.Context,
@@ -144,11 +183,19 @@ type App interface {
 	CompleteUpload(
 		ctx context.Context,
 		intentID string,
-		skipVerify bool,
 		metadata *model.DirectUploadMetadata,
 	) error
+	GetUploadStatus(ctx context.Context, intentID string) (*model.UploadLinkStatus, error)
+	MultipartUploadLink(
+		ctx context.Context,
+		expire time.Duration,
+		skipVerify bool,
+	) (*model.UploadLink, error)
+	UploadPartLink(ctx context.Context, intentID string, partNumber int) (*model.Link, error)
+	ListUploadParts(ctx context.Context, intentID string) ([]model.UploadPart, error)
 	GetImage(ctx context.Context, id string) (*model.Image, error)
 	DeleteImage(ctx context.Context, imageID string) error
+	UpdateDeltaStatus(ctx context.Context, id string, update model.DeltaStatusUpdate) error
 	CreateImage(ctx context.Context,
 		multipartUploadMsg *model.MultipartUploadMsg) (string, error)
 	GenerateImage(ctx context.Context,
@@ -164,9 +211,14 @@ type App interface {
 	// deployments
 	CreateDeployment(ctx context.Context,
 		constructor *model.DeploymentConstructor) (string, error)
+	PreviewDeployment(ctx context.Context,
+		constructor *model.DeploymentConstructor) (*model.DeploymentPreview, error)
 	GetDeployment(ctx context.Context, deploymentID string) (*model.Deployment, error)
 	IsDeploymentFinished(ctx context.Context, deploymentID string) (bool, error)
 	AbortDeployment(ctx context.Context, deploymentID string) error
+	FinishDeployment(ctx context.Context, deploymentID string) error
+	PauseDeployment(ctx context.Context, deploymentID string) error
+	ResumeDeployment(ctx context.Context, deploymentID string) error
 	GetDeploymentStats(ctx context.Context, deploymentID string) (model.Stats, error)
 	GetDeploymentsStats(ctx context.Context,
 		deploymentIDs ...string) ([]*model.DeploymentStats, error)
@@ -176,10 +228,14 @@ type App interface {
 		deviceID string) (bool, error)
 	UpdateDeviceDeploymentStatus(ctx context.Context, deploymentID string,
 		deviceID string, state model.DeviceDeploymentState) error
+	GetDeviceDownloadLink(ctx context.Context, deviceID string,
+		deploymentID string) (*model.Link, error)
 	GetDeviceStatusesForDeployment(ctx context.Context,
 		deploymentID string) ([]model.DeviceDeployment, error)
 	GetDevicesListForDeployment(ctx context.Context,
 		query store.ListQuery) ([]model.DeviceDeployment, int, error)
+	ExportDevicesForDeployment(ctx context.Context,
+		query store.ListQuery) (store.Iterator[model.DeviceDeployment], error)
 	GetDeviceDeploymentListForDevice(ctx context.Context,
 		query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentListItem, int, error)
 	LookupDeployment(ctx context.Context,
@@ -225,6 +281,14 @@ type Deployments struct {
 	workflowsClient   openapi.WorkflowsOtherAPI
 	inventoryClient   openapi.DeviceInventoryInternalAPIAPI
 	inventoryV2Client openapi.DeviceInventoryFiltersAndSearchInternalAPIAPI
+
+	enableDeltaGeneration bool
+
+	deploymentLogMaxSize   int
+	deploymentLogRetention time.Duration
+
+	continuousMatchInterval time.Duration
+	continuousMisses        continuousMisses
 }
 
 // Compile-time check
@@ -251,6 +315,31 @@ func (d *Deployments) SetInventoryClient(apiClient *openapi.APIClient) {
 	d.inventoryV2Client = apiClient.DeviceInventoryFiltersAndSearchInternalAPIAPI
 }
 
+// SetEnableDeltaGeneration enables serving binary delta artifacts to the
+// devices running a rootfs image known to the service.
+func (d *Deployments) SetEnableDeltaGeneration(enable bool) {
+	d.enableDeltaGeneration = enable
+}
+
+// SetDeploymentLogMaxSize sets the default maximum size of the deployment
+// logs, which the tenants can override with the deployment_log_size limit.
+func (d *Deployments) SetDeploymentLogMaxSize(size int) {
+	d.deploymentLogMaxSize = size
+}
+
+// SetDeploymentLogRetention sets the time after which the deployment logs
+// are removed; zero keeps them forever.
+func (d *Deployments) SetDeploymentLogRetention(retention time.Duration) {
+	d.deploymentLogRetention = retention
+}
+
+// SetContinuousMatchInterval sets the time the devices which don't match
+// the filter of a continuous deployment wait before they are matched against
+// it again; zero matches them on every poll.
+func (d *Deployments) SetContinuousMatchInterval(interval time.Duration) {
+	d.continuousMatchInterval = interval
+}
+
 func (d *Deployments) HealthCheck(ctx context.Context) error {
 	err := d.db.Ping(ctx)
 	if err != nil {
@@ -374,18 +463,23 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 	metadata *model.DirectUploadMetadata,
 ) (string, error) {
 
-	l := log.FromContext(ctx)
 	ctx, err := d.contextWithStorageSettings(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	verifier, err := d.newArtifactVerifier(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	// create pipe
 	pR, pW := io.Pipe()
 
 	artifactReader := utils.CountReads(multipartUploadMsg.ArtifactReader)
+	checksum := sha256.New()
 
-	tee := io.TeeReader(artifactReader, pW)
+	tee := io.TeeReader(artifactReader, io.MultiWriter(pW, checksum))
 
 	uid, err := uuid.Parse(multipartUploadMsg.ArtifactID)
 	if err != nil {
@@ -419,10 +513,18 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 
 	// parse artifact
 	// artifact library reads all the data from the given reader
-	metaArtifactConstructor, err := getMetaFromArchive(&tee, skipVerify)
+	metaArtifactConstructor, err := getMetaFromArchive(&tee, skipVerify, verifier)
+	if err == nil && verifier.required && !metaArtifactConstructor.Signed {
+		err = ErrModelArtifactNotSigned
+	}
 	if err != nil {
 		_ = pW.CloseWithError(err)
 		<-ch
+		if err == ErrModelArtifactNotSigned {
+			return artifactID, err
+		} else if errors.Is(err, ErrModelArtifactSignatureInvalid) {
+			return artifactID, ErrModelArtifactSignatureInvalid
+		}
 		return artifactID, errors.Wrap(ErrModelParsingArtifactFailed, err.Error())
 	}
 	validMetadata := false
@@ -440,16 +542,15 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 		return artifactID, ErrModelInvalidMetadata
 	}
 
-	if !skipVerify {
-		// read the rest of the data,
-		// just in case the artifact library did not read all the data from the reader
-		_, err = io.Copy(io.Discard, tee)
-		if err != nil {
-			// CloseWithError will cause the reading end to abort upload.
-			_ = pW.CloseWithError(err)
-			<-ch
-			return artifactID, err
-		}
+	// read the rest of the data,
+	// just in case the artifact library did not read all the data from the reader;
+	// the whole artifact has to go through the checksum
+	_, err = io.Copy(io.Discard, tee)
+	if err != nil {
+		// CloseWithError will cause the reading end to abort upload.
+		_ = pW.CloseWithError(err)
+		<-ch
+		return artifactID, err
 	}
 
 	// close the pipe
@@ -471,18 +572,22 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 		size,
 	)
 
+	image.Checksum = hex.EncodeToString(checksum.Sum(nil))
+	if err = d.checkDuplicateImage(ctx, image.Checksum); err != nil {
+		d.cleanupImageObject(ctx, artifactID)
+		return artifactID, err
+	}
+
 	// save image structure in the system
 	if err = d.db.InsertImage(ctx, image); err != nil {
-		// Try to remove the storage from s3.
-		if errDelete := d.objectStorage.DeleteObject(
-			ctx, model.ImagePathFromContext(ctx, artifactID),
-		); errDelete != nil {
-			l.Errorf(
-				"failed to clean up artifact storage after failure: %s",
-				errDelete,
-			)
-		}
-		if idxErr, ok := err.(*model.ConflictError); ok {
+		d.cleanupImageObject(ctx, artifactID)
+		if err == mongo.ErrConflictingChecksum {
+			// a concurrent upload of the same artifact won the race
+			if err = d.checkDuplicateImage(ctx, image.Checksum); err == nil {
+				err = model.NewConflictError(ErrModelArtifactDuplicate)
+			}
+			return artifactID, err
+		} else if idxErr, ok := err.(*model.ConflictError); ok {
 			return artifactID, idxErr
 		}
 		return artifactID, errors.Wrap(err, "Fail to store the metadata")
@@ -501,6 +606,33 @@ func (d *Deployments) handleArtifact(ctx context.Context,
 	return artifactID, nil
 }
 
+// checkDuplicateImage returns a conflict error referring to the existing
+// artifact if an artifact with the same checksum was already uploaded.
+func (d *Deployments) checkDuplicateImage(ctx context.Context, checksum string) error {
+	image, err := d.db.FindImageByChecksum(ctx, checksum)
+	if err != nil {
+		return errors.Wrap(err, "failed to look up artifacts by checksum")
+	} else if image != nil {
+		return model.NewConflictError(ErrModelArtifactDuplicate).
+			WithMetadata(map[string]interface{}{
+				"artifact_id": image.Id,
+			})
+	}
+	return nil
+}
+
+// cleanupImageObject removes the artifact of a failed upload from the storage.
+func (d *Deployments) cleanupImageObject(ctx context.Context, artifactID string) {
+	if err := d.objectStorage.DeleteObject(
+		ctx, model.ImagePathFromContext(ctx, artifactID),
+	); err != nil {
+		log.FromContext(ctx).Errorf(
+			"failed to clean up artifact storage after failure: %s",
+			err,
+		)
+	}
+}
+
 func validUpdates(constructorUpdates []model.Update, metadataUpdates []model.Update) bool {
 	valid := false
 	if len(constructorUpdates) == len(metadataUpdates) {
@@ -874,6 +1006,7 @@ func (d *Deployments) UploadLink(
 		ArtifactID: artifactID,
 		IssuedAt:   time.Now(),
 		Link:       *link,
+		SkipVerify: skipVerify,
 	}
 	err = d.db.InsertUploadIntent(ctx, upLink)
 	if err != nil {
@@ -926,9 +1059,14 @@ func (d *Deployments) processUploadedArtifact(
 		skipVerify,
 		metadata,
 	)
-	if err != nil {
+	if err != nil && !isArtifactError(err) {
+		// the upload stays in processing for the storage daemon to retry
+		l.Warnf("failed to process artifact %s, the processing will be retried: %s",
+			artifactID, err)
+		return err
+	} else if err != nil {
 		l.Warnf("failed to process artifact %s: %s", artifactID, err)
-		linkStatus = model.LinkStatusAborted
+		linkStatus = model.LinkStatusFailed
 	}
 	errDB := d.db.UpdateUploadIntentStatus(
 		ctx, artifactID,
@@ -940,10 +1078,27 @@ func (d *Deployments) processUploadedArtifact(
 	return err
 }
 
+// isArtifactError returns true if the upload failed because of the artifact
+// itself, in which case retrying the processing doesn't help.
+func isArtifactError(err error) bool {
+	var conflict *model.ConflictError
+	if errors.As(err, &conflict) {
+		return true
+	}
+	switch errors.Cause(err) {
+	case ErrModelArtifactNotUnique,
+		ErrModelArtifactNotSigned,
+		ErrModelArtifactSignatureInvalid,
+		ErrModelParsingArtifactFailed,
+		ErrModelInvalidMetadata:
+		return true
+	}
+	return false
+}
+
 func (d *Deployments) CompleteUpload(
 	ctx context.Context,
 	intentID string,
-	skipVerify bool,
 	metadata *model.DirectUploadMetadata,
 ) error {
 	l := log.FromContext(ctx)
@@ -952,6 +1107,18 @@ func (d *Deployments) CompleteUpload(
 	if err != nil {
 		return err
 	}
+	link, err := d.db.FindUploadLink(ctx, intentID)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrUploadNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "failed to find the upload link")
+	}
+	if link.UploadID != "" && link.Status == model.LinkStatusPending {
+		err = d.completeMultipartUpload(ctx, link)
+		if err != nil {
+			return err
+		}
+	}
 	// Create an async context that doesn't cancel when server connection
 	// closes.
 	ctxAsync := context.Background()
@@ -960,6 +1127,9 @@ func (d *Deployments) CompleteUpload(
 
 	settings, _ := storage.SettingsFromContext(ctx)
 	ctxAsync = storage.SettingsWithContext(ctxAsync, settings)
+	// the verification is decided when the upload is created, the object
+	// path depends on it
+	skipVerify := link.SkipVerify
 	var artifactReader io.ReadCloser
 	if skipVerify {
 		artifactReader, err = d.objectStorage.GetObject(
@@ -979,12 +1149,18 @@ func (d *Deployments) CompleteUpload(
 		return err
 	}
 
-	err = d.db.UpdateUploadIntentStatus(
-		ctx,
-		intentID,
-		model.LinkStatusPending,
-		model.LinkStatusProcessing,
-	)
+	if metadata != nil {
+		// keep the metadata to resume the processing if interrupted
+		err = d.db.UpdateUploadIntentMetadata(ctx, intentID, metadata)
+	}
+	if err == nil {
+		err = d.db.UpdateUploadIntentStatus(
+			ctx,
+			intentID,
+			model.LinkStatusPending,
+			model.LinkStatusProcessing,
+		)
+	}
 	if err != nil {
 		errClose := artifactReader.Close()
 		if errClose != nil {
@@ -1001,6 +1177,20 @@ func (d *Deployments) CompleteUpload(
 	return nil
 }
 
+// GetUploadStatus returns the processing status of a direct upload
+func (d *Deployments) GetUploadStatus(
+	ctx context.Context,
+	intentID string,
+) (*model.UploadLinkStatus, error) {
+	link, err := d.db.FindUploadLink(ctx, intentID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrUploadNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to find the upload link")
+	}
+	return model.NewUploadLinkStatus(link), nil
+}
+
 func getArtifactInfo(info artifact.Info) *model.ArtifactInfo {
 	return &model.ArtifactInfo{
 		Format:  info.Format,
@@ -1021,15 +1211,32 @@ func getUpdateFiles(uFiles []*handlers.DataFile) ([]model.UpdateFile, error) {
 	return files, nil
 }
 
-func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, error) {
+func getMetaFromArchive(
+	r *io.Reader,
+	skipVerify bool,
+	verifier *artifactVerifier,
+) (*model.ArtifactMeta, error) {
 	metaArtifact := model.NewArtifactMeta()
 
 	aReader := areader.NewReader(*r)
 
-	// There is no signature verification here.
-	// It is just simple check if artifact is signed or not.
+	// The signature is verified with the tenant's verification keys, and
+	// a signature no key can verify is only an error if signatures are
+	// required.
+	signed := false
 	aReader.VerifySignatureCallback = func(message, sig []byte) error {
-		metaArtifact.Signed = true
+		signed = true
+		if verifier == nil {
+			return nil
+		}
+		keyID, err := verifier.verify(message, sig)
+		if err != nil {
+			if verifier.required {
+				return err
+			}
+			return nil
+		}
+		metaArtifact.VerificationKeyID = keyID
 		return nil
 	}
 
@@ -1039,6 +1246,15 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 		if err != nil {
 			return nil, errors.Wrap(err, "reading artifact error")
 		}
+		if signed {
+			// the signature only covers the manifest: the payload must
+			// match the checksums in the manifest for the artifact to
+			// count as signed
+			err = aReader.ReadArtifactData()
+			if err != nil {
+				return nil, errors.Wrap(ErrModelArtifactSignatureInvalid, err.Error())
+			}
+		}
 	} else {
 		err = aReader.ReadArtifact()
 		if err != nil {
@@ -1046,6 +1262,7 @@ func getMetaFromArchive(r *io.Reader, skipVerify bool) (*model.ArtifactMeta, err
 		}
 	}
 
+	metaArtifact.Signed = signed
 	metaArtifact.Info = getArtifactInfo(aReader.GetInfo())
 	metaArtifact.DeviceTypesCompatible = aReader.GetCompatibleDevices()
 
@@ -1113,28 +1330,7 @@ func (d *Deployments) updateDeploymentConstructor(ctx context.Context,
 	searchParams := openapi.SearchParams{
 		Page:    &page,
 		PerPage: types.Pointer(int32(PerPageInventoryDevices)),
-		Filters: []openapi.FilterPredicate{
-			{
-				Scope:     InventoryIdentityScope,
-				Attribute: InventoryStatusAttributeName,
-				Type:      "$eq",
-				Value: openapi.AttributeValueRequest{
-					String: types.Pointer(InventoryStatusAccepted),
-				},
-			},
-		},
-	}
-	if len(constructor.Group) > 0 {
-		searchParams.Filters = append(
-			searchParams.Filters,
-			openapi.FilterPredicate{
-				Scope:     InventoryGroupScope,
-				Attribute: InventoryGroupAttributeName,
-				Type:      "$eq",
-				Value: openapi.AttributeValueRequest{
-					String: types.Pointer(constructor.Group),
-				},
-			})
+		Filters: getDeploymentSearchFilters(constructor),
 	}
 
 	for {
@@ -1160,6 +1356,40 @@ func (d *Deployments) updateDeploymentConstructor(ctx context.Context,
 	return constructor, nil
 }
 
+// getDeploymentSearchFilters returns the inventory search filters matching
+// the accepted devices of the deployment group, or all of them, narrowed down
+// by the filter terms of the deployment.
+func getDeploymentSearchFilters(
+	constructor *model.DeploymentConstructor,
+) []openapi.FilterPredicate {
+	filters := []openapi.FilterPredicate{
+		{
+			Scope:     InventoryIdentityScope,
+			Attribute: InventoryStatusAttributeName,
+			Type:      "$eq",
+			Value: openapi.AttributeValueRequest{
+				String: types.Pointer(InventoryStatusAccepted),
+			},
+		},
+	}
+	if len(constructor.Group) > 0 {
+		filters = append(
+			filters,
+			openapi.FilterPredicate{
+				Scope:     InventoryGroupScope,
+				Attribute: InventoryGroupAttributeName,
+				Type:      "$eq",
+				Value: openapi.AttributeValueRequest{
+					String: types.Pointer(constructor.Group),
+				},
+			})
+	}
+	for _, term := range constructor.FilterTerms {
+		filters = append(filters, newSearchFilterPredicate(term))
+	}
+	return filters
+}
+
 // CreateDeviceConfigurationDeployment creates new configuration deployment for the device.
 func (d *Deployments) CreateDeviceConfigurationDeployment(
 	ctx context.Context, constructor *model.ConfigurationDeploymentConstructor,
@@ -1216,9 +1446,13 @@ func (d *Deployments) CreateDeployment(ctx context.Context,
 		return "", errors.Wrap(err, "Validating deployment")
 	}
 
-	if len(constructor.Group) > 0 || constructor.AllDevices {
-		constructor, err = d.updateDeploymentConstructor(ctx, constructor)
-		if err != nil {
+	if len(constructor.Group) > 0 || constructor.AllDevices ||
+		len(constructor.FilterTerms) > 0 {
+		updated, err := d.updateDeploymentConstructor(ctx, constructor)
+		if err == nil {
+			constructor = updated
+		} else if !constructor.Continuous || !errors.Is(err, ErrNoDevices) {
+			// continuous deployments wait for the matching devices to show up
 			return "", err
 		}
 	}
@@ -1239,11 +1473,20 @@ func (d *Deployments) CreateDeployment(ctx context.Context,
 	if len(artifacts) == 0 {
 		return "", ErrNoArtifact
 	}
+	if err := d.checkArtifactsSigned(ctx, artifacts); err != nil {
+		return "", err
+	}
 
 	deployment.Artifacts = getArtifactIDs(artifacts)
 	deployment.DeviceList = constructor.Devices
 	deployment.MaxDevices = len(constructor.Devices)
 	deployment.Stats[model.DeviceDeploymentStatusPendingStr] = deployment.MaxDevices
+	startTime := *deployment.Created
+	if constructor.StartTime != nil && constructor.StartTime.After(startTime) {
+		startTime = *constructor.StartTime
+	}
+	deployment.Phases = model.NewDeploymentPhases(
+		constructor.Phases, deployment.MaxDevices, startTime)
 	deployment.Type = model.DeploymentTypeSoftware
 	deployment.Filter = getDeploymentFilter(constructor)
 	if len(constructor.Group) > 0 {
@@ -1342,6 +1585,22 @@ func getDeploymentFilter(
 		}
 	}
 
+	if len(constructor.FilterTerms) > 0 {
+		if filter == nil {
+			filter = &model.Filter{
+				Terms: []model.FilterPredicate{
+					{
+						Scope:     InventoryIdentityScope,
+						Attribute: InventoryStatusAttributeName,
+						Type:      "$eq",
+						Value:     InventoryStatusAccepted,
+					},
+				},
+			}
+		}
+		filter.Terms = append(filter.Terms, constructor.FilterTerms...)
+	}
+
 	return filter
 }
 
@@ -1430,6 +1689,11 @@ func (d *Deployments) getDeploymentForDevice(ctx context.Context,
 	if deployment == nil {
 		return nil, nil, errors.New("No deployment corresponding to device deployment")
 	}
+	if deviceDeployment.Status == model.DeviceDeploymentStatusPending &&
+		!deployment.CanStart(time.Now()) {
+		// hold back the instructions until the maintenance window opens
+		return nil, nil, nil
+	}
 
 	return deployment, deviceDeployment, nil
 }
@@ -1465,15 +1729,48 @@ func (d *Deployments) getNewDeploymentForDevice(ctx context.Context,
 			return nil, nil, errors.Wrap(err, "Failed to search for newer active deployments")
 		}
 		if deploy != nil {
+			if !deploy.CanStart(time.Now()) {
+				// the deployment doesn't hold back the newer deployments
+				// while it waits to start, and the device joins a
+				// continuous deployment only once it has started
+				lastDeployment = deploy.Created
+				continue
+			}
+			if deploy.IsContinuous() && !slices.Contains(deploy.DeviceList, deviceID) {
+				// continuous deployments are never phased, so the
+				// device is admitted as soon as it joins
+				joined, err := d.joinContinuousDeployment(ctx, deploy, deviceID)
+				if err != nil {
+					return nil, nil, err
+				} else if !joined {
+					lastDeployment = deploy.Created
+					continue
+				}
+			}
 			if deploy.MaxDevices > 0 &&
 				deploy.DeviceCount != nil &&
 				*deploy.DeviceCount >= deploy.MaxDevices {
 				lastDeployment = deploy.Created
 				continue
 			}
+			var phase *model.DeploymentPhase
+			if len(deploy.Phases) > 0 {
+				phase, err = d.admitDeviceToPhase(ctx, deploy)
+				if err != nil {
+					return nil, nil, err
+				} else if phase == nil {
+					// the device waits for the next phase, but the
+					// newer deployments can start in the meantime
+					lastDeployment = deploy.Created
+					continue
+				}
+			}
 			deviceDeployment, err := d.createDeviceDeploymentWithStatus(ctx,
 				deviceID, deploy, model.DeviceDeploymentStatusPending)
 			if err != nil {
+				if phase != nil {
+					d.releaseDeviceFromPhase(ctx, deploy, phase)
+				}
 				return nil, nil, err
 			}
 			return deploy, deviceDeployment, nil
@@ -1484,6 +1781,52 @@ func (d *Deployments) getNewDeploymentForDevice(ctx context.Context,
 	return nil, nil, nil
 }
 
+// admitDeviceToPhase reserves a place for a device in the current phase of
+// a phased deployment and returns the phase; it returns nil if none of the
+// started phases can admit more devices.
+func (d *Deployments) admitDeviceToPhase(
+	ctx context.Context,
+	deployment *model.Deployment,
+) (*model.DeploymentPhase, error) {
+	now := time.Now()
+	for {
+		i := deployment.CurrentPhase(now)
+		if i < 0 {
+			return nil, nil
+		}
+		phase := &deployment.Phases[i]
+		err := d.db.IncrementDeploymentPhaseDeviceCount(ctx,
+			deployment.Id, phase.Id, phase.MaxDevices)
+		if err == mongo.ErrDeploymentPhaseFull {
+			// concurrent requests admitted the remaining devices,
+			// try with the next phase
+			phase.DeviceCount = phase.MaxDevices
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to update the deployment phase")
+		}
+		phase.DeviceCount++
+		return phase, nil
+	}
+}
+
+// releaseDeviceFromPhase gives back the place reserved for a device in the
+// deployment phase when the device could not join the deployment.
+func (d *Deployments) releaseDeviceFromPhase(
+	ctx context.Context,
+	deployment *model.Deployment,
+	phase *model.DeploymentPhase,
+) {
+	err := d.db.DecrementDeploymentPhaseDeviceCount(ctx, deployment.Id, phase.Id)
+	if err != nil {
+		log.FromContext(ctx).Errorf(
+			"failed to release the device from phase %s of deployment %s: %s",
+			phase.Id, deployment.Id, err)
+		return
+	}
+	phase.DeviceCount--
+}
+
 func (d *Deployments) createDeviceDeploymentWithStatus(
 	ctx context.Context, deviceID string,
 	deployment *model.Deployment, status model.DeviceDeploymentStatus,
@@ -1500,6 +1843,9 @@ func (d *Deployments) createDeviceDeploymentWithStatus(
 	deviceDeployment.Status = status
 	deviceDeployment.Active = status.Active()
 	deviceDeployment.Created = deployment.Created
+	if deployment.DeploymentConstructor != nil {
+		deviceDeployment.Retries = deployment.Retries
+	}
 
 	if err := d.setDeploymentDeviceCountIfUnset(ctx, deployment); err != nil {
 		return nil, err
@@ -1598,16 +1944,23 @@ func (d *Deployments) getDeploymentInstructions(
 		return nil, err
 	}
 
-	imagePath := model.ImagePathFromContext(ctx, deviceDeployment.Image.Id)
-	link, err := d.objectStorage.GetRequest(
-		ctx,
-		imagePath,
-		deviceDeployment.Image.Name+model.ArtifactFileSuffix,
-		DefaultUpdateDownloadLinkExpire,
-		true,
-	)
+	object := d.deviceArtifactObject(ctx, deviceDeployment, request, true)
+	if deviceDeployment.Object == nil || *deviceDeployment.Object != *object {
+		// the links refreshed for the device serve the same object
+		err = d.db.SaveDeviceDeploymentObject(ctx, deviceDeployment.Id, object)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update the device deployment")
+		}
+		deviceDeployment.Object = object
+	}
+	link, err := d.deviceObjectLink(ctx, deviceDeployment, object)
 	if err != nil {
-		return nil, errors.Wrap(err, "Generating download link for the device")
+		return nil, err
+	}
+	// the checksum is known for the full artifact only
+	var checksum string
+	if object.ID == deviceDeployment.Image.Id {
+		checksum = deviceDeployment.Image.Checksum
 	}
 
 	instructions := &model.DeploymentInstructions{
@@ -1619,6 +1972,7 @@ func (d *Deployments) getDeploymentInstructions(
 			Source: *link,
 			DeviceTypesCompatible: deviceDeployment.Image.
 				ArtifactMeta.DeviceTypesCompatible,
+			Checksum: checksum,
 		},
 	}
 
@@ -1688,12 +2042,6 @@ func (d *Deployments) updateDeviceDeploymentStatus(
 		ddState.Status, dd.DeviceId, dd.DeploymentId,
 	)
 
-	var finishTime *time.Time = nil
-	if model.IsDeviceDeploymentStatusFinished(ddState.Status) {
-		now := time.Now()
-		finishTime = &now
-	}
-
 	currentStatus := dd.Status
 
 	if currentStatus == model.DeviceDeploymentStatusAborted {
@@ -1704,6 +2052,44 @@ func (d *Deployments) updateDeviceDeploymentStatus(
 		return ErrDeviceDecommissioned
 	}
 
+	if !currentStatus.CanTransitionTo(ddState.Status) {
+		l.Warnf("Device %s reported illegal status transition %s -> %s "+
+			"for deployment %s", dd.DeviceId, currentStatus, ddState.Status,
+			dd.DeploymentId)
+		if err := d.db.SaveDeviceDeploymentIllegalTransition(ctx, dd.Id,
+			model.DeviceDeploymentTransition{
+				From:      currentStatus,
+				To:        ddState.Status,
+				Timestamp: time.Now(),
+			}); err != nil {
+			l.Error(errors.Wrap(err,
+				"failed to save illegal device deployment status transition").Error())
+		}
+		return ErrIllegalStatusTransition
+	}
+
+	// the device retries the deployment if it has retries left
+	if ddState.Status == model.DeviceDeploymentStatusFailure &&
+		dd.Status.Active() && dd.Attempts < dd.Retries {
+		l.Infof("Device %s failed attempt %d of deployment %s, retrying",
+			dd.DeviceId, dd.Attempts+1, dd.DeploymentId)
+		ddState.Status = model.DeviceDeploymentStatusPending
+		err := d.db.IncrementDeviceDeploymentAttempts(ctx, dd.Id, 1)
+		if err != nil {
+			return errors.Wrap(err, "failed to update the device deployment")
+		}
+		// the device may report different data in the next request
+		if err := d.db.SaveDeviceDeploymentRequest(ctx, dd.Id, nil); err != nil {
+			return errors.Wrap(err, "failed to reset the device deployment request")
+		}
+	}
+
+	var finishTime *time.Time = nil
+	if model.IsDeviceDeploymentStatusFinished(ddState.Status) {
+		now := time.Now()
+		finishTime = &now
+	}
+
 	// nothing to do
 	if ddState.Status == currentStatus {
 		return nil
@@ -1734,11 +2120,28 @@ func (d *Deployments) updateDeviceDeploymentStatus(
 			return err
 		}
 		newStatus := deployment.GetStatus()
-		if beforeStatus != newStatus {
+		if ddState.Status == model.DeviceDeploymentStatusFailure &&
+			newStatus != model.DeploymentStatusFinished &&
+			deployment.IsFailureThresholdExceeded() {
+			l.Warnf("Deployment %s exceeded the failure threshold, aborting",
+				dd.DeploymentId)
+			if err := d.AbortDeployment(ctx, dd.DeploymentId); err != nil {
+				return errors.Wrap(err, "failed to abort the deployment")
+			}
+		} else if newStatus == model.DeploymentStatusFinished &&
+			beforeStatus != newStatus {
 			err = d.db.SetDeploymentStatus(ctx, dd.DeploymentId, newStatus, time.Now())
 			if err != nil {
 				return errors.Wrap(err, "failed to update deployment status")
 			}
+		} else if beforeStatus != newStatus {
+			// the deployment may have been paused or aborted in the meantime,
+			// in which case the status is left alone
+			err = d.db.UpdateDeploymentStatus(ctx,
+				dd.DeploymentId, deployment.Status, newStatus, time.Now())
+			if err != nil && err != mongo.ErrStorageNotFound {
+				return errors.Wrap(err, "failed to update deployment status")
+			}
 		}
 	}
 
@@ -1771,7 +2174,7 @@ func (d *Deployments) GetDeploymentStats(ctx context.Context,
 		return nil, nil
 	}
 
-	return deployment.Stats, nil
+	return deployment.Stats.WithPaused(deployment.IsPaused()), nil
 }
 func (d *Deployments) GetDeploymentsStats(ctx context.Context,
 	deploymentIDs ...string) (deploymentStats []*model.DeploymentStats, err error) {
@@ -1785,6 +2188,10 @@ func (d *Deployments) GetDeploymentsStats(ctx context.Context,
 	if deploymentStats == nil {
 		return nil, ErrModelDeploymentNotFound
 	}
+	for _, stats := range deploymentStats {
+		stats.Stats = stats.Stats.WithPaused(
+			stats.Status == model.DeploymentStatusPaused)
+	}
 
 	return deploymentStats, nil
 }
@@ -1830,6 +2237,25 @@ func (d *Deployments) GetDevicesListForDeployment(ctx context.Context,
 	return statuses, totalCount, nil
 }
 
+// ExportDevicesForDeployment returns an iterator over all the devices of the
+// deployment matching the query, regardless of its paging parameters.
+func (d *Deployments) ExportDevicesForDeployment(ctx context.Context,
+	query store.ListQuery) (store.Iterator[model.DeviceDeployment], error) {
+
+	deployment, err := d.db.FindDeploymentByID(ctx, query.DeploymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for deployment by ID")
+	} else if deployment == nil {
+		return nil, ErrModelDeploymentNotFound
+	}
+
+	it, err := d.db.FindDevicesForDeployment(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the devices of the deployment")
+	}
+	return it, nil
+}
+
 func (d *Deployments) GetDeviceDeploymentListForDevice(ctx context.Context,
 	query store.ListQueryDeviceDeployments) ([]model.DeviceDeploymentListItem, int, error) {
 	deviceDeployments, totalCount, err := d.db.GetDeviceDeploymentsForDevice(ctx, query)
@@ -1992,6 +2418,23 @@ func (d *Deployments) SaveDeviceDeploymentLog(ctx context.Context, deviceID stri
 		}
 	}
 
+	maxSize := d.deploymentLogMaxSize
+	limit, err := d.GetLimit(ctx, model.LimitDeploymentLogSize)
+	if err != nil {
+		return err
+	} else if limit.Value > 0 {
+		maxSize = int(limit.Value)
+	}
+	if dlog.Truncate(maxSize) {
+		log.FromContext(ctx).Infof(
+			"Truncated the log of device %s for deployment %s to %d bytes",
+			deviceID, deploymentID, maxSize)
+	}
+	if d.deploymentLogRetention > 0 {
+		expireAt := time.Now().Add(d.deploymentLogRetention)
+		dlog.ExpireAt = &expireAt
+	}
+
 	if err := d.db.SaveDeviceDeploymentLog(ctx, dlog); err != nil {
 		return err
 	}
@@ -2152,12 +2595,26 @@ func (d *Deployments) SetStorageSettings(
 	storageSettings *model.StorageSettings,
 ) error {
 	if storageSettings != nil {
-		ctx = storage.SettingsWithContext(ctx, storageSettings)
-		if err := d.objectStorage.HealthCheck(ctx); err != nil {
+		err := d.objectStorage.HealthCheck(
+			storage.SettingsWithContext(ctx, storageSettings),
+		)
+		if err != nil {
 			return errors.WithMessage(err,
 				"the provided storage settings failed the health check",
 			)
 		}
+		for i := range storageSettings.Locations {
+			location := &storageSettings.Locations[i]
+			err = d.objectStorage.HealthCheck(
+				storage.SettingsWithContext(ctx, &location.Settings),
+			)
+			if err != nil {
+				return errors.WithMessagef(err,
+					"the settings of the storage location %q failed the health check",
+					location.Name,
+				)
+			}
+		}
 	}
 	if err := d.db.SetStorageSettings(ctx, storageSettings); err != nil {
 		return errors.Wrap(err, "Failed to save settings")
@@ -2171,6 +2628,26 @@ func (d *Deployments) search(
 	tid string,
 	params openapi.SearchParams,
 ) ([]string, int64, error) {
+	devices, totalCount, err := d.searchInventories(ctx, tid, params)
+	if err != nil {
+		return nil, -1, err
+	}
+	deviceIDs := make([]string, 0, len(devices))
+	for _, device := range devices {
+		if device.Id != nil {
+			deviceIDs = append(deviceIDs, *device.Id)
+		}
+	}
+	return deviceIDs, totalCount, nil
+}
+
+// searchInventories returns a page of the device inventories matching the
+// search parameters, and the total count of the matching devices.
+func (d *Deployments) searchInventories(
+	ctx context.Context,
+	tid string,
+	params openapi.SearchParams,
+) ([]openapi.DeviceInventoryResponse, int64, error) {
 	devices, rsp, err := d.inventoryV2Client.InventoryInternalV2SearchDeviceInventories(ctx, tid).
 		SearchParams(params).
 		Execute()
@@ -2190,13 +2667,7 @@ func (d *Deployments) search(
 	if err != nil {
 		return nil, -1, fmt.Errorf("error parsing total count: %w", err)
 	}
-	deviceIDs := make([]string, 0, len(devices))
-	for _, device := range devices {
-		if device.Id != nil {
-			deviceIDs = append(deviceIDs, *device.Id)
-		}
-	}
-	return deviceIDs, totalCount, nil
+	return devices, totalCount, nil
 }
 
 func (d *Deployments) UpdateDeploymentsWithArtifactName(
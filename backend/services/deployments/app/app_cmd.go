@@ -19,6 +19,10 @@ import (
 	"path"
 	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
 	"github.com/mendersoftware/mender-server/services/deployments/model"
 	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/store"
@@ -32,22 +36,23 @@ func (d *Deployments) cleanupExpiredLink(
 	switch link.Status {
 	case model.LinkStatusProcessing:
 		if link.UpdatedTS.Before(now.Add(-inprogressIdleTime)) {
-			err = d.db.UpdateUploadIntentStatus(
-				ctx,
-				link.ArtifactID,
-				model.LinkStatusProcessing,
-				model.LinkStatusPending,
-			)
+			err = d.resumeUpload(ctx, link)
 			if err == store.ErrNotFound {
 				err = nil
 			}
 		}
-		// TODO: Call deployments API to restart processing
-		// TODO: Increment retry counter to avoid infinite loop
 
 	case model.LinkStatusAborted,
 		model.LinkStatusCompleted,
+		model.LinkStatusFailed,
 		model.LinkStatusPending:
+		if link.Status == model.LinkStatusPending && link.UploadID != "" {
+			// discard the parts of the unfinished multipart upload
+			err = d.abortMultipartUpload(ctx, link)
+			if err != nil {
+				break
+			}
+		}
 		objectPath := link.ArtifactID + fileSuffixTmp
 		if link.TenantID != "" {
 			objectPath = path.Join(link.TenantID, objectPath)
@@ -71,6 +76,69 @@ func (d *Deployments) cleanupExpiredLink(
 	return err
 }
 
+// resumeUpload restarts the processing of an upload which stopped making
+// progress, e.g. because the service restarted, and marks the upload failed
+// once the retries are exhausted.
+func (d *Deployments) resumeUpload(
+	ctx context.Context,
+	link model.UploadLink,
+) error {
+	l := log.FromContext(ctx)
+	ctx = contextWithTenant(ctx, link.TenantID)
+	if link.Retries >= maxUploadRetries {
+		l.Errorf("giving up processing the upload %s of tenant %q after %d retries",
+			link.ArtifactID, link.TenantID, link.Retries)
+		return d.db.UpdateUploadIntentStatus(
+			ctx,
+			link.ArtifactID,
+			model.LinkStatusProcessing,
+			model.LinkStatusFailed,
+		)
+	}
+	err := d.db.RetryUploadIntent(ctx, link.ArtifactID, link.Retries)
+	if err != nil {
+		return err
+	}
+
+	// the processing may have stopped right after saving the artifact
+	found, err := d.db.Exists(ctx, link.ArtifactID)
+	if err != nil {
+		return err
+	} else if found {
+		return d.db.UpdateUploadIntentStatus(
+			ctx,
+			link.ArtifactID,
+			model.LinkStatusProcessing,
+			model.LinkStatusCompleted,
+		)
+	}
+
+	ctx, err = d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return err
+	}
+	artifact, err := d.objectStorage.GetObject(ctx, uploadPath(ctx, &link))
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		l.Errorf("failed to resume the upload %s of tenant %q: object not found",
+			link.ArtifactID, link.TenantID)
+		return d.db.UpdateUploadIntentStatus(
+			ctx,
+			link.ArtifactID,
+			model.LinkStatusProcessing,
+			model.LinkStatusFailed,
+		)
+	} else if err != nil {
+		return err
+	}
+	l.Infof("resuming the processing of the upload %s of tenant %q (retry %d)",
+		link.ArtifactID, link.TenantID, link.Retries+1)
+	// processing errors are logged, and either recorded in the link status
+	// or retried once the upload stops making progress again
+	_ = d.processUploadedArtifact(ctx, link.ArtifactID, artifact,
+		link.SkipVerify, link.Metadata)
+	return nil
+}
+
 func (d *Deployments) CleanupExpiredUploads(
 	ctx context.Context, interval, jitter time.Duration,
 ) error {
@@ -88,36 +156,23 @@ func (d *Deployments) CleanupExpiredUploads(
 		close(c)
 		tc = c
 	}
-	var it store.Iterator[model.UploadLink]
-	defer func() {
-		if it != nil {
-			it.Close(ctx)
-		}
-	}()
 
 	for run && err == nil {
 		now := time.Now().Add(-jitter)
-		it, err = d.db.FindUploadLinks(ctx, now)
+		// the batch is loaded before processing it: resuming an upload
+		// can take longer than the cursor is allowed to stay idle
+		var links []model.UploadLink
+		links, err = d.findUploadLinks(ctx, now)
 		if err != nil {
 			break
 		}
-		for run && err == nil {
-			var link model.UploadLink
-			run, err = it.Next(ctx)
-			if !run {
-				break
-			}
-			err = it.Decode(&link)
-			if err != nil {
-				break
-			}
-			err = d.cleanupExpiredLink(ctx, link, now)
+		for i := 0; i < len(links) && err == nil; i++ {
+			err = d.cleanupExpiredLink(ctx, links[i], now)
 		}
 		if err != nil && err != store.ErrNotFound {
 			break
 		}
-		err = it.Close(ctx)
-		it = nil
+		err = nil
 		select {
 		case <-ctx.Done():
 			err = ctx.Err()
@@ -127,3 +182,35 @@ func (d *Deployments) CleanupExpiredUploads(
 	}
 	return err
 }
+
+// findUploadLinks loads the upload links expired at the given time.
+func (d *Deployments) findUploadLinks(
+	ctx context.Context,
+	expiredAt time.Time,
+) ([]model.UploadLink, error) {
+	it, err := d.db.FindUploadLinks(ctx, expiredAt)
+	if err != nil {
+		return nil, err
+	}
+	var links []model.UploadLink
+	for {
+		var next bool
+		next, err = it.Next(ctx)
+		if err != nil || !next {
+			break
+		}
+		var link model.UploadLink
+		err = it.Decode(&link)
+		if err != nil {
+			break
+		}
+		links = append(links, link)
+	}
+	if errClose := it.Close(ctx); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
@@ -17,7 +17,9 @@ package app
 import (
 	"context"
 	"errors"
+	"io"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -116,21 +118,30 @@ func TestCleanupExpiredUploads(t *testing.T) {
 		defer database.AssertExpectations(t)
 		defer objectStore.AssertExpectations(t)
 
+		iterator := NewArrayIterator[model.UploadLink](links)
 		database.On("FindUploadLinks", ctx, mock.Anything).
 			Run(func(args mock.Arguments) {
 				exp := args.Get(1).(time.Time)
 				assert.WithinDuration(t, time.Now().Add(-jitter), exp, time.Minute)
 			}).
-			Return(NewArrayIterator[model.UploadLink](links), nil).
+			Return(iterator, nil).
 			Once()
 
 		for _, link := range links {
 			switch status := link.Status; status {
 			case model.LinkStatusProcessing:
 				if link.UpdatedTS.Before(time.Now().Add(-inprogressIdleTime)) {
-					database.On("UpdateUploadIntentStatus",
-						ctx, link.ArtifactID,
-						model.LinkStatusProcessing, model.LinkStatusPending).
+					// resumed by another daemon
+					database.On("RetryUploadIntent",
+						ctx, link.ArtifactID, uint(0)).
+						Run(func(mock.Arguments) {
+							// uploads are resumed once the cursor is closed
+							select {
+							case <-iterator.closed:
+							default:
+								assert.Fail(t, "iterator not closed")
+							}
+						}).
 						Return(store.ErrNotFound).
 						Once()
 				}
@@ -263,3 +274,137 @@ func TestCleanupExpiredUploads(t *testing.T) {
 		assert.ErrorIs(t, err, errInternal)
 	})
 }
+
+func TestResumeUpload(t *testing.T) {
+	t.Parallel()
+
+	const (
+		artifactID = "1ea293ad-c94b-44b7-a137-af1dd9d6b126"
+		tenantID   = "123456789012345678901234"
+	)
+	objectPath := path.Join(tenantID, artifactID)
+
+	testCases := map[string]struct {
+		retries    uint
+		skipVerify bool
+		invalid    bool
+
+		retryErr  error
+		exists    bool
+		objectErr error
+		statusNew model.LinkStatus
+
+		err error
+	}{
+		"ok, resumed": {
+			retries: 1,
+		},
+		"ok, resumed without verification": {
+			skipVerify: true,
+		},
+		"ok, resumed, invalid artifact": {
+			skipVerify: true,
+			invalid:    true,
+			statusNew:  model.LinkStatusFailed,
+		},
+		"ok, already processed": {
+			exists:    true,
+			statusNew: model.LinkStatusCompleted,
+		},
+		"ok, retries exhausted": {
+			retries:   maxUploadRetries,
+			statusNew: model.LinkStatusFailed,
+		},
+		"ok, object not found": {
+			objectErr: storage.ErrObjectNotFound,
+			statusNew: model.LinkStatusFailed,
+		},
+		"error, resumed concurrently": {
+			retryErr: store.ErrNotFound,
+			err:      store.ErrNotFound,
+		},
+		"error, get object": {
+			objectErr: errors.New("internal error"),
+			err:       errors.New("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			link := model.UploadLink{
+				ArtifactID: artifactID,
+				Link:       model.Link{TenantID: tenantID},
+				Status:     model.LinkStatusProcessing,
+				SkipVerify: tc.skipVerify,
+				Retries:    tc.retries,
+			}
+			database := mstore.NewDataStore(t)
+			objectStore := mstorage.NewObjectStorage(t)
+
+			if tc.retries < maxUploadRetries {
+				database.On("RetryUploadIntent",
+					contextMatcher(tenantID), artifactID, tc.retries).
+					Return(tc.retryErr).
+					Once()
+			}
+			if tc.retries < maxUploadRetries && tc.retryErr == nil {
+				database.On("Exists", contextMatcher(tenantID), artifactID).
+					Return(tc.exists, nil).
+					Once()
+			}
+			if tc.retries < maxUploadRetries && tc.retryErr == nil && !tc.exists {
+				database.On("GetStorageSettings", contextMatcher(tenantID)).
+					Return(nil, nil).
+					Once()
+				expectedPath := objectPath + fileSuffixTmp
+				if tc.skipVerify {
+					expectedPath = objectPath
+				}
+				var artifact io.ReadCloser
+				if tc.objectErr == nil {
+					artifact = io.NopCloser(strings.NewReader("artifact"))
+					if tc.invalid {
+						// the artifact can't be parsed: the upload fails
+						database.On("GetArtifactVerificationSettings",
+							contextMatcher(tenantID)).
+							Return(nil, nil).
+							Once()
+						database.On("ListVerificationKeys",
+							contextMatcher(tenantID)).
+							Return(nil, nil).
+							Once()
+					} else {
+						// the processing fails straight away and the upload
+						// is left for the next retry
+						database.On("GetArtifactVerificationSettings",
+							contextMatcher(tenantID)).
+							Return(nil, errors.New("internal error")).
+							Once()
+					}
+				}
+				objectStore.On("GetObject", contextMatcher(tenantID), expectedPath).
+					Return(artifact, tc.objectErr).
+					Once()
+			}
+			if tc.statusNew != 0 {
+				database.On("UpdateUploadIntentStatus",
+					contextMatcher(tenantID), artifactID,
+					model.LinkStatusProcessing, tc.statusNew).
+					Return(nil).
+					Once()
+			}
+
+			app := NewDeployments(database, objectStore, 0, false)
+			err := app.resumeUpload(ctx, link)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
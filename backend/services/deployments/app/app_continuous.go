@@ -0,0 +1,196 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	openapi "github.com/mendersoftware/mender-server/pkg/api/client"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// FinishDeployment finishes a continuous deployment: no more devices join
+// it, while the devices which already started the deployment complete it.
+func (d *Deployments) FinishDeployment(ctx context.Context, deploymentID string) error {
+	deployment, err := d.db.FindDeploymentByID(ctx, deploymentID)
+	if err != nil {
+		return errors.Wrap(err, "Searching for deployment by ID")
+	} else if deployment == nil {
+		return ErrModelDeploymentNotFound
+	}
+	if !deployment.IsContinuous() {
+		return ErrDeploymentNotContinuous
+	}
+
+	if err := d.db.SetDeploymentStatus(ctx,
+		deploymentID, model.DeploymentStatusFinished, time.Now()); err != nil {
+		return errors.Wrap(err, "failed to update deployment status")
+	}
+	return nil
+}
+
+// continuousMisses remembers the devices which don't match the filter of a
+// continuous deployment, so that their polls don't search the inventory
+// again until the match interval passes.
+type continuousMisses struct {
+	mu      sync.Mutex
+	expires map[continuousMissKey]time.Time
+	sweep   time.Time
+}
+
+type continuousMissKey struct {
+	tenantID     string
+	deploymentID string
+	deviceID     string
+}
+
+// has reports whether the device was found not to match the deployment
+// filter less than the match interval ago.
+func (m *continuousMisses) has(key continuousMissKey, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires, ok := m.expires[key]
+	return ok && now.Before(expires)
+}
+
+// add remembers the device doesn't match the deployment filter until
+// expires; the expired entries are removed at most once per interval.
+func (m *continuousMisses) add(key continuousMissKey, now time.Time, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.expires == nil {
+		m.expires = make(map[continuousMissKey]time.Time)
+	}
+	if !now.Before(m.sweep) {
+		for k, expires := range m.expires {
+			if !now.Before(expires) {
+				delete(m.expires, k)
+			}
+		}
+		m.sweep = now.Add(interval)
+	}
+	m.expires[key] = now.Add(interval)
+}
+
+// joinContinuousDeployment adds the device to the continuous deployment if
+// it matches the deployment filter; it returns false if it doesn't.
+func (d *Deployments) joinContinuousDeployment(
+	ctx context.Context,
+	deployment *model.Deployment,
+	deviceID string,
+) (bool, error) {
+	if deployment.Filter == nil || len(deployment.Filter.Terms) == 0 {
+		return false, nil
+	}
+
+	id := identity.FromContext(ctx)
+	if id == nil {
+		id = &identity.Identity{}
+	}
+	key := continuousMissKey{
+		tenantID:     id.Tenant,
+		deploymentID: deployment.Id,
+		deviceID:     deviceID,
+	}
+	now := time.Now()
+	if d.continuousMatchInterval > 0 && d.continuousMisses.has(key, now) {
+		return false, nil
+	}
+	searchParams := openapi.SearchParams{
+		Page:      types.Pointer(int32(1)),
+		PerPage:   types.Pointer(int32(1)),
+		DeviceIds: []string{deviceID},
+		Filters:   make([]openapi.FilterPredicate, len(deployment.Filter.Terms)),
+	}
+	for i, term := range deployment.Filter.Terms {
+		searchParams.Filters[i] = newSearchFilterPredicate(term)
+	}
+	_, _, err := d.searchInventories(ctx, id.Tenant, searchParams)
+	if errors.Is(err, ErrNoDevices) {
+		if d.continuousMatchInterval > 0 {
+			d.continuousMisses.add(key, now, d.continuousMatchInterval)
+		}
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "failed to match the device with the deployment filter")
+	}
+
+	if err := d.db.AddDeviceToDeployment(ctx, deployment.Id, deviceID); err != nil {
+		return false, err
+	}
+	deployment.DeviceList = append(deployment.DeviceList, deviceID)
+	deployment.MaxDevices++
+	return true, nil
+}
+
+// newSearchFilterPredicate converts a deployment filter term to the
+// inventory search filter predicate.
+func newSearchFilterPredicate(term model.FilterPredicate) openapi.FilterPredicate {
+	return openapi.FilterPredicate{
+		Scope:     openapi.Scope(term.Scope),
+		Attribute: term.Attribute,
+		Type:      term.Type,
+		Value:     newAttributeValueRequest(term.Value),
+	}
+}
+
+func newAttributeValueRequest(value interface{}) openapi.AttributeValueRequest {
+	var values []interface{}
+	switch v := value.(type) {
+	case string:
+		return openapi.AttributeValueRequest{String: &v}
+	case float64:
+		return openapi.AttributeValueRequest{Float32: types.Pointer(float32(v))}
+	case int32:
+		return openapi.AttributeValueRequest{Float32: types.Pointer(float32(v))}
+	case int64:
+		return openapi.AttributeValueRequest{Float32: types.Pointer(float32(v))}
+	case []string:
+		return openapi.AttributeValueRequest{ArrayOfString: &v}
+	case bson.A:
+		values = v
+	case []interface{}:
+		values = v
+	}
+
+	var (
+		strs []string
+		nums []float32
+	)
+	for _, elem := range values {
+		switch e := elem.(type) {
+		case string:
+			strs = append(strs, e)
+		case float64:
+			nums = append(nums, float32(e))
+		case int32:
+			nums = append(nums, float32(e))
+		case int64:
+			nums = append(nums, float32(e))
+		}
+	}
+	if len(nums) > 0 {
+		return openapi.AttributeValueRequest{ArrayOfFloat32: &nums}
+	}
+	return openapi.AttributeValueRequest{ArrayOfString: &strs}
+}
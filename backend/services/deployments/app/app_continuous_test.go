@@ -0,0 +1,328 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+func TestFinishDeployment(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deployment *model.Deployment
+		findErr    error
+		setErr     error
+
+		err error
+	}{
+		"ok": {
+			deployment: &model.Deployment{
+				Id: "deployment",
+				DeploymentConstructor: &model.DeploymentConstructor{
+					Continuous: true,
+				},
+			},
+		},
+		"error, not found": {
+			err: ErrModelDeploymentNotFound,
+		},
+		"error, not continuous": {
+			deployment: &model.Deployment{
+				Id:                    "deployment",
+				DeploymentConstructor: &model.DeploymentConstructor{},
+			},
+			err: ErrDeploymentNotContinuous,
+		},
+		"error, find": {
+			findErr: errors.New("internal error"),
+			err:     errors.New("Searching for deployment by ID: internal error"),
+		},
+		"error, set status": {
+			deployment: &model.Deployment{
+				Id: "deployment",
+				DeploymentConstructor: &model.DeploymentConstructor{
+					Continuous: true,
+				},
+			},
+			setErr: errors.New("internal error"),
+			err:    errors.New("failed to update deployment status: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("FindDeploymentByID", ctx, "deployment").
+				Return(tc.deployment, tc.findErr)
+			if tc.deployment != nil && tc.deployment.IsContinuous() {
+				db.On("SetDeploymentStatus", ctx, "deployment",
+					model.DeploymentStatusFinished,
+					mock.AnythingOfType("time.Time")).
+					Return(tc.setErr)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.FinishDeployment(ctx, "deployment")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetNewDeploymentForDeviceContinuous(t *testing.T) {
+	t.Parallel()
+
+	const deviceID = "device"
+
+	testCases := map[string]struct {
+		deviceList []string
+		notStarted bool
+		matches    bool
+		searchErr  error
+
+		joined bool
+		err    error
+	}{
+		"ok, device joins the deployment": {
+			matches: true,
+			joined:  true,
+		},
+		"ok, device is already part of the deployment": {
+			deviceList: []string{deviceID},
+			joined:     true,
+		},
+		"ok, device does not match the filter": {},
+		"ok, device does not join before the deployment starts": {
+			notStarted: true,
+		},
+		"error, inventory": {
+			searchErr: errors.New("inventory unavailable"),
+			err: errors.New("failed to match the device with the deployment filter: " +
+				"inventory unavailable"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Subject: deviceID,
+				Tenant:  "tenant",
+			})
+			created := time.Now().Add(-time.Hour)
+			deployment := &model.Deployment{
+				Id:      "deployment",
+				Created: &created,
+				DeploymentConstructor: &model.DeploymentConstructor{
+					ArtifactName: "artifact",
+					Continuous:   true,
+				},
+				DeviceCount: types.Pointer(0),
+				MaxDevices:  1,
+				DeviceList:  tc.deviceList,
+				Filter: &model.Filter{
+					Terms: []model.FilterPredicate{{
+						Scope:     InventoryGroupScope,
+						Attribute: InventoryGroupAttributeName,
+						Type:      "$eq",
+						Value:     "factory",
+					}},
+				},
+			}
+			if tc.notStarted {
+				deployment.StartTime = types.Pointer(time.Now().Add(time.Hour))
+			}
+
+			db := mocks.NewDataStore(t)
+			db.On("FindLatestInactiveDeviceDeployment", ctx, deviceID).
+				Return(nil, nil)
+			db.On("FindNewerActiveDeployment", ctx, &time.Time{}, deviceID).
+				Return(deployment, nil).
+				Once()
+
+			inventoryV2Client := oas_mocks.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			if tc.deviceList == nil && !tc.notStarted {
+				req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+					ApiService: inventoryV2Client,
+				}
+				req = req.SearchParams(client.SearchParams{
+					Page:      types.Pointer(int32(1)),
+					PerPage:   types.Pointer(int32(1)),
+					DeviceIds: []string{deviceID},
+					Filters: []client.FilterPredicate{{
+						Scope:     InventoryGroupScope,
+						Attribute: InventoryGroupAttributeName,
+						Type:      "$eq",
+						Value: client.AttributeValueRequest{
+							String: types.Pointer("factory"),
+						},
+					}},
+				})
+				var devices []client.DeviceInventoryResponse
+				if tc.matches {
+					devices = append(devices, client.DeviceInventoryResponse{
+						Id: types.Pointer(deviceID),
+					})
+				}
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventories(ctx, "tenant").
+					Return(req)
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventoriesExecute(req).
+					Return(devices, &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(nil),
+						Header:     http.Header{"X-Total-Count": []string{"1"}},
+					}, tc.searchErr)
+			}
+			if tc.matches {
+				db.On("AddDeviceToDeployment", ctx, "deployment", deviceID).
+					Return(nil)
+			}
+			if tc.joined {
+				db.On("GetDeviceDeployment", ctx, "deployment", deviceID, true).
+					Return(nil, mongo.ErrStorageNotFound)
+				db.On("InsertDeviceDeployment", ctx,
+					mock.AnythingOfType("*model.DeviceDeployment"), true).
+					Return(nil)
+			} else if tc.err == nil {
+				db.On("FindNewerActiveDeployment", ctx, &created, deviceID).
+					Return(nil, nil).
+					Once()
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			d.inventoryV2Client = inventoryV2Client
+
+			deploy, deviceDeployment, err := d.getNewDeploymentForDevice(ctx, deviceID)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else if assert.NoError(t, err) && tc.joined {
+				assert.Equal(t, deployment, deploy)
+				if assert.NotNil(t, deviceDeployment) {
+					assert.Equal(t, model.DeviceDeploymentStatusPending,
+						deviceDeployment.Status)
+				}
+				if tc.matches {
+					assert.Equal(t, 2, deploy.MaxDevices)
+					assert.Equal(t, []string{deviceID}, deploy.DeviceList)
+				}
+			} else {
+				assert.Nil(t, deploy)
+				assert.Nil(t, deviceDeployment)
+			}
+		})
+	}
+}
+
+func TestJoinContinuousDeploymentMatchInterval(t *testing.T) {
+	t.Parallel()
+
+	const deviceID = "device"
+
+	testCases := map[string]struct {
+		interval time.Duration
+		searches int
+	}{
+		"ok, misses are cached": {
+			interval: time.Minute,
+			searches: 1,
+		},
+		"ok, misses are not cached": {
+			searches: 2,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Subject: deviceID,
+				Tenant:  "tenant",
+			})
+			deployment := &model.Deployment{
+				Id: "deployment",
+				DeploymentConstructor: &model.DeploymentConstructor{
+					ArtifactName: "artifact",
+					Continuous:   true,
+				},
+				Filter: &model.Filter{
+					Terms: []model.FilterPredicate{{
+						Scope:     InventoryGroupScope,
+						Attribute: InventoryGroupAttributeName,
+						Type:      "$eq",
+						Value:     "factory",
+					}},
+				},
+			}
+
+			inventoryV2Client := oas_mocks.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+				ApiService: inventoryV2Client,
+			}
+			inventoryV2Client.EXPECT().
+				InventoryInternalV2SearchDeviceInventories(ctx, "tenant").
+				Return(req).
+				Times(tc.searches)
+			inventoryV2Client.EXPECT().
+				InventoryInternalV2SearchDeviceInventoriesExecute(mock.Anything).
+				Return(nil, &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(nil),
+					Header:     http.Header{"X-Total-Count": []string{"0"}},
+				}, nil).
+				Times(tc.searches)
+
+			d := NewDeployments(mocks.NewDataStore(t), nil, 0, false)
+			d.inventoryV2Client = inventoryV2Client
+			d.SetContinuousMatchInterval(tc.interval)
+
+			for i := 0; i < 2; i++ {
+				joined, err := d.joinContinuousDeployment(ctx, deployment, deviceID)
+				assert.NoError(t, err)
+				assert.False(t, joined)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/api"
+	openapi "github.com/mendersoftware/mender-server/pkg/api/client"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+const (
+	workflowGenerateDelta = "generate_delta_artifact"
+
+	// InventoryUpdateModulesAttributeName lists the update modules
+	// installed on the device
+	InventoryUpdateModulesAttributeName = "update_modules"
+)
+
+// getDelta returns the delta artifact updating the device from the artifact
+// it reports as installed to the target image, if one is ready. If there is
+// no delta yet, its generation is scheduled (if generate is set) and nil is
+// returned, so the device gets the full image in the meantime. Deltas are
+// served only to the devices running the exact root filesystem of the
+// source image and able to install them.
+func (d *Deployments) getDelta(
+	ctx context.Context,
+	deviceID string,
+	target *model.Image,
+	request *model.DeploymentNextRequest,
+	generate bool,
+) *model.Delta {
+	l := log.FromContext(ctx)
+
+	if !target.IsRootfsImage() ||
+		request == nil || request.DeviceProvides == nil {
+		return nil
+	}
+	source, err := d.db.ImageByNameAndDeviceType(ctx,
+		request.DeviceProvides.ArtifactName,
+		request.DeviceProvides.DeviceType,
+	)
+	if err != nil {
+		l.Errorf("failed to look up the installed artifact: %s", err.Error())
+		return nil
+	} else if source == nil ||
+		source.Id == target.Id ||
+		!source.IsRootfsImage() {
+		return nil
+	}
+	// the delta applies to the root filesystem of the source image only
+	checksum := request.DeviceProvides.Provides[model.ArtifactProvidesRootfsChecksum]
+	if checksum == "" ||
+		checksum != source.ArtifactMeta.Provides[model.ArtifactProvidesRootfsChecksum] {
+		return nil
+	}
+
+	delta, err := d.db.FindDelta(ctx, source.Id, target.Id)
+	if err != nil {
+		l.Errorf("failed to look up the delta artifact: %s", err.Error())
+		return nil
+	}
+	switch {
+	case delta == nil, delta.CanRetry(time.Now()):
+		if !generate {
+			return nil
+		}
+	case delta.Status != model.DeltaStatusReady:
+		return nil
+	}
+
+	supported, err := d.deviceSupportsBinaryDelta(ctx, deviceID)
+	if err != nil {
+		l.Errorf("failed to check the update modules of the device: %s", err.Error())
+		return nil
+	} else if !supported {
+		return nil
+	}
+	if delta != nil && delta.Status == model.DeltaStatusReady {
+		return delta
+	}
+
+	if err := d.generateDelta(ctx, source, target, delta); err != nil {
+		l.Errorf("failed to generate the delta artifact from %s to %s: %s",
+			source.Id, target.Id, err.Error())
+	}
+	return nil
+}
+
+// deviceSupportsBinaryDelta reports whether the device has the update
+// module installing the delta artifacts, according to the update modules
+// the device reports in the inventory.
+func (d *Deployments) deviceSupportsBinaryDelta(
+	ctx context.Context,
+	deviceID string,
+) (bool, error) {
+	id := identity.FromContext(ctx)
+	if id == nil {
+		id = &identity.Identity{}
+	}
+	_, _, err := d.searchInventories(ctx, id.Tenant, openapi.SearchParams{
+		Page:      types.Pointer(int32(1)),
+		PerPage:   types.Pointer(int32(1)),
+		DeviceIds: []string{deviceID},
+		Filters: []openapi.FilterPredicate{{
+			Scope:     InventoryInventoryScope,
+			Attribute: InventoryUpdateModulesAttributeName,
+			Type:      "$in",
+			Value: openapi.AttributeValueRequest{
+				ArrayOfString: &[]string{model.UpdateModuleBinaryDelta},
+			},
+		}},
+	})
+	if errors.Is(err, ErrNoDevices) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// generateDelta starts the workflow generating the delta artifact from the
+// source to the target image; the failed generation of the delta, if set,
+// is attempted again.
+func (d *Deployments) generateDelta(
+	ctx context.Context,
+	source, target *model.Image,
+	delta *model.Delta,
+) error {
+	var err error
+	if delta == nil {
+		delta = model.NewDelta(source.Id, target.Id)
+		err = d.db.InsertDelta(ctx, delta)
+	} else {
+		log.FromContext(ctx).Infof("retrying the generation of the delta artifact %s "+
+			"(attempt %d of %d)", delta.Id, delta.Attempts+1, model.DeltaMaxAttempts)
+		err = d.db.RetryDelta(ctx, delta.Id, delta.Attempts)
+	}
+	if err == mongo.ErrConflictingDelta || err == mongo.ErrStorageNotFound {
+		// generation already scheduled by a concurrent request
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err = d.startDeltaWorkflow(ctx, delta, source, target); err != nil {
+		if e := d.db.UpdateDeltaStatus(ctx, delta.Id, model.DeltaStatusUpdate{
+			Status: model.DeltaStatusFailed,
+		}); e != nil {
+			return errors.Wrap(err, e.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+func (d *Deployments) startDeltaWorkflow(
+	ctx context.Context,
+	delta *model.Delta,
+	source, target *model.Image,
+) error {
+	msg := model.GenerateDeltaMsg{DeltaID: delta.Id}
+	if id := identity.FromContext(ctx); id != nil {
+		msg.TenantID = id.Tenant
+	}
+
+	link, err := d.objectStorage.GetRequest(ctx,
+		model.ImagePathFromContext(ctx, source.Id),
+		source.Name+model.ArtifactFileSuffix,
+		DefaultImageGenerationLinkExpire,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	msg.SourceArtifactURI = link.Uri
+
+	link, err = d.objectStorage.GetRequest(ctx,
+		model.ImagePathFromContext(ctx, target.Id),
+		target.Name+model.ArtifactFileSuffix,
+		DefaultImageGenerationLinkExpire,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	msg.TargetArtifactURI = link.Uri
+
+	link, err = d.objectStorage.PutRequest(ctx,
+		model.ImagePathFromContext(ctx, delta.Id),
+		DefaultImageGenerationLinkExpire,
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	msg.PutDeltaURI = link.Uri
+	msg.PutDeltaHeader = link.Header
+
+	_, rsp, err := d.workflowsClient.StartWorkflow(ctx, workflowGenerateDelta).
+		RequestBody(msg.ToRequestParams()).
+		Execute()
+	if rsp != nil {
+		defer rsp.Body.Close()
+	}
+	if err == nil && rsp.StatusCode >= 300 {
+		err = api.NewHTTPError(rsp.StatusCode)
+	}
+	return err
+}
+
+// UpdateDeltaStatus records the outcome of the delta artifact generation
+func (d *Deployments) UpdateDeltaStatus(
+	ctx context.Context,
+	id string,
+	update model.DeltaStatusUpdate,
+) error {
+	err := d.db.UpdateDeltaStatus(ctx, id, update)
+	if err == mongo.ErrStorageNotFound {
+		return ErrDeltaNotFound
+	} else if err == nil && update.Status == model.DeltaStatusFailed {
+		log.FromContext(ctx).Warnf("failed to generate the delta artifact %s; "+
+			"the generation is attempted up to %d times, the devices get the "+
+			"full artifact meanwhile", id, model.DeltaMaxAttempts)
+	}
+	return err
+}
@@ -0,0 +1,363 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_client "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
+)
+
+func TestGetDeploymentInstructionsWithDelta(t *testing.T) {
+	t.Parallel()
+
+	newImage := func(id, name, updateType string) *model.Image {
+		return &model.Image{
+			Id: id,
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  name,
+				DeviceTypesCompatible: []string{"foo"},
+				Updates: []model.Update{{
+					TypeInfo: model.ArtifactUpdateTypeInfo{Type: &updateType},
+				}},
+			},
+		}
+	}
+	source := newImage("source", "v1", model.ArtifactUpdateTypeRootfs)
+	source.ArtifactMeta.Provides = map[string]string{
+		model.ArtifactProvidesRootfsChecksum: "rootfs-v1",
+	}
+	target := newImage("target", "v2", model.ArtifactUpdateTypeRootfs)
+	target.Checksum = "checksum"
+	ready := &model.Delta{Id: "delta", Status: model.DeltaStatusReady}
+	pending := &model.Delta{Id: "delta", Status: model.DeltaStatusPending}
+	failed := func(attempts int, modified time.Time) *model.Delta {
+		return &model.Delta{
+			Id:       "delta",
+			Status:   model.DeltaStatusFailed,
+			Attempts: attempts,
+			Modified: modified,
+		}
+	}
+	longAgo := time.Now().Add(-2 * model.DeltaRetryInterval)
+
+	testCases := map[string]struct {
+		disabled    bool
+		source      *model.Image
+		checksum    *string
+		delta       *model.Delta
+		unsupported bool
+		generate    bool
+		workflowOK  bool
+		served      *model.DeviceDeploymentObject
+
+		objectID string
+	}{
+		"ok, delta ready": {
+			source:   source,
+			delta:    ready,
+			objectID: "delta",
+		},
+		"ok, delta ready, device without the delta update module": {
+			source:      source,
+			delta:       ready,
+			unsupported: true,
+			objectID:    "target",
+		},
+		"ok, installed root filesystem differs from the source image": {
+			source:   source,
+			checksum: types.Pointer("rootfs-modified"),
+			objectID: "target",
+		},
+		"ok, device does not report the root filesystem checksum": {
+			source:   source,
+			checksum: types.Pointer(""),
+			objectID: "target",
+		},
+		"ok, failed delta generation retried": {
+			source:     source,
+			delta:      failed(1, longAgo),
+			generate:   true,
+			workflowOK: true,
+			objectID:   "target",
+		},
+		"ok, failed delta generation waiting to be retried": {
+			source:   source,
+			delta:    failed(1, time.Now()),
+			objectID: "target",
+		},
+		"ok, failed delta generation given up": {
+			source:   source,
+			delta:    failed(model.DeltaMaxAttempts, longAgo),
+			objectID: "target",
+		},
+		"ok, delta not generated for devices without the delta update module": {
+			source:      source,
+			unsupported: true,
+			objectID:    "target",
+		},
+		"ok, delta ready and served before": {
+			source:   source,
+			delta:    ready,
+			served:   &model.DeviceDeploymentObject{ID: "delta"},
+			objectID: "delta",
+		},
+		"ok, delta generation disabled": {
+			disabled: true,
+			objectID: "target",
+		},
+		"ok, delta pending": {
+			source:   source,
+			delta:    pending,
+			objectID: "target",
+		},
+		"ok, unknown installed artifact": {
+			objectID: "target",
+		},
+		"ok, installed artifact is not a rootfs image": {
+			source:   newImage("source", "v1", "single-file"),
+			objectID: "target",
+		},
+		"ok, delta generated": {
+			source:     source,
+			generate:   true,
+			workflowOK: true,
+			objectID:   "target",
+		},
+		"ok, delta generation failed": {
+			source:   source,
+			generate: true,
+			objectID: "target",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			fs := fs_mocks.NewObjectStorage(t)
+			workflowsClient := oas_client.NewMockWorkflowsOtherAPI(t)
+
+			deployment := &model.Deployment{
+				Id:                    "deployment",
+				DeploymentConstructor: &model.DeploymentConstructor{},
+			}
+			deviceDeployment := &model.DeviceDeployment{
+				Id:           "device-deployment",
+				DeploymentId: "deployment",
+				Image:        target,
+				Status:       model.DeviceDeploymentStatusDownloading,
+				Object:       tc.served,
+			}
+			checksum := "rootfs-v1"
+			if tc.checksum != nil {
+				checksum = *tc.checksum
+			}
+			request := &model.DeploymentNextRequest{
+				DeviceProvides: &model.InstalledDeviceDeployment{
+					ArtifactName: "v1",
+					DeviceType:   "foo",
+					Provides: map[string]string{
+						model.ArtifactProvidesRootfsChecksum: checksum,
+					},
+				},
+			}
+			deviceDeployment.DeviceId = "device"
+
+			db.On("GetStorageSettings", ctx).Return(nil, nil)
+			db.On("GetDownloadSettings", h.ContextMatcher()).Return(nil, nil)
+			if !tc.disabled {
+				db.On("ImageByNameAndDeviceType", h.ContextMatcher(), "v1", "foo").
+					Return(tc.source, nil)
+			}
+			if tc.source != nil && tc.source.IsRootfsImage() && tc.checksum == nil {
+				db.On("FindDelta", h.ContextMatcher(), "source", "target").
+					Return(tc.delta, nil)
+			}
+			inventoryV2Client := oas_client.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			if tc.unsupported || tc.generate || tc.delta == ready {
+				var devices []client.DeviceInventoryResponse
+				if !tc.unsupported {
+					devices = append(devices, client.DeviceInventoryResponse{
+						Id: types.Pointer("device"),
+					})
+				}
+				req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+					ApiService: inventoryV2Client,
+				}
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventories(h.ContextMatcher(), "").
+					Return(req)
+				req = req.SearchParams(client.SearchParams{
+					Page:      types.Pointer(int32(1)),
+					PerPage:   types.Pointer(int32(1)),
+					DeviceIds: []string{"device"},
+					Filters: []client.FilterPredicate{{
+						Scope:     InventoryInventoryScope,
+						Attribute: InventoryUpdateModulesAttributeName,
+						Type:      "$in",
+						Value: client.AttributeValueRequest{
+							ArrayOfString: &[]string{model.UpdateModuleBinaryDelta},
+						},
+					}},
+				})
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventoriesExecute(req).
+					Return(devices, &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(nil),
+						Header:     http.Header{"X-Total-Count": []string{"1"}},
+					}, nil)
+			}
+			if tc.generate {
+				if tc.delta == nil {
+					db.On("InsertDelta", h.ContextMatcher(),
+						mock.AnythingOfType("*model.Delta")).
+						Return(nil)
+				} else {
+					db.On("RetryDelta", h.ContextMatcher(),
+						tc.delta.Id, tc.delta.Attempts).
+						Return(nil)
+				}
+				fs.On("GetRequest", h.ContextMatcher(),
+					"source", "v1"+model.ArtifactFileSuffix,
+					DefaultImageGenerationLinkExpire, false).
+					Return(&model.Link{Uri: "GET source"}, nil)
+				fs.On("GetRequest", h.ContextMatcher(),
+					"target", "v2"+model.ArtifactFileSuffix,
+					DefaultImageGenerationLinkExpire, false).
+					Return(&model.Link{Uri: "GET target"}, nil)
+				fs.On("PutRequest", h.ContextMatcher(),
+					mock.AnythingOfType("string"),
+					DefaultImageGenerationLinkExpire, false).
+					Return(&model.Link{Uri: "PUT delta"}, nil)
+
+				workflowsClient.EXPECT().
+					StartWorkflow(h.ContextMatcher(), workflowGenerateDelta).
+					Return(client.ApiStartWorkflowRequest{
+						ApiService: workflowsClient,
+					})
+				if tc.workflowOK {
+					workflowsClient.EXPECT().
+						StartWorkflowExecute(mock.Anything).
+						Return(nil, &http.Response{
+							StatusCode: http.StatusCreated,
+							Body:       io.NopCloser(nil),
+						}, nil)
+				} else {
+					workflowsClient.EXPECT().
+						StartWorkflowExecute(mock.Anything).
+						Return(nil, nil, errors.New("workflows unavailable"))
+					db.On("UpdateDeltaStatus", h.ContextMatcher(),
+						mock.AnythingOfType("string"),
+						model.DeltaStatusUpdate{Status: model.DeltaStatusFailed}).
+						Return(nil)
+				}
+			}
+			if tc.served == nil {
+				db.On("SaveDeviceDeploymentObject", h.ContextMatcher(),
+					"device-deployment",
+					&model.DeviceDeploymentObject{ID: tc.objectID}).
+					Return(nil)
+			}
+			fs.On("GetRequest", h.ContextMatcher(),
+				tc.objectID, "v2"+model.ArtifactFileSuffix,
+				DefaultUpdateDownloadLinkExpire, true).
+				Return(&model.Link{Uri: "GET " + tc.objectID}, nil)
+
+			d := NewDeployments(db, fs, 0, false)
+			d.workflowsClient = workflowsClient
+			d.inventoryV2Client = inventoryV2Client
+			d.SetEnableDeltaGeneration(!tc.disabled)
+
+			instructions, err := d.getDeploymentInstructions(
+				ctx, deployment, deviceDeployment, request,
+			)
+			assert.NoError(t, err)
+			if assert.NotNil(t, instructions) {
+				assert.Equal(t, "GET "+tc.objectID, instructions.Artifact.Source.Uri)
+				assert.Equal(t, "target", instructions.Artifact.ID)
+				assert.Equal(t, "v2", instructions.Artifact.ArtifactName)
+				// the checksum refers to the full artifact only
+				if tc.objectID == "target" {
+					assert.Equal(t, "checksum", instructions.Artifact.Checksum)
+				} else {
+					assert.Empty(t, instructions.Artifact.Checksum)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateDeltaStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		dbErr error
+		err   error
+	}{
+		"ok": {},
+		"error, not found": {
+			dbErr: mongo.ErrStorageNotFound,
+			err:   ErrDeltaNotFound,
+		},
+		"error, internal": {
+			dbErr: errors.New("internal error"),
+			err:   errors.New("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			update := model.DeltaStatusUpdate{
+				Status: model.DeltaStatusReady,
+				Size:   10,
+			}
+			db := mocks.NewDataStore(t)
+			db.On("UpdateDeltaStatus", ctx, "delta", update).Return(tc.dbErr)
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.UpdateDeltaStatus(ctx, "delta", update)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
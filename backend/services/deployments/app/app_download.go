@@ -0,0 +1,176 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// GetDownloadSettings returns the tenant's download link settings, or the
+// defaults if not set.
+func (d *Deployments) GetDownloadSettings(
+	ctx context.Context,
+) (*model.DownloadSettings, error) {
+	settings, err := d.db.GetDownloadSettings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get download settings")
+	} else if settings == nil {
+		settings = &model.DownloadSettings{}
+	}
+	return settings, nil
+}
+
+func (d *Deployments) SetDownloadSettings(
+	ctx context.Context,
+	settings model.DownloadSettings,
+) error {
+	err := d.db.SetDownloadSettings(ctx, &settings)
+	if err != nil {
+		return errors.Wrap(err, "failed to set download settings")
+	}
+	return nil
+}
+
+// downloadLinkExpire returns the validity of the link downloading size
+// bytes to the device, which lets the device download the artifact at the
+// minimum bandwidth configured for the tenant or the group of the device.
+func (d *Deployments) downloadLinkExpire(
+	ctx context.Context,
+	deviceID string,
+	size int64,
+) (time.Duration, error) {
+	settings, err := d.GetDownloadSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	minBandwidth := settings.MinBandwidth
+	if len(settings.Groups) > 0 {
+		group, err := d.deviceAttributeValue(ctx, deviceID, model.StorageLocationAttribute{
+			Scope: InventoryGroupScope,
+			Name:  InventoryGroupAttributeName,
+		})
+		if err != nil {
+			log.FromContext(ctx).Warnf("failed to look up the group of the device %s: %s",
+				deviceID, err)
+		} else {
+			minBandwidth = settings.MinBandwidthForGroup(group)
+		}
+	}
+	return model.DownloadLinkExpire(
+		size,
+		minBandwidth,
+		DefaultUpdateDownloadLinkExpire,
+		MaxUpdateDownloadLinkExpire,
+	), nil
+}
+
+// deviceArtifactObject selects the object served to the device for the
+// deployment: the delta artifact updating the device from the installed
+// artifact if one is ready, or the artifact of the deployment.
+func (d *Deployments) deviceArtifactObject(
+	ctx context.Context,
+	deviceDeployment *model.DeviceDeployment,
+	request *model.DeploymentNextRequest,
+	generateDelta bool,
+) *model.DeviceDeploymentObject {
+	if d.enableDeltaGeneration {
+		delta := d.getDelta(ctx, deviceDeployment.DeviceId,
+			deviceDeployment.Image, request, generateDelta)
+		if delta != nil {
+			return &model.DeviceDeploymentObject{
+				ID:   delta.Id,
+				Size: delta.Size,
+			}
+		}
+	}
+	return &model.DeviceDeploymentObject{
+		ID:   deviceDeployment.Image.Id,
+		Size: deviceDeployment.Image.Size,
+	}
+}
+
+// deviceObjectLink returns the link downloading the object served to the
+// device for the deployment. The context must carry the storage settings.
+func (d *Deployments) deviceObjectLink(
+	ctx context.Context,
+	deviceDeployment *model.DeviceDeployment,
+	object *model.DeviceDeploymentObject,
+) (*model.Link, error) {
+	expire, err := d.downloadLinkExpire(ctx, deviceDeployment.DeviceId, object.Size)
+	if err != nil {
+		return nil, err
+	}
+	link, err := d.deviceDownloadLink(
+		ctx,
+		deviceDeployment.DeviceId,
+		model.ImagePathFromContext(ctx, object.ID),
+		deviceDeployment.Image.Name+model.ArtifactFileSuffix,
+		expire,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Generating download link for the device")
+	}
+	return link, nil
+}
+
+// GetDeviceDownloadLink returns a new link downloading the artifact of an
+// ongoing device deployment, so that the device can resume a download after
+// the link expired without restarting the deployment.
+func (d *Deployments) GetDeviceDownloadLink(
+	ctx context.Context,
+	deviceID string,
+	deploymentID string,
+) (*model.Link, error) {
+	deviceDeployment, err := d.db.GetDeviceDeployment(ctx, deploymentID, deviceID, false)
+	if err == mongo.ErrStorageNotFound {
+		return nil, ErrStorageNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	switch deviceDeployment.Status {
+	case model.DeviceDeploymentStatusAborted:
+		return nil, ErrDeploymentAborted
+	case model.DeviceDeploymentStatusDecommissioned:
+		return nil, ErrDeviceDecommissioned
+	}
+	if !deviceDeployment.Status.Active() {
+		return nil, ErrDeviceDeploymentFinished
+	} else if deviceDeployment.Image == nil {
+		return nil, ErrNoArtifact
+	}
+
+	ctx, err = d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the link serves the object of the deployment instructions, so that
+	// the device resumes downloading the same object
+	object := deviceDeployment.Object
+	if object == nil {
+		object = &model.DeviceDeploymentObject{
+			ID:   deviceDeployment.Image.Id,
+			Size: deviceDeployment.Image.Size,
+		}
+	}
+	return d.deviceObjectLink(ctx, deviceDeployment, object)
+}
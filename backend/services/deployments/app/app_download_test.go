@@ -0,0 +1,295 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	mstorage "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+func TestDownloadLinkExpire(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tenantID = "tenant"
+		deviceID = "b1d2d1e4-6e7d-4c1a-8d6e-0d1c1b0e9f3a"
+		size     = 2 * 1024 * 1024 * 1024
+	)
+	groups := []model.GroupDownloadSettings{{
+		Group:        "cellular",
+		MinBandwidth: 8 * 1024,
+	}}
+
+	testCases := map[string]struct {
+		settings *model.DownloadSettings
+		err      error
+
+		group     string
+		searchErr error
+
+		expire time.Duration
+	}{
+		"ok, no settings": {
+			expire: DefaultUpdateDownloadLinkExpire,
+		},
+		"ok, tenant bandwidth": {
+			settings: &model.DownloadSettings{MinBandwidth: 16 * 1024},
+			expire:   131072 * time.Second,
+		},
+		"ok, group bandwidth": {
+			settings: &model.DownloadSettings{
+				MinBandwidth: 16 * 1024,
+				Groups:       groups,
+			},
+			group:  "cellular",
+			expire: 262144 * time.Second,
+		},
+		"ok, other group": {
+			settings: &model.DownloadSettings{
+				MinBandwidth: 16 * 1024,
+				Groups:       groups,
+			},
+			group:  "fleet",
+			expire: 131072 * time.Second,
+		},
+		"ok, inventory error": {
+			settings: &model.DownloadSettings{
+				MinBandwidth: 16 * 1024,
+				Groups:       groups,
+			},
+			searchErr: errors.New("connection refused"),
+			expire:    131072 * time.Second,
+		},
+		"error, settings": {
+			err: errors.New("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			db := mstore.NewDataStore(t)
+			inventoryV2Client := oas_mocks.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			d := NewDeployments(db, nil, 0, false)
+			d.inventoryV2Client = inventoryV2Client
+
+			db.On("GetDownloadSettings", ctx).Return(tc.settings, tc.err)
+			if tc.settings != nil && len(tc.settings.Groups) > 0 {
+				req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+					ApiService: inventoryV2Client,
+				}
+				req = req.SearchParams(client.SearchParams{
+					Page:      types.Pointer(int32(1)),
+					PerPage:   types.Pointer(int32(1)),
+					DeviceIds: []string{deviceID},
+					Attributes: []client.SelectAttribute{{
+						Scope:     InventoryGroupScope,
+						Attribute: InventoryGroupAttributeName,
+					}},
+				})
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventories(ctx, tenantID).
+					Return(req)
+				var devices []client.DeviceInventoryResponse
+				if tc.group != "" {
+					devices = []client.DeviceInventoryResponse{{
+						Id: types.Pointer(deviceID),
+						Attributes: []client.AttributeResponse{{
+							Name:  InventoryGroupAttributeName,
+							Scope: InventoryGroupScope,
+							Value: client.AttributeValueResponse{
+								String: types.Pointer(tc.group),
+							},
+						}},
+					}}
+				}
+				rsp := &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(nil),
+					Header: http.Header{
+						"X-Total-Count": []string{"1"},
+					},
+				}
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventoriesExecute(req).
+					Return(devices, rsp, tc.searchErr)
+			}
+
+			expire, err := d.downloadLinkExpire(ctx, deviceID, size)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expire, expire)
+		})
+	}
+}
+
+func TestGetDeviceDownloadLink(t *testing.T) {
+	t.Parallel()
+
+	const (
+		deviceID     = "b1d2d1e4-6e7d-4c1a-8d6e-0d1c1b0e9f3a"
+		deploymentID = "2e5d9f16-4d5b-4f4b-9c2f-8b0a4f6d3e21"
+	)
+	image := &model.Image{
+		Id: "artifact",
+		ArtifactMeta: &model.ArtifactMeta{
+			Name: "release-1",
+		},
+		Size: 2 * 1024 * 1024 * 1024,
+	}
+	link := &model.Link{Uri: "https://bucket/artifact"}
+
+	testCases := map[string]struct {
+		deviceDeployment *model.DeviceDeployment
+		getErr           error
+		linkErr          error
+
+		objectID string
+		expire   time.Duration
+		link     *model.Link
+		err      error
+	}{
+		"ok": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusDownloading,
+				Image:  image,
+				Object: &model.DeviceDeploymentObject{
+					ID:   "artifact",
+					Size: image.Size,
+				},
+			},
+			link: link,
+		},
+		"ok, delta served": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusDownloading,
+				Image:  image,
+				Object: &model.DeviceDeploymentObject{
+					ID:   "delta",
+					Size: image.Size / 4 * 3,
+				},
+			},
+			objectID: "delta",
+			expire:   98304 * time.Second,
+			link:     link,
+		},
+		"ok, object not recorded": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusDownloading,
+				Image:  image,
+			},
+			link: link,
+		},
+		"error, not found": {
+			getErr: mongo.ErrStorageNotFound,
+			err:    ErrStorageNotFound,
+		},
+		"error, aborted": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusAborted,
+				Image:  image,
+			},
+			err: ErrDeploymentAborted,
+		},
+		"error, finished": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusSuccess,
+				Image:  image,
+			},
+			err: ErrDeviceDeploymentFinished,
+		},
+		"error, no artifact": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusPending,
+			},
+			err: ErrNoArtifact,
+		},
+		"error, storage": {
+			deviceDeployment: &model.DeviceDeployment{
+				Status: model.DeviceDeploymentStatusDownloading,
+				Image:  image,
+			},
+			linkErr: errors.New("internal error"),
+			err:     errors.New("Generating download link for the device: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			objStore := mstorage.NewObjectStorage(t)
+			if tc.deviceDeployment != nil {
+				tc.deviceDeployment.DeviceId = deviceID
+				tc.deviceDeployment.DeploymentId = deploymentID
+			}
+
+			db.On("GetDeviceDeployment", ctx, deploymentID, deviceID, false).
+				Return(tc.deviceDeployment, tc.getErr)
+			if tc.objectID == "" {
+				tc.objectID = "artifact"
+				tc.expire = 131072 * time.Second
+			}
+			if tc.link != nil || tc.linkErr != nil {
+				db.On("GetStorageSettings", ctx).Return(nil, nil)
+				db.On("GetDownloadSettings", contextMatcher("")).
+					Return(&model.DownloadSettings{MinBandwidth: 16 * 1024}, nil)
+				objStore.On("GetRequest", contextMatcher(""),
+					tc.objectID, "release-1"+model.ArtifactFileSuffix,
+					tc.expire, true).
+					Return(tc.link, tc.linkErr)
+			}
+
+			d := NewDeployments(db, objStore, 0, false)
+			result, err := d.GetDeviceDownloadLink(ctx, deviceID, deploymentID)
+			switch {
+			case tc.linkErr != nil:
+				assert.EqualError(t, err, tc.err.Error())
+			case tc.err != nil:
+				assert.ErrorIs(t, err, tc.err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.link, result)
+			}
+		})
+	}
+}
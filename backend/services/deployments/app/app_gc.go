@@ -0,0 +1,249 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// GCOptions configures the garbage collection of the object storage
+type GCOptions struct {
+	// MinAge is the age the objects and the images must reach before they
+	// are collected; it must exceed the time to upload and process an
+	// artifact.
+	MinAge time.Duration
+
+	// UnusedImages also collects the images no deployment refers to;
+	// otherwise only the orphaned objects are collected.
+	UnusedImages bool
+
+	// DryRun only reports the garbage without removing it.
+	DryRun bool
+}
+
+// GCReport summarizes the garbage found by GarbageCollect
+type GCReport struct {
+	// Objects is the number of objects without a matching image or delta
+	Objects int
+	// ObjectsSize is the total size of the orphaned objects
+	ObjectsSize int64
+	// Images is the number of images not used by any deployment
+	Images int
+	// ImagesSize is the total size of the unused images
+	ImagesSize int64
+}
+
+// GarbageCollect removes the objects which do not belong to any image or
+// delta artifact from the default object storage, and from the storage and
+// the storage locations of the tenants with their own storage settings.
+// With opts.UnusedImages, it also removes the images older than opts.MinAge
+// which no deployment refers to. In dry-run mode the garbage is only logged.
+func (d *Deployments) GarbageCollect(
+	ctx context.Context,
+	opts GCOptions,
+) (*GCReport, error) {
+	report := new(GCReport)
+	before := time.Now().Add(-opts.MinAge)
+
+	tenantDbs, err := d.db.GetTenantDbs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tenant databases")
+	}
+	tenants := make([]string, 0, len(tenantDbs)+1)
+	tenants = append(tenants, "")
+	for _, db := range tenantDbs {
+		tenants = append(tenants, mstore.TenantFromDbName(db, mongo.DbName))
+	}
+	for _, tenantID := range tenants {
+		ctxTenant := contextWithTenant(ctx, tenantID)
+		if opts.UnusedImages {
+			err = d.collectUnusedImages(ctxTenant, before, opts, report)
+			if err != nil {
+				return report, err
+			}
+		}
+		settings, err := d.db.GetStorageSettings(ctxTenant)
+		if err != nil {
+			return report, errors.Wrapf(err,
+				"failed to get the storage settings of tenant %q", tenantID)
+		} else if settings == nil {
+			continue
+		}
+		// the tenant stores its artifacts in its own storage, and
+		// replicates them to the storage locations
+		prefix := ""
+		if tenantID != "" {
+			prefix = tenantID + "/"
+		}
+		err = d.collectOrphanedObjects(storage.SettingsWithContext(ctx, settings),
+			prefix, before, opts, report)
+		if err != nil {
+			return report, errors.WithMessagef(err, "storage of tenant %q", tenantID)
+		}
+		for i := range settings.Locations {
+			location := &settings.Locations[i]
+			err = d.collectOrphanedObjects(
+				storage.SettingsWithContext(ctx, &location.Settings),
+				prefix, before, opts, report)
+			if err != nil {
+				return report, errors.WithMessagef(err,
+					"storage location %q of tenant %q", location.Name, tenantID)
+			}
+		}
+	}
+
+	err = d.collectOrphanedObjects(ctx, "", before, opts, report)
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func contextWithTenant(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return identity.WithContext(ctx, &identity.Identity{
+		Tenant: tenantID,
+	})
+}
+
+func (d *Deployments) collectUnusedImages(
+	ctx context.Context,
+	before time.Time,
+	opts GCOptions,
+	report *GCReport,
+) error {
+	l := log.FromContext(ctx)
+	tenantID := ""
+	if idty := identity.FromContext(ctx); idty != nil {
+		tenantID = idty.Tenant
+	}
+
+	it, err := d.db.FindUnusedImages(ctx, before)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find unused images of tenant %q", tenantID)
+	}
+	defer it.Close(ctx)
+	for {
+		next, err := it.Next(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find unused images of tenant %q", tenantID)
+		} else if !next {
+			break
+		}
+		var image model.Image
+		if err = it.Decode(&image); err != nil {
+			return err
+		}
+		report.Images++
+		report.ImagesSize += image.Size
+		if opts.DryRun {
+			l.Infof("unused image: tenant %q, image %s, %d bytes",
+				tenantID, image.Id, image.Size)
+			continue
+		}
+		err = d.DeleteImage(ctx, image.Id)
+		if err == ErrModelImageInActiveDeployment || err == ErrImageMetaNotFound {
+			// the image is back in use or gone meanwhile
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to delete image %s of tenant %q",
+				image.Id, tenantID)
+		}
+		l.Infof("removed unused image: tenant %q, image %s, %d bytes",
+			tenantID, image.Id, image.Size)
+	}
+	return nil
+}
+
+// collectOrphanedObjects collects the orphaned objects with the prefix from
+// the object storage of the context.
+func (d *Deployments) collectOrphanedObjects(
+	ctx context.Context,
+	prefix string,
+	before time.Time,
+	opts GCOptions,
+	report *GCReport,
+) error {
+	err := d.objectStorage.ListObjects(ctx, prefix, func(obj storage.ObjectInfo) error {
+		return d.collectOrphanedObject(ctx, obj, before, opts, report)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list objects")
+	}
+	return nil
+}
+
+func (d *Deployments) collectOrphanedObject(
+	ctx context.Context,
+	obj storage.ObjectInfo,
+	before time.Time,
+	opts GCOptions,
+	report *GCReport,
+) error {
+	if obj.LastModified == nil || !obj.LastModified.Before(before) {
+		return nil
+	}
+	// the objects are stored as [<tenant ID>/]<image or delta ID>[.tmp]
+	tenantID, name := path.Split(obj.Path)
+	tenantID = strings.TrimSuffix(tenantID, "/")
+	id := strings.TrimSuffix(name, fileSuffixTmp)
+	if strings.Contains(tenantID, "/") || id == "" {
+		return nil
+	}
+
+	ctxTenant := contextWithTenant(ctx, tenantID)
+	found, err := d.db.Exists(ctxTenant, id)
+	if err == nil && !found {
+		found, err = d.db.DeltaExists(ctxTenant, id)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to look up the artifact of object %s", obj.Path)
+	} else if found {
+		return nil
+	}
+
+	var size int64
+	if obj.Size != nil {
+		size = *obj.Size
+	}
+	report.Objects++
+	report.ObjectsSize += size
+	l := log.FromContext(ctx)
+	if opts.DryRun {
+		l.Infof("orphaned object: %s, %d bytes", obj.Path, size)
+		return nil
+	}
+	err = d.objectStorage.DeleteObject(ctx, obj.Path)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		return errors.Wrapf(err, "failed to delete object %s", obj.Path)
+	}
+	l.Infof("removed orphaned object: %s, %d bytes", obj.Path, size)
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	mstorage "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func contextMatcher(tenantID string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		idty := identity.FromContext(ctx)
+		if tenantID == "" {
+			return idty == nil
+		}
+		return idty != nil && idty.Tenant == tenantID
+	})
+}
+
+func TestGarbageCollect(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant1"
+	old := time.Now().Add(-time.Hour * 48)
+	recent := time.Now()
+	size := int64(1024)
+	objects := []storage.ObjectInfo{{
+		// image of the default tenant
+		Path:         "image",
+		Size:         &size,
+		LastModified: &old,
+	}, {
+		// leftover of a failed upload
+		Path:         tenantID + "/orphan.tmp",
+		Size:         &size,
+		LastModified: &old,
+	}, {
+		Path:         tenantID + "/delta",
+		Size:         &size,
+		LastModified: &old,
+	}, {
+		// too recent, may be an upload in progress
+		Path:         tenantID + "/upload.tmp",
+		Size:         &size,
+		LastModified: &recent,
+	}, {
+		// unknown layout
+		Path:         "foo/bar/baz",
+		Size:         &size,
+		LastModified: &old,
+	}}
+	image := model.Image{
+		Id:           "unused",
+		ArtifactMeta: &model.ArtifactMeta{Name: "release"},
+		Size:         2048,
+	}
+
+	tenantSettings := &model.StorageSettings{
+		Type:   model.StorageTypeS3,
+		Region: "eu-west-1",
+		Bucket: "bucket-eu",
+		Key:    "access-key",
+		Secret: "secret-key",
+		Locations: []model.StorageLocation{{
+			Name:   "apac",
+			Values: []string{"ap-southeast"},
+			Settings: model.StorageSettings{
+				Type:   model.StorageTypeS3,
+				Region: "ap-southeast-1",
+				Bucket: "bucket-apac",
+				Key:    "access-key",
+				Secret: "secret-key",
+			},
+		}},
+	}
+	tenantObjects := map[string][]storage.ObjectInfo{
+		"bucket-eu": {{
+			Path:         tenantID + "/delta",
+			Size:         &size,
+			LastModified: &old,
+		}},
+		"bucket-apac": {{
+			// replica of an artifact removed since
+			Path:         tenantID + "/removed",
+			Size:         &size,
+			LastModified: &old,
+		}},
+	}
+
+	testCases := map[string]struct {
+		unusedImages bool
+		dryRun       bool
+		settings     *model.StorageSettings
+
+		report *GCReport
+	}{
+		"ok": {
+			report: &GCReport{
+				Objects:     1,
+				ObjectsSize: size,
+			},
+		},
+		"ok, unused images": {
+			unusedImages: true,
+			report: &GCReport{
+				Objects:     1,
+				ObjectsSize: size,
+				Images:      1,
+				ImagesSize:  image.Size,
+			},
+		},
+		"ok, dry run": {
+			unusedImages: true,
+			dryRun:       true,
+			report: &GCReport{
+				Objects:     1,
+				ObjectsSize: size,
+				Images:      1,
+				ImagesSize:  image.Size,
+			},
+		},
+		"ok, tenant storage and locations": {
+			settings: tenantSettings,
+			report: &GCReport{
+				Objects:     2,
+				ObjectsSize: 2 * size,
+			},
+		},
+	}
+
+	listObjects := func(objects []storage.ObjectInfo) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			fn := args.Get(2).(func(storage.ObjectInfo) error)
+			for _, obj := range objects {
+				if err := fn(obj); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			objStore := mstorage.NewObjectStorage(t)
+
+			db.On("GetTenantDbs").
+				Return([]string{"deployment_service-" + tenantID}, nil)
+			if tc.unusedImages {
+				db.On("FindUnusedImages", contextMatcher(""),
+					mock.AnythingOfType("time.Time")).
+					Return(NewArrayIterator([]model.Image{}), nil)
+				db.On("FindUnusedImages", contextMatcher(tenantID),
+					mock.AnythingOfType("time.Time")).
+					Return(NewArrayIterator([]model.Image{image}), nil)
+			}
+			db.On("GetStorageSettings", contextMatcher("")).
+				Return(nil, nil)
+			db.On("GetStorageSettings", contextMatcher(tenantID)).
+				Return(tc.settings, nil)
+
+			objStore.On("ListObjects", ctx, "", mock.Anything).
+				Run(listObjects(objects)).
+				Return(nil)
+			db.On("Exists", contextMatcher(""), "image").Return(true, nil)
+			db.On("Exists", contextMatcher(tenantID), "orphan").Return(false, nil)
+			db.On("DeltaExists", contextMatcher(tenantID), "orphan").Return(false, nil)
+			db.On("Exists", contextMatcher(tenantID), "delta").Return(false, nil)
+			db.On("DeltaExists", contextMatcher(tenantID), "delta").Return(true, nil)
+
+			if tc.settings != nil {
+				for bucket, objects := range tenantObjects {
+					objStore.On("ListObjects", settingsMatcher(bucket),
+						tenantID+"/", mock.Anything).
+						Run(listObjects(objects)).
+						Return(nil)
+				}
+				db.On("Exists", contextMatcher(tenantID), "removed").Return(false, nil)
+				db.On("DeltaExists", contextMatcher(tenantID), "removed").
+					Return(false, nil)
+				objStore.On("DeleteObject", settingsMatcher("bucket-apac"),
+					tenantID+"/removed").
+					Return(nil)
+			}
+			if tc.unusedImages && !tc.dryRun {
+				db.On("FindImageByID", contextMatcher(tenantID), image.Id).
+					Return(&image, nil)
+				db.On("ExistUnfinishedByArtifactId", contextMatcher(tenantID), image.Id).
+					Return(false, nil)
+				objStore.On("DeleteObject", contextMatcher(tenantID), tenantID+"/"+image.Id).
+					Return(nil)
+				db.On("DeleteImage", contextMatcher(tenantID), image.Id).
+					Return(nil)
+				db.On("UpdateReleaseArtifacts", contextMatcher(tenantID),
+					(*model.Image)(nil), &image, "release").
+					Return(nil)
+			}
+			if !tc.dryRun {
+				objStore.On("DeleteObject", ctx, tenantID+"/orphan.tmp").
+					Return(nil)
+			}
+
+			d := NewDeployments(db, objStore, 0, false)
+			report, err := d.GarbageCollect(ctx, GCOptions{
+				MinAge:       time.Hour * 24,
+				UnusedImages: tc.unusedImages,
+				DryRun:       tc.dryRun,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.report, report)
+		})
+	}
+}
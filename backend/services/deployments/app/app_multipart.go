@@ -0,0 +1,207 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+)
+
+var (
+	ErrMultipartNotSupported = errors.New("the storage does not support multipart uploads")
+	ErrUploadNotMultipart    = errors.New("the upload is not a multipart upload")
+	ErrUploadPartNumber      = errors.Errorf(
+		"part number must be between 1 and %d", model.UploadPartNumberMax,
+	)
+	ErrUploadNoParts     = errors.New("no parts have been uploaded")
+	ErrUploadPartMissing = errors.New("the parts must be numbered from 1 without gaps")
+	ErrUploadPartSize    = errors.Errorf(
+		"all the parts but the last must be at least %d bytes", model.UploadPartSizeMin,
+	)
+)
+
+// uploadPath returns the object path receiving the direct upload
+func uploadPath(ctx context.Context, link *model.UploadLink) string {
+	objectPath := model.ImagePathFromContext(ctx, link.ArtifactID)
+	if !link.SkipVerify {
+		objectPath += fileSuffixTmp
+	}
+	return objectPath
+}
+
+// MultipartUploadLink creates an upload intent receiving the artifact in
+// parts; the parts are uploaded using the links from UploadPartLink and
+// assembled by CompleteUpload.
+func (d *Deployments) MultipartUploadLink(
+	ctx context.Context,
+	expire time.Duration,
+	skipVerify bool,
+) (*model.UploadLink, error) {
+	ctx, err := d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	upLink := &model.UploadLink{
+		ArtifactID: uuid.New().String(),
+		SkipVerify: skipVerify,
+	}
+	uploadID, err := d.objectStorage.CreateMultipartUpload(ctx, uploadPath(ctx, upLink))
+	if errors.Is(err, storage.ErrMultipartNotSupported) {
+		return nil, ErrMultipartNotSupported
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "app: failed to create multipart upload")
+	}
+	upLink.UploadID = uploadID
+	upLink.IssuedAt = time.Now()
+	upLink.Expire = upLink.IssuedAt.Add(expire)
+	err = d.db.InsertUploadIntent(ctx, upLink)
+	if err != nil {
+		return nil, errors.WithMessage(err, "app: error recording the upload intent")
+	}
+	return upLink, nil
+}
+
+// findMultipartUpload returns the pending multipart upload intent
+func (d *Deployments) findMultipartUpload(
+	ctx context.Context,
+	intentID string,
+) (*model.UploadLink, error) {
+	link, err := d.db.FindUploadLink(ctx, intentID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrUploadNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to find the upload link")
+	}
+	if link.UploadID == "" {
+		return nil, ErrUploadNotMultipart
+	}
+	if link.Status != model.LinkStatusPending || !time.Now().Before(link.Expire) {
+		return nil, ErrUploadNotFound
+	}
+	return link, nil
+}
+
+// UploadPartLink returns a signed link uploading a part of a multipart
+// upload, valid until the upload intent expires.
+func (d *Deployments) UploadPartLink(
+	ctx context.Context,
+	intentID string,
+	partNumber int,
+) (*model.Link, error) {
+	if partNumber < 1 || partNumber > model.UploadPartNumberMax {
+		return nil, ErrUploadPartNumber
+	}
+	link, err := d.findMultipartUpload(ctx, intentID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	partLink, err := d.objectStorage.PartRequest(
+		ctx,
+		uploadPath(ctx, link),
+		link.UploadID,
+		partNumber,
+		time.Until(link.Expire),
+		true,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "app: failed to generate signed URL")
+	}
+	return partLink, nil
+}
+
+// ListUploadParts lists the parts received for a multipart upload, so that
+// an interrupted upload can be resumed from the missing parts.
+func (d *Deployments) ListUploadParts(
+	ctx context.Context,
+	intentID string,
+) ([]model.UploadPart, error) {
+	link, err := d.findMultipartUpload(ctx, intentID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := d.objectStorage.ListParts(ctx, uploadPath(ctx, link), link.UploadID)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, ErrUploadNotFound
+	} else if err != nil {
+		return nil, errors.WithMessage(err, "app: failed to list upload parts")
+	}
+	return parts, nil
+}
+
+// completeMultipartUpload assembles the artifact from the uploaded parts
+func (d *Deployments) completeMultipartUpload(
+	ctx context.Context,
+	link *model.UploadLink,
+) error {
+	objectPath := uploadPath(ctx, link)
+	parts, err := d.objectStorage.ListParts(ctx, objectPath, link.UploadID)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return ErrUploadNotFound
+	} else if err != nil {
+		return errors.WithMessage(err, "app: failed to list upload parts")
+	} else if len(parts) == 0 {
+		return ErrUploadNoParts
+	}
+	// the artifact would be assembled from the parts received so far
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return errors.Wrapf(ErrUploadPartMissing, "part %d is missing", i+1)
+		}
+		if i < len(parts)-1 && part.Size < model.UploadPartSizeMin {
+			return errors.Wrapf(ErrUploadPartSize, "part %d is %d bytes",
+				part.Number, part.Size)
+		}
+	}
+	err = d.objectStorage.CompleteMultipartUpload(ctx, objectPath, link.UploadID, parts)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return ErrUploadNotFound
+	} else if err != nil {
+		return errors.WithMessage(err, "app: failed to complete multipart upload")
+	}
+	return nil
+}
+
+// abortMultipartUpload discards the parts of an expired multipart upload
+func (d *Deployments) abortMultipartUpload(
+	ctx context.Context,
+	link model.UploadLink,
+) error {
+	ctx = contextWithTenant(ctx, link.TenantID)
+	ctx, err := d.contextWithStorageSettings(ctx)
+	if err != nil {
+		return err
+	}
+	err = d.objectStorage.AbortMultipartUpload(ctx, uploadPath(ctx, &link), link.UploadID)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		return err
+	}
+	return nil
+}
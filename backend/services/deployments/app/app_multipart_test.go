@@ -0,0 +1,303 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	mstorage "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store"
+	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func TestMultipartUploadLink(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant1"
+
+	testCases := map[string]struct {
+		skipVerify bool
+		createErr  error
+		insertErr  error
+
+		err error
+	}{
+		"ok": {},
+		"ok, skip verify": {
+			skipVerify: true,
+		},
+		"error, not supported": {
+			createErr: storage.ErrMultipartNotSupported,
+			err:       ErrMultipartNotSupported,
+		},
+		"error, storage": {
+			createErr: errors.New("internal error"),
+			err:       errors.New("app: failed to create multipart upload: internal error"),
+		},
+		"error, insert": {
+			insertErr: errors.New("internal error"),
+			err:       errors.New("app: error recording the upload intent: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			db := mstore.NewDataStore(t)
+			objStore := mstorage.NewObjectStorage(t)
+
+			var objectPath string
+			db.On("GetStorageSettings", contextMatcher(tenantID)).Return(nil, nil)
+			objStore.On("CreateMultipartUpload", contextMatcher(tenantID),
+				mock.AnythingOfType("string")).
+				Run(func(args mock.Arguments) {
+					objectPath = args.String(1)
+				}).
+				Return("upload-id", tc.createErr)
+			if tc.createErr == nil {
+				db.On("InsertUploadIntent", contextMatcher(tenantID),
+					mock.AnythingOfType("*model.UploadLink")).
+					Return(tc.insertErr)
+			}
+
+			app := NewDeployments(db, objStore, 0, false)
+			link, err := app.MultipartUploadLink(ctx, time.Hour, tc.skipVerify)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				assert.Nil(t, link)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "upload-id", link.UploadID)
+			assert.Equal(t, tc.skipVerify, link.SkipVerify)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), link.Expire, time.Minute)
+			expectedPath := tenantID + "/" + link.ArtifactID
+			if !tc.skipVerify {
+				expectedPath += fileSuffixTmp
+			}
+			assert.Equal(t, expectedPath, objectPath)
+		})
+	}
+}
+
+func TestUploadPartLink(t *testing.T) {
+	t.Parallel()
+
+	const intentID = "9bf1bfff-eeb4-49d4-b55d-d717d407888a"
+	partLink := &model.Link{
+		Uri:    "https://storage/part",
+		Method: "PUT",
+		Expire: time.Now().Add(time.Hour),
+	}
+
+	testCases := map[string]struct {
+		partNumber int
+		link       *model.UploadLink
+		findErr    error
+
+		err error
+	}{
+		"ok": {
+			partNumber: 1,
+			link: &model.UploadLink{
+				ArtifactID: intentID,
+				Link:       model.Link{Expire: time.Now().Add(time.Hour)},
+				Status:     model.LinkStatusPending,
+				UploadID:   "upload-id",
+			},
+		},
+		"error, invalid part number": {
+			partNumber: model.UploadPartNumberMax + 1,
+			err:        ErrUploadPartNumber,
+		},
+		"error, not found": {
+			partNumber: 1,
+			findErr:    store.ErrNotFound,
+			err:        ErrUploadNotFound,
+		},
+		"error, not multipart": {
+			partNumber: 1,
+			link: &model.UploadLink{
+				ArtifactID: intentID,
+				Link:       model.Link{Expire: time.Now().Add(time.Hour)},
+				Status:     model.LinkStatusPending,
+			},
+			err: ErrUploadNotMultipart,
+		},
+		"error, expired": {
+			partNumber: 1,
+			link: &model.UploadLink{
+				ArtifactID: intentID,
+				Link:       model.Link{Expire: time.Now().Add(-time.Hour)},
+				Status:     model.LinkStatusPending,
+				UploadID:   "upload-id",
+			},
+			err: ErrUploadNotFound,
+		},
+		"error, already completed": {
+			partNumber: 1,
+			link: &model.UploadLink{
+				ArtifactID: intentID,
+				Link:       model.Link{Expire: time.Now().Add(time.Hour)},
+				Status:     model.LinkStatusProcessing,
+				UploadID:   "upload-id",
+			},
+			err: ErrUploadNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			objStore := mstorage.NewObjectStorage(t)
+
+			if tc.link != nil || tc.findErr != nil {
+				db.On("FindUploadLink", ctx, intentID).Return(tc.link, tc.findErr)
+			}
+			if tc.err == nil {
+				db.On("GetStorageSettings", ctx).Return(nil, nil)
+				objStore.On("PartRequest",
+					contextMatcher(""),
+					intentID+fileSuffixTmp,
+					"upload-id",
+					tc.partNumber,
+					mock.AnythingOfType("time.Duration"),
+					true).
+					Return(partLink, nil)
+			}
+
+			app := NewDeployments(db, objStore, 0, false)
+			link, err := app.UploadPartLink(ctx, intentID, tc.partNumber)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, partLink, link)
+		})
+	}
+}
+
+func TestListUploadParts(t *testing.T) {
+	t.Parallel()
+
+	const intentID = "9bf1bfff-eeb4-49d4-b55d-d717d407888a"
+	link := &model.UploadLink{
+		ArtifactID: intentID,
+		Link:       model.Link{Expire: time.Now().Add(time.Hour)},
+		Status:     model.LinkStatusPending,
+		UploadID:   "upload-id",
+		SkipVerify: true,
+	}
+	parts := []model.UploadPart{
+		{Number: 1, Size: 1024, ETag: "etag-1"},
+		{Number: 3, Size: 1024, ETag: "etag-3"},
+	}
+
+	testCases := map[string]struct {
+		listErr error
+
+		parts []model.UploadPart
+		err   error
+	}{
+		"ok": {
+			parts: parts,
+		},
+		"error, upload gone": {
+			listErr: storage.ErrObjectNotFound,
+			err:     ErrUploadNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			objStore := mstorage.NewObjectStorage(t)
+
+			db.On("FindUploadLink", ctx, intentID).Return(link, nil)
+			db.On("GetStorageSettings", ctx).Return(nil, nil)
+			objStore.On("ListParts", contextMatcher(""), intentID, "upload-id").
+				Return(tc.parts, tc.listErr)
+
+			app := NewDeployments(db, objStore, 0, false)
+			result, err := app.ListUploadParts(ctx, intentID)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.parts, result)
+		})
+	}
+}
+
+func TestCleanupExpiredMultipartUpload(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant1"
+	ctx := context.Background()
+	link := model.UploadLink{
+		ArtifactID: "624836fd-29f5-474e-b101-5482b67c9204",
+		Link: model.Link{
+			TenantID: tenantID,
+			Expire:   time.Now().Add(-time.Hour),
+		},
+		Status:   model.LinkStatusPending,
+		UploadID: "upload-id",
+	}
+	db := mstore.NewDataStore(t)
+	objStore := mstorage.NewObjectStorage(t)
+
+	db.On("GetStorageSettings", contextMatcher(tenantID)).Return(nil, nil)
+	objStore.On("AbortMultipartUpload", contextMatcher(tenantID),
+		tenantID+"/"+link.ArtifactID+fileSuffixTmp, "upload-id").
+		Return(storage.ErrObjectNotFound)
+	objStore.On("DeleteObject", ctx,
+		tenantID+"/"+link.ArtifactID+fileSuffixTmp).
+		Return(storage.ErrObjectNotFound)
+	db.On("UpdateUploadIntentStatus", ctx, link.ArtifactID,
+		model.LinkStatusPending,
+		model.LinkStatusAborted|model.LinkStatusProcessedBit).
+		Return(nil)
+
+	app := NewDeployments(db, objStore, 0, false)
+	err := app.cleanupExpiredLink(ctx, link, time.Now())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+// PauseDeployment pauses the deployment: the pending devices don't get the
+// deployment until it is resumed, while the devices which already started
+// the deployment keep going.
+func (d *Deployments) PauseDeployment(ctx context.Context, deploymentID string) error {
+	deployment, err := d.db.FindDeploymentByID(ctx, deploymentID)
+	if err != nil {
+		return errors.Wrap(err, "Searching for deployment by ID")
+	} else if deployment == nil {
+		return ErrModelDeploymentNotFound
+	}
+	if deployment.Status == model.DeploymentStatusFinished {
+		return ErrDeploymentFinished
+	} else if deployment.IsPaused() {
+		return ErrDeploymentPaused
+	}
+
+	return d.updateDeploymentStatus(ctx,
+		deploymentID, deployment.Status, model.DeploymentStatusPaused)
+}
+
+// ResumeDeployment resumes a paused deployment, setting back the status
+// computed from the deployment statistics.
+func (d *Deployments) ResumeDeployment(ctx context.Context, deploymentID string) error {
+	deployment, err := d.db.FindDeploymentByID(ctx, deploymentID)
+	if err != nil {
+		return errors.Wrap(err, "Searching for deployment by ID")
+	} else if deployment == nil {
+		return ErrModelDeploymentNotFound
+	}
+	if deployment.Status == model.DeploymentStatusFinished {
+		return ErrDeploymentFinished
+	} else if !deployment.IsPaused() {
+		return ErrDeploymentNotPaused
+	}
+
+	deployment.Status = ""
+	return d.updateDeploymentStatus(ctx,
+		deploymentID, model.DeploymentStatusPaused, deployment.GetStatus())
+}
+
+// updateDeploymentStatus sets the deployment status unless it changed since
+// the deployment was read.
+func (d *Deployments) updateDeploymentStatus(
+	ctx context.Context,
+	deploymentID string,
+	from, to model.DeploymentStatus,
+) error {
+	err := d.db.UpdateDeploymentStatus(ctx, deploymentID, from, to, time.Now())
+	if err == mongo.ErrStorageNotFound {
+		return ErrDeploymentStatusChanged
+	} else if err != nil {
+		return errors.Wrap(err, "failed to update deployment status")
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+func TestPauseDeployment(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deployment *model.Deployment
+		findErr    error
+		setErr     error
+
+		err error
+	}{
+		"ok": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusInProgress,
+			},
+		},
+		"error, not found": {
+			err: ErrModelDeploymentNotFound,
+		},
+		"error, already paused": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+			},
+			err: ErrDeploymentPaused,
+		},
+		"error, finished": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusFinished,
+			},
+			err: ErrDeploymentFinished,
+		},
+		"error, find": {
+			findErr: errors.New("internal error"),
+			err:     errors.New("Searching for deployment by ID: internal error"),
+		},
+		"error, set status": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPending,
+			},
+			setErr: errors.New("internal error"),
+			err:    errors.New("failed to update deployment status: internal error"),
+		},
+		"error, status changed concurrently": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusInProgress,
+			},
+			setErr: mongo.ErrStorageNotFound,
+			err:    ErrDeploymentStatusChanged,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("FindDeploymentByID", ctx, "deployment").
+				Return(tc.deployment, tc.findErr)
+			if tc.deployment != nil && !tc.deployment.IsPaused() &&
+				tc.deployment.Status != model.DeploymentStatusFinished {
+				db.On("UpdateDeploymentStatus", ctx, "deployment",
+					tc.deployment.Status,
+					model.DeploymentStatusPaused,
+					mock.AnythingOfType("time.Time")).
+					Return(tc.setErr)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.PauseDeployment(ctx, "deployment")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResumeDeployment(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deployment *model.Deployment
+		findErr    error
+		setErr     error
+
+		status model.DeploymentStatus
+		err    error
+	}{
+		"ok, pending": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+				Stats: model.Stats{
+					model.DeviceDeploymentStatusPendingStr: 2,
+				},
+				MaxDevices: 2,
+			},
+			status: model.DeploymentStatusPending,
+		},
+		"ok, in progress": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+				Stats: model.Stats{
+					model.DeviceDeploymentStatusPendingStr:     1,
+					model.DeviceDeploymentStatusDownloadingStr: 1,
+				},
+				MaxDevices: 2,
+			},
+			status: model.DeploymentStatusInProgress,
+		},
+		"ok, finished while paused": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+				Stats: model.Stats{
+					model.DeviceDeploymentStatusSuccessStr: 2,
+				},
+				MaxDevices: 2,
+			},
+			status: model.DeploymentStatusFinished,
+		},
+		"error, not found": {
+			err: ErrModelDeploymentNotFound,
+		},
+		"error, not paused": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusInProgress,
+			},
+			err: ErrDeploymentNotPaused,
+		},
+		"error, finished": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusFinished,
+			},
+			err: ErrDeploymentFinished,
+		},
+		"error, find": {
+			findErr: errors.New("internal error"),
+			err:     errors.New("Searching for deployment by ID: internal error"),
+		},
+		"error, set status": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+				Stats: model.Stats{
+					model.DeviceDeploymentStatusPendingStr: 1,
+				},
+				MaxDevices: 1,
+			},
+			setErr: errors.New("internal error"),
+			status: model.DeploymentStatusPending,
+			err:    errors.New("failed to update deployment status: internal error"),
+		},
+		"error, status changed concurrently": {
+			deployment: &model.Deployment{
+				Id:     "deployment",
+				Status: model.DeploymentStatusPaused,
+				Stats: model.Stats{
+					model.DeviceDeploymentStatusPendingStr: 1,
+				},
+				MaxDevices: 1,
+			},
+			setErr: mongo.ErrStorageNotFound,
+			status: model.DeploymentStatusPending,
+			err:    ErrDeploymentStatusChanged,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("FindDeploymentByID", ctx, "deployment").
+				Return(tc.deployment, tc.findErr)
+			if tc.status != "" {
+				db.On("UpdateDeploymentStatus", ctx, "deployment",
+					model.DeploymentStatusPaused,
+					tc.status,
+					mock.AnythingOfType("time.Time")).
+					Return(tc.setErr)
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			err := d.ResumeDeployment(ctx, "deployment")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	openapi "github.com/mendersoftware/mender-server/pkg/api/client"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+// PreviewDeployment resolves the devices the deployment would target, the
+// same way CreateDeployment does, and counts how many of them can install
+// the release without creating the deployment.
+func (d *Deployments) PreviewDeployment(
+	ctx context.Context,
+	constructor *model.DeploymentConstructor,
+) (*model.DeploymentPreview, error) {
+	if constructor == nil {
+		return nil, ErrModelMissingInput
+	}
+
+	if err := constructor.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Validating deployment")
+	}
+
+	artifacts, err := d.db.ImagesByName(ctx, constructor.ArtifactName)
+	if err != nil {
+		return nil, errors.Wrap(err, "Finding artifact with given name")
+	}
+	if len(artifacts) == 0 {
+		return nil, ErrNoArtifact
+	}
+
+	id := identity.FromContext(ctx)
+	if id == nil {
+		id = &identity.Identity{}
+	}
+
+	preview := &model.DeploymentPreview{}
+	page := int32(1)
+	searchParams := openapi.SearchParams{
+		Page:       &page,
+		PerPage:    types.Pointer(int32(PerPageInventoryDevices)),
+		Attributes: getPreviewSearchAttributes(artifacts),
+	}
+	dynamic := len(constructor.Group) > 0 || constructor.AllDevices ||
+		len(constructor.FilterTerms) > 0
+	if dynamic {
+		searchParams.Filters = getDeploymentSearchFilters(constructor)
+	} else {
+		searchParams.DeviceIds = constructor.Devices
+		preview.TargetDevices = len(constructor.Devices)
+	}
+
+	var found int
+	for {
+		devices, count, err := d.searchInventories(ctx, id.Tenant, searchParams)
+		if errors.Is(err, ErrNoDevices) {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to search for devices")
+		}
+		if dynamic {
+			preview.TargetDevices = int(count)
+		}
+		for _, device := range devices {
+			provides := getInventoryProvides(device)
+			for _, artifact := range artifacts {
+				if artifact.ArtifactMeta == nil ||
+					!artifact.ArtifactMeta.DependsSatisfied(provides) {
+					continue
+				}
+				preview.CompatibleDevices++
+				if provides[InventoryArtifactAttributeName] == constructor.ArtifactName {
+					preview.AlreadyInstalled++
+				}
+				break
+			}
+		}
+		found += len(devices)
+		if found >= int(count) {
+			break
+		}
+		page++
+	}
+
+	return preview, nil
+}
+
+// getPreviewSearchAttributes returns the inventory attributes the
+// dependencies of the artifacts are checked against.
+func getPreviewSearchAttributes(artifacts []*model.Image) []openapi.SelectAttribute {
+	names := []string{InventoryDeviceTypeAttributeName, InventoryArtifactAttributeName}
+	for _, artifact := range artifacts {
+		if artifact.ArtifactMeta == nil {
+			continue
+		}
+		for key := range artifact.ArtifactMeta.Depends {
+			if !slices.Contains(names, key) {
+				names = append(names, key)
+			}
+		}
+	}
+	attributes := make([]openapi.SelectAttribute, len(names))
+	for i, name := range names {
+		attributes[i] = openapi.SelectAttribute{
+			Attribute: name,
+			Scope:     InventoryInventoryScope,
+		}
+	}
+	return attributes
+}
+
+// getInventoryProvides returns the string attributes the device reported
+// in the inventory scope.
+func getInventoryProvides(device openapi.DeviceInventoryResponse) map[string]string {
+	provides := make(map[string]string, len(device.Attributes))
+	for _, attr := range device.Attributes {
+		if attr.Scope == InventoryInventoryScope && attr.Value.String != nil {
+			provides[attr.Name] = *attr.Value.String
+		}
+	}
+	return provides
+}
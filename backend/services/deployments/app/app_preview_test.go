@@ -0,0 +1,229 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+)
+
+func TestPreviewDeployment(t *testing.T) {
+	t.Parallel()
+
+	newDevice := func(id, deviceType, artifactName string) client.DeviceInventoryResponse {
+		attributes := []client.AttributeResponse{{
+			Name:  InventoryDeviceTypeAttributeName,
+			Scope: InventoryInventoryScope,
+			Value: client.AttributeValueResponse{String: types.Pointer(deviceType)},
+		}}
+		if artifactName != "" {
+			attributes = append(attributes, client.AttributeResponse{
+				Name:  InventoryArtifactAttributeName,
+				Scope: InventoryInventoryScope,
+				Value: client.AttributeValueResponse{String: types.Pointer(artifactName)},
+			})
+		}
+		return client.DeviceInventoryResponse{
+			Id:         types.Pointer(id),
+			Attributes: attributes,
+		}
+	}
+	artifacts := []*model.Image{{
+		Id: "artifact",
+		ArtifactMeta: &model.ArtifactMeta{
+			Name:                  "v2",
+			DeviceTypesCompatible: []string{"foo"},
+			Depends: map[string]interface{}{
+				"device_type":   []interface{}{"foo"},
+				"artifact_name": []interface{}{"v1", "v2"},
+			},
+		},
+	}}
+	attributes := []client.SelectAttribute{{
+		Attribute: InventoryDeviceTypeAttributeName,
+		Scope:     InventoryInventoryScope,
+	}, {
+		Attribute: InventoryArtifactAttributeName,
+		Scope:     InventoryInventoryScope,
+	}}
+
+	testCases := map[string]struct {
+		constructor *model.DeploymentConstructor
+
+		artifacts []*model.Image
+		pages     [][]client.DeviceInventoryResponse
+		searchErr error
+
+		preview *model.DeploymentPreview
+		err     error
+	}{
+		"ok, all devices": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				AllDevices:   true,
+			},
+			artifacts: artifacts,
+			pages: [][]client.DeviceInventoryResponse{{
+				newDevice("1", "foo", "v1"),
+				newDevice("2", "foo", "v2"),
+			}, {
+				newDevice("3", "bar", "v1"),
+				newDevice("4", "foo", "v0"),
+				newDevice("5", "foo", ""),
+			}},
+			preview: &model.DeploymentPreview{
+				TargetDevices:     5,
+				CompatibleDevices: 2,
+				AlreadyInstalled:  1,
+			},
+		},
+		"ok, group": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				Group:        "group",
+			},
+			artifacts: artifacts,
+			pages: [][]client.DeviceInventoryResponse{{
+				newDevice("1", "foo", "v1"),
+			}},
+			preview: &model.DeploymentPreview{
+				TargetDevices:     1,
+				CompatibleDevices: 1,
+			},
+		},
+		"ok, devices": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				Devices:      []string{"1", "2", "3"},
+			},
+			artifacts: artifacts,
+			pages: [][]client.DeviceInventoryResponse{{
+				newDevice("1", "foo", "v2"),
+				newDevice("2", "foo", "v2"),
+			}},
+			preview: &model.DeploymentPreview{
+				TargetDevices:     3,
+				CompatibleDevices: 2,
+				AlreadyInstalled:  2,
+			},
+		},
+		"ok, no devices": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				Group:        "group",
+			},
+			artifacts: artifacts,
+			pages:     [][]client.DeviceInventoryResponse{{}},
+			preview:   &model.DeploymentPreview{},
+		},
+		"error, no artifact": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				AllDevices:   true,
+			},
+			err: ErrNoArtifact,
+		},
+		"error, inventory": {
+			constructor: &model.DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "v2",
+				AllDevices:   true,
+			},
+			artifacts: artifacts,
+			pages:     [][]client.DeviceInventoryResponse{nil},
+			searchErr: errors.New("inventory unavailable"),
+			err:       errors.New("failed to search for devices: inventory unavailable"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: "tenant",
+			})
+			db := mocks.NewDataStore(t)
+			db.On("ImagesByName", ctx, "v2").Return(tc.artifacts, nil)
+
+			inventoryV2Client := oas_mocks.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			var total int
+			for _, devices := range tc.pages {
+				total += len(devices)
+			}
+			for page, devices := range tc.pages {
+				params := client.SearchParams{
+					Page:       types.Pointer(int32(page + 1)),
+					PerPage:    types.Pointer(int32(PerPageInventoryDevices)),
+					Attributes: attributes,
+				}
+				if len(tc.constructor.Devices) > 0 {
+					params.DeviceIds = tc.constructor.Devices
+				} else {
+					params.Filters = getDeploymentSearchFilters(tc.constructor)
+				}
+				req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+					ApiService: inventoryV2Client,
+				}
+				req = req.SearchParams(params)
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventories(ctx, "tenant").
+					Return(req).
+					Once()
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventoriesExecute(req).
+					Return(devices, &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(nil),
+						Header: http.Header{
+							"X-Total-Count": []string{strconv.Itoa(total)},
+						},
+					}, tc.searchErr).
+					Once()
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			d.inventoryV2Client = inventoryV2Client
+
+			preview, err := d.PreviewDeployment(ctx, tc.constructor)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.preview, preview)
+			}
+		})
+	}
+}
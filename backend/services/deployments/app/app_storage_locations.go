@@ -0,0 +1,134 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	openapi "github.com/mendersoftware/mender-server/pkg/api/client"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+// deviceAttributeValue returns the value of the device inventory attribute,
+// or an empty string if the device does not have the attribute.
+func (d *Deployments) deviceAttributeValue(
+	ctx context.Context,
+	deviceID string,
+	attr model.StorageLocationAttribute,
+) (string, error) {
+	id := identity.FromContext(ctx)
+	if id == nil {
+		id = &identity.Identity{}
+	}
+	devices, _, err := d.searchInventories(ctx, id.Tenant, openapi.SearchParams{
+		Page:      types.Pointer(int32(1)),
+		PerPage:   types.Pointer(int32(1)),
+		DeviceIds: []string{deviceID},
+		Attributes: []openapi.SelectAttribute{{
+			Scope:     openapi.Scope(attr.Scope),
+			Attribute: attr.Name,
+		}},
+	})
+	if errors.Is(err, ErrNoDevices) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "searching inventory for device")
+	}
+	for _, device := range devices {
+		for _, attribute := range device.Attributes {
+			if attribute.Name != attr.Name || string(attribute.Scope) != attr.Scope {
+				continue
+			}
+			if attribute.Value.String != nil {
+				return *attribute.Value.String, nil
+			} else if attribute.Value.ArrayOfString != nil &&
+				len(*attribute.Value.ArrayOfString) > 0 {
+				return (*attribute.Value.ArrayOfString)[0], nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// storageLocationForDevice returns the storage location configured for
+// the device's value of the location attribute, or nil if none.
+func (d *Deployments) storageLocationForDevice(
+	ctx context.Context,
+	settings *model.StorageSettings,
+	deviceID string,
+) (*model.StorageLocation, error) {
+	if settings == nil || settings.LocationAttribute == nil || len(settings.Locations) == 0 {
+		return nil, nil
+	}
+	value, err := d.deviceAttributeValue(ctx, deviceID, *settings.LocationAttribute)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return settings.Location(value), nil
+}
+
+// deviceDownloadLink signs the artifact download link from the storage
+// location of the device; the default storage serves the devices without
+// a location, or if the artifact is not available in the location, e.g.
+// because it was not replicated yet.
+func (d *Deployments) deviceDownloadLink(
+	ctx context.Context,
+	deviceID string,
+	objectPath string,
+	filename string,
+	expire time.Duration,
+) (*model.Link, error) {
+	l := log.FromContext(ctx)
+	settings, _ := storage.SettingsFromContext(ctx)
+	location, err := d.storageLocationForDevice(ctx, settings, deviceID)
+	if err != nil {
+		l.Warnf("failed to pick the storage location of the device %s: %s",
+			deviceID, err)
+	} else if location != nil {
+		// signing the link succeeds whether or not the object exists,
+		// so make sure the location can serve the artifact first
+		locationCtx := storage.SettingsWithContext(ctx, &location.Settings)
+		_, err = d.objectStorage.StatObject(locationCtx, objectPath)
+		if err == nil {
+			var link *model.Link
+			link, err = d.objectStorage.GetRequest(
+				locationCtx,
+				objectPath,
+				filename,
+				expire,
+				true,
+			)
+			if err == nil {
+				return link, nil
+			}
+		}
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			l.Infof("the artifact %s is not available in the storage location %q yet",
+				objectPath, location.Name)
+		} else {
+			l.Warnf("failed to sign the download link from the storage location %q: %s",
+				location.Name, err)
+		}
+	}
+	return d.objectStorage.GetRequest(ctx, objectPath, filename, expire, true)
+}
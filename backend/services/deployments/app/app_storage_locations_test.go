@@ -0,0 +1,214 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/utils/types"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	mstorage "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+)
+
+func settingsMatcher(bucket string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		settings, _ := storage.SettingsFromContext(ctx)
+		return settings != nil && settings.Bucket == bucket
+	})
+}
+
+func TestDeviceDownloadLink(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tenantID   = "tenant"
+		deviceID   = "b1d2d1e4-6e7d-4c1a-8d6e-0d1c1b0e9f3a"
+		objectPath = tenantID + "/artifact"
+		filename   = "release.mender"
+	)
+	attribute := model.StorageLocationAttribute{
+		Scope: "inventory",
+		Name:  "region",
+	}
+	settings := &model.StorageSettings{
+		Type:              model.StorageTypeS3,
+		Region:            "eu-west-1",
+		Bucket:            "bucket-eu",
+		Key:               "access-key",
+		Secret:            "secret-key",
+		LocationAttribute: &attribute,
+		Locations: []model.StorageLocation{{
+			Name:   "apac",
+			Values: []string{"ap-southeast", "ap-northeast"},
+			Settings: model.StorageSettings{
+				Type:   model.StorageTypeS3,
+				Region: "ap-southeast-1",
+				Bucket: "bucket-apac",
+				Key:    "access-key",
+				Secret: "secret-key",
+			},
+		}},
+	}
+	defaultLink := &model.Link{Uri: "https://bucket-eu/artifact"}
+	locationLink := &model.Link{Uri: "https://bucket-apac/artifact"}
+
+	device := func(value string) []client.DeviceInventoryResponse {
+		return []client.DeviceInventoryResponse{{
+			Id: types.Pointer(deviceID),
+			Attributes: []client.AttributeResponse{{
+				Name:  attribute.Name,
+				Scope: client.Scope(attribute.Scope),
+				Value: client.AttributeValueResponse{String: types.Pointer(value)},
+			}},
+		}}
+	}
+
+	testCases := map[string]struct {
+		settings *model.StorageSettings
+
+		devices   []client.DeviceInventoryResponse
+		searchErr error
+
+		statErr error
+		signErr error
+
+		link *model.Link
+	}{
+		"ok, no locations": {
+			settings: &model.StorageSettings{
+				Type:   model.StorageTypeS3,
+				Region: "eu-west-1",
+				Bucket: "bucket-eu",
+				Key:    "access-key",
+				Secret: "secret-key",
+			},
+			link: defaultLink,
+		},
+		"ok, device location": {
+			settings: settings,
+			devices:  device("ap-northeast"),
+			link:     locationLink,
+		},
+		"ok, no location for the value": {
+			settings: settings,
+			devices:  device("eu-central"),
+			link:     defaultLink,
+		},
+		"ok, device not in inventory": {
+			settings: settings,
+			link:     defaultLink,
+		},
+		"ok, inventory error": {
+			settings:  settings,
+			searchErr: errors.New("connection refused"),
+			link:      defaultLink,
+		},
+		"ok, artifact not replicated": {
+			settings: settings,
+			devices:  device("ap-southeast"),
+			statErr:  storage.ErrObjectNotFound,
+			link:     defaultLink,
+		},
+		"ok, location unavailable": {
+			settings: settings,
+			devices:  device("ap-southeast"),
+			statErr:  errors.New("connection refused"),
+			link:     defaultLink,
+		},
+		"ok, location cannot sign the link": {
+			settings: settings,
+			devices:  device("ap-southeast"),
+			signErr:  errors.New("invalid credentials"),
+			link:     defaultLink,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			ctx = storage.SettingsWithContext(ctx, tc.settings)
+
+			objStore := mstorage.NewObjectStorage(t)
+			inventoryV2Client := oas_mocks.NewMockDeviceInventoryFiltersAndSearchInternalAPIAPI(t)
+			d := NewDeployments(nil, objStore, 0, false)
+			d.inventoryV2Client = inventoryV2Client
+
+			if len(tc.settings.Locations) > 0 {
+				req := client.ApiInventoryInternalV2SearchDeviceInventoriesRequest{
+					ApiService: inventoryV2Client,
+				}
+				req = req.SearchParams(client.SearchParams{
+					Page:      types.Pointer(int32(1)),
+					PerPage:   types.Pointer(int32(1)),
+					DeviceIds: []string{deviceID},
+					Attributes: []client.SelectAttribute{{
+						Scope:     client.Scope(attribute.Scope),
+						Attribute: attribute.Name,
+					}},
+				})
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventories(ctx, tenantID).
+					Return(req)
+				rsp := &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(nil),
+					Header: http.Header{
+						"X-Total-Count": []string{"1"},
+					},
+				}
+				inventoryV2Client.EXPECT().
+					InventoryInternalV2SearchDeviceInventoriesExecute(req).
+					Return(tc.devices, rsp, tc.searchErr)
+			}
+			if tc.devices != nil && tc.settings.Location(
+				*tc.devices[0].Attributes[0].Value.String) != nil {
+				objStore.On("StatObject", settingsMatcher("bucket-apac"), objectPath).
+					Return(&storage.ObjectInfo{Path: objectPath}, tc.statErr)
+				if tc.statErr == nil {
+					objStore.On("GetRequest", settingsMatcher("bucket-apac"),
+						objectPath, filename, time.Hour, true).
+						Return(locationLink, tc.signErr)
+				}
+			}
+			if tc.link == defaultLink {
+				objStore.On("GetRequest", settingsMatcher("bucket-eu"),
+					objectPath, filename, time.Hour, true).
+					Return(defaultLink, nil)
+			}
+
+			link, err := d.deviceDownloadLink(ctx, deviceID, objectPath, filename, time.Hour)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.link, link)
+		})
+	}
+}
@@ -190,6 +190,9 @@ func TestDeploymentModelCreateDeployment(t *testing.T) {
 		InputDeploymentStorageInsertError error
 		InputImagesByNameError            error
 
+		RequireSignature  bool
+		VerificationKeyID string
+
 		InvDevices        []client.DeviceInventoryResponse
 		InvDevicesPageTwo []client.DeviceInventoryResponse
 		TotalCount        int
@@ -274,6 +277,16 @@ func TestDeploymentModelCreateDeployment(t *testing.T) {
 
 			OutputError: ErrNoDevices,
 		},
+		"ok, continuous with group, no device found": {
+			InputConstructor: &model.DeploymentConstructor{
+				Name:         "group",
+				ArtifactName: "App 123",
+				Group:        "group",
+				Continuous:   true,
+			},
+
+			OutputBody: true,
+		},
 		"ko, name too long": {
 			InputConstructor: &model.DeploymentConstructor{
 				Name:         longStr,
@@ -304,6 +317,28 @@ func TestDeploymentModelCreateDeployment(t *testing.T) {
 
 			OutputError: ErrConflictingDeployment,
 		},
+		"ok, signature required": {
+			InputConstructor: &model.DeploymentConstructor{
+				Name:         "NYC Production",
+				ArtifactName: "App 123",
+				Devices:      []string{"b532b01a-9313-404f-8d19-e7fcbe5cc347"},
+			},
+			CallGetDeviceGroups: true,
+			RequireSignature:    true,
+			VerificationKeyID:   "key",
+
+			OutputBody: true,
+		},
+		"ko, signature required, artifact not signed": {
+			InputConstructor: &model.DeploymentConstructor{
+				Name:         "NYC Production",
+				ArtifactName: "App 123",
+				Devices:      []string{"b532b01a-9313-404f-8d19-e7fcbe5cc347"},
+			},
+			RequireSignature: true,
+
+			OutputError: ErrModelArtifactNotSigned,
+		},
 	}
 
 	for testCaseName, testCase := range testCases {
@@ -331,9 +366,15 @@ func TestDeploymentModelCreateDeployment(t *testing.T) {
 							DeviceTypesCompatible: []string{
 								"hammer",
 							},
-							Depends: map[string]interface{}{},
+							Depends:           map[string]interface{}{},
+							Signed:            testCase.VerificationKeyID != "",
+							VerificationKeyID: testCase.VerificationKeyID,
 						}, artifactSize)},
 					testCase.InputImagesByNameError)
+			db.On("GetArtifactVerificationSettings", ctx).
+				Return(&model.ArtifactVerificationSettings{
+					RequireSignature: testCase.RequireSignature,
+				}, nil)
 
 			fs := &fs_mocks.ObjectStorage{}
 			ds := NewDeployments(&db, fs, 0, false)
@@ -604,6 +645,7 @@ func TestCompleteUpload(t *testing.T) {
 		Database      func(t *testing.T, self *testCase) *mocks.DataStore
 		ObjectStorage func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage
 		SkipVerify    bool
+		Metadata      *model.DirectUploadMetadata
 
 		syncChan chan struct{}
 
@@ -623,6 +665,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -630,11 +681,17 @@ func TestCompleteUpload(t *testing.T) {
 					model.LinkStatusProcessing).
 				Return(nil).
 				Once().
+				On("GetArtifactVerificationSettings",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("ListVerificationKeys",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
 					model.LinkStatusProcessing,
-					model.LinkStatusAborted).
+					model.LinkStatusFailed).
 				Return(nil)
 
 			return ds
@@ -678,6 +735,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -685,11 +751,17 @@ func TestCompleteUpload(t *testing.T) {
 					model.LinkStatusProcessing).
 				Return(nil).
 				Once().
+				On("GetArtifactVerificationSettings",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("ListVerificationKeys",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
 					model.LinkStatusProcessing,
-					model.LinkStatusAborted).
+					model.LinkStatusFailed).
 				Return(nil)
 
 			return ds
@@ -731,6 +803,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -738,11 +819,17 @@ func TestCompleteUpload(t *testing.T) {
 					model.LinkStatusProcessing).
 				Return(nil).
 				Once().
+				On("GetArtifactVerificationSettings",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("ListVerificationKeys",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
 					model.LinkStatusProcessing,
-					model.LinkStatusAborted).
+					model.LinkStatusFailed).
 				Return(errors.New("internal error"))
 
 			return ds
@@ -790,6 +877,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -797,11 +893,17 @@ func TestCompleteUpload(t *testing.T) {
 					model.LinkStatusProcessing).
 				Return(nil).
 				Once().
+				On("GetArtifactVerificationSettings",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("ListVerificationKeys",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
 					model.LinkStatusProcessing,
-					model.LinkStatusAborted).
+					model.LinkStatusFailed).
 				Return(errors.New("internal error"))
 
 			return ds
@@ -841,6 +943,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -884,6 +995,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
 				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
 				On("UpdateUploadIntentStatus",
 					contextHasIdentity(t, self.Identity),
 					intentID,
@@ -919,6 +1039,62 @@ func TestCompleteUpload(t *testing.T) {
 						"to be called")
 			}
 		},
+	}, {
+		Name: "error/save metadata",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
+				Once().
+				On("UpdateUploadIntentMetadata",
+					contextHasIdentity(t, self.Identity),
+					intentID,
+					self.Metadata).
+				Return(store.ErrNotFound).
+				Once()
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			os := new(fs_mocks.ObjectStorage)
+			r := newEOFReadCloser(nil)
+			os.On("GetObject",
+				contextHasIdentity(t, self.Identity),
+				intentID).
+				Return(r, nil).
+				Once()
+			self.syncChan = r.ch
+			return os
+		},
+		SkipVerify: true,
+		Metadata: &model.DirectUploadMetadata{
+			Size:    123,
+			Updates: []model.Update{{}},
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			deadline, ok := t.Deadline()
+			if !ok || time.Until(deadline) > time.Minute {
+				deadline = time.Now().Add(time.Minute)
+			}
+			select {
+			case <-self.syncChan:
+				assert.ErrorIs(t, err, ErrUploadNotFound)
+			case <-time.After(time.Until(deadline)):
+				assert.FailNow(t,
+					"timed out waiting for the artifact reader "+
+						"to be closed")
+			}
+		},
 	}, {
 		Name: "error/object not found",
 
@@ -926,6 +1102,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds := new(mocks.DataStore)
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
 				Once()
 			return ds
 		},
@@ -949,6 +1134,15 @@ func TestCompleteUpload(t *testing.T) {
 			ds := new(mocks.DataStore)
 			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
 				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+				}, nil).
 				Once()
 			return ds
 		},
@@ -965,6 +1159,224 @@ func TestCompleteUpload(t *testing.T) {
 		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
 			assert.ErrorIs(t, err, testErr)
 		},
+	}, {
+		Name: "ok/multipart",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+					UploadID:   "upload-id",
+				}, nil).
+				Once().
+				On("UpdateUploadIntentStatus",
+					contextHasIdentity(t, self.Identity),
+					intentID,
+					model.LinkStatusPending,
+					model.LinkStatusProcessing).
+				Return(nil).
+				Once().
+				On("GetArtifactVerificationSettings",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("ListVerificationKeys",
+					contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				On("UpdateUploadIntentStatus",
+					contextHasIdentity(t, self.Identity),
+					intentID,
+					model.LinkStatusProcessing,
+					model.LinkStatusFailed).
+				Return(nil)
+
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			os := new(fs_mocks.ObjectStorage)
+			r := newEOFReadCloser(nil)
+			parts := []model.UploadPart{
+				{Number: 1, Size: model.UploadPartSizeMin, ETag: "etag-1"},
+				{Number: 2, Size: 512, ETag: "etag-2"},
+			}
+			os.On("ListParts",
+				contextHasIdentity(t, self.Identity),
+				intentID+fileSuffixTmp,
+				"upload-id").
+				Return(parts, nil).
+				Once().
+				On("CompleteMultipartUpload",
+					contextHasIdentity(t, self.Identity),
+					intentID+fileSuffixTmp,
+					"upload-id",
+					parts).
+				Return(nil).
+				Once().
+				On("GetObject",
+					contextHasIdentity(t, self.Identity),
+					intentID+fileSuffixTmp).
+				Return(r, nil).
+				Once().
+				On("PutObject",
+					contextHasIdentity(t, self.Identity),
+					intentID,
+					mock.AnythingOfType("*io.PipeReader")).
+				Return(nil)
+			self.syncChan = r.ch
+			return os
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			deadline, ok := t.Deadline()
+			if !ok || time.Until(deadline) > time.Minute {
+				deadline = time.Now().Add(time.Minute)
+			}
+			select {
+			case <-self.syncChan:
+				assert.NoError(t, err)
+			case <-time.After(time.Until(deadline)):
+				assert.FailNow(t,
+					"timed out waiting for processUploadedArtifact"+
+						"to be called")
+			}
+		},
+	}, {
+		Name: "error/multipart without parts",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+					UploadID:   "upload-id",
+				}, nil).
+				Once()
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			os := new(fs_mocks.ObjectStorage)
+			os.On("ListParts",
+				contextHasIdentity(t, self.Identity),
+				intentID+fileSuffixTmp,
+				"upload-id").
+				Return([]model.UploadPart{}, nil).
+				Once()
+			return os
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			assert.ErrorIs(t, err, ErrUploadNoParts)
+		},
+	}, {
+		Name: "error/multipart with a missing part",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+					UploadID:   "upload-id",
+				}, nil).
+				Once()
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			os := new(fs_mocks.ObjectStorage)
+			os.On("ListParts",
+				contextHasIdentity(t, self.Identity),
+				intentID+fileSuffixTmp,
+				"upload-id").
+				Return([]model.UploadPart{
+					{Number: 1, Size: model.UploadPartSizeMin, ETag: "etag-1"},
+					{Number: 3, Size: 512, ETag: "etag-3"},
+				}, nil).
+				Once()
+			return os
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			assert.ErrorIs(t, err, ErrUploadPartMissing)
+		},
+	}, {
+		Name: "error/multipart with a small part",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(&model.UploadLink{
+					ArtifactID: intentID,
+					Status:     model.LinkStatusPending,
+					SkipVerify: self.SkipVerify,
+					UploadID:   "upload-id",
+				}, nil).
+				Once()
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			os := new(fs_mocks.ObjectStorage)
+			os.On("ListParts",
+				contextHasIdentity(t, self.Identity),
+				intentID+fileSuffixTmp,
+				"upload-id").
+				Return([]model.UploadPart{
+					{Number: 1, Size: 1024, ETag: "etag-1"},
+					{Number: 2, Size: 512, ETag: "etag-2"},
+				}, nil).
+				Once()
+			return os
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			assert.ErrorIs(t, err, ErrUploadPartSize)
+		},
+	}, {
+		Name: "error/upload link not found",
+
+		Database: func(t *testing.T, self *testCase) *mocks.DataStore {
+			ds := new(mocks.DataStore)
+			ds.On("GetStorageSettings", contextHasIdentity(t, self.Identity)).
+				Return(nil, nil).
+				Once().
+				On("FindUploadLink",
+					contextHasIdentity(t, self.Identity),
+					intentID).
+				Return(nil, store.ErrNotFound).
+				Once()
+			return ds
+		},
+		ObjectStorage: func(t *testing.T, self *testCase) *fs_mocks.ObjectStorage {
+			return new(fs_mocks.ObjectStorage)
+		},
+
+		ErrorAssertionFunc: func(t *testing.T, self *testCase, err error) {
+			assert.ErrorIs(t, err, ErrUploadNotFound)
+		},
 	}, {
 		Name: "error/retrieve storage settings",
 
@@ -997,7 +1409,7 @@ func TestCompleteUpload(t *testing.T) {
 			defer objStore.AssertExpectations(t)
 			deploy := NewDeployments(ds, objStore, 0, false)
 
-			err := deploy.CompleteUpload(ctx, intentID, tc.SkipVerify, nil)
+			err := deploy.CompleteUpload(ctx, intentID, tc.Metadata)
 			tc.ErrorAssertionFunc(t, tc, err)
 		})
 	}
@@ -2063,6 +2475,65 @@ func TestGetDeploymentFilter(t *testing.T) {
 				},
 			},
 		},
+		"filter terms": {
+			inConstructor: &model.DeploymentConstructor{
+				Continuous: true,
+				FilterTerms: []model.FilterPredicate{
+					{
+						Scope:     InventoryInventoryScope,
+						Attribute: "device_type",
+						Type:      "$eq",
+						Value:     "foo",
+					},
+				},
+			},
+			outFilter: &model.Filter{
+				Terms: []model.FilterPredicate{
+					{
+						Scope:     InventoryIdentityScope,
+						Attribute: InventoryStatusAttributeName,
+						Type:      "$eq",
+						Value:     InventoryStatusAccepted,
+					},
+					{
+						Scope:     InventoryInventoryScope,
+						Attribute: "device_type",
+						Type:      "$eq",
+						Value:     "foo",
+					},
+				},
+			},
+		},
+		"group and filter terms": {
+			inConstructor: &model.DeploymentConstructor{
+				Group:      "foo",
+				Continuous: true,
+				FilterTerms: []model.FilterPredicate{
+					{
+						Scope:     InventoryInventoryScope,
+						Attribute: "device_type",
+						Type:      "$eq",
+						Value:     "foo",
+					},
+				},
+			},
+			outFilter: &model.Filter{
+				Terms: []model.FilterPredicate{
+					{
+						Scope:     InventoryGroupScope,
+						Attribute: InventoryGroupAttributeName,
+						Type:      "$eq",
+						Value:     "foo",
+					},
+					{
+						Scope:     InventoryInventoryScope,
+						Attribute: "device_type",
+						Type:      "$eq",
+						Value:     "foo",
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -2149,3 +2620,66 @@ func TestDeployments_GetDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestExportDevicesForDeployment(t *testing.T) {
+	t.Parallel()
+
+	query := store.ListQuery{DeploymentID: "deployment"}
+
+	testCases := map[string]struct {
+		deployment *model.Deployment
+		findErr    error
+		iterErr    error
+
+		err error
+	}{
+		"ok": {
+			deployment: &model.Deployment{Id: "deployment"},
+		},
+		"error, deployment not found": {
+			err: ErrModelDeploymentNotFound,
+		},
+		"error, find deployment": {
+			findErr: errors.New("internal error"),
+			err:     errors.New("searching for deployment by ID: internal error"),
+		},
+		"error, find devices": {
+			deployment: &model.Deployment{Id: "deployment"},
+			iterErr:    errors.New("internal error"),
+			err: errors.New(
+				"failed to list the devices of the deployment: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			it := NewArrayIterator[model.DeviceDeployment](nil)
+			db := mocks.NewDataStore(t)
+			db.On("FindDeploymentByID", ctx, "deployment").
+				Return(tc.deployment, tc.findErr)
+			if tc.deployment != nil {
+				if tc.iterErr != nil {
+					db.On("FindDevicesForDeployment", ctx, query).
+						Return(nil, tc.iterErr)
+				} else {
+					db.On("FindDevicesForDeployment", ctx, query).
+						Return(it, nil)
+				}
+			}
+
+			d := NewDeployments(db, nil, 0, false)
+			res, err := d.ExportDevicesForDeployment(ctx, query)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, it, res)
+			}
+		})
+	}
+}
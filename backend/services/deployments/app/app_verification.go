@@ -0,0 +1,139 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+func (d *Deployments) ListVerificationKeys(
+	ctx context.Context,
+) ([]model.VerificationKey, error) {
+	keys, err := d.db.ListVerificationKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list verification keys")
+	}
+	return keys, nil
+}
+
+func (d *Deployments) AddVerificationKey(
+	ctx context.Context,
+	newKey model.NewVerificationKey,
+) (string, error) {
+	key, err := newKey.VerificationKey()
+	if err != nil {
+		return "", err
+	}
+	if err := d.db.InsertVerificationKey(ctx, key); err != nil {
+		return "", errors.Wrap(err, "failed to store verification key")
+	}
+	return key.Id, nil
+}
+
+func (d *Deployments) DeleteVerificationKey(ctx context.Context, id string) error {
+	err := d.db.DeleteVerificationKey(ctx, id)
+	if err == mongo.ErrStorageNotFound {
+		return ErrVerificationKeyNotFound
+	}
+	return err
+}
+
+// GetArtifactVerificationSettings returns the tenant's artifact verification
+// settings, or the defaults if not set.
+func (d *Deployments) GetArtifactVerificationSettings(
+	ctx context.Context,
+) (*model.ArtifactVerificationSettings, error) {
+	settings, err := d.db.GetArtifactVerificationSettings(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get artifact verification settings")
+	} else if settings == nil {
+		settings = &model.ArtifactVerificationSettings{}
+	}
+	return settings, nil
+}
+
+func (d *Deployments) SetArtifactVerificationSettings(
+	ctx context.Context,
+	settings model.ArtifactVerificationSettings,
+) error {
+	err := d.db.SetArtifactVerificationSettings(ctx, &settings)
+	if err != nil {
+		return errors.Wrap(err, "failed to set artifact verification settings")
+	}
+	return nil
+}
+
+// checkArtifactsSigned returns ErrModelArtifactNotSigned if signatures are
+// required and any of the artifacts was not verified with a tenant key, e.g.
+// because it was uploaded before the setting was turned on.
+func (d *Deployments) checkArtifactsSigned(
+	ctx context.Context,
+	artifacts []*model.Image,
+) error {
+	settings, err := d.GetArtifactVerificationSettings(ctx)
+	if err != nil {
+		return err
+	} else if !settings.RequireSignature {
+		return nil
+	}
+	for _, artifact := range artifacts {
+		meta := artifact.ArtifactMeta
+		if meta == nil || !meta.Signed || meta.VerificationKeyID == "" {
+			return ErrModelArtifactNotSigned
+		}
+	}
+	return nil
+}
+
+// artifactVerifier verifies the signature of the uploaded artifacts with the
+// tenant's verification keys.
+type artifactVerifier struct {
+	keys     []model.VerificationKey
+	required bool
+}
+
+func (d *Deployments) newArtifactVerifier(ctx context.Context) (*artifactVerifier, error) {
+	settings, err := d.GetArtifactVerificationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := d.ListVerificationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &artifactVerifier{
+		keys:     keys,
+		required: settings.RequireSignature,
+	}, nil
+}
+
+// verify returns the identifier of the key the signature was made with.
+func (v *artifactVerifier) verify(message, sig []byte) (string, error) {
+	for _, key := range v.keys {
+		verifier, err := key.Verifier()
+		if err != nil {
+			continue
+		}
+		if verifier.Verify(message, sig) == nil {
+			return key.Id, nil
+		}
+	}
+	return "", ErrModelArtifactSignatureInvalid
+}
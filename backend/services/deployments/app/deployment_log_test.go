@@ -0,0 +1,106 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
+)
+
+func TestSaveDeviceDeploymentLog(t *testing.T) {
+	t.Parallel()
+
+	const (
+		deviceID     = "device"
+		deploymentID = "30b3e62c-9ec2-4312-a7fa-cff24cc7397a"
+	)
+	now := time.Now()
+	messages := []model.LogMessage{
+		{Timestamp: &now, Level: "info", Message: "aaaa"},
+		{Timestamp: &now, Level: "info", Message: "bbbb"},
+		{Timestamp: &now, Level: "info", Message: "cccc"},
+		{Timestamp: &now, Level: "info", Message: "dddd"},
+	}
+
+	testCases := map[string]struct {
+		maxSize   int
+		retention time.Duration
+		limit     *model.Limit
+
+		messages int
+		expire   bool
+	}{
+		"ok": {
+			messages: 4,
+		},
+		"ok, default max size": {
+			maxSize:  8,
+			messages: 3,
+		},
+		"ok, tenant max size": {
+			maxSize:  4,
+			limit:    &model.Limit{Name: model.LimitDeploymentLogSize, Value: 100},
+			messages: 4,
+		},
+		"ok, retention": {
+			retention: time.Hour,
+			messages:  4,
+			expire:    true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			db.On("HasDeploymentForDevice", ctx, deploymentID, deviceID).
+				Return(true, nil)
+			if tc.limit != nil {
+				db.On("GetLimit", ctx, model.LimitDeploymentLogSize).
+					Return(tc.limit, nil)
+			} else {
+				db.On("GetLimit", ctx, model.LimitDeploymentLogSize).
+					Return(nil, mongo.ErrLimitNotFound)
+			}
+			db.On("SaveDeviceDeploymentLog", ctx,
+				mock.MatchedBy(func(dlog model.DeploymentLog) bool {
+					return len(dlog.Messages) == tc.messages &&
+						(dlog.ExpireAt != nil) == tc.expire
+				})).Return(nil)
+			db.On("UpdateDeviceDeploymentLogAvailability", ctx,
+				deviceID, deploymentID, true).Return(nil)
+
+			d := NewDeployments(db, nil, 0, false)
+			d.SetDeploymentLogMaxSize(tc.maxSize)
+			d.SetDeploymentLogRetention(tc.retention)
+
+			logs := make([]model.LogMessage, len(messages))
+			copy(logs, messages)
+			err := d.SaveDeviceDeploymentLog(ctx, deviceID, deploymentID, logs)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -17,7 +17,16 @@ package app
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
@@ -28,6 +37,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/mendersoftware/mender-artifact/areader"
 	"github.com/mendersoftware/mender-artifact/artifact"
+	"github.com/mendersoftware/mender-artifact/awriter"
+	"github.com/mendersoftware/mender-artifact/handlers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -39,6 +50,7 @@ import (
 	"github.com/mendersoftware/mender-server/services/deployments/model"
 	fs_mocks "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
 	"github.com/mendersoftware/mender-server/services/deployments/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deployments/store/mongo"
 	h "github.com/mendersoftware/mender-server/services/deployments/utils/testing"
 )
 
@@ -913,3 +925,330 @@ func TestListImagesV2(t *testing.T) {
 		})
 	}
 }
+
+const testArtifactUpdateType = "single-file"
+
+// writeTestArtifact returns a mender artifact named "artifact", signed with
+// the signer if given.
+func writeTestArtifact(t *testing.T, signer artifact.Signer) []byte {
+	var buf bytes.Buffer
+	updateType := testArtifactUpdateType
+	writer := awriter.NewWriter(&buf, artifact.NewCompressorNone())
+	if signer != nil {
+		writer = awriter.NewWriterSigned(&buf, artifact.NewCompressorNone(), signer)
+	}
+	err := writer.WriteArtifact(&awriter.WriteArtifactArgs{
+		Format:  "mender",
+		Version: 3,
+		Devices: []string{"foo"},
+		Name:    "artifact",
+		Updates: &awriter.Updates{Updates: []handlers.Composer{
+			handlers.NewModuleImage(updateType),
+		}},
+		Depends: &artifact.ArtifactDepends{
+			CompatibleDevices: []string{"foo"},
+		},
+		Provides: &artifact.ArtifactProvides{
+			ArtifactName: "artifact",
+		},
+		TypeInfoV3: &artifact.TypeInfoV3{Type: &updateType},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return buf.Bytes()
+}
+
+func TestCreateImageChecksum(t *testing.T) {
+	t.Parallel()
+
+	artifactBytes := writeTestArtifact(t, nil)
+	sum := sha256.Sum256(artifactBytes)
+	expectedChecksum := hex.EncodeToString(sum[:])
+
+	testCases := map[string]struct {
+		existing *model.Image
+		dbErr    error
+		raced    *model.Image
+
+		skipVerify bool
+
+		err error
+	}{
+		"ok": {},
+		"ok, skip verify": {
+			skipVerify: true,
+		},
+		"error, duplicate artifact, skip verify": {
+			existing:   &model.Image{Id: "existing"},
+			skipVerify: true,
+			err:        ErrModelArtifactDuplicate,
+		},
+		"error, duplicate artifact": {
+			existing: &model.Image{Id: "existing"},
+			err:      ErrModelArtifactDuplicate,
+		},
+		"error, concurrent duplicate upload": {
+			raced: &model.Image{Id: "existing"},
+			err:   ErrModelArtifactDuplicate,
+		},
+		"error, looking up checksum": {
+			dbErr: errors.New("internal error"),
+			err:   errors.New("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			fs := fs_mocks.NewObjectStorage(t)
+
+			db.On("GetStorageSettings", ctx).Return(nil, nil)
+			db.On("GetArtifactVerificationSettings", h.ContextMatcher()).
+				Return(nil, nil)
+			db.On("ListVerificationKeys", h.ContextMatcher()).
+				Return(nil, nil)
+			if !tc.skipVerify {
+				fs.On("PutObject", h.ContextMatcher(), validUUIDv4,
+					mock.AnythingOfType("*io.PipeReader")).
+					Run(func(args mock.Arguments) {
+						_, _ = io.Copy(io.Discard, args.Get(2).(io.Reader))
+					}).
+					Return(nil)
+			}
+			db.On("FindImageByChecksum", h.ContextMatcher(), expectedChecksum).
+				Return(tc.existing, tc.dbErr).
+				Once()
+			if tc.err != nil {
+				fs.On("DeleteObject", h.ContextMatcher(), validUUIDv4).Return(nil)
+			}
+			if tc.raced != nil {
+				db.On("InsertImage", h.ContextMatcher(), mock.AnythingOfType("*model.Image")).
+					Return(mongo.ErrConflictingChecksum)
+				db.On("FindImageByChecksum", h.ContextMatcher(), expectedChecksum).
+					Return(tc.raced, nil).
+					Once()
+			} else if tc.err == nil {
+				db.On("InsertImage", h.ContextMatcher(), mock.MatchedBy(func(img *model.Image) bool {
+					return img.Checksum == expectedChecksum
+				})).Return(nil)
+				db.On("SaveUpdateTypes", h.ContextMatcher(), []string{testArtifactUpdateType}).Return(nil)
+				db.On("UpdateReleaseArtifacts", h.ContextMatcher(),
+					mock.AnythingOfType("*model.Image"), (*model.Image)(nil), "artifact").
+					Return(nil)
+				db.On("ExistUnfinishedByArtifactName", h.ContextMatcher(), "artifact").
+					Return(false, nil)
+			}
+
+			d := NewDeployments(db, fs, 0, false)
+			artifactID, err := d.handleArtifact(ctx, &model.MultipartUploadMsg{
+				ArtifactID:      validUUIDv4,
+				MetaConstructor: &model.ImageMeta{},
+				ArtifactReader:  bytes.NewReader(artifactBytes),
+			}, tc.skipVerify, nil)
+			assert.Equal(t, validUUIDv4, artifactID)
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+				if existing := tc.existing; existing != nil || tc.raced != nil {
+					if existing == nil {
+						existing = tc.raced
+					}
+					var conflict *model.ConflictError
+					if assert.ErrorAs(t, err, &conflict) {
+						assert.Equal(t, map[string]interface{}{
+							"artifact_id": existing.Id,
+						}, conflict.Metadata)
+					}
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type ed25519Signer ed25519.PrivateKey
+
+func (s ed25519Signer) Sign(message []byte) ([]byte, error) {
+	sig := ed25519.Sign(ed25519.PrivateKey(s), message)
+	return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+func newTestVerificationKey(t *testing.T, id string, pub interface{}) model.VerificationKey {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return model.VerificationKey{
+		Id: id,
+		PublicKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: der,
+		})),
+	}
+}
+
+func TestCreateImageSignature(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	ecSigner, err := artifact.NewPKISigner(pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: ecDER,
+	}))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ecdsaKey := newTestVerificationKey(t, "ecdsa", ecKey.Public())
+	edKeyVerif := newTestVerificationKey(t, "ed25519", edPub)
+	otherKey := newTestVerificationKey(t, "other", otherPub)
+
+	testCases := map[string]struct {
+		signer   artifact.Signer
+		keys     []model.VerificationKey
+		required bool
+
+		skipVerify bool
+		tampered   bool
+
+		signed bool
+		keyID  string
+		err    error
+	}{
+		"ok, not signed": {
+			keys: []model.VerificationKey{ecdsaKey},
+		},
+		"ok, ecdsa key": {
+			signer: ecSigner,
+			keys:   []model.VerificationKey{otherKey, ecdsaKey},
+			signed: true,
+			keyID:  "ecdsa",
+		},
+		"ok, ed25519 key": {
+			signer:   ed25519Signer(edKey),
+			keys:     []model.VerificationKey{ecdsaKey, edKeyVerif},
+			required: true,
+			signed:   true,
+			keyID:    "ed25519",
+		},
+		"ok, unknown key": {
+			signer: ed25519Signer(edKey),
+			keys:   []model.VerificationKey{otherKey},
+			signed: true,
+		},
+		"error, signature required": {
+			keys:     []model.VerificationKey{ecdsaKey},
+			required: true,
+			err:      ErrModelArtifactNotSigned,
+		},
+		"error, unknown key": {
+			signer:   ecSigner,
+			keys:     []model.VerificationKey{otherKey, edKeyVerif},
+			required: true,
+			err:      ErrModelArtifactSignatureInvalid,
+		},
+		"ok, skip verify": {
+			signer:     ecSigner,
+			keys:       []model.VerificationKey{ecdsaKey},
+			required:   true,
+			skipVerify: true,
+			signed:     true,
+			keyID:      "ecdsa",
+		},
+		"error, skip verify, payload tampered": {
+			signer:     ecSigner,
+			keys:       []model.VerificationKey{ecdsaKey},
+			skipVerify: true,
+			tampered:   true,
+			err:        ErrModelArtifactSignatureInvalid,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mocks.NewDataStore(t)
+			fs := fs_mocks.NewObjectStorage(t)
+
+			db.On("GetStorageSettings", ctx).Return(nil, nil)
+			db.On("GetArtifactVerificationSettings", h.ContextMatcher()).
+				Return(&model.ArtifactVerificationSettings{
+					RequireSignature: tc.required,
+				}, nil)
+			db.On("ListVerificationKeys", h.ContextMatcher()).
+				Return(tc.keys, nil)
+			if !tc.skipVerify {
+				fs.On("PutObject", h.ContextMatcher(), validUUIDv4,
+					mock.AnythingOfType("*io.PipeReader")).
+					Run(func(args mock.Arguments) {
+						_, _ = io.Copy(io.Discard, args.Get(2).(io.Reader))
+					}).
+					Return(nil)
+			}
+			if tc.err == nil {
+				db.On("FindImageByChecksum", h.ContextMatcher(),
+					mock.AnythingOfType("string")).
+					Return(nil, nil)
+				db.On("InsertImage", h.ContextMatcher(),
+					mock.MatchedBy(func(img *model.Image) bool {
+						return assert.Equal(t, tc.signed, img.Signed) &&
+							assert.Equal(t, tc.keyID, img.VerificationKeyID)
+					})).
+					Return(nil)
+				db.On("SaveUpdateTypes", h.ContextMatcher(),
+					[]string{testArtifactUpdateType}).
+					Return(nil)
+				db.On("UpdateReleaseArtifacts", h.ContextMatcher(),
+					mock.AnythingOfType("*model.Image"), (*model.Image)(nil), "artifact").
+					Return(nil)
+				db.On("ExistUnfinishedByArtifactName", h.ContextMatcher(), "artifact").
+					Return(false, nil)
+			}
+
+			artifactBytes := writeTestArtifact(t, tc.signer)
+			if tc.tampered {
+				// corrupt the payload past the signed manifest
+				payload := bytes.Index(artifactBytes, []byte("data/0000.tar"))
+				if !assert.Greater(t, payload, 0) {
+					t.FailNow()
+				}
+				artifactBytes[payload+512+600] ^= 0xff
+			}
+
+			d := NewDeployments(db, fs, 0, false)
+			_, err := d.handleArtifact(ctx, &model.MultipartUploadMsg{
+				ArtifactID:      validUUIDv4,
+				MetaConstructor: &model.ImageMeta{},
+				ArtifactReader:  bytes.NewReader(artifactBytes),
+			}, tc.skipVerify, nil)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -70,17 +70,45 @@ func (_m *App) AbortDeviceDeployments(ctx context.Context, deviceID string) erro
 	return r0
 }
 
-// CompleteUpload provides a mock function with given fields: ctx, intentID, skipVerify, metadata
-func (_m *App) CompleteUpload(ctx context.Context, intentID string, skipVerify bool, metadata *model.DirectUploadMetadata) error {
-	ret := _m.Called(ctx, intentID, skipVerify, metadata)
+// AddVerificationKey provides a mock function with given fields: ctx, key
+func (_m *App) AddVerificationKey(ctx context.Context, key model.NewVerificationKey) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddVerificationKey")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.NewVerificationKey) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.NewVerificationKey) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.NewVerificationKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteUpload provides a mock function with given fields: ctx, intentID, metadata
+func (_m *App) CompleteUpload(ctx context.Context, intentID string, metadata *model.DirectUploadMetadata) error {
+	ret := _m.Called(ctx, intentID, metadata)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CompleteUpload")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool, *model.DirectUploadMetadata) error); ok {
-		r0 = rf(ctx, intentID, skipVerify, metadata)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.DirectUploadMetadata) error); ok {
+		r0 = rf(ctx, intentID, metadata)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -256,6 +284,24 @@ func (_m *App) DeleteReleases(ctx context.Context, releaseNames []string) ([]str
 	return r0, r1
 }
 
+// DeleteVerificationKey provides a mock function with given fields: ctx, id
+func (_m *App) DeleteVerificationKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerificationKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DownloadLink provides a mock function with given fields: ctx, imageID, expire
 func (_m *App) DownloadLink(ctx context.Context, imageID string, expire time.Duration) (*model.Link, error) {
 	ret := _m.Called(ctx, imageID, expire)
@@ -314,6 +360,54 @@ func (_m *App) EditImage(ctx context.Context, id string, constructorData *model.
 	return r0, r1
 }
 
+// ExportDevicesForDeployment provides a mock function with given fields: ctx, query
+func (_m *App) ExportDevicesForDeployment(ctx context.Context, query store.ListQuery) (store.Iterator[model.DeviceDeployment], error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDevicesForDeployment")
+	}
+
+	var r0 store.Iterator[model.DeviceDeployment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQuery) (store.Iterator[model.DeviceDeployment], error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQuery) store.Iterator[model.DeviceDeployment]); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator[model.DeviceDeployment])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishDeployment provides a mock function with given fields: ctx, deploymentID
+func (_m *App) FinishDeployment(ctx context.Context, deploymentID string) error {
+	ret := _m.Called(ctx, deploymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishDeployment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deploymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GenerateConfigurationImage provides a mock function with given fields: ctx, deviceType, deploymentID
 func (_m *App) GenerateConfigurationImage(ctx context.Context, deviceType string, deploymentID string) (io.Reader, error) {
 	ret := _m.Called(ctx, deviceType, deploymentID)
@@ -372,6 +466,36 @@ func (_m *App) GenerateImage(ctx context.Context, multipartUploadMsg *model.Mult
 	return r0, r1
 }
 
+// GetArtifactVerificationSettings provides a mock function with given fields: ctx
+func (_m *App) GetArtifactVerificationSettings(ctx context.Context) (*model.ArtifactVerificationSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactVerificationSettings")
+	}
+
+	var r0 *model.ArtifactVerificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.ArtifactVerificationSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.ArtifactVerificationSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ArtifactVerificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeployment provides a mock function with given fields: ctx, deploymentID
 func (_m *App) GetDeployment(ctx context.Context, deploymentID string) (*model.Deployment, error) {
 	ret := _m.Called(ctx, deploymentID)
@@ -594,6 +718,36 @@ func (_m *App) GetDeviceDeploymentLog(ctx context.Context, deviceID string, depl
 	return r0, r1
 }
 
+// GetDeviceDownloadLink provides a mock function with given fields: ctx, deviceID, deploymentID
+func (_m *App) GetDeviceDownloadLink(ctx context.Context, deviceID string, deploymentID string) (*model.Link, error) {
+	ret := _m.Called(ctx, deviceID, deploymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceDownloadLink")
+	}
+
+	var r0 *model.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Link, error)); ok {
+		return rf(ctx, deviceID, deploymentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Link); ok {
+		r0 = rf(ctx, deviceID, deploymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, deviceID, deploymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeviceStatusesForDeployment provides a mock function with given fields: ctx, deploymentID
 func (_m *App) GetDeviceStatusesForDeployment(ctx context.Context, deploymentID string) ([]model.DeviceDeployment, error) {
 	ret := _m.Called(ctx, deploymentID)
@@ -661,6 +815,36 @@ func (_m *App) GetDevicesListForDeployment(ctx context.Context, query store.List
 	return r0, r1, r2
 }
 
+// GetDownloadSettings provides a mock function with given fields: ctx
+func (_m *App) GetDownloadSettings(ctx context.Context) (*model.DownloadSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDownloadSettings")
+	}
+
+	var r0 *model.DownloadSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.DownloadSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.DownloadSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DownloadSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, id
 func (_m *App) GetImage(ctx context.Context, id string) (*model.Image, error) {
 	ret := _m.Called(ctx, id)
@@ -811,6 +995,36 @@ func (_m *App) GetStorageSettings(ctx context.Context) (*model.StorageSettings,
 	return r0, r1
 }
 
+// GetUploadStatus provides a mock function with given fields: ctx, intentID
+func (_m *App) GetUploadStatus(ctx context.Context, intentID string) (*model.UploadLinkStatus, error) {
+	ret := _m.Called(ctx, intentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUploadStatus")
+	}
+
+	var r0 *model.UploadLinkStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UploadLinkStatus, error)); ok {
+		return rf(ctx, intentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UploadLinkStatus); ok {
+		r0 = rf(ctx, intentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UploadLinkStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, intentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasDeploymentForDevice provides a mock function with given fields: ctx, deploymentID, deviceID
 func (_m *App) HasDeploymentForDevice(ctx context.Context, deploymentID string, deviceID string) (bool, error) {
 	ret := _m.Called(ctx, deploymentID, deviceID)
@@ -1049,6 +1263,66 @@ func (_m *App) ListSoftwareTags(ctx context.Context, filter *model.SoftwareTagsF
 	return r0, r1
 }
 
+// ListUploadParts provides a mock function with given fields: ctx, intentID
+func (_m *App) ListUploadParts(ctx context.Context, intentID string) ([]model.UploadPart, error) {
+	ret := _m.Called(ctx, intentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUploadParts")
+	}
+
+	var r0 []model.UploadPart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.UploadPart, error)); ok {
+		return rf(ctx, intentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.UploadPart); ok {
+		r0 = rf(ctx, intentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UploadPart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, intentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListVerificationKeys provides a mock function with given fields: ctx
+func (_m *App) ListVerificationKeys(ctx context.Context) ([]model.VerificationKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVerificationKeys")
+	}
+
+	var r0 []model.VerificationKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.VerificationKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.VerificationKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.VerificationKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LookupDeployment provides a mock function with given fields: ctx, query
 func (_m *App) LookupDeployment(ctx context.Context, query model.Query) ([]*model.Deployment, int64, error) {
 	ret := _m.Called(ctx, query)
@@ -1086,6 +1360,84 @@ func (_m *App) LookupDeployment(ctx context.Context, query model.Query) ([]*mode
 	return r0, r1, r2
 }
 
+// MultipartUploadLink provides a mock function with given fields: ctx, expire, skipVerify
+func (_m *App) MultipartUploadLink(ctx context.Context, expire time.Duration, skipVerify bool) (*model.UploadLink, error) {
+	ret := _m.Called(ctx, expire, skipVerify)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MultipartUploadLink")
+	}
+
+	var r0 *model.UploadLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, bool) (*model.UploadLink, error)); ok {
+		return rf(ctx, expire, skipVerify)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, bool) *model.UploadLink); ok {
+		r0 = rf(ctx, expire, skipVerify)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UploadLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, bool) error); ok {
+		r1 = rf(ctx, expire, skipVerify)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PauseDeployment provides a mock function with given fields: ctx, deploymentID
+func (_m *App) PauseDeployment(ctx context.Context, deploymentID string) error {
+	ret := _m.Called(ctx, deploymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseDeployment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deploymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PreviewDeployment provides a mock function with given fields: ctx, constructor
+func (_m *App) PreviewDeployment(ctx context.Context, constructor *model.DeploymentConstructor) (*model.DeploymentPreview, error) {
+	ret := _m.Called(ctx, constructor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDeployment")
+	}
+
+	var r0 *model.DeploymentPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeploymentConstructor) (*model.DeploymentPreview, error)); ok {
+		return rf(ctx, constructor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeploymentConstructor) *model.DeploymentPreview); ok {
+		r0 = rf(ctx, constructor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DeploymentPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.DeploymentConstructor) error); ok {
+		r1 = rf(ctx, constructor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProvisionTenant provides a mock function with given fields: ctx, tenant_id
 func (_m *App) ProvisionTenant(ctx context.Context, tenant_id string) error {
 	ret := _m.Called(ctx, tenant_id)
@@ -1122,6 +1474,24 @@ func (_m *App) ReplaceReleaseTags(ctx context.Context, releaseName string, tags
 	return r0
 }
 
+// ResumeDeployment provides a mock function with given fields: ctx, deploymentID
+func (_m *App) ResumeDeployment(ctx context.Context, deploymentID string) error {
+	ret := _m.Called(ctx, deploymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeDeployment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deploymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveDeviceDeploymentLog provides a mock function with given fields: ctx, deviceID, deploymentID, logs
 func (_m *App) SaveDeviceDeploymentLog(ctx context.Context, deviceID string, deploymentID string, logs []model.LogMessage) error {
 	ret := _m.Called(ctx, deviceID, deploymentID, logs)
@@ -1140,6 +1510,42 @@ func (_m *App) SaveDeviceDeploymentLog(ctx context.Context, deviceID string, dep
 	return r0
 }
 
+// SetArtifactVerificationSettings provides a mock function with given fields: ctx, settings
+func (_m *App) SetArtifactVerificationSettings(ctx context.Context, settings model.ArtifactVerificationSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArtifactVerificationSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ArtifactVerificationSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDownloadSettings provides a mock function with given fields: ctx, settings
+func (_m *App) SetDownloadSettings(ctx context.Context, settings model.DownloadSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDownloadSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.DownloadSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetStorageSettings provides a mock function with given fields: ctx, storageSettings
 func (_m *App) SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error {
 	ret := _m.Called(ctx, storageSettings)
@@ -1158,6 +1564,24 @@ func (_m *App) SetStorageSettings(ctx context.Context, storageSettings *model.St
 	return r0
 }
 
+// UpdateDeltaStatus provides a mock function with given fields: ctx, id, update
+func (_m *App) UpdateDeltaStatus(ctx context.Context, id string, update model.DeltaStatusUpdate) error {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeltaStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeltaStatusUpdate) error); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDeploymentsWithArtifactName provides a mock function with given fields: ctx, artifactName
 func (_m *App) UpdateDeploymentsWithArtifactName(ctx context.Context, artifactName string) error {
 	ret := _m.Called(ctx, artifactName)
@@ -1242,6 +1666,36 @@ func (_m *App) UploadLink(ctx context.Context, expire time.Duration, skipVerify
 	return r0, r1
 }
 
+// UploadPartLink provides a mock function with given fields: ctx, intentID, partNumber
+func (_m *App) UploadPartLink(ctx context.Context, intentID string, partNumber int) (*model.Link, error) {
+	ret := _m.Called(ctx, intentID, partNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPartLink")
+	}
+
+	var r0 *model.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*model.Link, error)); ok {
+		return rf(ctx, intentID, partNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *model.Link); ok {
+		r0 = rf(ctx, intentID, partNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, intentID, partNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewApp creates a new instance of App. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApp(t interface {
@@ -124,7 +124,7 @@ func TestSetStorageSettings(t *testing.T) {
 			if actual == nil {
 				return false
 			}
-			return *settings == *actual
+			return assert.ObjectsAreEqual(settings, actual)
 		}
 	}
 
@@ -154,3 +154,60 @@ func TestSetStorageSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestSetStorageSettingsLocations(t *testing.T) {
+	settings := &model.StorageSettings{
+		Region: "eu-west-1",
+		Key:    "secretkey",
+		Secret: "secret",
+		Bucket: "bucket-eu",
+		LocationAttribute: &model.StorageLocationAttribute{
+			Scope: "system",
+			Name:  "group",
+		},
+		Locations: []model.StorageLocation{{
+			Name:   "apac",
+			Values: []string{"asia"},
+			Settings: model.StorageSettings{
+				Region: "ap-southeast-1",
+				Key:    "secretkey",
+				Secret: "secret",
+				Bucket: "bucket-apac",
+			},
+		}},
+	}
+	testCases := map[string]struct {
+		locationErr error
+		err         error
+	}{
+		"ok": {},
+		"error, location health check": {
+			locationErr: errors.New("access denied"),
+			err: errors.New(`the settings of the storage location "apac" ` +
+				`failed the health check: access denied`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := mocks.NewDataStore(t)
+			objStore := storageMocks.NewObjectStorage(t)
+			objStore.On("HealthCheck", settingsMatcher("bucket-eu")).Return(nil)
+			objStore.On("HealthCheck", settingsMatcher("bucket-apac")).Return(tc.locationErr)
+			if tc.err == nil {
+				db.On("SetStorageSettings", context.Background(), settings).Return(nil)
+			}
+			ds := &Deployments{
+				db:            db,
+				objectStorage: objStore,
+			}
+
+			err := ds.SetStorageSettings(context.Background(), settings)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err.Error())
+			}
+		})
+	}
+}
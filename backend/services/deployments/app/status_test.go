@@ -45,6 +45,8 @@ func TestUpdateDeviceDeploymentStatus(t *testing.T) {
 		notFounddDepByID bool
 		findDepByIDErr   error
 
+		updateStatusErr error
+
 		err error
 	}{
 		"ok": {
@@ -53,6 +55,21 @@ func TestUpdateDeviceDeploymentStatus(t *testing.T) {
 			},
 			deviceStatus: model.DeviceDeploymentStatusDownloading,
 		},
+		"ok, deployment status changed concurrently": {
+			ddStatueNew: model.DeviceDeploymentState{
+				Status: model.DeviceDeploymentStatusInstalling,
+			},
+			deviceStatus:    model.DeviceDeploymentStatusDownloading,
+			updateStatusErr: mongo.ErrStorageNotFound,
+		},
+		"error: updating deployment status": {
+			ddStatueNew: model.DeviceDeploymentState{
+				Status: model.DeviceDeploymentStatusInstalling,
+			},
+			deviceStatus:    model.DeviceDeploymentStatusDownloading,
+			updateStatusErr: errors.New("internal error"),
+			err:             errors.New("internal error"),
+		},
 		"error: device deployment not found": {
 			ddStatueNew: model.DeviceDeploymentState{
 				Status: model.DeviceDeploymentStatusInstalling,
@@ -117,10 +134,13 @@ func TestUpdateDeviceDeploymentStatus(t *testing.T) {
 				db.On("FindDeploymentByID", ctx, fakeDeployment.Id).Return(
 					foundDepById, tc.findDepByIDErr).Once()
 				if tc.findDepByIDErr == nil && foundDepById != nil {
-					db.On("SetDeploymentStatus", ctx,
+					db.On("UpdateDeploymentStatus", ctx,
 						fakeDeployment.Id,
+						model.DeploymentStatusPending,
 						model.DeploymentStatusInProgress,
-						mock.AnythingOfType("time.Time")).Return(nil).Once()
+						mock.AnythingOfType("time.Time")).
+						Return(tc.updateStatusErr).
+						Once()
 
 				}
 			}
@@ -128,7 +148,210 @@ func TestUpdateDeviceDeploymentStatus(t *testing.T) {
 
 			err = ds.UpdateDeviceDeploymentStatus(ctx, fakeDeployment.Id, fakeDeviceDeployment.DeviceId, tc.ddStatueNew)
 
-			assert.ErrorIs(t, err, tc.err)
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateDeviceDeploymentStatusFailureThreshold(t *testing.T) {
+	ctx := context.TODO()
+	devId := "somedevice"
+
+	testCases := map[string]struct {
+		maxFailures int
+		failures    int
+		aborted     bool
+	}{
+		"ok, threshold not exceeded": {
+			maxFailures: 1,
+		},
+		"ok, threshold exceeded": {
+			maxFailures: 1,
+			failures:    1,
+			aborted:     true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			maxFailures := tc.maxFailures
+			fakeDeployment, err := model.NewDeploymentFromConstructor(
+				&model.DeploymentConstructor{
+					Name:         "foo",
+					ArtifactName: "bar",
+					Devices:      []string{devId, "otherdevice", "anotherdevice"},
+					MaxFailures:  &maxFailures,
+				},
+			)
+			assert.NoError(t, err)
+			fakeDeployment.MaxDevices = 3
+			fakeDeployment.Stats.Set(model.DeviceDeploymentStatusFailure, tc.failures)
+			fakeDeployment.Stats.Set(model.DeviceDeploymentStatusPending, 2)
+
+			fakeDeviceDeployment := model.NewDeviceDeployment(
+				devId, fakeDeployment.Id)
+			fakeDeviceDeployment.Status = model.DeviceDeploymentStatusInstalling
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+
+			db.On("GetDeviceDeployment", ctx,
+				devId, fakeDeployment.Id, false).Return(
+				fakeDeviceDeployment, nil).Once()
+			db.On("UpdateDeviceDeploymentStatus", ctx,
+				devId,
+				fakeDeployment.Id,
+				mock.AnythingOfType("model.DeviceDeploymentState"),
+				model.DeviceDeploymentStatusInstalling,
+			).Return(model.DeviceDeploymentStatusInstalling, nil).Once()
+			db.On("FindDeploymentByID", ctx, fakeDeployment.Id).Return(
+				fakeDeployment, nil).Once()
+			stats := model.NewDeviceDeploymentStats()
+			stats.Set(model.DeviceDeploymentStatusFailure, tc.failures+1)
+			stats.Set(model.DeviceDeploymentStatusPending, 1)
+			db.On("UpdateStatsInc", ctx,
+				fakeDeployment.Id,
+				model.DeviceDeploymentStatusInstalling,
+				model.DeviceDeploymentStatusFailure,
+			).Return(stats, nil).Once()
+			if tc.aborted {
+				db.On("AbortDeviceDeployments", ctx, fakeDeployment.Id).
+					Return(nil).Once()
+				db.On("AggregateDeviceDeploymentByStatus", ctx, fakeDeployment.Id).
+					Return(stats, nil).Once()
+				db.On("UpdateStats", ctx, fakeDeployment.Id, stats).
+					Return(nil).Once()
+				db.On("SetDeploymentStatus", ctx,
+					fakeDeployment.Id,
+					model.DeploymentStatusFinished,
+					mock.AnythingOfType("time.Time")).Return(nil).Once()
+			} else {
+				db.On("UpdateDeploymentStatus", ctx,
+					fakeDeployment.Id,
+					model.DeploymentStatusPending,
+					model.DeploymentStatusInProgress,
+					mock.AnythingOfType("time.Time")).Return(nil).Once()
+			}
+			db.On("SaveLastDeviceDeploymentStatus", ctx,
+				mock.AnythingOfType("model.DeviceDeployment"),
+			).Return(nil).Once()
+
+			ds := NewDeployments(db, nil, 0, false)
+			err = ds.UpdateDeviceDeploymentStatus(ctx, devId, fakeDeployment.Id,
+				model.DeviceDeploymentState{
+					Status: model.DeviceDeploymentStatusFailure,
+				})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateDeviceDeploymentStatusRetry(t *testing.T) {
+	ctx := context.TODO()
+	devId := "somedevice"
+
+	testCases := map[string]struct {
+		attempts uint
+		retries  uint
+		status   model.DeviceDeploymentStatus
+	}{
+		"ok, retry": {
+			retries: 1,
+			status:  model.DeviceDeploymentStatusPending,
+		},
+		"ok, retry again": {
+			attempts: 1,
+			retries:  2,
+			status:   model.DeviceDeploymentStatusPending,
+		},
+		"ok, no retries left": {
+			attempts: 1,
+			retries:  1,
+			status:   model.DeviceDeploymentStatusFailure,
+		},
+		"ok, no retries": {
+			status: model.DeviceDeploymentStatusFailure,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeDeployment, err := model.NewDeploymentFromConstructor(
+				&model.DeploymentConstructor{
+					Name:         "foo",
+					ArtifactName: "bar",
+					Devices:      []string{devId, "otherdevice"},
+					Retries:      tc.retries,
+				},
+			)
+			assert.NoError(t, err)
+			fakeDeployment.MaxDevices = 2
+			fakeDeployment.Stats.Set(model.DeviceDeploymentStatusInstalling, 1)
+			fakeDeployment.Stats.Set(model.DeviceDeploymentStatusPending, 1)
+			fakeDeployment.Status = model.DeploymentStatusInProgress
+
+			fakeDeviceDeployment := model.NewDeviceDeployment(
+				devId, fakeDeployment.Id)
+			fakeDeviceDeployment.Status = model.DeviceDeploymentStatusInstalling
+			fakeDeviceDeployment.Attempts = tc.attempts
+			fakeDeviceDeployment.Retries = tc.retries
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+
+			db.On("GetDeviceDeployment", ctx,
+				devId, fakeDeployment.Id, false).Return(
+				fakeDeviceDeployment, nil).Once()
+			if tc.status == model.DeviceDeploymentStatusPending {
+				db.On("IncrementDeviceDeploymentAttempts", ctx,
+					fakeDeviceDeployment.Id, uint(1)).
+					Return(nil).
+					Once()
+				db.On("SaveDeviceDeploymentRequest", ctx,
+					fakeDeviceDeployment.Id,
+					(*model.DeploymentNextRequest)(nil),
+				).Return(nil).Once()
+			}
+			db.On("UpdateDeviceDeploymentStatus", ctx,
+				devId,
+				fakeDeployment.Id,
+				mock.MatchedBy(func(ddState model.DeviceDeploymentState) bool {
+					return ddState.Status == tc.status &&
+						(ddState.FinishTime == nil) == tc.status.Active()
+				}),
+				model.DeviceDeploymentStatusInstalling,
+			).Return(model.DeviceDeploymentStatusInstalling, nil).Once()
+			db.On("FindDeploymentByID", ctx, fakeDeployment.Id).Return(
+				fakeDeployment, nil).Once()
+			stats := model.NewDeviceDeploymentStats()
+			stats.Set(tc.status, 1)
+			stats.Set(model.DeviceDeploymentStatusPending,
+				stats.Get(model.DeviceDeploymentStatusPending)+1)
+			db.On("UpdateStatsInc", ctx,
+				fakeDeployment.Id,
+				model.DeviceDeploymentStatusInstalling,
+				tc.status,
+			).Return(stats, nil).Once()
+			if tc.status == model.DeviceDeploymentStatusPending {
+				db.On("UpdateDeploymentStatus", ctx,
+					fakeDeployment.Id,
+					model.DeploymentStatusInProgress,
+					model.DeploymentStatusPending,
+					mock.AnythingOfType("time.Time")).Return(nil).Once()
+			} else {
+				db.On("SaveLastDeviceDeploymentStatus", ctx,
+					mock.AnythingOfType("model.DeviceDeployment"),
+				).Return(nil).Once()
+			}
+
+			ds := NewDeployments(db, nil, 0, false)
+			err = ds.UpdateDeviceDeploymentStatus(ctx, devId, fakeDeployment.Id,
+				model.DeviceDeploymentState{
+					Status: model.DeviceDeploymentStatusFailure,
+				})
+			assert.NoError(t, err)
 		})
 	}
 }
@@ -187,9 +410,6 @@ func TestGetDeploymentForDeviceWithCurrent(t *testing.T) {
 		fakeDeployment.Id, fakeDeviceDeployment.DeviceId, false).Return(
 		fakeDeviceDeployment, nil)
 
-	db.On("IncrementDeviceDeploymentAttempts", ctx,
-		fakeDeviceDeployment.Id, uint(1)).Return(nil)
-
 	db.On("UpdateDeviceDeploymentStatus", ctx,
 		fakeDeviceDeployment.DeviceId,
 		fakeDeployment.Id,
@@ -242,6 +462,238 @@ func TestGetDeploymentForDeviceWithCurrent(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetNewDeploymentForDeviceWithPhases(t *testing.T) {
+	ctx := context.TODO()
+	devId := "somedevice"
+
+	testCases := map[string]struct {
+		startTs   time.Time
+		phaseErr  error
+		insertErr error
+		admitted  bool
+		expectErr bool
+	}{
+		"ok, phase started": {
+			startTs:  time.Now().Add(-time.Minute),
+			admitted: true,
+		},
+		"ok, phase not started yet": {
+			startTs: time.Now().Add(time.Hour),
+		},
+		"ok, phase filled up concurrently": {
+			startTs:  time.Now().Add(-time.Minute),
+			phaseErr: mongo.ErrDeploymentPhaseFull,
+		},
+		"error, updating the phase": {
+			startTs:   time.Now().Add(-time.Minute),
+			phaseErr:  errors.New("internal error"),
+			expectErr: true,
+		},
+		"error, creating the device deployment releases the phase": {
+			startTs:   time.Now().Add(-time.Minute),
+			insertErr: errors.New("internal error"),
+			expectErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeDeployment, err := model.NewDeploymentFromConstructor(
+				&model.DeploymentConstructor{
+					Name:         "foo",
+					ArtifactName: "bar",
+					Devices:      []string{devId, "otherdevice"},
+				},
+			)
+			assert.NoError(t, err)
+			fakeDeployment.MaxDevices = 2
+			fakeDeployment.Phases = []model.DeploymentPhase{{
+				Id:         "phase-1",
+				StartTs:    &tc.startTs,
+				MaxDevices: 2,
+			}}
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+
+			db.On("FindLatestInactiveDeviceDeployment", ctx, devId).
+				Return(nil, nil)
+			db.On("FindNewerActiveDeployment", ctx, &time.Time{}, devId).
+				Return(fakeDeployment, nil).Once()
+			if !tc.admitted && !tc.expectErr {
+				// the device waits for the phase, but the search
+				// goes on with the newer deployments
+				db.On("FindNewerActiveDeployment", ctx,
+					fakeDeployment.Created, devId).
+					Return(nil, nil).Once()
+			}
+			if tc.startTs.Before(time.Now()) {
+				db.On("IncrementDeploymentPhaseDeviceCount", ctx,
+					fakeDeployment.Id, "phase-1", 2).
+					Return(tc.phaseErr).Once()
+			}
+			if tc.admitted || tc.insertErr != nil {
+				db.On("GetDeviceDeployment", ctx,
+					fakeDeployment.Id, devId, true).
+					Return(nil, mongo.ErrStorageNotFound)
+				db.On("InsertDeviceDeployment", ctx,
+					mock.AnythingOfType("*model.DeviceDeployment"), true).
+					Return(tc.insertErr)
+			}
+			if tc.insertErr != nil {
+				db.On("DecrementDeploymentPhaseDeviceCount", ctx,
+					fakeDeployment.Id, "phase-1").
+					Return(nil).Once()
+			}
+
+			ds := NewDeployments(db, nil, 0, false)
+			deployment, deviceDeployment, err := ds.getNewDeploymentForDevice(ctx, devId)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, 0, fakeDeployment.Phases[0].DeviceCount)
+			} else if tc.admitted {
+				assert.NoError(t, err)
+				assert.Equal(t, fakeDeployment, deployment)
+				assert.NotNil(t, deviceDeployment)
+				assert.Equal(t, 1, deployment.Phases[0].DeviceCount)
+			} else {
+				assert.NoError(t, err)
+				assert.Nil(t, deployment)
+				assert.Nil(t, deviceDeployment)
+			}
+		})
+	}
+}
+
+func TestGetDeploymentForDeviceScheduled(t *testing.T) {
+	ctx := context.TODO()
+	devId := "somedevice"
+	later := time.Now().Add(time.Hour)
+
+	testCases := map[string]struct {
+		deviceDeploymentStatus model.DeviceDeploymentStatus
+		startTime              *time.Time
+		window                 *model.MaintenanceWindow
+		paused                 bool
+		newer                  bool
+		returned               bool
+	}{
+		"new deployment, not started yet": {
+			startTime: &later,
+		},
+		"new deployment, not started yet, newer deployment started": {
+			startTime: &later,
+			newer:     true,
+			returned:  true,
+		},
+		"new deployment, paused, newer deployment started": {
+			paused:   true,
+			newer:    true,
+			returned: true,
+		},
+		"pending device deployment, not started yet": {
+			deviceDeploymentStatus: model.DeviceDeploymentStatusPending,
+			startTime:              &later,
+		},
+		"pending device deployment, outside the window": {
+			deviceDeploymentStatus: model.DeviceDeploymentStatusPending,
+			window: &model.MaintenanceWindow{
+				Start: later.UTC().Format("15:04"),
+				End:   later.Add(time.Minute).UTC().Format("15:04"),
+			},
+		},
+		"pending device deployment, started": {
+			deviceDeploymentStatus: model.DeviceDeploymentStatusPending,
+			returned:               true,
+		},
+		"device deployment in progress": {
+			deviceDeploymentStatus: model.DeviceDeploymentStatusDownloading,
+			startTime:              &later,
+			returned:               true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeDeployment, err := model.NewDeploymentFromConstructor(
+				&model.DeploymentConstructor{
+					Name:              "foo",
+					ArtifactName:      "bar",
+					Devices:           []string{devId},
+					StartTime:         tc.startTime,
+					MaintenanceWindow: tc.window,
+				},
+			)
+			assert.NoError(t, err)
+			fakeDeployment.MaxDevices = 1
+			if tc.paused {
+				fakeDeployment.Status = model.DeploymentStatusPaused
+			}
+			newerDeployment, err := model.NewDeploymentFromConstructor(
+				&model.DeploymentConstructor{
+					Name:         "baz",
+					ArtifactName: "bar",
+					Devices:      []string{devId},
+				},
+			)
+			assert.NoError(t, err)
+			newerDeployment.MaxDevices = 1
+			newerDeployment.DeviceCount = intPtr(0)
+
+			db := &mocks.DataStore{}
+			defer db.AssertExpectations(t)
+
+			expected := fakeDeployment
+			if tc.deviceDeploymentStatus == model.DeviceDeploymentStatusNull {
+				db.On("FindOldestActiveDeviceDeployment", ctx, devId).
+					Return(nil, nil)
+				db.On("FindLatestInactiveDeviceDeployment", ctx, devId).
+					Return(nil, nil)
+				db.On("FindNewerActiveDeployment", ctx, &time.Time{}, devId).
+					Return(fakeDeployment, nil).
+					Once()
+				// the deployment waiting to start doesn't hold back
+				// the newer deployments
+				if tc.newer {
+					expected = newerDeployment
+					db.On("FindNewerActiveDeployment", ctx,
+						fakeDeployment.Created, devId).
+						Return(newerDeployment, nil).
+						Once()
+					db.On("GetDeviceDeployment", ctx,
+						newerDeployment.Id, devId, true).
+						Return(nil, mongo.ErrStorageNotFound)
+					db.On("InsertDeviceDeployment", ctx,
+						mock.AnythingOfType("*model.DeviceDeployment"), true).
+						Return(nil)
+				} else {
+					db.On("FindNewerActiveDeployment", ctx,
+						fakeDeployment.Created, devId).
+						Return(nil, nil).
+						Once()
+				}
+			} else {
+				fakeDeviceDeployment := model.NewDeviceDeployment(
+					devId, fakeDeployment.Id)
+				fakeDeviceDeployment.Status = tc.deviceDeploymentStatus
+				db.On("FindOldestActiveDeviceDeployment", ctx, devId).
+					Return(fakeDeviceDeployment, nil)
+				db.On("FindDeploymentByID", ctx, fakeDeployment.Id).
+					Return(fakeDeployment, nil)
+			}
+
+			ds := NewDeployments(db, nil, 0, false)
+			deployment, deviceDeployment, err := ds.getDeploymentForDevice(ctx, devId)
+			assert.NoError(t, err)
+			if tc.returned {
+				assert.Equal(t, expected, deployment)
+				assert.NotNil(t, deviceDeployment)
+			} else {
+				assert.Nil(t, deployment)
+				assert.Nil(t, deviceDeployment)
+			}
+		})
+	}
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
@@ -645,3 +1097,52 @@ func TestAbortDeviceDeployments(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateDeviceDeploymentStatusIllegalTransition(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deviceStatus model.DeviceDeploymentStatus
+		status       model.DeviceDeploymentStatus
+		saveErr      error
+	}{
+		"success to downloading": {
+			deviceStatus: model.DeviceDeploymentStatusSuccess,
+			status:       model.DeviceDeploymentStatusDownloading,
+		},
+		"installing to downloading": {
+			deviceStatus: model.DeviceDeploymentStatusInstalling,
+			status:       model.DeviceDeploymentStatusDownloading,
+		},
+		"failure to success, error saving the transition": {
+			deviceStatus: model.DeviceDeploymentStatusFailure,
+			status:       model.DeviceDeploymentStatusSuccess,
+			saveErr:      errors.New("internal error"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			dd := model.NewDeviceDeployment("device", "deployment")
+			dd.Status = tc.deviceStatus
+
+			db := mocks.NewDataStore(t)
+			db.On("GetDeviceDeployment", ctx, "deployment", "device", false).
+				Return(dd, nil)
+			db.On("SaveDeviceDeploymentIllegalTransition", ctx, dd.Id,
+				mock.MatchedBy(func(transition model.DeviceDeploymentTransition) bool {
+					return transition.From == tc.deviceStatus &&
+						transition.To == tc.status
+				})).
+				Return(tc.saveErr)
+
+			ds := NewDeployments(db, nil, 0, false)
+			err := ds.UpdateDeviceDeploymentStatus(ctx, "deployment", "device",
+				model.DeviceDeploymentState{Status: tc.status})
+			assert.ErrorIs(t, err, ErrIllegalStatusTransition)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"strings"
 
@@ -64,6 +65,26 @@ const (
 	SettingsStorageUploadExpireSeconds          = SettingStorage + ".upload_expire_seconds"
 	SettingsStorageUploadExpireSecondsDefault   = 3600
 
+	// SettingStorageCDN fronts the default storage with a CDN: the public
+	// artifact download links point to the CDN and carry its signature,
+	// so that the devices are served by the edge caches. Tenants with
+	// their own storage settings keep downloading from their storage.
+	SettingStorageCDN    = SettingStorage + ".cdn"
+	SettingStorageCDNURI = SettingStorageCDN + ".uri"
+	// SettingStorageCDNSigner is the signing scheme of the CDN links, one
+	// of "cloudfront" (signed URL with a canned policy) or "hmac" (expiry
+	// and HMAC-SHA256 token in the query).
+	SettingStorageCDNSigner        = SettingStorageCDN + ".signer"
+	SettingStorageCDNSignerDefault = CDNSignerCloudFront
+	// SettingStorageCDNKeyPairID and SettingStorageCDNPrivateKey are the
+	// ID of the CloudFront public key and the path to the PEM encoded RSA
+	// private key signing the links.
+	SettingStorageCDNKeyPairID  = SettingStorageCDN + ".key_pair_id"
+	SettingStorageCDNPrivateKey = SettingStorageCDN + ".private_key"
+	// SettingStorageCDNSecret is the base64 encoded secret of the "hmac"
+	// signer, shared with the CDN validating the tokens.
+	SettingStorageCDNSecret = SettingStorageCDN + ".secret"
+
 	SettingsAws                       = "aws"
 	SettingAwsS3Region                = SettingsAws + ".region"
 	SettingAwsS3RegionDefault         = "us-east-1"
@@ -93,6 +114,18 @@ const (
 	SettingAzureSharedKeyAccountKey = SettingAzureSharedKey + ".account_key"
 	SettingAzureSharedKeyURI        = SettingAzureSharedKey + ".uri"
 
+	// SettingFilesystem configures the filesystem storage, which stores the
+	// objects under a local directory and serves them through signed links
+	// to the deployments service itself. The public links use the
+	// presign.url_scheme and presign.url_hostname settings, while the
+	// links handed out to the internal services use internal_uri.
+	// The service replicas must share the directory and presign.secret.
+	SettingFilesystem                   = "filesystem"
+	SettingFilesystemRootDir            = SettingFilesystem + ".root_dir"
+	SettingFilesystemRootDirDefault     = "/var/lib/mender/deployments"
+	SettingFilesystemInternalURI        = SettingFilesystem + ".internal_uri"
+	SettingFilesystemInternalURIDefault = "http://mender-deployments:8080"
+
 	SettingMongo        = "mongo-url"
 	SettingMongoDefault = "mongodb://mongo-deployments:27017"
 
@@ -155,11 +188,43 @@ const (
 	// Max Request body size
 	SettingMaxRequestSize        = "request_size_limit"
 	SettingMaxRequestSizeDefault = 1024 * 1024 // 1 MiB
+
+	// SettingEnableDeltaGeneration enables generating and serving binary
+	// delta artifacts to the devices running a rootfs image known to the
+	// service. It requires the create-artifact-worker to have access to the
+	// mender-binary-delta-generator tool.
+	SettingEnableDeltaGeneration        = "enable_delta_generation"
+	SettingEnableDeltaGenerationDefault = false
+
+	SettingDeploymentLog = "deployment_log"
+
+	// SettingDeploymentLogMaxSize is the default maximum size of the
+	// deployment logs in bytes, which the tenants can override with the
+	// deployment_log_size limit; the middle of longer logs is truncated.
+	SettingDeploymentLogMaxSize        = SettingDeploymentLog + ".max_size"
+	SettingDeploymentLogMaxSizeDefault = 512 * 1024 // 512 KiB
+
+	// SettingDeploymentLogRetentionSeconds is the number of seconds the
+	// deployment logs are kept for; 0 keeps them forever.
+	SettingDeploymentLogRetentionSeconds        = SettingDeploymentLog + ".retention_seconds"
+	SettingDeploymentLogRetentionSecondsDefault = 0
+
+	// SettingContinuousMatchIntervalSeconds is the number of seconds the
+	// devices which don't match the filter of a continuous deployment wait
+	// before they are matched against it again; 0 matches them on every poll.
+	SettingContinuousMatchIntervalSeconds        = "continuous_deployments.match_interval_seconds"
+	SettingContinuousMatchIntervalSecondsDefault = 300
+)
+
+const (
+	StorageTypeAWS        = "aws"
+	StorageTypeAzure      = "azure"
+	StorageTypeFilesystem = "filesystem"
 )
 
 const (
-	StorageTypeAWS   = "aws"
-	StorageTypeAzure = "azure"
+	CDNSignerCloudFront = "cloudfront"
+	CDNSignerHMAC       = "hmac"
 )
 
 const (
@@ -213,16 +278,54 @@ func ValidateHttps(c config.Reader) error {
 }
 
 func ValidateStorage(c config.Reader) error {
-	svc := c.GetString(SettingDefaultStorage)
-	if svc != StorageTypeAWS && svc != StorageTypeAzure {
+	switch svc := c.GetString(SettingDefaultStorage); svc {
+	case StorageTypeAWS, StorageTypeAzure:
+	case StorageTypeFilesystem:
+		// the object links are served through the gateway
+		if c.GetString(SettingPresignHost) == "" {
+			return MissingOptionError(SettingPresignHost)
+		}
+	default:
 		return fmt.Errorf(
-			`setting "%s" (%s) must be one of "aws" or "azure"`,
+			`setting "%s" (%s) must be one of "aws", "azure" or "filesystem"`,
 			SettingDefaultStorage, svc,
 		)
 	}
 	return nil
 }
 
+// ValidateCDN validates configuration of SettingStorageCDN section if the
+// CDN is enabled.
+func ValidateCDN(c config.Reader) error {
+	if c.GetString(SettingStorageCDNURI) == "" {
+		return nil
+	}
+	uri, err := url.Parse(c.GetString(SettingStorageCDNURI))
+	if err != nil {
+		return errors.WithMessagef(err, `invalid setting "%s"`, SettingStorageCDNURI)
+	} else if uri.Scheme == "" || uri.Host == "" {
+		return fmt.Errorf(`setting "%s" must be an absolute URL`, SettingStorageCDNURI)
+	}
+	var required []string
+	switch signer := c.GetString(SettingStorageCDNSigner); signer {
+	case CDNSignerCloudFront:
+		required = []string{SettingStorageCDNKeyPairID, SettingStorageCDNPrivateKey}
+	case CDNSignerHMAC:
+		required = []string{SettingStorageCDNSecret}
+	default:
+		return fmt.Errorf(
+			`setting "%s" (%s) must be one of "cloudfront" or "hmac"`,
+			SettingStorageCDNSigner, signer,
+		)
+	}
+	for _, key := range required {
+		if c.GetString(key) == "" {
+			return MissingOptionError(key)
+		}
+	}
+	return nil
+}
+
 // Generate error with missing required option message.
 func MissingOptionError(option string) error {
 	return fmt.Errorf("Required option: '%s'", option)
@@ -274,7 +377,12 @@ func Setup(configPath string) error {
 }
 
 var (
-	Validators = []config.Validator{ValidateAwsAuth, ValidateHttps, ValidateStorage}
+	Validators = []config.Validator{
+		ValidateAwsAuth,
+		ValidateHttps,
+		ValidateStorage,
+		ValidateCDN,
+	}
 	// Aliases for deprecated configuration names to preserve backward compatibility.
 	Aliases = []struct {
 		Key   string
@@ -303,6 +411,9 @@ var (
 		{Key: SettingsStorageDownloadExpireSeconds,
 			Value: SettingsStorageDownloadExpireSecondsDefault},
 		{Key: SettingsStorageUploadExpireSeconds, Value: SettingsStorageUploadExpireSecondsDefault},
+		{Key: SettingStorageCDNSigner, Value: SettingStorageCDNSignerDefault},
+		{Key: SettingFilesystemRootDir, Value: SettingFilesystemRootDirDefault},
+		{Key: SettingFilesystemInternalURI, Value: SettingFilesystemInternalURIDefault},
 		{Key: SettingMongo, Value: SettingMongoDefault},
 		{Key: SettingDbSSL, Value: SettingDbSSLDefault},
 		{Key: SettingDbSSLSkipVerify, Value: SettingDbSSLSkipVerifyDefault},
@@ -317,5 +428,11 @@ var (
 		{Key: SettingPresignScheme, Value: SettingPresignSchemeDefault},
 		{Key: SettingDisableNewReleasesFeature, Value: SettingDisableNewReleasesFeatureDefault},
 		{Key: SettingMaxRequestSize, Value: SettingMaxRequestSizeDefault},
+		{Key: SettingEnableDeltaGeneration, Value: SettingEnableDeltaGenerationDefault},
+		{Key: SettingDeploymentLogMaxSize, Value: SettingDeploymentLogMaxSizeDefault},
+		{Key: SettingDeploymentLogRetentionSeconds,
+			Value: SettingDeploymentLogRetentionSecondsDefault},
+		{Key: SettingContinuousMatchIntervalSeconds,
+			Value: SettingContinuousMatchIntervalSecondsDefault},
 	}
 )
@@ -100,6 +100,26 @@ func doMain(args []string) {
 						"to be removed.",
 					Value: time.Second * 3,
 				},
+				cli.BoolFlag{
+					Name: "gc",
+					Usage: "Run a single garbage collection pass removing " +
+						"the objects without a matching artifact, then exit.",
+				},
+				cli.BoolFlag{
+					Name: "gc-unused-artifacts",
+					Usage: "Also remove the artifacts unused by any " +
+						"deployment during the garbage collection.",
+				},
+				cli.DurationFlag{
+					Name: "gc-min-age",
+					Usage: "Only collect objects and artifacts older than " +
+						"`DURATION`.",
+					Value: time.Hour * 24 * 30,
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Report the garbage without removing it.",
+				},
 			},
 			Action: cmdStorageDaemon,
 		},
@@ -221,9 +241,33 @@ func cmdStorageDaemon(args *cli.Context) error {
 	}
 	database := mongo.NewDataStoreMongoWithClient(mgo)
 	app := app.NewDeployments(database, objectStorage, 0, false)
+	if args.Bool("gc") {
+		return garbageCollect(ctx, app, args)
+	}
 	return app.CleanupExpiredUploads(
 		ctx,
 		args.Duration("interval"),
 		args.Duration("time-jitter"),
 	)
 }
+
+func garbageCollect(ctx context.Context, deployments *app.Deployments, args *cli.Context) error {
+	l := log.FromContext(ctx)
+	dryRun := args.Bool("dry-run")
+	report, err := deployments.GarbageCollect(ctx, app.GCOptions{
+		MinAge:       args.Duration("gc-min-age"),
+		UnusedImages: args.Bool("gc-unused-artifacts"),
+		DryRun:       dryRun,
+	})
+	if report != nil {
+		verb := "removed"
+		if dryRun {
+			verb = "found"
+		}
+		l.Infof("garbage collection %s %d orphaned objects (%d bytes) "+
+			"and %d unused artifacts (%d bytes)", verb,
+			report.Objects, report.ObjectsSize,
+			report.Images, report.ImagesSize)
+	}
+	return err
+}
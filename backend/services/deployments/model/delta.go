@@ -0,0 +1,148 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	DeltaStatusPending = "pending"
+	DeltaStatusReady   = "ready"
+	DeltaStatusFailed  = "failed"
+
+	// ArtifactUpdateTypeRootfs is the update type supported by the binary
+	// delta generator
+	ArtifactUpdateTypeRootfs = "rootfs-image"
+	// ArtifactProvidesRootfsChecksum is the checksum of the root filesystem
+	// the delta artifacts apply to
+	ArtifactProvidesRootfsChecksum = "rootfs-image.checksum"
+	// UpdateModuleBinaryDelta is the update module installing the delta
+	// artifacts on the devices
+	UpdateModuleBinaryDelta = "mender-binary-delta"
+
+	// DeltaMaxAttempts is the number of times the generation of a delta is
+	// attempted before the devices get the full image for good
+	DeltaMaxAttempts = 3
+	// DeltaRetryInterval is the time a failed delta generation waits before
+	// it is attempted again
+	DeltaRetryInterval = time.Hour
+)
+
+// Delta is a binary delta artifact updating the devices running the source
+// image to the target image. Deltas are generated on demand, the first time a
+// device running the source image is assigned the target image.
+type Delta struct {
+	// Delta artifact identifier, also the object storage key
+	Id string `json:"id" bson:"_id"`
+
+	// Identifier of the image installed on the device
+	SourceImageId string `json:"source_image_id" bson:"source_image_id"`
+
+	// Identifier of the image the device is updated to
+	TargetImageId string `json:"target_image_id" bson:"target_image_id"`
+
+	// Status of the delta generation
+	Status string `json:"status" bson:"status"`
+
+	// Size of the delta artifact, set once it is ready
+	Size int64 `json:"size,omitempty" bson:"size,omitempty"`
+
+	// Number of times the generation was attempted
+	Attempts int `json:"attempts" bson:"attempts"`
+
+	// Creation time
+	Created time.Time `json:"created" bson:"created"`
+
+	// Last modification time
+	Modified time.Time `json:"modified" bson:"modified"`
+}
+
+// NewDelta returns a new pending delta from source to target image
+func NewDelta(sourceImageID, targetImageID string) *Delta {
+	now := time.Now()
+	return &Delta{
+		Id:            uuid.NewString(),
+		SourceImageId: sourceImageID,
+		TargetImageId: targetImageID,
+		Status:        DeltaStatusPending,
+		Attempts:      1,
+		Created:       now,
+		Modified:      now,
+	}
+}
+
+// CanRetry reports whether the failed generation of the delta can be
+// attempted again.
+func (delta *Delta) CanRetry(now time.Time) bool {
+	return delta.Status == DeltaStatusFailed &&
+		delta.Attempts < DeltaMaxAttempts &&
+		!now.Before(delta.Modified.Add(DeltaRetryInterval))
+}
+
+// DeltaStatusUpdate is the outcome of the delta generation job
+type DeltaStatusUpdate struct {
+	Status string `json:"status"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+func (u DeltaStatusUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Status, validation.Required,
+			validation.In(DeltaStatusReady, DeltaStatusFailed)),
+		validation.Field(&u.Size, validation.Min(int64(0)),
+			validation.When(u.Status == DeltaStatusReady, validation.Required)),
+	)
+}
+
+// IsRootfsImage reports whether the image contains a single full root
+// filesystem update, which is the only kind of image deltas can be
+// generated for.
+func (img *Image) IsRootfsImage() bool {
+	if img == nil || img.ArtifactMeta == nil ||
+		len(img.ArtifactMeta.Updates) != 1 {
+		return false
+	}
+	typ := img.ArtifactMeta.Updates[0].TypeInfo.Type
+	return typ != nil && *typ == ArtifactUpdateTypeRootfs
+}
+
+// GenerateDeltaMsg holds the input of the delta artifact generation workflow
+type GenerateDeltaMsg struct {
+	DeltaID           string
+	TenantID          string
+	SourceArtifactURI string
+	TargetArtifactURI string
+	PutDeltaURI       string
+	PutDeltaHeader    map[string]string
+}
+
+func (msg GenerateDeltaMsg) ToRequestParams() map[string]any {
+	// workflow input parameters are plain strings
+	header, _ := json.Marshal(msg.PutDeltaHeader)
+	return map[string]any{
+		"delta_id":            msg.DeltaID,
+		"tenant_id":           msg.TenantID,
+		"source_artifact_uri": msg.SourceArtifactURI,
+		"target_artifact_uri": msg.TargetArtifactURI,
+		"put_delta_uri":       msg.PutDeltaURI,
+		"put_delta_header":    string(header),
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaStatusUpdateValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		update DeltaStatusUpdate
+		valid  bool
+	}{
+		"ok, ready": {
+			update: DeltaStatusUpdate{Status: DeltaStatusReady, Size: 10},
+			valid:  true,
+		},
+		"ok, failed": {
+			update: DeltaStatusUpdate{Status: DeltaStatusFailed},
+			valid:  true,
+		},
+		"error, ready without size": {
+			update: DeltaStatusUpdate{Status: DeltaStatusReady},
+		},
+		"error, pending": {
+			update: DeltaStatusUpdate{Status: DeltaStatusPending},
+		},
+		"error, negative size": {
+			update: DeltaStatusUpdate{Status: DeltaStatusFailed, Size: -1},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.update.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDeltaCanRetry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	longAgo := now.Add(-DeltaRetryInterval)
+
+	assert.True(t, (&Delta{
+		Status: DeltaStatusFailed, Attempts: 1, Modified: longAgo,
+	}).CanRetry(now))
+	assert.False(t, (&Delta{
+		Status: DeltaStatusFailed, Attempts: 1, Modified: now,
+	}).CanRetry(now))
+	assert.False(t, (&Delta{
+		Status: DeltaStatusFailed, Attempts: DeltaMaxAttempts, Modified: longAgo,
+	}).CanRetry(now))
+	assert.False(t, (&Delta{
+		Status: DeltaStatusPending, Attempts: 1, Modified: longAgo,
+	}).CanRetry(now))
+}
+
+func TestImageIsRootfsImage(t *testing.T) {
+	t.Parallel()
+
+	rootfs := ArtifactUpdateTypeRootfs
+	singleFile := "single-file"
+	newImage := func(types ...*string) *Image {
+		img := &Image{ArtifactMeta: &ArtifactMeta{}}
+		for _, typ := range types {
+			img.Updates = append(img.Updates, Update{
+				TypeInfo: ArtifactUpdateTypeInfo{Type: typ},
+			})
+		}
+		return img
+	}
+
+	assert.True(t, newImage(&rootfs).IsRootfsImage())
+	assert.False(t, newImage(&singleFile).IsRootfsImage())
+	assert.False(t, newImage(&rootfs, &rootfs).IsRootfsImage())
+	assert.False(t, newImage(nil).IsRootfsImage())
+	assert.False(t, newImage().IsRootfsImage())
+	assert.False(t, (&Image{}).IsRootfsImage())
+}
@@ -44,8 +44,22 @@ var (
 		"The deployment for group constructor should have neither list of devices" +
 			" nor all_devices flag set",
 	)
+	ErrInvalidDeploymentFilterNotContinuous = errors.New(
+		"Invalid deployments definition: filter_terms can be set for continuous deployments only",
+	)
+	ErrInvalidContinuousDeploymentDevices = errors.New(
+		"Invalid deployments definition: continuous deployments target a group," +
+			" all devices or filter_terms, not a list of devices",
+	)
+	ErrInvalidContinuousDeploymentPhases = errors.New(
+		"Invalid deployments definition: continuous deployments cannot be phased",
+	)
 )
 
+// DeploymentMaxRetries is the maximum number of times a device retries
+// a deployment.
+const DeploymentMaxRetries = 100
+
 type DeploymentStatus string
 type DeploymentType string
 
@@ -53,6 +67,11 @@ const (
 	DeploymentStatusFinished   DeploymentStatus = "finished"
 	DeploymentStatusInProgress DeploymentStatus = "inprogress"
 	DeploymentStatusPending    DeploymentStatus = "pending"
+	DeploymentStatusPaused     DeploymentStatus = "paused"
+
+	// DeploymentStatusResumed is requested to resume a paused deployment,
+	// which then gets back to the status computed from its statistics
+	DeploymentStatusResumed DeploymentStatus = "resumed"
 
 	DeploymentTypeSoftware      DeploymentType = "software"
 	DeploymentTypeConfiguration DeploymentType = "configuration"
@@ -63,6 +82,7 @@ func (stat DeploymentStatus) Validate() error {
 		DeploymentStatusFinished,
 		DeploymentStatusInProgress,
 		DeploymentStatusPending,
+		DeploymentStatusPaused,
 	).Validate(stat)
 }
 
@@ -92,6 +112,36 @@ type DeploymentConstructor struct {
 
 	// When set the deployment will be created for all accepted devices from a given group
 	Group string `json:"-" bson:"-"`
+
+	// Phases of the deployment, optional; when set, the devices are admitted
+	// to the deployment in batches
+	Phases []DeploymentPhase `json:"phases,omitempty" bson:"-"`
+
+	// Number of times a device retries the deployment after a failure
+	Retries uint `json:"retries,omitempty" bson:"retries,omitempty"`
+
+	// Time the deployment starts at, optional; devices don't get the
+	// deployment before this time
+	StartTime *time.Time `json:"start_time,omitempty" bson:"start_time,omitempty"`
+
+	// Recurring time window the devices can start the deployment in, optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty" bson:"maintenance_window,omitempty"`
+
+	// The deployment is aborted automatically when the percentage of the
+	// targeted devices which failed the update exceeds this value, optional
+	MaxFailurePercentage *int `json:"max_failure_percentage,omitempty" bson:"max_failure_percentage,omitempty"`
+
+	// The deployment is aborted automatically when the number of devices
+	// which failed the update exceeds this value, optional
+	MaxFailures *int `json:"max_failures,omitempty" bson:"max_failures,omitempty"`
+
+	// When set, the deployment keeps targeting the devices which join the
+	// group or start matching the filter until it is explicitly finished
+	Continuous bool `json:"continuous,omitempty" bson:"continuous,omitempty"`
+
+	// Inventory filter predicates the devices targeted by a continuous
+	// deployment have to match, optional
+	FilterTerms []FilterPredicate `json:"filter_terms,omitempty" bson:"-"`
 }
 
 // Validate checks structure according to valid tags
@@ -101,6 +151,12 @@ func (c DeploymentConstructor) Validate() error {
 		validation.Field(&c.Name, validation.By(rules.DeploymentName)),
 		validation.Field(&c.ArtifactName, validation.Required, lengthIn1To4096),
 		validation.Field(&c.Devices, validation.Each(validation.Required)),
+		validation.Field(&c.Phases, validation.By(validateDeploymentPhases)),
+		validation.Field(&c.MaxFailurePercentage, validation.Min(0), validation.Max(100)),
+		validation.Field(&c.MaxFailures, validation.Min(0)),
+		validation.Field(&c.Retries, validation.Max(uint(DeploymentMaxRetries))),
+		validation.Field(&c.MaintenanceWindow),
+		validation.Field(&c.FilterTerms, lengthIn0To200),
 	)
 }
 
@@ -109,8 +165,20 @@ func (c DeploymentConstructor) ValidateNew() error {
 		return err
 	}
 
+	if len(c.FilterTerms) > 0 && !c.Continuous {
+		return ErrInvalidDeploymentFilterNotContinuous
+	}
+	if c.Continuous {
+		if len(c.Devices) > 0 {
+			return ErrInvalidContinuousDeploymentDevices
+		}
+		if len(c.Phases) > 0 {
+			return ErrInvalidContinuousDeploymentPhases
+		}
+	}
+
 	if len(c.Group) == 0 {
-		if len(c.Devices) == 0 && !c.AllDevices {
+		if len(c.Devices) == 0 && !c.AllDevices && len(c.FilterTerms) == 0 {
 			return ErrInvalidDeploymentDefinitionNoDevices
 		}
 		if len(c.Devices) > 0 && c.AllDevices {
@@ -175,6 +243,9 @@ type Deployment struct {
 	// Total number of devices targeted
 	MaxDevices int `json:"max_devices,omitempty" bson:"max_devices"`
 
+	// Phases of the deployment, empty if the deployment is not phased
+	Phases []DeploymentPhase `json:"phases,omitempty" bson:"phases,omitempty"`
+
 	// device filter
 	Filter *Filter `json:"filter,omitempty" bson:"filter"`
 
@@ -272,7 +343,7 @@ func (d *Deployment) MarshalJSON() ([]byte, error) {
 	if slim.Type == "" {
 		slim.Type = DeploymentTypeSoftware
 	}
-	slim.Statistics.Status = slim.Stats
+	slim.Statistics.Status = slim.Stats.WithPaused(d.IsPaused())
 
 	return json.Marshal(&slim)
 }
@@ -296,23 +367,83 @@ func (d *Deployment) IsNotPending() bool {
 	return false
 }
 
+// IsContinuous reports whether the deployment keeps targeting new devices
+// until it is explicitly finished.
+func (d *Deployment) IsContinuous() bool {
+	return d.DeploymentConstructor != nil && d.Continuous
+}
+
 func (d *Deployment) IsFinished() bool {
 	if d.Finished != nil ||
-		d.MaxDevices > 0 && ((d.Stats[DeviceDeploymentStatusAlreadyInstStr]+
-			d.Stats[DeviceDeploymentStatusSuccessStr]+
-			d.Stats[DeviceDeploymentStatusFailureStr]+
-			d.Stats[DeviceDeploymentStatusNoArtifactStr]+
-			d.Stats[DeviceDeploymentStatusDecommissionedStr]+
-			d.Stats[DeviceDeploymentStatusAbortedStr]) >= d.MaxDevices) {
+		!d.IsContinuous() && d.MaxDevices > 0 && d.finishedDevices() >= d.MaxDevices {
 		return true
 	}
 
 	return false
 }
 
+// finishedDevices returns the number of devices which finished the deployment
+func (d *Deployment) finishedDevices() int {
+	return d.Stats[DeviceDeploymentStatusAlreadyInstStr] +
+		d.Stats[DeviceDeploymentStatusSuccessStr] +
+		d.Stats[DeviceDeploymentStatusFailureStr] +
+		d.Stats[DeviceDeploymentStatusNoArtifactStr] +
+		d.Stats[DeviceDeploymentStatusDecommissionedStr] +
+		d.Stats[DeviceDeploymentStatusAbortedStr]
+}
+
+// IsFailureThresholdExceeded returns true if the number of failed devices
+// exceeds the max_failures or max_failure_percentage policy of the deployment.
+func (d *Deployment) IsFailureThresholdExceeded() bool {
+	if d.DeploymentConstructor == nil {
+		return false
+	}
+	failures := d.Stats[DeviceDeploymentStatusFailureStr]
+	if d.MaxFailures != nil && failures > *d.MaxFailures {
+		return true
+	}
+	// the devices targeted by a continuous deployment grow as they join,
+	// so the failures are measured against the devices which finished
+	devices := d.MaxDevices
+	if d.IsContinuous() {
+		devices = d.finishedDevices()
+	}
+	if d.MaxFailurePercentage != nil && devices > 0 &&
+		failures*100 > *d.MaxFailurePercentage*devices {
+		return true
+	}
+	return false
+}
+
+// CanStart reports whether devices can start the deployment at the given
+// time, according to its start time and maintenance window; devices can't
+// start paused deployments.
+func (d *Deployment) CanStart(now time.Time) bool {
+	if d.IsPaused() {
+		return false
+	}
+	if d.DeploymentConstructor == nil {
+		return true
+	}
+	if d.StartTime != nil && now.Before(*d.StartTime) {
+		return false
+	}
+	if d.MaintenanceWindow != nil && !d.MaintenanceWindow.Contains(now) {
+		return false
+	}
+	return true
+}
+
+// IsPaused reports whether the deployment was paused by the user.
+func (d *Deployment) IsPaused() bool {
+	return d.Status == DeploymentStatusPaused
+}
+
 func (d *Deployment) GetStatus() DeploymentStatus {
 	if d.IsFinished() {
 		return DeploymentStatusFinished
+	} else if d.IsPaused() {
+		return DeploymentStatusPaused
 	} else if d.IsNotPending() {
 		return DeploymentStatusInProgress
 	} else {
@@ -328,6 +459,7 @@ const (
 	StatusQueryInProgress
 	StatusQueryFinished
 	StatusQueryAborted
+	StatusQueryPaused
 
 	SortDirectionAscending  = "asc"
 	SortDirectionDescending = "desc"
@@ -382,6 +514,21 @@ func (d DeploymentIDs) Validate() error {
 }
 
 type DeploymentStats struct {
-	ID    string `json:"id" bson:"_id"`
-	Stats Stats  `json:"stats" bson:"stats"`
+	ID     string           `json:"id" bson:"_id"`
+	Stats  Stats            `json:"stats" bson:"stats"`
+	Status DeploymentStatus `json:"-" bson:"status"`
+}
+
+// DeploymentPreview summarizes the devices a deployment would target,
+// computed without creating the deployment.
+type DeploymentPreview struct {
+	// Number of devices the deployment would target
+	TargetDevices int `json:"target_devices"`
+
+	// Number of devices satisfying the dependencies of any of the
+	// artifacts in the release
+	CompatibleDevices int `json:"compatible_devices"`
+
+	// Number of compatible devices already running the release
+	AlreadyInstalled int `json:"already_installed"`
 }
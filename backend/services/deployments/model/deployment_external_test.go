@@ -34,6 +34,7 @@ func TestDeploymentConstructorValidate(t *testing.T) {
 		InputDevices      []string
 		InputAllDevices   bool
 		InputGroup        string
+		InputRetries      uint
 		IsValid           bool
 	}{
 		{
@@ -119,6 +120,20 @@ func TestDeploymentConstructorValidate(t *testing.T) {
 			InputAllDevices:   true,
 			IsValid:           false,
 		},
+		{
+			InputName:         "f826484e-1157-4109-af21-304e6d711560",
+			InputArtifactName: "f826484e-1157-4109-af21-304e6d711560",
+			InputDevices:      []string{"lala"},
+			InputRetries:      DeploymentMaxRetries,
+			IsValid:           true,
+		},
+		{
+			InputName:         "f826484e-1157-4109-af21-304e6d711560",
+			InputArtifactName: "f826484e-1157-4109-af21-304e6d711560",
+			InputDevices:      []string{"lala"},
+			InputRetries:      DeploymentMaxRetries + 1,
+			IsValid:           false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -129,6 +144,7 @@ func TestDeploymentConstructorValidate(t *testing.T) {
 		dep.Devices = test.InputDevices
 		dep.Group = test.InputGroup
 		dep.AllDevices = test.InputAllDevices
+		dep.Retries = test.InputRetries
 
 		err := dep.ValidateNew()
 
@@ -141,6 +157,110 @@ func TestDeploymentConstructorValidate(t *testing.T) {
 
 }
 
+func TestDeploymentConstructorValidateContinuous(t *testing.T) {
+	t.Parallel()
+
+	terms := []FilterPredicate{{
+		Scope:     "inventory",
+		Attribute: "device_type",
+		Type:      "$in",
+		Value:     []interface{}{"foo", "bar"},
+	}}
+
+	testCases := map[string]struct {
+		constructor DeploymentConstructor
+		err         string
+	}{
+		"ok, group": {
+			constructor: DeploymentConstructor{
+				Group:      "foo",
+				Continuous: true,
+			},
+		},
+		"ok, all devices": {
+			constructor: DeploymentConstructor{
+				AllDevices: true,
+				Continuous: true,
+			},
+		},
+		"ok, filter": {
+			constructor: DeploymentConstructor{
+				FilterTerms: terms,
+				Continuous:  true,
+			},
+		},
+		"ok, group and filter": {
+			constructor: DeploymentConstructor{
+				Group:       "foo",
+				FilterTerms: terms,
+				Continuous:  true,
+			},
+		},
+		"error, filter without continuous": {
+			constructor: DeploymentConstructor{
+				FilterTerms: terms,
+			},
+			err: ErrInvalidDeploymentFilterNotContinuous.Error(),
+		},
+		"error, list of devices": {
+			constructor: DeploymentConstructor{
+				Devices:    []string{"foo"},
+				Continuous: true,
+			},
+			err: ErrInvalidContinuousDeploymentDevices.Error(),
+		},
+		"error, phases": {
+			constructor: DeploymentConstructor{
+				AllDevices: true,
+				Continuous: true,
+				Phases:     []DeploymentPhase{{}},
+			},
+			err: ErrInvalidContinuousDeploymentPhases.Error(),
+		},
+		"error, invalid filter type": {
+			constructor: DeploymentConstructor{
+				FilterTerms: []FilterPredicate{{
+					Scope:     "inventory",
+					Attribute: "device_type",
+					Type:      "$where",
+					Value:     "foo",
+				}},
+				Continuous: true,
+			},
+			err: "filter_terms: (0: (type: must be a valid value.).).",
+		},
+		"error, invalid filter value": {
+			constructor: DeploymentConstructor{
+				FilterTerms: []FilterPredicate{{
+					Scope:     "inventory",
+					Attribute: "device_type",
+					Type:      "$in",
+					Value:     []interface{}{"foo", 1.0},
+				}},
+				Continuous: true,
+			},
+			err: "filter_terms: (0: (value: must be a string, a number " +
+				"or an array of strings or numbers.).).",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.constructor.Name = "foo"
+			tc.constructor.ArtifactName = "bar"
+			err := tc.constructor.ValidateNew()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestNewDeploymentFromConstructor(t *testing.T) {
 
 	t.Parallel()
@@ -351,12 +471,108 @@ func TestDeploymentIs(t *testing.T) {
 		assert.False(t, d.IsFinished())
 		assert.False(t, d.IsNotPending())
 	}
+
+	// continuous deployments are finished explicitly only
+	d.Continuous = true
+	d.Stats = NewDeviceDeploymentStats()
+	d.Stats.Set(DeviceDeploymentStatusSuccess, 1)
+	assert.False(t, d.IsFinished())
+	now := time.Now()
+	d.Finished = &now
+	assert.True(t, d.IsFinished())
+}
+
+func TestDeploymentIsFailureThresholdExceeded(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(i int) *int { return &i }
+
+	testCases := map[string]struct {
+		maxFailures          *int
+		maxFailurePercentage *int
+		continuous           bool
+		maxDevices           int
+		failures             int
+		successes            int
+		exceeded             bool
+	}{
+		"no policy": {
+			failures: 10,
+		},
+		"max failures, not exceeded": {
+			maxFailures: intPtr(2),
+			failures:    2,
+		},
+		"max failures, exceeded": {
+			maxFailures: intPtr(2),
+			failures:    3,
+			exceeded:    true,
+		},
+		"max failures zero, exceeded": {
+			maxFailures: intPtr(0),
+			failures:    1,
+			exceeded:    true,
+		},
+		"max failure percentage, not exceeded": {
+			maxFailurePercentage: intPtr(10),
+			failures:             1,
+		},
+		"max failure percentage, exceeded": {
+			maxFailurePercentage: intPtr(10),
+			failures:             2,
+			exceeded:             true,
+		},
+		"max failure percentage, continuous, not exceeded": {
+			maxFailurePercentage: intPtr(10),
+			continuous:           true,
+			maxDevices:           100,
+			failures:             1,
+			successes:            9,
+		},
+		"max failure percentage, continuous, exceeded as devices join": {
+			maxFailurePercentage: intPtr(10),
+			continuous:           true,
+			maxDevices:           100,
+			failures:             2,
+			successes:            8,
+			exceeded:             true,
+		},
+		"max failure percentage, continuous, no finished devices": {
+			maxFailurePercentage: intPtr(0),
+			continuous:           true,
+			maxDevices:           100,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewDeployment()
+			assert.NoError(t, err)
+			d.MaxDevices = 10
+			if tc.maxDevices > 0 {
+				d.MaxDevices = tc.maxDevices
+			}
+			d.DeploymentConstructor = &DeploymentConstructor{
+				MaxFailures:          tc.maxFailures,
+				MaxFailurePercentage: tc.maxFailurePercentage,
+				Continuous:           tc.continuous,
+			}
+			d.Stats.Set(DeviceDeploymentStatusFailure, tc.failures)
+			d.Stats.Set(DeviceDeploymentStatusSuccess, tc.successes)
+
+			assert.Equal(t, tc.exceeded, d.IsFailureThresholdExceeded())
+		})
+	}
 }
 
 func TestDeploymentGetStatus(t *testing.T) {
 
 	tests := map[string]struct {
 		Stats        Stats
+		Status       DeploymentStatus
 		OutputStatus DeploymentStatus
 	}{
 		"Single NoArtifact": {
@@ -462,6 +678,29 @@ func TestDeploymentGetStatus(t *testing.T) {
 			},
 			OutputStatus: "finished",
 		},
+		"paused + pending": {
+			Stats: Stats{
+				DeviceDeploymentStatusPendingStr: 1,
+			},
+			Status:       DeploymentStatusPaused,
+			OutputStatus: "paused",
+		},
+		"paused + in progress": {
+			Stats: Stats{
+				DeviceDeploymentStatusPendingStr:    1,
+				DeviceDeploymentStatusInstallingStr: 1,
+			},
+			Status:       DeploymentStatusPaused,
+			OutputStatus: "paused",
+		},
+		//verify a paused deployment finishes with its last device
+		"paused + finished": {
+			Stats: Stats{
+				DeviceDeploymentStatusSuccessStr: 1,
+			},
+			Status:       DeploymentStatusPaused,
+			OutputStatus: "finished",
+		},
 	}
 
 	for name, test := range tests {
@@ -472,6 +711,7 @@ func TestDeploymentGetStatus(t *testing.T) {
 		assert.NoError(t, err)
 
 		dep.Stats = test.Stats
+		dep.Status = test.Status
 		for _, n := range dep.Stats {
 			dep.MaxDevices += n
 		}
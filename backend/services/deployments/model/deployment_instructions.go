@@ -19,6 +19,9 @@ type ArtifactDeploymentInstructions struct {
 	ArtifactName          string   `json:"artifact_name"`
 	Source                Link     `json:"source"`
 	DeviceTypesCompatible []string `json:"device_types_compatible"`
+	// Checksum is the hex encoded SHA256 checksum of the file behind
+	// Source, if known.
+	Checksum string `json:"checksum,omitempty"`
 }
 
 type DeploymentInstructions struct {
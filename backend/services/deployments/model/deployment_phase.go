@@ -0,0 +1,160 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// MaxDeploymentPhases is the maximum number of phases of a deployment
+	MaxDeploymentPhases = 10
+)
+
+var (
+	ErrInvalidDeploymentPhasesBatchSize = errors.New(
+		"Invalid deployment phases: the sum of batch sizes must be equal to 100",
+	)
+	ErrInvalidDeploymentPhasesBatchSizeMissing = errors.New(
+		"Invalid deployment phases: batch_size can be omitted only for the last phase",
+	)
+	ErrInvalidDeploymentPhasesStartTime = errors.New(
+		"Invalid deployment phases: start_ts is required for all but the first phase " +
+			"and must be strictly increasing",
+	)
+)
+
+// DeploymentPhase represents a single batch of a phased (canary) deployment.
+// Devices are admitted to the deployment batch by batch: a phase takes up
+// to batch_size percent of the targeted devices, on the first come, first
+// served basis, once its start time has passed.
+type DeploymentPhase struct {
+	// Phase identifier, set on create
+	Id string `json:"id" bson:"id"`
+
+	// Percentage of the targeted devices included in the phase; if omitted
+	// for the last phase, it includes all the remaining devices
+	BatchSize *int `json:"batch_size,omitempty" bson:"batch_size,omitempty"`
+
+	// Start time of the phase; if omitted for the first phase, it starts
+	// right away
+	StartTs *time.Time `json:"start_ts,omitempty" bson:"start_ts,omitempty"`
+
+	// Number of devices which already requested the update within the phase
+	DeviceCount int `json:"device_count" bson:"device_count"`
+
+	// Maximum number of devices admitted in the phase, computed on create
+	MaxDevices int `json:"-" bson:"max_devices"`
+}
+
+func (p DeploymentPhase) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.BatchSize, validation.NilOrNotEmpty,
+			validation.Min(1), validation.Max(100)),
+	)
+}
+
+// validateDeploymentPhases checks the phases of the deployment constructor
+func validateDeploymentPhases(value interface{}) error {
+	phases, _ := value.([]DeploymentPhase)
+	if len(phases) == 0 {
+		return nil
+	}
+	if err := validation.Validate(phases,
+		validation.Length(1, MaxDeploymentPhases)); err != nil {
+		return err
+	}
+	var (
+		batchTotal int
+		startTs    *time.Time
+	)
+	for i, phase := range phases {
+		if err := phase.Validate(); err != nil {
+			return err
+		}
+		if phase.BatchSize != nil {
+			batchTotal += *phase.BatchSize
+		} else if i < len(phases)-1 {
+			return ErrInvalidDeploymentPhasesBatchSizeMissing
+		}
+		if i > 0 {
+			if phase.StartTs == nil ||
+				(startTs != nil && !phase.StartTs.After(*startTs)) {
+				return ErrInvalidDeploymentPhasesStartTime
+			}
+		}
+		startTs = phase.StartTs
+	}
+	last := phases[len(phases)-1]
+	if batchTotal > 100 ||
+		(last.BatchSize != nil && batchTotal != 100) ||
+		(last.BatchSize == nil && batchTotal == 100) {
+		return ErrInvalidDeploymentPhasesBatchSize
+	}
+	return nil
+}
+
+// NewDeploymentPhases creates the phases of a deployment targeting maxDevices
+// devices from the phases of the deployment constructor. The number of devices
+// admitted in each phase is rounded up, with the last phase taking all the
+// remaining devices.
+func NewDeploymentPhases(
+	phases []DeploymentPhase,
+	maxDevices int,
+	created time.Time,
+) []DeploymentPhase {
+	if len(phases) == 0 {
+		return nil
+	}
+	ret := make([]DeploymentPhase, len(phases))
+	remaining := maxDevices
+	for i, phase := range phases {
+		ret[i] = DeploymentPhase{
+			Id:        uuid.NewString(),
+			BatchSize: phase.BatchSize,
+			StartTs:   phase.StartTs,
+		}
+		if i == 0 && ret[i].StartTs == nil {
+			ret[i].StartTs = &created
+		}
+		if i == len(phases)-1 || phase.BatchSize == nil {
+			ret[i].MaxDevices = remaining
+		} else {
+			ret[i].MaxDevices = min(remaining,
+				(*phase.BatchSize*maxDevices+99)/100)
+		}
+		remaining -= ret[i].MaxDevices
+	}
+	return ret
+}
+
+// CurrentPhase returns the index of the first started phase which can still
+// admit devices, or -1 if no phase can admit devices at the given time.
+func (d *Deployment) CurrentPhase(now time.Time) int {
+	for i, phase := range d.Phases {
+		if phase.StartTs != nil && now.Before(*phase.StartTs) {
+			break
+		}
+		if phase.DeviceCount < phase.MaxDevices {
+			return i
+		}
+	}
+	return -1
+}
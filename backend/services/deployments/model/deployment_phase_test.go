@@ -0,0 +1,196 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentConstructorValidatePhases(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(i int) *int { return &i }
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	testCases := map[string]struct {
+		phases []DeploymentPhase
+		err    error
+		// invalid is set for errors reported by the validation rules
+		invalid bool
+	}{
+		"ok, no phases": {},
+		"ok, two phases": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(10)},
+				{BatchSize: intPtr(90), StartTs: &later},
+			},
+		},
+		"ok, last phase takes the remaining devices": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(10), StartTs: &now},
+				{StartTs: &later},
+			},
+		},
+		"error, batch sizes do not sum up to 100": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(10)},
+				{BatchSize: intPtr(80), StartTs: &later},
+			},
+			err: ErrInvalidDeploymentPhasesBatchSize,
+		},
+		"error, nothing left for the last phase": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(100)},
+				{StartTs: &later},
+			},
+			err: ErrInvalidDeploymentPhasesBatchSize,
+		},
+		"error, missing batch size": {
+			phases: []DeploymentPhase{
+				{},
+				{BatchSize: intPtr(90), StartTs: &later},
+			},
+			err: ErrInvalidDeploymentPhasesBatchSizeMissing,
+		},
+		"error, missing start time": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(10)},
+				{BatchSize: intPtr(90)},
+			},
+			err: ErrInvalidDeploymentPhasesStartTime,
+		},
+		"error, start times not increasing": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(10), StartTs: &later},
+				{BatchSize: intPtr(90), StartTs: &now},
+			},
+			err: ErrInvalidDeploymentPhasesStartTime,
+		},
+		"error, invalid batch size": {
+			phases: []DeploymentPhase{
+				{BatchSize: intPtr(0)},
+				{StartTs: &later},
+			},
+			invalid: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			constructor := DeploymentConstructor{
+				Name:         "foo",
+				ArtifactName: "bar",
+				Devices:      []string{"baz"},
+				Phases:       tc.phases,
+			}
+			err := constructor.ValidateNew()
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error())
+			} else if tc.invalid {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewDeploymentPhases(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(i int) *int { return &i }
+	created := time.Now()
+	later := created.Add(time.Hour)
+
+	phases := NewDeploymentPhases([]DeploymentPhase{
+		{BatchSize: intPtr(5)},
+		{BatchSize: intPtr(50), StartTs: &later},
+		{StartTs: &later},
+	}, 10, created)
+
+	if assert.Len(t, phases, 3) {
+		assert.Equal(t, 1, phases[0].MaxDevices)
+		assert.Equal(t, 5, phases[1].MaxDevices)
+		assert.Equal(t, 4, phases[2].MaxDevices)
+		assert.Equal(t, created, *phases[0].StartTs)
+		for _, phase := range phases {
+			assert.NotEmpty(t, phase.Id)
+			assert.Zero(t, phase.DeviceCount)
+		}
+	}
+	assert.Nil(t, NewDeploymentPhases(nil, 10, created))
+}
+
+func TestDeploymentCurrentPhase(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	testCases := map[string]struct {
+		phases []DeploymentPhase
+		index  int
+	}{
+		"first phase": {
+			phases: []DeploymentPhase{
+				{StartTs: &before, MaxDevices: 1},
+				{StartTs: &later, MaxDevices: 1},
+			},
+			index: 0,
+		},
+		"first phase full, second not started": {
+			phases: []DeploymentPhase{
+				{StartTs: &before, MaxDevices: 1, DeviceCount: 1},
+				{StartTs: &later, MaxDevices: 1},
+			},
+			index: -1,
+		},
+		"first phase full, second started": {
+			phases: []DeploymentPhase{
+				{StartTs: &before, MaxDevices: 1, DeviceCount: 1},
+				{StartTs: &now, MaxDevices: 1},
+			},
+			index: 1,
+		},
+		"leftover devices from the first phase": {
+			phases: []DeploymentPhase{
+				{StartTs: &before, MaxDevices: 2, DeviceCount: 1},
+				{StartTs: &now, MaxDevices: 1},
+			},
+			index: 0,
+		},
+		"no phases": {
+			index: -1,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			deployment := &Deployment{Phases: tc.phases}
+			assert.Equal(t, tc.index, deployment.CurrentPhase(now))
+		})
+	}
+}
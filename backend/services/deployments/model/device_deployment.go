@@ -16,6 +16,7 @@ package model
 
 import (
 	"encoding/json"
+	"slices"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -194,6 +195,69 @@ func (stat DeviceDeploymentStatus) Active() bool {
 		stat <= DeviceDeploymentStatusActiveHigh
 }
 
+// deviceDeploymentTransitions declares the active statuses a device
+// deployment can move to from each of the active statuses; the statuses
+// follow the order of the update process on the device, which may skip the
+// optional ones. Any active status can move to a finished one, while the
+// finished statuses are final.
+var deviceDeploymentTransitions = map[DeviceDeploymentStatus][]DeviceDeploymentStatus{
+	DeviceDeploymentStatusPending: {
+		DeviceDeploymentStatusDownloading,
+		DeviceDeploymentStatusPauseBeforeInstall,
+		DeviceDeploymentStatusInstalling,
+		DeviceDeploymentStatusPauseBeforeReboot,
+		DeviceDeploymentStatusRebooting,
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+	DeviceDeploymentStatusDownloading: {
+		DeviceDeploymentStatusPauseBeforeInstall,
+		DeviceDeploymentStatusInstalling,
+		DeviceDeploymentStatusPauseBeforeReboot,
+		DeviceDeploymentStatusRebooting,
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+	DeviceDeploymentStatusPauseBeforeInstall: {
+		DeviceDeploymentStatusInstalling,
+		DeviceDeploymentStatusPauseBeforeReboot,
+		DeviceDeploymentStatusRebooting,
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+	DeviceDeploymentStatusInstalling: {
+		DeviceDeploymentStatusPauseBeforeReboot,
+		DeviceDeploymentStatusRebooting,
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+	DeviceDeploymentStatusPauseBeforeReboot: {
+		DeviceDeploymentStatusRebooting,
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+	DeviceDeploymentStatusRebooting: {
+		DeviceDeploymentStatusPauseBeforeCommit,
+	},
+}
+
+// CanTransitionTo reports whether a device deployment can move from the
+// status to the next one.
+func (stat DeviceDeploymentStatus) CanTransitionTo(next DeviceDeploymentStatus) bool {
+	if stat == next || stat == DeviceDeploymentStatusNull {
+		return true
+	}
+	if !stat.Active() {
+		return false
+	}
+	if IsDeviceDeploymentStatusFinished(next) {
+		return true
+	}
+	return slices.Contains(deviceDeploymentTransitions[stat], next)
+}
+
+// DeviceDeploymentTransition is a status transition of a device deployment
+type DeviceDeploymentTransition struct {
+	From      DeviceDeploymentStatus `json:"from" bson:"from"`
+	To        DeviceDeploymentStatus `json:"to" bson:"to"`
+	Timestamp time.Time              `json:"timestamp" bson:"timestamp"`
+}
+
 // DeviceDeploymentStatus is a helper type for reporting status changes through
 // the layers
 type DeviceDeploymentState struct {
@@ -246,11 +310,32 @@ type DeviceDeployment struct {
 	// deployments/next request from the device
 	Request *DeploymentNextRequest `json:"-"`
 
+	// Object (the artifact or a delta artifact) served to the device
+	Object *DeviceDeploymentObject `json:"-" bson:"object,omitempty"`
+
 	// Presence of deployment log
 	IsLogAvailable bool `json:"log" bson:"log"`
 
 	// Device reported substate
 	SubState string `json:"substate,omitempty" bson:"substate,omitempty"`
+
+	// Number of times the device retries the deployment after a failure
+	Retries uint `json:"retries,omitempty" bson:"retries,omitempty"`
+
+	// Number of failed attempts the device retried
+	Attempts uint `json:"attempts,omitempty" bson:"attempts,omitempty"`
+
+	// Latest status transitions reported by the device and rejected
+	//nolint:lll
+	IllegalTransitions []DeviceDeploymentTransition `json:"illegal_transitions,omitempty" bson:"illegal_transitions,omitempty"`
+}
+
+// DeviceDeploymentObject is the object in the storage served to the device
+// for the deployment: the artifact, or a delta artifact updating the device
+// from the installed artifact.
+type DeviceDeploymentObject struct {
+	ID   string `bson:"id"`
+	Size int64  `bson:"size"`
 }
 
 func NewDeviceDeployment(deviceId, deploymentId string) *DeviceDeployment {
@@ -329,6 +414,26 @@ func (s Stats) Get(status DeviceDeploymentStatus) int {
 	return s[key]
 }
 
+// StatsPausedStr is the key of the counter of the pending devices held back
+// by a paused deployment.
+const StatsPausedStr = "paused"
+
+// WithPaused returns the statistics of a deployment; if the deployment is
+// paused, the pending devices are counted as paused instead, in a copy of
+// the statistics.
+func (s Stats) WithPaused(paused bool) Stats {
+	if s == nil || !paused {
+		return s
+	}
+	stats := make(Stats, len(s)+1)
+	for k, v := range s {
+		stats[k] = v
+	}
+	stats[StatsPausedStr] = s[DeviceDeploymentStatusPendingStr]
+	stats[DeviceDeploymentStatusPendingStr] = 0
+	return stats
+}
+
 func IsDeviceDeploymentStatusFinished(status DeviceDeploymentStatus) bool {
 	if status == DeviceDeploymentStatusFailure || status == DeviceDeploymentStatusSuccess ||
 		status == DeviceDeploymentStatusNoArtifact || status == DeviceDeploymentStatusAlreadyInst ||
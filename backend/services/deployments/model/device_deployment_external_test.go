@@ -146,6 +146,23 @@ func TestDeviceDeploymentStats(t *testing.T) {
 	}
 }
 
+func TestDeviceDeploymentStatsWithPaused(t *testing.T) {
+	ds := NewDeviceDeploymentStats()
+	ds.Set(DeviceDeploymentStatusPending, 3)
+	ds.Set(DeviceDeploymentStatusDownloading, 1)
+
+	paused := ds.WithPaused(true)
+	assert.Equal(t, 3, paused[StatsPausedStr])
+	assert.Equal(t, 0, paused.Get(DeviceDeploymentStatusPending))
+	assert.Equal(t, 1, paused.Get(DeviceDeploymentStatusDownloading))
+	assert.NotContains(t, ds, StatsPausedStr)
+	assert.Equal(t, 3, ds.Get(DeviceDeploymentStatusPending))
+
+	assert.Equal(t, ds, ds.WithPaused(false))
+	assert.NotContains(t, ds.WithPaused(false), StatsPausedStr)
+	assert.Nil(t, Stats(nil).WithPaused(true))
+}
+
 func TestDeviceDeploymentIsFinished(t *testing.T) {
 	tcs := []struct {
 		status   DeviceDeploymentStatus
@@ -179,3 +196,35 @@ func TestDeviceDeploymentIsFinishedWithFinishedTimestamp(t *testing.T) {
 	deployment = Deployment{Finished: &now}
 	assert.True(t, deployment.IsFinished())
 }
+
+func TestDeviceDeploymentStatusCanTransitionTo(t *testing.T) {
+	tcs := []struct {
+		from  DeviceDeploymentStatus
+		to    DeviceDeploymentStatus
+		legal bool
+	}{
+		{DeviceDeploymentStatusNull, DeviceDeploymentStatusDownloading, true},
+		{DeviceDeploymentStatusPending, DeviceDeploymentStatusDownloading, true},
+		{DeviceDeploymentStatusPending, DeviceDeploymentStatusAlreadyInst, true},
+		{DeviceDeploymentStatusDownloading, DeviceDeploymentStatusDownloading, true},
+		{DeviceDeploymentStatusDownloading, DeviceDeploymentStatusInstalling, true},
+		{DeviceDeploymentStatusDownloading, DeviceDeploymentStatusRebooting, true},
+		{DeviceDeploymentStatusInstalling, DeviceDeploymentStatusPauseBeforeReboot, true},
+		{DeviceDeploymentStatusRebooting, DeviceDeploymentStatusPauseBeforeCommit, true},
+		{DeviceDeploymentStatusRebooting, DeviceDeploymentStatusFailure, true},
+		{DeviceDeploymentStatusPauseBeforeCommit, DeviceDeploymentStatusSuccess, true},
+		// illegal transitions
+		{DeviceDeploymentStatusDownloading, DeviceDeploymentStatusPending, false},
+		{DeviceDeploymentStatusInstalling, DeviceDeploymentStatusDownloading, false},
+		{DeviceDeploymentStatusRebooting, DeviceDeploymentStatusInstalling, false},
+		{DeviceDeploymentStatusPauseBeforeCommit, DeviceDeploymentStatusRebooting, false},
+		{DeviceDeploymentStatusSuccess, DeviceDeploymentStatusDownloading, false},
+		{DeviceDeploymentStatusFailure, DeviceDeploymentStatusSuccess, false},
+		{DeviceDeploymentStatusNoArtifact, DeviceDeploymentStatusInstalling, false},
+		{DeviceDeploymentStatusAlreadyInst, DeviceDeploymentStatusFailure, false},
+	}
+	for _, tc := range tcs {
+		assert.Equal(t, tc.legal, tc.from.CanTransitionTo(tc.to),
+			"%s -> %s", tc.from, tc.to)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -59,12 +60,19 @@ func (l LogMessage) String() string {
 	return fmt.Sprintf("%s %s: %s", l.Timestamp.UTC().String(), l.Level, l.Message)
 }
 
+// LogLevelWarning is the level of the messages added by the server to the
+// deployment logs
+const LogLevelWarning = "warning"
+
 type DeploymentLog struct {
 	// skip these 2 field when (un)marshaling to/from JSON
 	DeviceID     string `json:"-" valid:"required"`
 	DeploymentID string `json:"-" valid:"uuidv4,required"`
 
 	Messages []LogMessage `json:"messages" valid:"required"`
+
+	// time after which the log is removed, if set
+	ExpireAt *time.Time `json:"-"`
 }
 
 func (d *DeploymentLog) UnmarshalJSON(raw []byte) error {
@@ -91,3 +99,98 @@ func (d DeploymentLog) Validate() error {
 		validation.Field(&d.Messages, validation.Required),
 	)
 }
+
+// Size returns the size of the log messages in bytes
+func (d DeploymentLog) Size() int {
+	size := 0
+	for _, m := range d.Messages {
+		size += len(m.Message)
+	}
+	return size
+}
+
+// Truncate caps the size of the log messages to maxSize bytes, keeping the
+// head and the tail of the log; the bytes dropped from the middle, within
+// a message or across messages, are replaced by a single message reporting
+// how many of them were truncated. It returns false if the log did not
+// exceed the size.
+func (d *DeploymentLog) Truncate(maxSize int) bool {
+	logSize := d.Size()
+	if maxSize <= 0 || logSize <= maxSize {
+		return false
+	}
+
+	head, size := 0, 0
+	for ; head < len(d.Messages); head++ {
+		if size+len(d.Messages[head].Message) > maxSize/2 {
+			break
+		}
+		size += len(d.Messages[head].Message)
+	}
+	// the first message which does not fit keeps its beginning
+	headMessage := d.Messages[head]
+	headMessage.Message = truncateEnd(headMessage.Message, maxSize/2-size)
+	size += len(headMessage.Message)
+
+	tail := len(d.Messages)
+	for ; tail > head+1; tail-- {
+		if size+len(d.Messages[tail-1].Message) > maxSize {
+			break
+		}
+		size += len(d.Messages[tail-1].Message)
+	}
+	// the last message which does not fit keeps its end, where the
+	// failure is usually reported
+	tailMessage := d.Messages[tail-1]
+	if tail-1 == head {
+		tailMessage.Message = tailMessage.Message[len(headMessage.Message):]
+	}
+	tailMessage.Message = truncateStart(tailMessage.Message, maxSize-size)
+	size += len(tailMessage.Message)
+
+	truncated := LogMessage{
+		Timestamp: d.Messages[head].Timestamp,
+		Level:     LogLevelWarning,
+		Message: fmt.Sprintf(
+			"[%d bytes truncated: the log exceeds %d bytes]",
+			logSize-size, maxSize,
+		),
+	}
+	messages := make([]LogMessage, 0, head+3+len(d.Messages)-tail)
+	messages = append(messages, d.Messages[:head]...)
+	if headMessage.Message != "" {
+		messages = append(messages, headMessage)
+	}
+	messages = append(messages, truncated)
+	if tailMessage.Message != "" {
+		messages = append(messages, tailMessage)
+	}
+	messages = append(messages, d.Messages[tail:]...)
+	d.Messages = messages
+	return true
+}
+
+// truncateEnd returns the beginning of the message up to size bytes,
+// without splitting a UTF-8 character.
+func truncateEnd(message string, size int) string {
+	if size >= len(message) {
+		return message
+	}
+	for size > 0 && !utf8.RuneStart(message[size]) {
+		size--
+	}
+	return message[:size]
+}
+
+// truncateStart returns the end of the message up to size bytes, without
+// splitting a UTF-8 character.
+func truncateStart(message string, size int) string {
+	if size >= len(message) {
+		return message
+	}
+	start := len(message) - max(size, 0)
+	for start < len(message) && !utf8.RuneStart(message[start]) {
+		start++
+	}
+	return message[start:]
+}
@@ -194,3 +194,108 @@ func TestUnmarshalLogMessage(t *testing.T) {
 	}
 
 }
+
+func TestDeploymentLogTruncate(t *testing.T) {
+	now := time.Now()
+	newLog := func(messages ...string) DeploymentLog {
+		dlog := DeploymentLog{}
+		for _, m := range messages {
+			dlog.Messages = append(dlog.Messages, LogMessage{
+				Timestamp: &now,
+				Level:     "info",
+				Message:   m,
+			})
+		}
+		return dlog
+	}
+
+	tcs := map[string]struct {
+		log     DeploymentLog
+		maxSize int
+
+		truncated bool
+		expected  []string
+	}{
+		"ok, within the size": {
+			log:     newLog("foo", "bar"),
+			maxSize: 6,
+
+			expected: []string{"foo", "bar"},
+		},
+		"ok, no limit": {
+			log: newLog("foo", "bar"),
+
+			expected: []string{"foo", "bar"},
+		},
+		"ok, truncated": {
+			log:     newLog("aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff"),
+			maxSize: 12,
+
+			truncated: true,
+			expected: []string{
+				"aaaa",
+				"bb",
+				"[12 bytes truncated: the log exceeds 12 bytes]",
+				"ee",
+				"ffff",
+			},
+		},
+		"ok, truncated large messages": {
+			log:     newLog("aaaaaaaa", "bbbbbbbb"),
+			maxSize: 4,
+
+			truncated: true,
+			expected: []string{
+				"aa",
+				"[12 bytes truncated: the log exceeds 4 bytes]",
+				"bb",
+			},
+		},
+		"ok, truncated single message": {
+			log:     newLog("download started; retrying; failed: no space left"),
+			maxSize: 32,
+
+			truncated: true,
+			expected: []string{
+				"download started",
+				"[17 bytes truncated: the log exceeds 32 bytes]",
+				"d: no space left",
+			},
+		},
+		"ok, truncated single message after small messages": {
+			log:     newLog("aaaa", "bbbb", "cc"),
+			maxSize: 9,
+
+			truncated: true,
+			expected: []string{
+				"aaaa",
+				"[1 bytes truncated: the log exceeds 9 bytes]",
+				"bbb",
+				"cc",
+			},
+		},
+		"ok, truncated between UTF-8 characters": {
+			log:     newLog("ééé"),
+			maxSize: 3,
+
+			truncated: true,
+			expected: []string{
+				"[4 bytes truncated: the log exceeds 3 bytes]",
+				"é",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			dlog := tc.log
+			assert.Equal(t, tc.truncated, dlog.Truncate(tc.maxSize))
+
+			messages := make([]string, len(dlog.Messages))
+			for i, m := range dlog.Messages {
+				messages[i] = m.Message
+			}
+			assert.Equal(t, tc.expected, messages)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// DeviceDeploymentReportHeader lists the columns of the device deployment
+// reports exported as CSV
+var DeviceDeploymentReportHeader = []string{
+	"device_id",
+	"status",
+	"substate",
+	"created",
+	"started",
+	"finished",
+	"artifact_id",
+	"artifact_name",
+	"attempts",
+	"retries",
+}
+
+// DeviceDeploymentReport is the result of a deployment for a single device
+type DeviceDeploymentReport struct {
+	DeviceID     string                 `json:"device_id"`
+	Status       DeviceDeploymentStatus `json:"status"`
+	SubState     string                 `json:"substate,omitempty"`
+	Created      *time.Time             `json:"created,omitempty"`
+	Started      *time.Time             `json:"started,omitempty"`
+	Finished     *time.Time             `json:"finished,omitempty"`
+	ArtifactID   string                 `json:"artifact_id,omitempty"`
+	ArtifactName string                 `json:"artifact_name,omitempty"`
+	Attempts     uint                   `json:"attempts"`
+	Retries      uint                   `json:"retries"`
+}
+
+func NewDeviceDeploymentReport(dd *DeviceDeployment) *DeviceDeploymentReport {
+	report := &DeviceDeploymentReport{
+		DeviceID: dd.DeviceId,
+		Status:   dd.Status,
+		SubState: dd.SubState,
+		Created:  dd.Created,
+		Started:  dd.Started,
+		Finished: dd.Finished,
+		Attempts: dd.Attempts,
+		Retries:  dd.Retries,
+	}
+	if dd.Image != nil {
+		report.ArtifactID = dd.Image.Id
+		if dd.Image.ArtifactMeta != nil {
+			report.ArtifactName = dd.Image.ArtifactMeta.Name
+		}
+	}
+	return report
+}
+
+// Record returns the report as a CSV record matching
+// DeviceDeploymentReportHeader
+func (r *DeviceDeploymentReport) Record() []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return []string{
+		r.DeviceID,
+		r.Status.String(),
+		r.SubState,
+		formatTime(r.Created),
+		formatTime(r.Started),
+		formatTime(r.Finished),
+		r.ArtifactID,
+		r.ArtifactName,
+		strconv.FormatUint(uint64(r.Attempts), 10),
+		strconv.FormatUint(uint64(r.Retries), 10),
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// DownloadSettings are the tenant settings of the artifact download links
+// handed out to the devices.
+type DownloadSettings struct {
+	// MinBandwidth is the download rate, in bytes per second, of the
+	// slowest devices; the download links stay valid long enough for
+	// downloading the artifact at this rate. Zero disables the setting.
+	MinBandwidth int64 `json:"min_bandwidth" bson:"min_bandwidth"`
+	// Groups override MinBandwidth for the devices in the groups.
+	Groups []GroupDownloadSettings `json:"groups,omitempty" bson:"groups,omitempty"`
+}
+
+// GroupDownloadSettings are the download settings of a device group.
+type GroupDownloadSettings struct {
+	Group        string `json:"group" bson:"group"`
+	MinBandwidth int64  `json:"min_bandwidth" bson:"min_bandwidth"`
+}
+
+func (s GroupDownloadSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Group, validation.Required),
+		validation.Field(&s.MinBandwidth, validation.Min(int64(0))),
+	)
+}
+
+func (s DownloadSettings) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.MinBandwidth, validation.Min(int64(0))),
+		validation.Field(&s.Groups, ruleUniqueGroups),
+	)
+}
+
+var ruleUniqueGroups = validation.By(func(value interface{}) error {
+	groups, _ := value.([]GroupDownloadSettings)
+	names := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		if _, ok := names[g.Group]; ok {
+			return errors.Errorf("duplicate group %q", g.Group)
+		}
+		names[g.Group] = struct{}{}
+	}
+	return nil
+})
+
+// MinBandwidthForGroup returns the minimum bandwidth of the devices in the
+// group, falling back to the tenant's minimum bandwidth.
+func (s DownloadSettings) MinBandwidthForGroup(group string) int64 {
+	for _, g := range s.Groups {
+		if g.Group == group && g.MinBandwidth > 0 {
+			return g.MinBandwidth
+		}
+	}
+	return s.MinBandwidth
+}
+
+// DownloadLinkExpire returns the validity of a link downloading size bytes
+// at the minimum bandwidth, which is never shorter than defaultExpire nor
+// longer than maxExpire.
+func DownloadLinkExpire(
+	size, minBandwidth int64,
+	defaultExpire, maxExpire time.Duration,
+) time.Duration {
+	if minBandwidth <= 0 || size <= 0 {
+		return defaultExpire
+	}
+	seconds := (size + minBandwidth - 1) / minBandwidth
+	if seconds >= int64(maxExpire/time.Second) {
+		return maxExpire
+	}
+	return max(defaultExpire, time.Duration(seconds)*time.Second)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadSettingsValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		settings DownloadSettings
+
+		err string
+	}{
+		"ok, empty": {},
+		"ok": {
+			settings: DownloadSettings{
+				MinBandwidth: 1024,
+				Groups: []GroupDownloadSettings{
+					{Group: "cellular", MinBandwidth: 128},
+					{Group: "satellite", MinBandwidth: 16},
+				},
+			},
+		},
+		"error, negative bandwidth": {
+			settings: DownloadSettings{MinBandwidth: -1},
+			err:      "min_bandwidth: must be no less than 0.",
+		},
+		"error, missing group": {
+			settings: DownloadSettings{
+				Groups: []GroupDownloadSettings{{MinBandwidth: 128}},
+			},
+			err: "groups: (0: (group: cannot be blank.).).",
+		},
+		"error, duplicate group": {
+			settings: DownloadSettings{
+				Groups: []GroupDownloadSettings{
+					{Group: "cellular", MinBandwidth: 128},
+					{Group: "cellular", MinBandwidth: 16},
+				},
+			},
+			err: `groups: duplicate group "cellular".`,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.settings.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMinBandwidthForGroup(t *testing.T) {
+	t.Parallel()
+
+	settings := DownloadSettings{
+		MinBandwidth: 1024,
+		Groups: []GroupDownloadSettings{
+			{Group: "cellular", MinBandwidth: 128},
+			{Group: "lab"},
+		},
+	}
+	assert.Equal(t, int64(128), settings.MinBandwidthForGroup("cellular"))
+	assert.Equal(t, int64(1024), settings.MinBandwidthForGroup("lab"))
+	assert.Equal(t, int64(1024), settings.MinBandwidthForGroup("fleet"))
+	assert.Equal(t, int64(1024), settings.MinBandwidthForGroup(""))
+}
+
+func TestDownloadLinkExpire(t *testing.T) {
+	t.Parallel()
+
+	const (
+		defaultExpire = 24 * time.Hour
+		maxExpire     = 7 * 24 * time.Hour
+		gigabyte      = int64(1024 * 1024 * 1024)
+	)
+	testCases := map[string]struct {
+		size         int64
+		minBandwidth int64
+
+		expire time.Duration
+	}{
+		"no bandwidth": {
+			size:   2 * gigabyte,
+			expire: defaultExpire,
+		},
+		"no size": {
+			minBandwidth: 1024,
+			expire:       defaultExpire,
+		},
+		"faster than the default": {
+			size:         2 * gigabyte,
+			minBandwidth: 1024 * 1024,
+			expire:       defaultExpire,
+		},
+		"slower than the default": {
+			size:         2 * gigabyte,
+			minBandwidth: 16 * 1024,
+			expire:       131072 * time.Second,
+		},
+		"rounded up": {
+			size:         2*gigabyte + 1,
+			minBandwidth: 16 * 1024,
+			expire:       131073 * time.Second,
+		},
+		"capped": {
+			size:         2 * gigabyte,
+			minBandwidth: 1024,
+			expire:       maxExpire,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			expire := DownloadLinkExpire(tc.size, tc.minBandwidth, defaultExpire, maxExpire)
+			assert.Equal(t, tc.expire, expire)
+		})
+	}
+}
@@ -14,6 +14,25 @@
 
 package model
 
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var (
+	filterPredicateScopes = []interface{}{
+		"inventory", "identity", "system", "tags", "monitor",
+	}
+	filterPredicateTypes = []interface{}{
+		"$eq", "$ne", "$in", "$nin", "$gt", "$gte", "$lt", "$lte", "$regex",
+	}
+
+	errFilterValueType = errors.New(
+		"must be a string, a number or an array of strings or numbers",
+	)
+)
+
 type Filter struct {
 	Id    string            `json:"id" bson:"_id"`
 	Name  string            `json:"name" bson:"name"`
@@ -26,3 +45,38 @@ type FilterPredicate struct {
 	Type      string      `json:"type" bson:"type"`
 	Value     interface{} `json:"value" bson:"value"`
 }
+
+func (p FilterPredicate) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Scope, validation.Required,
+			validation.In(filterPredicateScopes...)),
+		validation.Field(&p.Attribute, validation.Required, lengthIn1To4096),
+		validation.Field(&p.Type, validation.Required,
+			validation.In(filterPredicateTypes...)),
+		validation.Field(&p.Value, validation.NotNil,
+			validation.By(validateFilterValue)),
+	)
+}
+
+// validateFilterValue checks the value is one of the attribute types
+// supported by the inventory search.
+func validateFilterValue(value interface{}) error {
+	switch v := value.(type) {
+	case string, float64, []string, []float64:
+		return nil
+	case []interface{}:
+		var strs, nums int
+		for _, elem := range v {
+			switch elem.(type) {
+			case string:
+				strs++
+			case float64:
+				nums++
+			}
+		}
+		if strs == len(v) || nums == len(v) {
+			return nil
+		}
+	}
+	return errFilterValueType
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"path"
+	"slices"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -100,6 +101,11 @@ type ArtifactMeta struct {
 	// Flag that indicates if artifact is signed or not
 	Signed bool `json:"signed" bson:"signed"`
 
+	// Identifier of the tenant verification key the signature was
+	// verified with
+	//nolint:lll
+	VerificationKeyID string `json:"verification_key_id,omitempty" bson:"verification_key_id,omitempty"`
+
 	// List of updates
 	Updates []Update `json:"updates" valid:"-"`
 
@@ -171,6 +177,45 @@ func (am *ArtifactMeta) Validate() error {
 	)
 }
 
+// DependsSatisfied reports whether a device with the given provides,
+// including its device_type, can install the artifact. Each dependency
+// must match the provided value, or one of the values for list dependencies.
+func (am *ArtifactMeta) DependsSatisfied(provides map[string]string) bool {
+	if !slices.Contains(am.DeviceTypesCompatible, provides["device_type"]) {
+		return false
+	}
+	for key, value := range am.Depends {
+		if key == "device_type" {
+			continue
+		}
+		provided, ok := provides[key]
+		if !ok || !dependsValueMatches(value, provided) {
+			return false
+		}
+	}
+	return true
+}
+
+func dependsValueMatches(value interface{}, provided string) bool {
+	var values []interface{}
+	switch v := value.(type) {
+	case string:
+		return v == provided
+	case []string:
+		return slices.Contains(v, provided)
+	case bson.A:
+		values = v
+	case []interface{}:
+		values = v
+	}
+	for _, v := range values {
+		if s, ok := v.(string); ok && s == provided {
+			return true
+		}
+	}
+	return false
+}
+
 func NewArtifactMeta() *ArtifactMeta {
 	return &ArtifactMeta{}
 }
@@ -189,6 +234,10 @@ type Image struct {
 	// Artifact total size
 	Size int64 `json:"size" bson:"size" valid:"-"`
 
+	// Hex encoded SHA256 checksum of the artifact file, computed on
+	// upload. Not set for the artifacts uploaded without verification.
+	Checksum string `json:"checksum,omitempty" bson:"checksum,omitempty" valid:"-"`
+
 	// Last modification time, including image upload time
 	Modified *time.Time `json:"modified" valid:"-"`
 }
@@ -76,3 +76,56 @@ func TestValidateCorrectImage(t *testing.T) {
 		t.Errorf("%v", err)
 	}
 }
+
+func TestArtifactMetaDependsSatisfied(t *testing.T) {
+	meta := &ArtifactMeta{
+		DeviceTypesCompatible: []string{"foo", "bar"},
+		Depends: map[string]interface{}{
+			"device_type":          []interface{}{"foo", "bar"},
+			"rootfs-image.version": []interface{}{"v1", "v2"},
+			"checksum":             "abc",
+		},
+	}
+
+	testCases := map[string]struct {
+		provides map[string]string
+		result   bool
+	}{
+		"ok": {
+			provides: map[string]string{
+				"device_type":          "bar",
+				"rootfs-image.version": "v2",
+				"checksum":             "abc",
+			},
+			result: true,
+		},
+		"incompatible device type": {
+			provides: map[string]string{
+				"device_type":          "baz",
+				"rootfs-image.version": "v2",
+				"checksum":             "abc",
+			},
+		},
+		"unsatisfied list dependency": {
+			provides: map[string]string{
+				"device_type":          "foo",
+				"rootfs-image.version": "v3",
+				"checksum":             "abc",
+			},
+		},
+		"missing dependency": {
+			provides: map[string]string{
+				"device_type":          "foo",
+				"rootfs-image.version": "v1",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if result := meta.DependsSatisfied(tc.provides); result != tc.result {
+				t.Errorf("expected %v, got %v", tc.result, result)
+			}
+		})
+	}
+}
@@ -16,10 +16,13 @@ package model
 
 const (
 	LimitStorage = "storage"
+	// LimitDeploymentLogSize is the maximum size of a deployment log in
+	// bytes; 0 applies the service default
+	LimitDeploymentLogSize = "deployment_log_size"
 )
 
 var (
-	ValidLimits = []string{LimitStorage}
+	ValidLimits = []string{LimitStorage, LimitDeploymentLogSize}
 )
 
 type Limit struct {
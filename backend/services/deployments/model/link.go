@@ -36,6 +36,53 @@ type UploadLink struct {
 	IssuedAt  time.Time  `json:"-" bson:"issued_ts"`
 	UpdatedTS time.Time  `json:"-" bson:"updated_ts"`
 	Status    LinkStatus `json:"-" bson:"status"`
+
+	// Parameters of the upload processing, kept to resume it
+	SkipVerify bool                  `json:"-" bson:"skip_verify,omitempty"`
+	Metadata   *DirectUploadMetadata `json:"-" bson:"metadata,omitempty"`
+
+	// Number of times the processing was resumed
+	Retries uint `json:"-" bson:"retries,omitempty"`
+
+	// UploadID identifies the multipart upload in the object storage,
+	// empty if the artifact is uploaded in a single request.
+	UploadID string `json:"-" bson:"upload_id,omitempty"`
+}
+
+// UploadPartNumberMax is the largest part number of a multipart upload
+const UploadPartNumberMax = 10000
+
+// UploadPartSizeMin is the smallest size of the parts of a multipart upload
+// but the last one.
+const UploadPartSizeMin = 5 * 1024 * 1024
+
+// UploadPart is a part of a multipart upload received by the object storage
+type UploadPart struct {
+	Number       int        `json:"number"`
+	Size         int64      `json:"size"`
+	ETag         string     `json:"etag,omitempty"`
+	LastModified *time.Time `json:"last_modified,omitempty"`
+}
+
+// MultipartUploadLink is a direct upload receiving the artifact in parts
+type MultipartUploadLink struct {
+	ArtifactID string    `json:"id"`
+	Expire     time.Time `json:"expire"`
+}
+
+// UploadLinkStatus is the processing status of a direct upload
+type UploadLinkStatus struct {
+	ArtifactID string     `json:"id"`
+	Status     LinkStatus `json:"status"`
+	Retries    uint       `json:"retries"`
+}
+
+func NewUploadLinkStatus(link *UploadLink) *UploadLinkStatus {
+	return &UploadLinkStatus{
+		ArtifactID: link.ArtifactID,
+		Status:     link.Status,
+		Retries:    link.Retries,
+	}
 }
 
 type LinkStatus uint32
@@ -45,6 +92,8 @@ const (
 	LinkStatusProcessing
 	LinkStatusCompleted
 	LinkStatusAborted
+	// LinkStatusFailed is set when the processing could not be resumed
+	LinkStatusFailed
 
 	LinkStatusProcessedBit  = LinkStatus(1 << 7)
 	LinkStatusProcessedMask = ^LinkStatus(LinkStatusProcessedBit)
@@ -53,6 +102,7 @@ const (
 	linkStatusProcessing = "processing"
 	linkStatusCompleted  = "completed"
 	linkStatusAborted    = "aborted"
+	linkStatusFailed     = "failed"
 )
 
 func (status LinkStatus) MarshalText() (b []byte, err error) {
@@ -65,6 +115,8 @@ func (status LinkStatus) MarshalText() (b []byte, err error) {
 		b = []byte(linkStatusCompleted)
 	case LinkStatusAborted:
 		b = []byte(linkStatusAborted)
+	case LinkStatusFailed:
+		b = []byte(linkStatusFailed)
 	default:
 		err = fmt.Errorf("invalid link status value '%d'", status)
 	}
@@ -84,6 +136,8 @@ func (status *LinkStatus) UnmarshalText(b []byte) error {
 		*status = LinkStatusCompleted
 	case linkStatusAborted:
 		*status = LinkStatusAborted
+	case linkStatusFailed:
+		*status = LinkStatusFailed
 	default:
 		err = fmt.Errorf("invalid link status %q", s)
 	}
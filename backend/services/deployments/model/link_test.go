@@ -15,8 +15,11 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewLink(t *testing.T) {
@@ -32,3 +35,22 @@ func TestNewLink(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestUploadLinkStatus(t *testing.T) {
+	link := &UploadLink{
+		ArtifactID: "artifact",
+		Status:     LinkStatusFailed | LinkStatusProcessedBit,
+		Retries:    3,
+	}
+	b, err := json.Marshal(NewUploadLinkStatus(link))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"artifact","status":"failed","retries":3}`, string(b))
+
+	var status UploadLinkStatus
+	err = json.Unmarshal(b, &status)
+	assert.NoError(t, err)
+	assert.Equal(t, LinkStatusFailed, status.Status)
+
+	err = json.Unmarshal([]byte(`{"status":"dummy"}`), &status)
+	assert.Error(t, err)
+}
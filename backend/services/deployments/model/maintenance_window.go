@@ -0,0 +1,131 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const maintenanceWindowTimeFormat = "15:04"
+
+var (
+	ErrInvalidMaintenanceWindowTime = errors.New(
+		"must be a time of the day in the HH:MM format",
+	)
+	ErrInvalidMaintenanceWindowEmpty = errors.New(
+		"Invalid maintenance window: start and end must differ",
+	)
+
+	weekdays = map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"monday":    time.Monday,
+		"tuesday":   time.Tuesday,
+		"wednesday": time.Wednesday,
+		"thursday":  time.Thursday,
+		"friday":    time.Friday,
+		"saturday":  time.Saturday,
+	}
+)
+
+// MaintenanceWindow is a recurring time window, in UTC, during which the
+// devices are allowed to start a deployment. A window ending before it
+// starts (e.g. 22:00-03:00) spans midnight; in that case the weekdays refer
+// to the day the window opens.
+type MaintenanceWindow struct {
+	// Start time of the window, HH:MM
+	Start string `json:"start" bson:"start"`
+
+	// End time of the window, HH:MM
+	End string `json:"end" bson:"end"`
+
+	// Days of the week the window opens on, optional; every day if empty
+	Weekdays []string `json:"weekdays,omitempty" bson:"weekdays,omitempty"`
+}
+
+func validateMaintenanceWindowTime(value interface{}) error {
+	s, _ := value.(string)
+	if _, err := time.Parse(maintenanceWindowTimeFormat, s); err != nil {
+		return ErrInvalidMaintenanceWindowTime
+	}
+	return nil
+}
+
+func (w MaintenanceWindow) Validate() error {
+	weekdayNames := make([]interface{}, 0, len(weekdays))
+	for name := range weekdays {
+		weekdayNames = append(weekdayNames, name)
+	}
+	err := validation.ValidateStruct(&w,
+		validation.Field(&w.Start, validation.Required,
+			validation.By(validateMaintenanceWindowTime)),
+		validation.Field(&w.End, validation.Required,
+			validation.By(validateMaintenanceWindowTime)),
+		validation.Field(&w.Weekdays,
+			validation.Each(validation.In(weekdayNames...))),
+	)
+	if err != nil {
+		return err
+	}
+	if w.Start == w.End {
+		return ErrInvalidMaintenanceWindowEmpty
+	}
+	return nil
+}
+
+// minutes returns the time of the day in minutes
+func minutes(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// Contains reports whether the given time is within the maintenance window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	start, err := time.Parse(maintenanceWindowTimeFormat, w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(maintenanceWindowTimeFormat, w.End)
+	if err != nil {
+		return false
+	}
+	t = t.UTC()
+	now := minutes(t)
+	day := t.Weekday()
+	switch {
+	case minutes(start) < minutes(end):
+		if now < minutes(start) || now >= minutes(end) {
+			return false
+		}
+	case now >= minutes(start):
+		// the window opened today and spans midnight
+	case now < minutes(end):
+		// the window opened yesterday
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, name := range w.Weekdays {
+		if weekdays[name] == day {
+			return true
+		}
+	}
+	return false
+}
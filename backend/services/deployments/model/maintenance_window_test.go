@@ -0,0 +1,196 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		window MaintenanceWindow
+		valid  bool
+	}{
+		"ok": {
+			window: MaintenanceWindow{Start: "02:00", End: "05:00"},
+			valid:  true,
+		},
+		"ok, weekdays": {
+			window: MaintenanceWindow{
+				Start:    "22:00",
+				End:      "03:30",
+				Weekdays: []string{"monday", "friday"},
+			},
+			valid: true,
+		},
+		"error, invalid start": {
+			window: MaintenanceWindow{Start: "25:00", End: "05:00"},
+		},
+		"error, missing end": {
+			window: MaintenanceWindow{Start: "02:00"},
+		},
+		"error, empty window": {
+			window: MaintenanceWindow{Start: "02:00", End: "02:00"},
+		},
+		"error, invalid weekday": {
+			window: MaintenanceWindow{
+				Start:    "02:00",
+				End:      "05:00",
+				Weekdays: []string{"caturday"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.window.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	t.Parallel()
+
+	// 2026-10-14 is a Wednesday
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 10, 14, hour, min, 0, 0, time.UTC)
+	}
+
+	testCases := map[string]struct {
+		window   MaintenanceWindow
+		time     time.Time
+		contains bool
+	}{
+		"inside": {
+			window:   MaintenanceWindow{Start: "02:00", End: "05:00"},
+			time:     at(3, 0),
+			contains: true,
+		},
+		"start is inclusive": {
+			window:   MaintenanceWindow{Start: "02:00", End: "05:00"},
+			time:     at(2, 0),
+			contains: true,
+		},
+		"end is exclusive": {
+			window: MaintenanceWindow{Start: "02:00", End: "05:00"},
+			time:   at(5, 0),
+		},
+		"outside": {
+			window: MaintenanceWindow{Start: "02:00", End: "05:00"},
+			time:   at(12, 0),
+		},
+		"other timezone": {
+			window:   MaintenanceWindow{Start: "02:00", End: "05:00"},
+			time:     at(3, 0).In(time.FixedZone("UTC+2", 2*60*60)),
+			contains: true,
+		},
+		"inside, weekday": {
+			window: MaintenanceWindow{
+				Start:    "02:00",
+				End:      "05:00",
+				Weekdays: []string{"wednesday"},
+			},
+			time:     at(3, 0),
+			contains: true,
+		},
+		"inside, other weekday": {
+			window: MaintenanceWindow{
+				Start:    "02:00",
+				End:      "05:00",
+				Weekdays: []string{"saturday", "sunday"},
+			},
+			time: at(3, 0),
+		},
+		"spanning midnight, before midnight": {
+			window: MaintenanceWindow{
+				Start:    "22:00",
+				End:      "03:00",
+				Weekdays: []string{"wednesday"},
+			},
+			time:     at(23, 0),
+			contains: true,
+		},
+		"spanning midnight, after midnight": {
+			window: MaintenanceWindow{
+				Start:    "22:00",
+				End:      "03:00",
+				Weekdays: []string{"tuesday"},
+			},
+			time:     at(1, 0),
+			contains: true,
+		},
+		"spanning midnight, opened on another day": {
+			window: MaintenanceWindow{
+				Start:    "22:00",
+				End:      "03:00",
+				Weekdays: []string{"wednesday"},
+			},
+			time: at(1, 0),
+		},
+		"spanning midnight, outside": {
+			window: MaintenanceWindow{Start: "22:00", End: "03:00"},
+			time:   at(12, 0),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.contains, tc.window.Contains(tc.time))
+		})
+	}
+}
+
+func TestDeploymentCanStart(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	d, err := NewDeployment()
+	assert.NoError(t, err)
+	assert.True(t, d.CanStart(now))
+
+	d.StartTime = &later
+	assert.False(t, d.CanStart(now))
+
+	d.StartTime = &before
+	assert.True(t, d.CanStart(now))
+
+	d.MaintenanceWindow = &MaintenanceWindow{Start: "02:00", End: "05:00"}
+	assert.False(t, d.CanStart(now))
+
+	d.MaintenanceWindow = &MaintenanceWindow{Start: "11:00", End: "13:00"}
+	assert.True(t, d.CanStart(now))
+
+	d.Status = DeploymentStatusPaused
+	assert.False(t, d.CanStart(now))
+}
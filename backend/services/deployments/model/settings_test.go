@@ -16,6 +16,7 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -85,6 +86,136 @@ func TestStorageSettingsDeserialize(t *testing.T) {
 			Key:    "AccountName",
 			Secret: "AccountKey",
 		},
+	}, {
+		Name: "ok/locations",
+
+		Raw: `
+{
+  "type": "s3",
+  "key": "not_so_secret_key_id",
+  "secret": "super_secret",
+  "bucket": "bucketMcBucketFace",
+  "region": "eu-west-1",
+  "location_attribute": {"scope": "inventory", "name": "region"},
+  "locations": [{
+    "name": "apac",
+    "values": ["ap-southeast", "ap-northeast"],
+    "settings": {
+      "key": "not_so_secret_key_id",
+      "secret": "super_secret",
+      "bucket": "bucketMcApacFace",
+      "region": "ap-southeast-1",
+      "uri": "https://s3.example.com",
+      "use_accelerate": true
+    }
+  }]
+}
+`,
+		Expected: StorageSettings{
+			Type:   StorageTypeS3,
+			Bucket: "bucketMcBucketFace",
+			Key:    "not_so_secret_key_id",
+			Secret: "super_secret",
+			Region: "eu-west-1",
+			LocationAttribute: &StorageLocationAttribute{
+				Scope: "inventory",
+				Name:  "region",
+			},
+			Locations: []StorageLocation{{
+				Name:   "apac",
+				Values: []string{"ap-southeast", "ap-northeast"},
+				Settings: StorageSettings{
+					Type:   StorageTypeS3,
+					Bucket: "bucketMcApacFace",
+					Key:    "not_so_secret_key_id",
+					Secret: "super_secret",
+					Region: "ap-southeast-1",
+					Uri:    "https://s3.example.com",
+				},
+			}},
+		},
+	}, {
+		Name: "error/locations without attribute",
+
+		Raw: `
+{
+  "key": "not_so_secret_key_id",
+  "secret": "super_secret",
+  "bucket": "bucketMcBucketFace",
+  "region": "eu-west-1",
+  "locations": [{
+    "name": "apac",
+    "values": ["ap-southeast"],
+    "settings": {
+      "key": "not_so_secret_key_id",
+      "secret": "super_secret",
+      "bucket": "bucketMcApacFace",
+      "region": "ap-southeast-1"
+    }
+  }]
+}
+`,
+		Error: errors.New("invalid settings schema: " +
+			"location_attribute: required when 'locations' are set."),
+	}, {
+		Name: "error/value in several locations",
+
+		Raw: `
+{
+  "key": "not_so_secret_key_id",
+  "secret": "super_secret",
+  "bucket": "bucketMcBucketFace",
+  "region": "eu-west-1",
+  "location_attribute": {"scope": "system", "name": "group"},
+  "locations": [{
+    "name": "apac",
+    "values": ["asia"],
+    "settings": {
+      "key": "not_so_secret_key_id",
+      "secret": "super_secret",
+      "bucket": "bucketMcApacFace",
+      "region": "ap-southeast-1"
+    }
+  }, {
+    "name": "japan",
+    "values": ["asia"],
+    "settings": {
+      "key": "not_so_secret_key_id",
+      "secret": "super_secret",
+      "bucket": "bucketMcJapanFace",
+      "region": "ap-northeast-1"
+    }
+  }]
+}
+`,
+		Error: errors.New("invalid settings schema: " +
+			`locations: value "asia" assigned to several storage locations.`),
+	}, {
+		Name: "error/nested locations",
+
+		Raw: `
+{
+  "key": "not_so_secret_key_id",
+  "secret": "super_secret",
+  "bucket": "bucketMcBucketFace",
+  "region": "eu-west-1",
+  "location_attribute": {"scope": "system", "name": "group"},
+  "locations": [{
+    "name": "apac",
+    "values": ["asia"],
+    "settings": {
+      "key": "not_so_secret_key_id",
+      "secret": "super_secret",
+      "bucket": "bucketMcApacFace",
+      "region": "ap-southeast-1",
+      "location_attribute": {"scope": "system", "name": "group"},
+      "locations": [{"name": "nested", "values": ["asia"]}]
+    }
+  }]
+}
+`,
+		Error: errors.New("invalid settings schema: " +
+			"locations: (0: (settings: nested storage locations are not allowed.).)."),
 	}, {
 		Name: "error/malformed data",
 
@@ -96,8 +227,11 @@ func TestStorageSettingsDeserialize(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			t.Parallel()
 			actual, err := ParseStorageSettingsRequest(strings.NewReader(tc.Raw))
-			if tc.Error != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(tc.Error, &syntaxErr) {
 				assert.ErrorAs(t, err, &tc.Error)
+			} else if tc.Error != nil {
+				assert.EqualError(t, err, tc.Error.Error())
 			} else if assert.NoError(t, err) && assert.NotNil(t, actual) {
 				assert.Equal(t, tc.Expected, *actual)
 			}
@@ -30,6 +30,9 @@ const (
 	ParamExpire    = "x-men-expire"
 	ParamSignature = "x-men-signature"
 	ParamTenantID  = "tenant_id"
+	// ParamFilename holds the filename suggested to the client downloading
+	// the object; it is part of the signature when present.
+	ParamFilename = "filename"
 )
 
 var ErrLinkExpired = errors.New("URL expired")
@@ -89,12 +92,16 @@ func (sig *RequestSignature) Bytes() []byte {
 	// The format is similar to s3 signed request with
 	// <Method>\n<Canonical URI>\n<Canonical parameters>\n[<Canonical headers>]\n
 	q := sig.URL.Query()
-	return []byte(fmt.Sprintf(
+	b := []byte(fmt.Sprintf(
 		"%s\n%s\n%s=%s\n%s=%s\n",
 		sig.Method, sig.URL.Path,
 		ParamExpire, q.Get(ParamExpire),
 		ParamTenantID, q.Get(ParamTenantID),
 	))
+	if q.Has(ParamFilename) {
+		b = fmt.Appendf(b, "%s=%s\n", ParamFilename, q.Get(ParamFilename))
+	}
+	return b
 }
 
 // VerifyHMAC256 verifies the request signature with the parameter.
@@ -31,7 +31,8 @@ func TestRequestSignatureValidate(t *testing.T) {
 
 		Request *RequestSignature
 
-		Error error // Validation error
+		Error      error // Validation error
+		Unverified bool  // Signature doesn't match the request
 	}{{
 		Name: "ok",
 
@@ -81,6 +82,31 @@ func TestRequestSignatureValidate(t *testing.T) {
 			return sig
 		}(),
 		Error: ErrLinkExpired,
+	}, {
+		Name: "ok, filename",
+
+		Request: func() *RequestSignature {
+			req, _ := http.NewRequest(http.MethodGet,
+				"https://localhost?"+ParamFilename+"=artifact.mender", nil)
+			sig := NewRequestSignature(req, []byte("test"))
+			sig.SetExpire(time.Now().Add(time.Hour))
+			sig.PresignURL()
+			return sig
+		}(),
+	}, {
+		Name: "error, filename not signed",
+
+		Request: func() *RequestSignature {
+			req, _ := http.NewRequest(http.MethodGet, "https://localhost", nil)
+			sig := NewRequestSignature(req, []byte("test"))
+			sig.SetExpire(time.Now().Add(time.Hour))
+			sig.PresignURL()
+			q := req.URL.Query()
+			q.Set(ParamFilename, "evil.exe")
+			req.URL.RawQuery = q.Encode()
+			return sig
+		}(),
+		Unverified: true,
 	}}
 	for i := range testCases {
 		tc := testCases[i]
@@ -91,7 +117,7 @@ func TestRequestSignatureValidate(t *testing.T) {
 				assert.EqualError(t, err, tc.Error.Error())
 			} else {
 				assert.NoError(t, err)
-				assert.True(t, tc.Request.VerifyHMAC256())
+				assert.Equal(t, !tc.Unverified, tc.Request.VerifyHMAC256())
 			}
 		})
 	}
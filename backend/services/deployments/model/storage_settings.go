@@ -90,8 +90,84 @@ type StorageSettings struct {
 	ForcePathStyle bool `json:"force_path_style" bson:"force_path_style"`
 	// UseAccelerate (s3) enables AWS transfer acceleration.
 	UseAccelerate bool `json:"use_accelerate" bson:"use_accelerate"`
+
+	// LocationAttribute is the device inventory attribute whose value
+	// picks the storage location serving the artifact downloads.
+	//nolint:lll
+	LocationAttribute *StorageLocationAttribute `json:"location_attribute,omitempty" bson:"location_attribute,omitempty"`
+	// Locations are named storage locations holding replicas of the
+	// artifacts, e.g. through bucket replication, closer to the devices.
+	Locations []StorageLocation `json:"locations,omitempty" bson:"locations,omitempty"`
+}
+
+// StorageLocationAttribute identifies the inventory attribute routing the
+// devices to the storage locations, e.g. the "group" in the "system" scope.
+type StorageLocationAttribute struct {
+	Scope string `json:"scope" bson:"scope"`
+	Name  string `json:"name" bson:"name"`
+}
+
+func (attr StorageLocationAttribute) Validate() error {
+	return validation.ValidateStruct(&attr,
+		validation.Field(&attr.Scope, validation.Required, lengthLessThan4096),
+		validation.Field(&attr.Name, validation.Required, lengthLessThan4096),
+	)
+}
+
+// StorageLocation serves the artifact downloads to the devices with one
+// of the values of the location attribute.
+type StorageLocation struct {
+	Name     string          `json:"name" bson:"name"`
+	Values   []string        `json:"values" bson:"values"`
+	Settings StorageSettings `json:"settings" bson:"settings"`
+}
+
+func (loc StorageLocation) Validate() error {
+	return validation.ValidateStruct(&loc,
+		validation.Field(&loc.Name, validation.Required, lengthLessThan4096),
+		validation.Field(&loc.Values, validation.Required,
+			validation.Each(validation.Required, lengthLessThan4096)),
+		validation.Field(&loc.Settings, validation.By(func(value interface{}) error {
+			if settings, _ := value.(StorageSettings); len(settings.Locations) > 0 {
+				return errors.New("nested storage locations are not allowed")
+			}
+			return nil
+		})),
+	)
+}
+
+// Location returns the storage location serving the devices with the
+// given value of the location attribute, or nil if there is none.
+func (s StorageSettings) Location(value string) *StorageLocation {
+	for i := range s.Locations {
+		for _, v := range s.Locations[i].Values {
+			if v == value {
+				return &s.Locations[i]
+			}
+		}
+	}
+	return nil
 }
 
+var ruleUniqueLocations = validation.By(func(value interface{}) error {
+	locations, _ := value.([]StorageLocation)
+	names := make(map[string]struct{}, len(locations))
+	values := make(map[string]struct{}, len(locations))
+	for _, loc := range locations {
+		if _, ok := names[loc.Name]; ok {
+			return errors.Errorf("duplicate storage location %q", loc.Name)
+		}
+		names[loc.Name] = struct{}{}
+		for _, v := range loc.Values {
+			if _, ok := values[v]; ok {
+				return errors.Errorf("value %q assigned to several storage locations", v)
+			}
+			values[v] = struct{}{}
+		}
+	}
+	return nil
+})
+
 func ParseStorageSettingsRequest(source io.Reader) (settings *StorageSettings, err error) {
 	// NOTE: by wrapping StorageSettings as an embedded struct field,
 	// passing an empty object `{}` will unmarshall as nil.
@@ -118,6 +194,10 @@ func ParseStorageSettingsRequest(source io.Reader) (settings *StorageSettings, e
 		}
 		settings = s.StorageSettings
 		settings.UseAccelerate = settings.UseAccelerate && settings.Uri == ""
+		for i := range settings.Locations {
+			loc := &settings.Locations[i].Settings
+			loc.UseAccelerate = loc.UseAccelerate && loc.Uri == ""
+		}
 		err = errors.WithMessage(
 			settings.Validate(),
 			"invalid settings schema",
@@ -158,5 +238,10 @@ func (s StorageSettings) Validate() error {
 		)),
 		validation.Field(&s.ExternalUri, ruleLen3_2000),
 		validation.Field(&s.Token, ruleLen5_100),
+		validation.Field(&s.LocationAttribute, validation.When(
+			len(s.Locations) > 0,
+			validation.Required.Error("required when 'locations' are set"),
+		)),
+		validation.Field(&s.Locations, ruleUniqueLocations),
 	)
 }
@@ -0,0 +1,150 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/mendersoftware/mender-artifact/artifact"
+	"github.com/pkg/errors"
+)
+
+const (
+	VerificationKeyTypeRSA     = "rsa"
+	VerificationKeyTypeECDSA   = "ecdsa"
+	VerificationKeyTypeEd25519 = "ed25519"
+)
+
+var (
+	ErrVerificationKeyInvalidPEM  = errors.New("public key is not PEM encoded")
+	ErrVerificationKeyUnsupported = errors.New(
+		"unsupported public key type: supported types are RSA, ECDSA and Ed25519",
+	)
+	ErrInvalidSignature = errors.New("signature verification failed")
+)
+
+// VerificationKey is a public key the tenant verifies the signature of the
+// uploaded artifacts with.
+type VerificationKey struct {
+	Id string `json:"id" bson:"_id"`
+
+	// Human readable name of the key
+	Name string `json:"name" bson:"name"`
+
+	// PEM encoded PKIX public key
+	PublicKey string `json:"public_key" bson:"public_key"`
+
+	// Type of the key, derived from the public key
+	Type string `json:"type" bson:"type"`
+
+	Created time.Time `json:"created" bson:"created"`
+}
+
+// NewVerificationKey holds the request to register a verification key
+type NewVerificationKey struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+func (k NewVerificationKey) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.Name, validation.Required, lengthIn1To4096),
+		validation.Field(&k.PublicKey, validation.Required,
+			validation.By(func(interface{}) error {
+				_, _, err := parsePublicKey(k.PublicKey)
+				return err
+			}),
+		),
+	)
+}
+
+// VerificationKey returns the verification key to store.
+func (k NewVerificationKey) VerificationKey() (*VerificationKey, error) {
+	_, typ, err := parsePublicKey(k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationKey{
+		Id:        uuid.NewString(),
+		Name:      k.Name,
+		PublicKey: k.PublicKey,
+		Type:      typ,
+		Created:   time.Now(),
+	}, nil
+}
+
+// Verifier returns the artifact signature verifier for the key.
+func (k *VerificationKey) Verifier() (artifact.Verifier, error) {
+	key, typ, err := parsePublicKey(k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if typ == VerificationKeyTypeEd25519 {
+		return ed25519Verifier(key.(ed25519.PublicKey)), nil
+	}
+	return artifact.NewPKIVerifier([]byte(k.PublicKey))
+}
+
+func parsePublicKey(keyPEM string) (interface{}, string, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, "", ErrVerificationKeyInvalidPEM
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to parse public key")
+	}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return key, VerificationKeyTypeRSA, nil
+	case *ecdsa.PublicKey:
+		return key, VerificationKeyTypeECDSA, nil
+	case ed25519.PublicKey:
+		return key, VerificationKeyTypeEd25519, nil
+	default:
+		return nil, "", ErrVerificationKeyUnsupported
+	}
+}
+
+// ed25519Verifier verifies the base64 encoded Ed25519 artifact signatures,
+// which the artifact library does not support.
+type ed25519Verifier ed25519.PublicKey
+
+func (v ed25519Verifier) Verify(message, sig []byte) error {
+	dec, err := base64.StdEncoding.DecodeString(string(sig))
+	if err != nil {
+		return errors.Wrap(err, "error decoding signature")
+	}
+	if !ed25519.Verify(ed25519.PublicKey(v), message, dec) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ArtifactVerificationSettings are the tenant settings of the artifact
+// signature verification.
+type ArtifactVerificationSettings struct {
+	// RequireSignature rejects the artifacts which are not signed with
+	// any of the tenant's verification keys.
+	RequireSignature bool `json:"require_signature" bson:"require_signature"`
+}
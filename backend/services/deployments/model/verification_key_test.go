@@ -0,0 +1,139 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePublicKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerificationKey(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("manifest")
+	digest := sha256.Sum256(message)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	ecSig := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	edSig := ed25519.Sign(edKey, message)
+	xKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	testCases := map[string]struct {
+		key NewVerificationKey
+		sig []byte
+
+		typ string
+		err string
+	}{
+		"ok, rsa": {
+			key: NewVerificationKey{Name: "rsa", PublicKey: encodePublicKey(t, rsaKey.Public())},
+			sig: rsaSig,
+			typ: VerificationKeyTypeRSA,
+		},
+		"ok, ecdsa": {
+			key: NewVerificationKey{Name: "ecdsa", PublicKey: encodePublicKey(t, ecKey.Public())},
+			sig: ecSig,
+			typ: VerificationKeyTypeECDSA,
+		},
+		"ok, ed25519": {
+			key: NewVerificationKey{Name: "ed25519", PublicKey: encodePublicKey(t, edPub)},
+			sig: edSig,
+			typ: VerificationKeyTypeEd25519,
+		},
+		"error, unsupported key type": {
+			key: NewVerificationKey{Name: "x25519", PublicKey: encodePublicKey(t, xKey.PublicKey())},
+			err: ErrVerificationKeyUnsupported.Error(),
+		},
+		"error, not pem": {
+			key: NewVerificationKey{Name: "key", PublicKey: "ssh-ed25519 AAAA"},
+			err: ErrVerificationKeyInvalidPEM.Error(),
+		},
+		"error, no name": {
+			key: NewVerificationKey{PublicKey: encodePublicKey(t, edPub)},
+			err: "name: cannot be blank",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.key.Validate()
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			key, err := tc.key.VerificationKey()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NotEmpty(t, key.Id)
+			assert.Equal(t, tc.typ, key.Type)
+
+			verifier, err := key.Verifier()
+			if !assert.NoError(t, err) {
+				return
+			}
+			sig := []byte(base64.StdEncoding.EncodeToString(tc.sig))
+			assert.NoError(t, verifier.Verify(message, sig))
+			assert.Error(t, verifier.Verify([]byte("tampered"), sig))
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	pkgapi "github.com/mendersoftware/mender-server/pkg/api"
 	"github.com/mendersoftware/mender-server/pkg/api/client"
 	"github.com/mendersoftware/mender-server/pkg/config"
+	"github.com/mendersoftware/mender-server/pkg/keys"
 	"github.com/mendersoftware/mender-server/pkg/log"
 
 	api "github.com/mendersoftware/mender-server/services/deployments/api/http"
@@ -38,6 +39,8 @@ import (
 	dconfig "github.com/mendersoftware/mender-server/services/deployments/config"
 	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/azblob"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/cdn"
+	"github.com/mendersoftware/mender-server/services/deployments/storage/filesystem"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/manager"
 	"github.com/mendersoftware/mender-server/services/deployments/storage/s3"
 	mstore "github.com/mendersoftware/mender-server/services/deployments/store/mongo"
@@ -143,6 +146,80 @@ func SetupBlobStorage(
 	return azblob.New(ctx, c.GetString(dconfig.SettingStorageBucket), options)
 }
 
+// decodeSecret decodes the base64 secret in either std or URL encoding
+// ignoring padding.
+func decodeSecret(secret string) ([]byte, error) {
+	base64Repl := strings.NewReplacer("-", "+", "_", "/", "=", "")
+	return base64.RawStdEncoding.DecodeString(base64Repl.Replace(secret))
+}
+
+func presignSecret(c config.Reader) ([]byte, error) {
+	return decodeSecret(c.GetString(dconfig.SettingPresignSecret))
+}
+
+func SetupFilesystem(ctx context.Context) (storage.ObjectStorage, error) {
+	c := config.Config
+
+	uri, err := url.Parse(
+		c.GetString(dconfig.SettingPresignScheme) + "://" +
+			c.GetString(dconfig.SettingPresignHost),
+	)
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting %q", dconfig.SettingPresignHost)
+	}
+	internalURI, err := url.Parse(c.GetString(dconfig.SettingFilesystemInternalURI))
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting %q", dconfig.SettingFilesystemInternalURI)
+	}
+	secret, err := presignSecret(c)
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting %q", dconfig.SettingPresignSecret)
+	}
+	objectsPath := api.ApiUrlDevices + api.ApiUrlDevicesDownloadObjects
+	options := filesystem.NewOptions().
+		SetURI(uri.JoinPath(objectsPath)).
+		SetInternalURI(internalURI.JoinPath(objectsPath)).
+		SetSecret(secret)
+	return filesystem.New(ctx, c.GetString(dconfig.SettingFilesystemRootDir), options)
+}
+
+// SetupCDN fronts the origin storage with the CDN signing the artifact
+// download links.
+func SetupCDN(origin storage.ObjectStorage) (storage.ObjectStorage, error) {
+	c := config.Config
+
+	uri, err := url.Parse(c.GetString(dconfig.SettingStorageCDNURI))
+	if err != nil {
+		return nil, errors.WithMessagef(err,
+			"invalid setting %q", dconfig.SettingStorageCDNURI)
+	}
+	var signer cdn.Signer
+	switch c.GetString(dconfig.SettingStorageCDNSigner) {
+	case dconfig.CDNSignerCloudFront:
+		key, err := keys.LoadRSAPrivate(c.GetString(dconfig.SettingStorageCDNPrivateKey))
+		if err != nil {
+			return nil, errors.WithMessagef(err,
+				"invalid setting %q", dconfig.SettingStorageCDNPrivateKey)
+		}
+		signer = cdn.NewCloudFrontSigner(
+			c.GetString(dconfig.SettingStorageCDNKeyPairID), key,
+		)
+	case dconfig.CDNSignerHMAC:
+		secret, err := decodeSecret(c.GetString(dconfig.SettingStorageCDNSecret))
+		if err != nil {
+			return nil, errors.WithMessagef(err,
+				"invalid setting %q", dconfig.SettingStorageCDNSecret)
+		}
+		signer = cdn.NewHMACSigner(secret)
+	}
+	return cdn.New(origin, cdn.NewOptions().
+		SetURI(uri).
+		SetSigner(signer))
+}
+
 func SetupObjectStorage(ctx context.Context) (objManager storage.ObjectStorage, err error) {
 	c := config.Config
 
@@ -165,16 +242,26 @@ func SetupObjectStorage(ctx context.Context) (objManager storage.ObjectStorage,
 		defaultStorage, err = SetupS3(ctx, s3Options)
 	case dconfig.StorageTypeAzure:
 		defaultStorage, err = SetupBlobStorage(ctx, azOptions)
+	case dconfig.StorageTypeFilesystem:
+		defaultStorage, err = SetupFilesystem(ctx)
 	default:
 		err = errors.Errorf(
-			`storage type must be one of %q or %q, received value %q`,
-			dconfig.StorageTypeAWS, dconfig.StorageTypeAzure, defType,
+			`storage type must be one of %q, %q or %q, received value %q`,
+			dconfig.StorageTypeAWS, dconfig.StorageTypeAzure,
+			dconfig.StorageTypeFilesystem, defType,
 		)
 	}
 	if err != nil {
 		return nil, err
 	}
-	return manager.New(ctx, defaultStorage, s3Options, azOptions)
+	objManager, err = manager.New(ctx, defaultStorage, s3Options, azOptions)
+	if err != nil {
+		return nil, err
+	}
+	if c.GetString(dconfig.SettingStorageCDNURI) != "" {
+		objManager, err = SetupCDN(objManager)
+	}
+	return objManager, err
 }
 
 func setupClients(c config.Reader, deployments *app.Deployments) error {
@@ -220,9 +307,14 @@ func RunServer(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	app.SetEnableDeltaGeneration(c.GetBool(dconfig.SettingEnableDeltaGeneration))
+	app.SetDeploymentLogMaxSize(c.GetInt(dconfig.SettingDeploymentLogMaxSize))
+	app.SetDeploymentLogRetention(time.Second *
+		c.GetDuration(dconfig.SettingDeploymentLogRetentionSeconds))
+	app.SetContinuousMatchInterval(time.Second *
+		c.GetDuration(dconfig.SettingContinuousMatchIntervalSeconds))
 
 	// Setup API Router configuration
-	base64Repl := strings.NewReplacer("-", "+", "_", "/", "=", "")
 	expire := c.GetDuration(dconfig.SettingPresignExpireSeconds)
 	apiConf := api.NewConfig().
 		SetPresignExpire(time.Second * expire).
@@ -234,13 +326,14 @@ func RunServer(ctx context.Context) error {
 		SetEnableDirectUploadSkipVerify(c.GetBool(dconfig.SettingStorageDirectUploadSkipVerify)).
 		SetDisableNewReleasesFeature(c.GetBool(dconfig.SettingDisableNewReleasesFeature)).
 		SetMaxRequestSize(c.GetInt64(dconfig.SettingMaxRequestSize))
-	if key, err := base64.RawStdEncoding.DecodeString(
-		base64Repl.Replace(
-			c.GetString(dconfig.SettingPresignSecret),
-		),
-	); err == nil {
+	if key, err := presignSecret(c); err == nil {
 		apiConf.SetPresignSecret(key)
 	}
+	if c.GetString(dconfig.SettingDefaultStorage) == dconfig.StorageTypeFilesystem {
+		// Without tenant storage settings in the request context, the
+		// object storage resolves to the default (filesystem) storage.
+		apiConf.SetObjectStorage(objStore)
+	}
 	handler := api.NewRouter(ctx, app, ds, apiConf)
 
 	listen := c.GetString(dconfig.SettingListen)
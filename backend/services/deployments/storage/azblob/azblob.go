@@ -17,12 +17,17 @@ package azblob
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/mendersoftware/mender-server/services/deployments/model"
 	"github.com/mendersoftware/mender-server/services/deployments/storage"
 	"github.com/mendersoftware/mender-server/services/deployments/utils"
@@ -41,6 +46,10 @@ const (
 	headerBlobType = "x-ms-blob-type"
 
 	blobTypeBlock = "BlockBlob"
+
+	// blockIDFormat encodes the upload ID and part number in the block ID;
+	// all block IDs of a blob must have the same length.
+	blockIDFormat = "%s-%05d"
 )
 
 type client struct {
@@ -320,6 +329,49 @@ func (c *client) StatObject(
 	}, nil
 }
 
+func (c *client) ListObjects(
+	ctx context.Context,
+	prefix string,
+	fn func(storage.ObjectInfo) error,
+) error {
+	azClient, err := c.clientFromContext(ctx)
+	if err != nil {
+		return OpError{
+			Op:     OpListObjects,
+			Reason: err,
+		}
+	}
+	pager := azClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return OpError{
+				Op:      OpListObjects,
+				Message: "failed to list objects",
+				Reason:  err,
+			}
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			info := storage.ObjectInfo{
+				Path: *item.Name,
+			}
+			if item.Properties != nil {
+				info.Size = item.Properties.ContentLength
+				info.LastModified = item.Properties.LastModified
+			}
+			if err = fn(info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *client) buildSignedURL(
 	ctx context.Context,
 	method string,
@@ -519,3 +571,165 @@ func (c *client) PutRequest(
 	}
 	return link, nil
 }
+
+// CreateMultipartUpload returns a new upload ID; Azure has no explicit
+// multipart upload, the parts are staged as blocks tagged with the ID.
+func (c *client) CreateMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+) (string, error) {
+	if _, err := c.clientFromContext(ctx); err != nil {
+		return "", OpError{
+			Op:     OpCreateMultipartUpload,
+			Reason: err,
+		}
+	}
+	return uuid.NewString(), nil
+}
+
+func encodeBlockID(uploadID string, partNumber int) string {
+	return base64.StdEncoding.EncodeToString(
+		[]byte(fmt.Sprintf(blockIDFormat, uploadID, partNumber)),
+	)
+}
+
+func decodeBlockID(uploadID string, blockID string) (int, bool) {
+	b, err := base64.StdEncoding.DecodeString(blockID)
+	if err != nil {
+		return 0, false
+	}
+	number, found := strings.CutPrefix(string(b), uploadID+"-")
+	if !found {
+		return 0, false
+	}
+	var partNumber int
+	if _, err := fmt.Sscanf(number, "%d", &partNumber); err != nil {
+		return 0, false
+	}
+	return partNumber, true
+}
+
+// PartRequest returns a signed Put Block request staging the part.
+func (c *client) PartRequest(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+	partNumber int,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	azClient, err := c.clientFromContext(ctx)
+	if err != nil {
+		return nil, OpError{
+			Op:     OpPartRequest,
+			Reason: err,
+		}
+	}
+	bc := azClient.NewBlockBlobClient(objectPath)
+	q := url.Values{}
+	q.Set("comp", "block")
+	q.Set("blockid", encodeBlockID(uploadID, partNumber))
+	link, err := c.buildSignedURL(
+		ctx, http.MethodPut, bc.URL()+"?"+q.Encode(), duration, "", public,
+	)
+	if err != nil {
+		return nil, OpError{
+			Op:      OpPartRequest,
+			Message: "failed to generate signed URL",
+			Reason:  err,
+		}
+	}
+	return link, nil
+}
+
+// ListParts lists the uncommitted blocks staged for the upload.
+func (c *client) ListParts(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+) ([]model.UploadPart, error) {
+	azClient, err := c.clientFromContext(ctx)
+	if err != nil {
+		return nil, OpError{
+			Op:     OpListParts,
+			Reason: err,
+		}
+	}
+	bc := azClient.NewBlockBlobClient(objectPath)
+	rsp, err := bc.GetBlockList(ctx, blockblob.BlockListTypeUncommitted, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		// No blocks have been staged yet
+		return []model.UploadPart{}, nil
+	} else if err != nil {
+		return nil, OpError{
+			Op:      OpListParts,
+			Message: "failed to retrieve block list",
+			Reason:  err,
+		}
+	}
+	parts := make([]model.UploadPart, 0, len(rsp.UncommittedBlocks))
+	for _, block := range rsp.UncommittedBlocks {
+		if block.Name == nil {
+			continue
+		}
+		partNumber, ok := decodeBlockID(uploadID, *block.Name)
+		if !ok {
+			continue
+		}
+		part := model.UploadPart{
+			Number: partNumber,
+		}
+		if block.Size != nil {
+			part.Size = *block.Size
+		}
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Number < parts[j].Number
+	})
+	return parts, nil
+}
+
+// CompleteMultipartUpload commits the block list of the parts.
+func (c *client) CompleteMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+	parts []model.UploadPart,
+) error {
+	azClient, err := c.clientFromContext(ctx)
+	if err != nil {
+		return OpError{
+			Op:     OpCompleteMultipartUpload,
+			Reason: err,
+		}
+	}
+	blockIDs := make([]string, len(parts))
+	for i, part := range parts {
+		blockIDs[i] = encodeBlockID(uploadID, part.Number)
+	}
+	bc := azClient.NewBlockBlobClient(objectPath)
+	_, err = bc.CommitBlockList(ctx, blockIDs, &blockblob.CommitBlockListOptions{
+		HTTPHeaders: &blob.HTTPHeaders{
+			BlobContentType: c.contentType,
+		},
+	})
+	if err != nil {
+		return OpError{
+			Op:      OpCompleteMultipartUpload,
+			Message: "failed to commit block list",
+			Reason:  err,
+		}
+	}
+	return nil
+}
+
+// AbortMultipartUpload is a noop: Azure garbage collects uncommitted
+// blocks after a week.
+func (c *client) AbortMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+) error {
+	return nil
+}
@@ -254,6 +254,38 @@ func TestObjectStorage(t *testing.T) {
 				}
 			}
 
+			uploadID, err := c.CreateMultipartUpload(ctx, subPrefix+"multipart")
+			if assert.NoError(t, err) {
+				for _, partNumber := range []int{2, 1} {
+					link, err = c.PartRequest(ctx, subPrefix+"multipart",
+						uploadID, partNumber, time.Minute*5, true)
+					if !assert.NoError(t, err) {
+						continue
+					}
+					req, err := http.NewRequest(link.Method, link.Uri,
+						strings.NewReader(blobContent))
+					if assert.NoError(t, err) {
+						rsp, err := client.Do(req)
+						if assert.NoError(t, err) {
+							assert.Equal(t, http.StatusCreated, rsp.StatusCode)
+						}
+					}
+				}
+				parts, err := c.ListParts(ctx, subPrefix+"multipart", uploadID)
+				if assert.NoError(t, err) && assert.Len(t, parts, 2) {
+					assert.Equal(t, 1, parts[0].Number)
+					assert.Equal(t, 2, parts[1].Number)
+					err = c.CompleteMultipartUpload(ctx, subPrefix+"multipart",
+						uploadID, parts)
+					if assert.NoError(t, err) {
+						stat, err = c.StatObject(ctx, subPrefix+"multipart")
+						if assert.NoError(t, err) {
+							assert.Equal(t, int64(2*len(blobContent)), *stat.Size)
+						}
+					}
+				}
+			}
+
 			err = c.DeleteObject(ctx, subPrefix+"baz")
 			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
 			assert.Contains(t, err.Error(), storage.ErrObjectNotFound.Error())
@@ -268,6 +300,25 @@ func TestObjectStorage(t *testing.T) {
 
 }
 
+func TestBlockID(t *testing.T) {
+	t.Parallel()
+
+	const uploadID = "9bf1bfff-eeb4-49d4-b55d-d717d407888a"
+	first := encodeBlockID(uploadID, 1)
+	last := encodeBlockID(uploadID, model.UploadPartNumberMax)
+	assert.Len(t, last, len(first), "block IDs of a blob must have the same length")
+
+	partNumber, ok := decodeBlockID(uploadID, last)
+	assert.True(t, ok)
+	assert.Equal(t, model.UploadPartNumberMax, partNumber)
+
+	_, ok = decodeBlockID(uuid.NewString(), first)
+	assert.False(t, ok, "block of another upload")
+
+	_, ok = decodeBlockID(uploadID, "not base64!")
+	assert.False(t, ok)
+}
+
 func TestKeyFromConnectionString(t *testing.T) {
 	const (
 		ConnStr = "AccountName=foobar;AccountNotKey=notfoobar;Spam=spam;AccountKey=Zm9vYmFy"
@@ -46,9 +46,15 @@ const (
 	OpPutObject     = "PutObject"
 	OpDeleteObject  = "DeleteObject"
 	OpStatObject    = "StatObject"
+	OpListObjects   = "ListObjects"
 	OpGetRequest    = "GetRequest"
 	OpDeleteRequest = "DeleteRequest"
 	OpPutRequest    = "PutRequest"
+
+	OpCreateMultipartUpload   = "CreateMultipartUpload"
+	OpPartRequest             = "PartRequest"
+	OpListParts               = "ListParts"
+	OpCompleteMultipartUpload = "CompleteMultipartUpload"
 )
 
 var (
@@ -0,0 +1,97 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+const (
+	// ParamContentDisposition is the query parameter of the download
+	// links holding the Content-Disposition of the response; CDNs
+	// forwarding the query string to an S3 origin serve the artifact
+	// with the suggested filename.
+	ParamContentDisposition = "response-content-disposition"
+)
+
+var (
+	ErrMissingURI    = errors.New("cdn: missing distribution URI")
+	ErrMissingSigner = errors.New("cdn: missing link signer")
+)
+
+// client fronts the default object storage with a CDN: the public download
+// links are signed for the CDN domain, while the links to the tenants' own
+// storage, the internal links and every other operation are served by the
+// origin storage.
+type client struct {
+	storage.ObjectStorage
+	uri    *url.URL
+	signer Signer
+}
+
+func New(origin storage.ObjectStorage, opts ...*Options) (storage.ObjectStorage, error) {
+	opt := NewOptions(opts...)
+	if opt.URI == nil {
+		return nil, ErrMissingURI
+	}
+	if opt.Signer == nil {
+		return nil, ErrMissingSigner
+	}
+	return &client{
+		ObjectStorage: origin,
+		uri:           opt.URI,
+		signer:        opt.Signer,
+	}, nil
+}
+
+func (c *client) GetRequest(
+	ctx context.Context,
+	objectPath string,
+	filename string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	if settings, _ := storage.SettingsFromContext(ctx); settings != nil || !public {
+		return c.ObjectStorage.GetRequest(ctx, objectPath, filename, duration, public)
+	}
+	if _, err := c.StatObject(ctx, objectPath); err != nil {
+		return nil, errors.WithMessage(err, "cdn: stat object")
+	}
+	uri := c.uri.JoinPath(objectPath)
+	if filename != "" {
+		q := uri.Query()
+		q.Set(ParamContentDisposition,
+			fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		uri.RawQuery = q.Encode()
+	}
+	expire := time.Now().Add(duration).Truncate(time.Second)
+	if err := c.signer.SignURL(uri, expire); err != nil {
+		return nil, errors.WithMessage(err, "cdn: failed to sign GET request")
+	}
+	return &model.Link{
+		Uri:    uri.String(),
+		Expire: expire,
+		Method: http.MethodGet,
+	}, nil
+}
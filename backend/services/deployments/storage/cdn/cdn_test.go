@@ -0,0 +1,149 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cdn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+	mstorage "github.com/mendersoftware/mender-server/services/deployments/storage/mocks"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	uri, _ := url.Parse("https://cdn.mender.io")
+	_, err := New(nil, NewOptions().SetSigner(NewHMACSigner([]byte("secret"))))
+	assert.ErrorIs(t, err, ErrMissingURI)
+
+	_, err = New(nil, NewOptions().SetURI(uri))
+	assert.ErrorIs(t, err, ErrMissingSigner)
+}
+
+func TestGetRequest(t *testing.T) {
+	t.Parallel()
+
+	const objectPath = "tenant/artifact"
+	secret := []byte("secret")
+	originLink := &model.Link{
+		Uri:    "https://bucket.s3.amazonaws.com/tenant/artifact",
+		Method: http.MethodGet,
+	}
+
+	testCases := map[string]struct {
+		settings *model.StorageSettings
+		public   bool
+		filename string
+		statErr  error
+
+		origin bool
+		uri    string
+		err    error
+	}{
+		"ok": {
+			public: true,
+			uri:    "https://cdn.mender.io/artifacts/tenant/artifact",
+		},
+		"ok, filename": {
+			public:   true,
+			filename: "release.mender",
+			uri: "https://cdn.mender.io/artifacts/tenant/artifact?" +
+				"response-content-disposition=attachment%3B+filename%3D%22release.mender%22",
+		},
+		"ok, internal link": {
+			origin: true,
+		},
+		"ok, tenant storage": {
+			settings: &model.StorageSettings{
+				Type:   model.StorageTypeS3,
+				Region: "eu-west-1",
+				Bucket: "tenant-bucket",
+				Key:    "access-key",
+				Secret: "secret-key",
+			},
+			public: true,
+			origin: true,
+		},
+		"error, object not found": {
+			public:  true,
+			statErr: storage.ErrObjectNotFound,
+			err:     storage.ErrObjectNotFound,
+		},
+		"error, stat": {
+			public:  true,
+			statErr: errors.New("internal error"),
+			err:     errors.New("cdn: stat object: internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tc.settings != nil {
+				ctx = storage.SettingsWithContext(ctx, tc.settings)
+			}
+			origin := mstorage.NewObjectStorage(t)
+			if tc.origin {
+				origin.On("GetRequest", ctx, objectPath, tc.filename, time.Hour, tc.public).
+					Return(originLink, nil)
+			} else {
+				origin.On("StatObject", ctx, objectPath).
+					Return(&storage.ObjectInfo{Path: objectPath}, tc.statErr)
+			}
+
+			uri, _ := url.Parse("https://cdn.mender.io/artifacts")
+			client, err := New(origin, NewOptions().
+				SetURI(uri).
+				SetSigner(NewHMACSigner(secret)))
+			if !assert.NoError(t, err) {
+				return
+			}
+			link, err := client.GetRequest(ctx, objectPath, tc.filename, time.Hour, tc.public)
+			switch {
+			case tc.err != nil:
+				if errors.Is(tc.err, storage.ErrObjectNotFound) {
+					assert.ErrorIs(t, err, tc.err)
+				} else {
+					assert.EqualError(t, err, tc.err.Error())
+				}
+			case tc.origin:
+				assert.NoError(t, err)
+				assert.Equal(t, originLink, link)
+			default:
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, http.MethodGet, link.Method)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), link.Expire, time.Minute)
+
+				expected, _ := url.Parse(tc.uri)
+				err = NewHMACSigner(secret).SignURL(expected, link.Expire)
+				assert.NoError(t, err)
+				assert.Equal(t, expected.String(), link.Uri)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cdn
+
+import (
+	"net/url"
+)
+
+type Options struct {
+	// URI is the base URL of the CDN distribution serving the objects
+	// of the default storage.
+	URI *url.URL
+
+	// Signer signs the links to the CDN.
+	Signer Signer
+}
+
+func NewOptions(opts ...*Options) *Options {
+	opt := &Options{}
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		if o.URI != nil {
+			opt.URI = o.URI
+		}
+		if o.Signer != nil {
+			opt.Signer = o.Signer
+		}
+	}
+	return opt
+}
+
+func (opts *Options) SetURI(uri *url.URL) *Options {
+	opts.URI = uri
+	return opts
+}
+
+func (opts *Options) SetSigner(signer Signer) *Options {
+	opts.Signer = signer
+	return opts
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package cdn
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudFrontSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	expire := time.Unix(1700000000, 0)
+	const resource = "https://cdn.mender.io/tenant/artifact?" +
+		"response-content-disposition=attachment%3B+filename%3D%22release.mender%22"
+
+	uri, _ := url.Parse(resource)
+	signer := NewCloudFrontSigner("K2JCJMDEHXQW5F", key)
+	err = signer.SignURL(uri, expire)
+	require.NoError(t, err)
+
+	q := uri.Query()
+	assert.Equal(t, "1700000000", q.Get(ParamExpires))
+	assert.Equal(t, "K2JCJMDEHXQW5F", q.Get(ParamKeyPairID))
+	assert.Equal(t, `attachment; filename="release.mender"`,
+		q.Get(ParamContentDisposition))
+
+	sig, err := base64.StdEncoding.DecodeString(
+		strings.NewReplacer("-", "+", "_", "=", "~", "/").
+			Replace(q.Get(ParamSignature)),
+	)
+	require.NoError(t, err)
+	policy := fmt.Sprintf(`{"Statement":[{"Resource":%q,`+
+		`"Condition":{"DateLessThan":{"AWS:EpochTime":1700000000}}}]}`, resource)
+	digest := sha1.Sum([]byte(policy)) //nolint:gosec
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, digest[:], sig))
+}
+
+func TestHMACSigner(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	expire := time.Unix(1700000000, 0)
+
+	uri, _ := url.Parse("https://cdn.mender.io/tenant/artifact?filename=release.mender")
+	signer := NewHMACSigner(secret)
+	err := signer.SignURL(uri, expire)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte("/tenant/artifact?expires=1700000000&filename=release.mender"))
+	assert.Equal(t,
+		"https://cdn.mender.io/tenant/artifact?expires=1700000000&filename=release.mender"+
+			"&token="+base64.RawURLEncoding.EncodeToString(mac.Sum(nil)),
+		uri.String(),
+	)
+
+	// signing again replaces the token
+	err = signer.SignURL(uri, expire)
+	require.NoError(t, err)
+	assert.Len(t, uri.Query()[ParamToken], 1)
+}
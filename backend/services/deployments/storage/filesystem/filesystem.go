@@ -0,0 +1,477 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+const (
+	// ParamFilename is the query parameter of the download links holding
+	// the filename suggested to the client.
+	ParamFilename = model.ParamFilename
+
+	dirMode    = 0o750
+	tmpPattern = ".upload-*"
+)
+
+// client stores the objects as regular files under a root directory. The
+// signed links point to the deployments service itself, which is expected
+// to verify the signature and serve the object using this client.
+type client struct {
+	root        string
+	uri         *url.URL
+	internalURI *url.URL
+	secret      []byte
+}
+
+func New(ctx context.Context, rootDir string, opts ...*Options) (storage.ObjectStorage, error) {
+	opt := NewOptions(opts...)
+	if opt.URI == nil {
+		return nil, OpError{
+			Message: "missing object link URI",
+			Reason:  ErrStorageSettings,
+		}
+	}
+	if len(opt.Secret) == 0 {
+		return nil, OpError{
+			Message: "missing object link signing secret",
+			Reason:  ErrStorageSettings,
+		}
+	}
+	internalURI := opt.InternalURI
+	if internalURI == nil {
+		internalURI = opt.URI
+	}
+	root, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, OpError{
+			Message: "invalid root directory",
+			Reason:  err,
+		}
+	}
+	err = os.MkdirAll(root, dirMode)
+	if err != nil {
+		return nil, OpError{
+			Message: "failed to create root directory",
+			Reason:  err,
+		}
+	}
+	return &client{
+		root:        root,
+		uri:         opt.URI,
+		internalURI: internalURI,
+		secret:      opt.Secret,
+	}, nil
+}
+
+// cleanPath returns the slash separated object path rooted at "/" and
+// stripped of any relative element.
+func cleanPath(objectPath string) (string, error) {
+	cleaned := path.Clean("/" + objectPath)
+	if cleaned == "/" {
+		return "", ErrInvalidPath
+	}
+	return cleaned, nil
+}
+
+func (c *client) filePath(objectPath string) (string, error) {
+	cleaned, err := cleanPath(objectPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(c.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (c *client) HealthCheck(ctx context.Context) error {
+	info, err := os.Stat(c.root)
+	if err == nil && !info.IsDir() {
+		err = &os.PathError{Op: "stat", Path: c.root, Err: os.ErrInvalid}
+	}
+	if err != nil {
+		return OpError{
+			Op:      OpHealthCheck,
+			Message: "root directory is not accessible",
+			Reason:  err,
+		}
+	}
+	return nil
+}
+
+// objectReader is seekable, so that the downloads can be served in ranges.
+type objectReader struct {
+	*os.File
+	length  int64
+	modTime time.Time
+}
+
+func (r objectReader) Length() int64 {
+	return r.length
+}
+
+func (r objectReader) ModTime() time.Time {
+	return r.modTime
+}
+
+func (c *client) GetObject(
+	ctx context.Context,
+	objectPath string,
+) (io.ReadCloser, error) {
+	fpath, err := c.filePath(objectPath)
+	if err != nil {
+		return nil, OpError{
+			Op:     OpGetObject,
+			Reason: err,
+		}
+	}
+	f, err := os.Open(fpath)
+	if os.IsNotExist(err) {
+		err = storage.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, OpError{
+			Op:     OpGetObject,
+			Reason: err,
+		}
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, OpError{
+			Op:      OpGetObject,
+			Message: "failed to retrieve object properties",
+			Reason:  err,
+		}
+	}
+	return objectReader{
+		File:    f,
+		length:  info.Size(),
+		modTime: info.ModTime(),
+	}, nil
+}
+
+// PutObject writes the object to a temporary file and moves it in place
+// once complete, so that readers never observe partial objects.
+func (c *client) PutObject(
+	ctx context.Context,
+	objectPath string,
+	src io.Reader,
+) error {
+	fpath, err := c.filePath(objectPath)
+	if err != nil {
+		return OpError{
+			Op:     OpPutObject,
+			Reason: err,
+		}
+	}
+	dir := filepath.Dir(fpath)
+	err = os.MkdirAll(dir, dirMode)
+	if err != nil {
+		return OpError{
+			Op:      OpPutObject,
+			Message: "failed to create object directory",
+			Reason:  err,
+		}
+	}
+	f, err := os.CreateTemp(dir, tmpPattern)
+	if err != nil {
+		return OpError{
+			Op:      OpPutObject,
+			Message: "failed to create object file",
+			Reason:  err,
+		}
+	}
+	_, err = io.Copy(f, src)
+	if err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), fpath)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return OpError{
+			Op:      OpPutObject,
+			Message: "failed to write object",
+			Reason:  err,
+		}
+	}
+	return nil
+}
+
+func (c *client) DeleteObject(
+	ctx context.Context,
+	objectPath string,
+) error {
+	fpath, err := c.filePath(objectPath)
+	if err != nil {
+		return OpError{
+			Op:     OpDeleteObject,
+			Reason: err,
+		}
+	}
+	err = os.Remove(fpath)
+	if os.IsNotExist(err) {
+		err = storage.ErrObjectNotFound
+	}
+	if err != nil {
+		return OpError{
+			Op:      OpDeleteObject,
+			Message: "failed to delete object",
+			Reason:  err,
+		}
+	}
+	return nil
+}
+
+func (c *client) StatObject(
+	ctx context.Context,
+	objectPath string,
+) (*storage.ObjectInfo, error) {
+	fpath, err := c.filePath(objectPath)
+	if err != nil {
+		return nil, OpError{
+			Op:     OpStatObject,
+			Reason: err,
+		}
+	}
+	info, err := os.Stat(fpath)
+	if os.IsNotExist(err) {
+		err = storage.ErrObjectNotFound
+	} else if err == nil && info.IsDir() {
+		err = storage.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, OpError{
+			Op:      OpStatObject,
+			Message: "failed to retrieve object properties",
+			Reason:  err,
+		}
+	}
+	size := info.Size()
+	modTime := info.ModTime()
+	return &storage.ObjectInfo{
+		Path:         objectPath,
+		Size:         &size,
+		LastModified: &modTime,
+	}, nil
+}
+
+// ListObjects walks the files under the root directory, skipping the
+// temporary files of the uploads in progress.
+func (c *client) ListObjects(
+	ctx context.Context,
+	prefix string,
+	fn func(storage.ObjectInfo) error,
+) error {
+	err := filepath.WalkDir(c.root, func(fpath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			return ctx.Err()
+		} else if ok, _ := filepath.Match(tmpPattern, entry.Name()); ok {
+			return nil
+		}
+		rel, err := filepath.Rel(c.root, fpath)
+		if err != nil {
+			return err
+		}
+		objectPath := filepath.ToSlash(rel)
+		if !strings.HasPrefix(objectPath, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		size := info.Size()
+		modTime := info.ModTime()
+		return fn(storage.ObjectInfo{
+			Path:         objectPath,
+			Size:         &size,
+			LastModified: &modTime,
+		})
+	})
+	if err != nil {
+		return OpError{
+			Op:      OpListObjects,
+			Message: "failed to list objects",
+			Reason:  err,
+		}
+	}
+	return nil
+}
+
+func (c *client) buildSignedURL(
+	method string,
+	objectPath string,
+	filename string,
+	expire time.Duration,
+	public bool,
+) (*model.Link, error) {
+	cleaned, err := cleanPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := c.internalURI
+	if public {
+		baseURL = c.uri
+	}
+	linkURL := baseURL.JoinPath(cleaned)
+	if filename != "" {
+		q := linkURL.Query()
+		q.Set(ParamFilename, filename)
+		linkURL.RawQuery = q.Encode()
+	}
+	exp := time.Now().Add(expire)
+	sig := model.NewRequestSignature(&http.Request{
+		Method: method,
+		URL:    linkURL,
+	}, c.secret)
+	sig.SetExpire(exp)
+	return &model.Link{
+		Uri:    sig.PresignURL(),
+		Expire: exp,
+		Method: method,
+	}, nil
+}
+
+func (c *client) GetRequest(
+	ctx context.Context,
+	objectPath string,
+	filename string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	// Check if object exists
+	_, err := c.StatObject(ctx, objectPath)
+	if err != nil {
+		return nil, OpError{
+			Op:     OpGetRequest,
+			Reason: err,
+		}
+	}
+	link, err := c.buildSignedURL(
+		http.MethodGet, objectPath, filename, duration, public,
+	)
+	if err != nil {
+		return nil, OpError{
+			Op:      OpGetRequest,
+			Message: "failed to generate signed URL",
+			Reason:  err,
+		}
+	}
+	return link, nil
+}
+
+func (c *client) DeleteRequest(
+	ctx context.Context,
+	objectPath string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	link, err := c.buildSignedURL(
+		http.MethodDelete, objectPath, "", duration, public,
+	)
+	if err != nil {
+		return nil, OpError{
+			Op:      OpDeleteRequest,
+			Message: "failed to generate signed URL",
+			Reason:  err,
+		}
+	}
+	return link, nil
+}
+
+func (c *client) PutRequest(
+	ctx context.Context,
+	objectPath string,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	link, err := c.buildSignedURL(
+		http.MethodPut, objectPath, "", duration, public,
+	)
+	if err != nil {
+		return nil, OpError{
+			Op:      OpPutRequest,
+			Message: "failed to generate signed URL",
+			Reason:  err,
+		}
+	}
+	return link, nil
+}
+
+// The filesystem storage serves uploads in a single request only.
+
+func (c *client) CreateMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+) (string, error) {
+	return "", storage.ErrMultipartNotSupported
+}
+
+func (c *client) PartRequest(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+	partNumber int,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	return nil, storage.ErrMultipartNotSupported
+}
+
+func (c *client) ListParts(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+) ([]model.UploadPart, error) {
+	return nil, storage.ErrMultipartNotSupported
+}
+
+func (c *client) CompleteMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+	parts []model.UploadPart,
+) error {
+	return storage.ErrMultipartNotSupported
+}
+
+func (c *client) AbortMultipartUpload(
+	ctx context.Context,
+	objectPath string,
+	uploadID string,
+) error {
+	return storage.ErrMultipartNotSupported
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+	"github.com/mendersoftware/mender-server/services/deployments/storage"
+)
+
+var testSecret = []byte("secret")
+
+func newTestClient(t *testing.T) (storage.ObjectStorage, string) {
+	root := t.TempDir()
+	uri, _ := url.Parse("https://mender.io/objects")
+	internalURI, _ := url.Parse("http://mender-deployments:8080/objects")
+	objStore, err := New(context.Background(), root, NewOptions().
+		SetURI(uri).
+		SetInternalURI(internalURI).
+		SetSecret(testSecret))
+	require.NoError(t, err)
+	return objStore, root
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	uri, _ := url.Parse("https://mender.io/objects")
+
+	testCases := map[string]struct {
+		options *Options
+
+		err error
+	}{
+		"ok": {
+			options: NewOptions().SetURI(uri).SetSecret(testSecret),
+		},
+		"error, missing uri": {
+			options: NewOptions().SetSecret(testSecret),
+			err:     ErrStorageSettings,
+		},
+		"error, missing secret": {
+			options: NewOptions().SetURI(uri),
+			err:     ErrStorageSettings,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			root := filepath.Join(t.TempDir(), "storage")
+			objStore, err := New(context.Background(), root, tc.options)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.DirExists(t, root)
+			assert.NoError(t, objStore.HealthCheck(context.Background()))
+		})
+	}
+}
+
+func TestObjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	objStore, root := newTestClient(t)
+
+	const objectPath = "tenant/artifact"
+	const content = "*Just imagine an artifact here*"
+
+	_, err := objStore.StatObject(ctx, objectPath)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = objStore.GetObject(ctx, objectPath)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	err = objStore.PutObject(ctx, objectPath, strings.NewReader(content))
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "tenant", "artifact"))
+
+	info, err := objStore.StatObject(ctx, objectPath)
+	require.NoError(t, err)
+	assert.Equal(t, objectPath, info.Path)
+	if assert.NotNil(t, info.Size) {
+		assert.Equal(t, int64(len(content)), *info.Size)
+	}
+
+	obj, err := objStore.GetObject(ctx, objectPath)
+	require.NoError(t, err)
+	if r, ok := obj.(storage.ObjectReader); assert.True(t, ok) {
+		assert.Equal(t, int64(len(content)), r.Length())
+	}
+	b, err := io.ReadAll(obj)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+	if r, ok := obj.(io.ReadSeeker); assert.True(t, ok) {
+		_, err = r.Seek(1, io.SeekStart)
+		assert.NoError(t, err)
+		b, err = io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, content[1:], string(b))
+	}
+	assert.NoError(t, obj.Close())
+
+	// directories are not objects
+	_, err = objStore.StatObject(ctx, "tenant")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	err = objStore.DeleteObject(ctx, objectPath)
+	assert.NoError(t, err)
+	err = objStore.DeleteObject(ctx, objectPath)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(root, "tenant"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestListObjects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	objStore, root := newTestClient(t)
+
+	for _, objectPath := range []string{"artifact", "tenant/artifact", "tenant/artifact.tmp"} {
+		err := objStore.PutObject(ctx, objectPath, strings.NewReader("data"))
+		require.NoError(t, err)
+	}
+	// uploads in progress are not listed
+	err := os.WriteFile(filepath.Join(root, "tenant", ".upload-123"), []byte("data"), 0o600)
+	require.NoError(t, err)
+
+	list := func(prefix string) []string {
+		var paths []string
+		err := objStore.ListObjects(ctx, prefix, func(info storage.ObjectInfo) error {
+			if assert.NotNil(t, info.Size) {
+				assert.Equal(t, int64(4), *info.Size)
+			}
+			assert.NotNil(t, info.LastModified)
+			paths = append(paths, info.Path)
+			return nil
+		})
+		assert.NoError(t, err)
+		return paths
+	}
+	assert.ElementsMatch(t,
+		[]string{"artifact", "tenant/artifact", "tenant/artifact.tmp"},
+		list(""))
+	assert.ElementsMatch(t,
+		[]string{"tenant/artifact", "tenant/artifact.tmp"},
+		list("tenant/"))
+
+	// errors returned by the callback stop the listing
+	errStop := errors.New("stop")
+	calls := 0
+	err = objStore.ListObjects(ctx, "", func(storage.ObjectInfo) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestObjectPath(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	objStore, root := newTestClient(t)
+
+	// relative elements can't escape the root directory
+	err := objStore.PutObject(ctx, "../../escape", strings.NewReader("data"))
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "escape"))
+
+	err = objStore.PutObject(ctx, "..", strings.NewReader("data"))
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = objStore.PutRequest(ctx, "", time.Minute, true)
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestSignedRequests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	objStore, _ := newTestClient(t)
+
+	err := objStore.PutObject(ctx, "tenant/artifact", strings.NewReader("data"))
+	require.NoError(t, err)
+
+	_, err = objStore.GetRequest(ctx, "tenant/missing", "", time.Minute, true)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	testCases := map[string]struct {
+		request func() (*model.Link, error)
+
+		method   string
+		base     string
+		filename string
+	}{
+		"get, public": {
+			request: func() (*model.Link, error) {
+				return objStore.GetRequest(ctx,
+					"tenant/artifact", "artifact.mender", time.Minute, true)
+			},
+			method:   http.MethodGet,
+			base:     "https://mender.io/objects/tenant/artifact",
+			filename: "artifact.mender",
+		},
+		"get, internal": {
+			request: func() (*model.Link, error) {
+				return objStore.GetRequest(ctx,
+					"tenant/artifact", "", time.Minute, false)
+			},
+			method: http.MethodGet,
+			base:   "http://mender-deployments:8080/objects/tenant/artifact",
+		},
+		"put": {
+			request: func() (*model.Link, error) {
+				return objStore.PutRequest(ctx, "tenant/upload", time.Minute, true)
+			},
+			method: http.MethodPut,
+			base:   "https://mender.io/objects/tenant/upload",
+		},
+		"delete": {
+			request: func() (*model.Link, error) {
+				return objStore.DeleteRequest(ctx, "tenant/artifact", time.Minute, false)
+			},
+			method: http.MethodDelete,
+			base:   "http://mender-deployments:8080/objects/tenant/artifact",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			link, err := tc.request()
+			require.NoError(t, err)
+			assert.Equal(t, tc.method, link.Method)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), link.Expire, time.Second)
+			assert.True(t, strings.HasPrefix(link.Uri, tc.base+"?"), link.Uri)
+
+			req, err := http.NewRequest(link.Method, link.Uri, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.filename, req.URL.Query().Get(ParamFilename))
+
+			sig := model.NewRequestSignature(req, testSecret)
+			assert.NoError(t, sig.Validate())
+			assert.True(t, sig.VerifyHMAC256())
+
+			req.Method = http.MethodPost
+			assert.False(t, sig.VerifyHMAC256())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package filesystem
+
+import (
+	"net/url"
+)
+
+type Options struct {
+	// URI is the base URL of the object links handed out to the clients.
+	URI *url.URL
+	// InternalURI is the base URL of the object links used by the
+	// internal services (non-public links). Defaults to URI.
+	InternalURI *url.URL
+
+	// Secret is the key used for signing the object links.
+	Secret []byte
+}
+
+func NewOptions(opts ...*Options) *Options {
+	opt := &Options{}
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		if o.URI != nil {
+			opt.URI = o.URI
+		}
+		if o.InternalURI != nil {
+			opt.InternalURI = o.InternalURI
+		}
+		if o.Secret != nil {
+			opt.Secret = o.Secret
+		}
+	}
+	return opt
+}
+
+func (opts *Options) SetURI(uri *url.URL) *Options {
+	opts.URI = uri
+	return opts
+}
+
+func (opts *Options) SetInternalURI(uri *url.URL) *Options {
+	opts.InternalURI = uri
+	return opts
+}
+
+func (opts *Options) SetSecret(secret []byte) *Options {
+	opts.Secret = secret
+	return opts
+}
@@ -114,6 +114,18 @@ func (c *client) StatObject(ctx context.Context, path string) (*storage.ObjectIn
 	return objStore.StatObject(ctx, path)
 }
 
+func (c *client) ListObjects(
+	ctx context.Context,
+	prefix string,
+	fn func(storage.ObjectInfo) error,
+) error {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return objStore.ListObjects(ctx, prefix, fn)
+}
+
 func (c *client) GetRequest(
 	ctx context.Context,
 	path string,
@@ -153,3 +165,66 @@ func (c *client) PutRequest(
 	}
 	return objStore.PutRequest(ctx, path, duration, public)
 }
+
+func (c *client) CreateMultipartUpload(
+	ctx context.Context,
+	path string,
+) (string, error) {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return objStore.CreateMultipartUpload(ctx, path)
+}
+
+func (c *client) PartRequest(
+	ctx context.Context,
+	path string,
+	uploadID string,
+	partNumber int,
+	duration time.Duration,
+	public bool,
+) (*model.Link, error) {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return objStore.PartRequest(ctx, path, uploadID, partNumber, duration, public)
+}
+
+func (c *client) ListParts(
+	ctx context.Context,
+	path string,
+	uploadID string,
+) ([]model.UploadPart, error) {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return objStore.ListParts(ctx, path, uploadID)
+}
+
+func (c *client) CompleteMultipartUpload(
+	ctx context.Context,
+	path string,
+	uploadID string,
+	parts []model.UploadPart,
+) error {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return objStore.CompleteMultipartUpload(ctx, path, uploadID, parts)
+}
+
+func (c *client) AbortMultipartUpload(
+	ctx context.Context,
+	path string,
+	uploadID string,
+) error {
+	objStore, err := c.clientFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return objStore.AbortMultipartUpload(ctx, path, uploadID)
+}
@@ -34,6 +34,70 @@ type ObjectStorage struct {
 	mock.Mock
 }
 
+// AbortMultipartUpload provides a mock function with given fields: ctx, path, uploadID
+func (_m *ObjectStorage) AbortMultipartUpload(ctx context.Context, path string, uploadID string) error {
+	ret := _m.Called(ctx, path, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbortMultipartUpload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, path, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteMultipartUpload provides a mock function with given fields: ctx, path, uploadID, parts
+func (_m *ObjectStorage) CompleteMultipartUpload(ctx context.Context, path string, uploadID string, parts []model.UploadPart) error {
+	ret := _m.Called(ctx, path, uploadID, parts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteMultipartUpload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []model.UploadPart) error); ok {
+		r0 = rf(ctx, path, uploadID, parts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMultipartUpload provides a mock function with given fields: ctx, path
+func (_m *ObjectStorage) CreateMultipartUpload(ctx context.Context, path string) (string, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMultipartUpload")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteObject provides a mock function with given fields: ctx, path
 func (_m *ObjectStorage) DeleteObject(ctx context.Context, path string) error {
 	ret := _m.Called(ctx, path)
@@ -160,6 +224,84 @@ func (_m *ObjectStorage) HealthCheck(ctx context.Context) error {
 	return r0
 }
 
+// ListObjects provides a mock function with given fields: ctx, prefix, fn
+func (_m *ObjectStorage) ListObjects(ctx context.Context, prefix string, fn func(storage.ObjectInfo) error) error {
+	ret := _m.Called(ctx, prefix, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListObjects")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(storage.ObjectInfo) error) error); ok {
+		r0 = rf(ctx, prefix, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListParts provides a mock function with given fields: ctx, path, uploadID
+func (_m *ObjectStorage) ListParts(ctx context.Context, path string, uploadID string) ([]model.UploadPart, error) {
+	ret := _m.Called(ctx, path, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListParts")
+	}
+
+	var r0 []model.UploadPart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]model.UploadPart, error)); ok {
+		return rf(ctx, path, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []model.UploadPart); ok {
+		r0 = rf(ctx, path, uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UploadPart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, path, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PartRequest provides a mock function with given fields: ctx, path, uploadID, partNumber, duration, public
+func (_m *ObjectStorage) PartRequest(ctx context.Context, path string, uploadID string, partNumber int, duration time.Duration, public bool) (*model.Link, error) {
+	ret := _m.Called(ctx, path, uploadID, partNumber, duration, public)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PartRequest")
+	}
+
+	var r0 *model.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration, bool) (*model.Link, error)); ok {
+		return rf(ctx, path, uploadID, partNumber, duration, public)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, time.Duration, bool) *model.Link); ok {
+		r0 = rf(ctx, path, uploadID, partNumber, duration, public)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, time.Duration, bool) error); ok {
+		r1 = rf(ctx, path, uploadID, partNumber, duration, public)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutObject provides a mock function with given fields: ctx, path, src
 func (_m *ObjectStorage) PutObject(ctx context.Context, path string, src io.Reader) error {
 	ret := _m.Called(ctx, path, src)
@@ -25,6 +25,9 @@ import (
 
 var (
 	ErrObjectNotFound = errors.New("object not found")
+	// ErrMultipartNotSupported is returned by backends that cannot
+	// assemble objects from separately uploaded parts.
+	ErrMultipartNotSupported = errors.New("multipart uploads are not supported")
 )
 
 // ObjectStorage allows to store and manage large files
@@ -36,6 +39,9 @@ type ObjectStorage interface {
 	PutObject(ctx context.Context, path string, src io.Reader) error
 	DeleteObject(ctx context.Context, path string) error
 	StatObject(ctx context.Context, path string) (*ObjectInfo, error)
+	// ListObjects calls fn for every object with the given path prefix;
+	// the listing stops at the first error returned by fn.
+	ListObjects(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
 
 	// The following interface generates signed URLs.
 	GetRequest(ctx context.Context, path string, filename string,
@@ -44,6 +50,16 @@ type ObjectStorage interface {
 		duration time.Duration, public bool) (*model.Link, error)
 	PutRequest(ctx context.Context, path string,
 		duration time.Duration, public bool) (*model.Link, error)
+
+	// The following interface uploads an object in parts; the parts are
+	// assembled in ascending part number order on completion.
+	CreateMultipartUpload(ctx context.Context, path string) (uploadID string, err error)
+	PartRequest(ctx context.Context, path, uploadID string, partNumber int,
+		duration time.Duration, public bool) (*model.Link, error)
+	ListParts(ctx context.Context, path, uploadID string) ([]model.UploadPart, error)
+	CompleteMultipartUpload(ctx context.Context, path, uploadID string,
+		parts []model.UploadPart) error
+	AbortMultipartUpload(ctx context.Context, path, uploadID string) error
 }
 
 type ObjectInfo struct {
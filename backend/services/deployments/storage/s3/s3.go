@@ -348,6 +348,43 @@ func (s *SimpleStorageService) StatObject(
 	}, nil
 }
 
+// ListObjects lists the objects with the given prefix page by page
+func (s *SimpleStorageService) ListObjects(
+	ctx context.Context,
+	prefix string,
+	fn func(storage.ObjectInfo) error,
+) error {
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := &s3.ListObjectsV2Input{
+		Bucket: opts.BucketName,
+		Prefix: aws.String(prefix),
+
+		RequestPayer: types.RequestPayerRequester,
+	}
+	pager := s3.NewListObjectsV2Paginator(s.client, params)
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx, opts.options)
+		if err != nil {
+			return errors.WithMessage(err, "s3: error listing objects")
+		}
+		for _, obj := range page.Contents {
+			err = fn(storage.ObjectInfo{
+				Path:         aws.ToString(obj.Key),
+				Size:         obj.Size,
+				LastModified: obj.LastModified,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func fillBuffer(b []byte, r io.Reader) (int, error) {
 	var offset int
 	var err error
@@ -600,6 +637,185 @@ func (s *SimpleStorageService) PutRequest(
 	return buildLink(req, signDate, expireAfter, opts.ProxyURI)
 }
 
+// CreateMultipartUpload initiates a multipart upload of the object
+func (s *SimpleStorageService) CreateMultipartUpload(
+	ctx context.Context,
+	path string,
+) (string, error) {
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	params := &s3.CreateMultipartUploadInput{
+		Bucket:      opts.BucketName,
+		Key:         aws.String(path),
+		ContentType: s.contentType,
+	}
+	rsp, err := s.client.CreateMultipartUpload(ctx, params, opts.options)
+	if err != nil {
+		return "", errors.WithMessage(err, "s3: error creating multipart upload")
+	}
+	return aws.ToString(rsp.UploadId), nil
+}
+
+// PartRequest returns a presigned request uploading a part of a
+// multipart upload
+func (s *SimpleStorageService) PartRequest(
+	ctx context.Context,
+	path string,
+	uploadID string,
+	partNumber int,
+	expireAfter time.Duration,
+	public bool,
+) (*model.Link, error) {
+
+	expireAfter = capDurationToLimits(expireAfter).Truncate(time.Second)
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !public {
+		// Copy the options to prevent overwriting global settings
+		optsCopy := *opts
+		optsCopy.ExternalURI = nil
+		optsCopy.ProxyURI = nil
+		opts = &optsCopy
+	}
+
+	params := &s3.UploadPartInput{
+		Bucket:     opts.BucketName,
+		Key:        aws.String(path),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(int32(partNumber)),
+	}
+
+	signDate := time.Now()
+	req, err := s.presignClient.PresignUploadPart(
+		ctx,
+		params,
+		opts.presignOptions,
+		s3.WithPresignExpires(expireAfter),
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "s3: failed to sign upload part request")
+	}
+	return buildLink(req, signDate, expireAfter, opts.ProxyURI)
+}
+
+// ListParts lists the parts received for the multipart upload
+func (s *SimpleStorageService) ListParts(
+	ctx context.Context,
+	path string,
+	uploadID string,
+) ([]model.UploadPart, error) {
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &s3.ListPartsInput{
+		Bucket:   opts.BucketName,
+		Key:      aws.String(path),
+		UploadId: aws.String(uploadID),
+
+		RequestPayer: types.RequestPayerRequester,
+	}
+	parts := []model.UploadPart{}
+	pager := s3.NewListPartsPaginator(s.client, params)
+	for pager.HasMorePages() {
+		page, err := pager.NextPage(ctx, opts.options)
+		var rspErr *awsHttp.ResponseError
+		if errors.As(err, &rspErr) {
+			if rspErr.Response.StatusCode == http.StatusNotFound {
+				err = storage.ErrObjectNotFound
+			}
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "s3: error listing upload parts")
+		}
+		for _, part := range page.Parts {
+			parts = append(parts, model.UploadPart{
+				Number:       int(aws.ToInt32(part.PartNumber)),
+				Size:         aws.ToInt64(part.Size),
+				ETag:         aws.ToString(part.ETag),
+				LastModified: part.LastModified,
+			})
+		}
+	}
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the object from the uploaded parts
+func (s *SimpleStorageService) CompleteMultipartUpload(
+	ctx context.Context,
+	path string,
+	uploadID string,
+	parts []model.UploadPart,
+) error {
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			ETag:       aws.String(part.ETag),
+			PartNumber: aws.Int32(int32(part.Number)),
+		}
+	}
+	params := &s3.CompleteMultipartUploadInput{
+		Bucket:   opts.BucketName,
+		Key:      aws.String(path),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	}
+	_, err = s.client.CompleteMultipartUpload(ctx, params, opts.options)
+	var rspErr *awsHttp.ResponseError
+	if errors.As(err, &rspErr) {
+		if rspErr.Response.StatusCode == http.StatusNotFound {
+			err = storage.ErrObjectNotFound
+		}
+	}
+	if err != nil {
+		return errors.WithMessage(err, "s3: error completing multipart upload")
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards the multipart upload and its parts
+func (s *SimpleStorageService) AbortMultipartUpload(
+	ctx context.Context,
+	path string,
+	uploadID string,
+) error {
+	opts, err := s.optionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := &s3.AbortMultipartUploadInput{
+		Bucket:   opts.BucketName,
+		Key:      aws.String(path),
+		UploadId: aws.String(uploadID),
+
+		RequestPayer: types.RequestPayerRequester,
+	}
+	_, err = s.client.AbortMultipartUpload(ctx, params, opts.options)
+	var rspErr *awsHttp.ResponseError
+	if errors.As(err, &rspErr) {
+		if rspErr.Response.StatusCode == http.StatusNotFound {
+			err = storage.ErrObjectNotFound
+		}
+	}
+	if err != nil {
+		return errors.WithMessage(err, "s3: error aborting multipart upload")
+	}
+	return nil
+}
+
 // GetRequest duration is limited to 7 days (AWS limitation)
 func (s *SimpleStorageService) GetRequest(
 	ctx context.Context,
@@ -47,6 +47,22 @@ type DataStore interface {
 	GetStorageSettings(ctx context.Context) (*model.StorageSettings, error)
 	SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error
 
+	//artifact verification
+	ListVerificationKeys(ctx context.Context) ([]model.VerificationKey, error)
+	InsertVerificationKey(ctx context.Context, key *model.VerificationKey) error
+	DeleteVerificationKey(ctx context.Context, id string) error
+	GetArtifactVerificationSettings(
+		ctx context.Context,
+	) (*model.ArtifactVerificationSettings, error)
+	SetArtifactVerificationSettings(
+		ctx context.Context,
+		settings *model.ArtifactVerificationSettings,
+	) error
+
+	//download links
+	GetDownloadSettings(ctx context.Context) (*model.DownloadSettings, error)
+	SetDownloadSettings(ctx context.Context, settings *model.DownloadSettings) error
+
 	//tenants
 	ProvisionTenant(ctx context.Context, tenantId string) error
 
@@ -55,12 +71,14 @@ type DataStore interface {
 	Update(ctx context.Context, image *model.Image) (bool, error)
 	InsertImage(ctx context.Context, image *model.Image) error
 	FindImageByID(ctx context.Context, id string) (*model.Image, error)
+	FindImageByChecksum(ctx context.Context, checksum string) (*model.Image, error)
 	IsArtifactUnique(ctx context.Context, artifactName string,
 		deviceTypesCompatible []string) (bool, error)
 	DeleteImage(ctx context.Context, id string) error
 	ListImages(ctx context.Context, filt *model.ReleaseOrImageFilter) ([]*model.Image, int, error)
 	ListImagesV2(ctx context.Context, filt *model.ImageFilter) ([]*model.Image, error)
 	DeleteImagesByNames(ctx context.Context, names []string) error
+	FindUnusedImages(ctx context.Context, modifiedBefore time.Time) (Iterator[model.Image], error)
 
 	//artifact getter
 	ImagesByName(ctx context.Context,
@@ -70,10 +88,24 @@ type DataStore interface {
 	ImageByNameAndDeviceType(ctx context.Context,
 		name, deviceType string) (*model.Image, error)
 
+	// delta artifacts
+	InsertDelta(ctx context.Context, delta *model.Delta) error
+	FindDelta(ctx context.Context, sourceImageID, targetImageID string) (*model.Delta, error)
+	UpdateDeltaStatus(ctx context.Context, id string, update model.DeltaStatusUpdate) error
+	RetryDelta(ctx context.Context, id string, attempts int) error
+	DeltaExists(ctx context.Context, id string) (bool, error)
+
 	// upload intents
 	InsertUploadIntent(ctx context.Context, link *model.UploadLink) error
 	UpdateUploadIntentStatus(ctx context.Context, id string, from, to model.LinkStatus) error
 	FindUploadLinks(ctx context.Context, expired time.Time) (Iterator[model.UploadLink], error)
+	FindUploadLink(ctx context.Context, id string) (*model.UploadLink, error)
+	UpdateUploadIntentMetadata(
+		ctx context.Context,
+		id string,
+		metadata *model.DirectUploadMetadata,
+	) error
+	RetryUploadIntent(ctx context.Context, id string, retries uint) error
 
 	//device deployment log
 	SaveDeviceDeploymentLog(ctx context.Context, log model.DeploymentLog) error
@@ -102,6 +134,7 @@ type DataStore interface {
 	) (model.DeviceDeploymentStatus, error)
 	UpdateDeviceDeploymentLogAvailability(ctx context.Context,
 		deviceID string, deploymentID string, log bool) error
+	IncrementDeviceDeploymentAttempts(ctx context.Context, id string, inc uint) error
 	AssignArtifact(
 		ctx context.Context,
 		deviceID string,
@@ -114,6 +147,8 @@ type DataStore interface {
 		deploymentID string) ([]model.DeviceDeployment, error)
 	GetDevicesListForDeployment(ctx context.Context,
 		query ListQuery) ([]model.DeviceDeployment, int, error)
+	FindDevicesForDeployment(ctx context.Context,
+		query ListQuery) (Iterator[model.DeviceDeployment], error)
 	GetDeviceDeploymentsForDevice(ctx context.Context,
 		query ListQueryDeviceDeployments) ([]model.DeviceDeployment, int, error)
 	HasDeploymentForDevice(ctx context.Context,
@@ -136,6 +171,16 @@ type DataStore interface {
 		ID string,
 		request *model.DeploymentNextRequest,
 	) error
+	SaveDeviceDeploymentObject(
+		ctx context.Context,
+		ID string,
+		object *model.DeviceDeploymentObject,
+	) error
+	SaveDeviceDeploymentIllegalTransition(
+		ctx context.Context,
+		ID string,
+		transition model.DeviceDeploymentTransition,
+	) error
 
 	// deployments
 	InsertDeployment(ctx context.Context, deployment *model.Deployment) error
@@ -162,8 +207,15 @@ type DataStore interface {
 		status model.DeploymentStatus,
 		now time.Time,
 	) error
+	UpdateDeploymentStatus(
+		ctx context.Context,
+		id string,
+		from, to model.DeploymentStatus,
+		now time.Time,
+	) error
 	FindNewerActiveDeployment(ctx context.Context,
 		createdAfter *time.Time, deviceID string) (*model.Deployment, error)
+	AddDeviceToDeployment(ctx context.Context, deploymentID string, deviceID string) error
 	FindNewerActiveDeployments(ctx context.Context,
 		createdAfter *time.Time, skip, limit int) ([]*model.Deployment, error)
 	ExistUnfinishedByArtifactId(ctx context.Context, id string) (bool, error)
@@ -171,6 +223,17 @@ type DataStore interface {
 	ExistByArtifactId(ctx context.Context, id string) (bool, error)
 	SetDeploymentDeviceCount(ctx context.Context, deploymentID string, count int) error
 	IncrementDeploymentDeviceCount(ctx context.Context, deploymentID string, increment int) error
+	IncrementDeploymentPhaseDeviceCount(
+		ctx context.Context,
+		deploymentID string,
+		phaseID string,
+		maxDevices int,
+	) error
+	DecrementDeploymentPhaseDeviceCount(
+		ctx context.Context,
+		deploymentID string,
+		phaseID string,
+	) error
 	IncrementDeploymentTotalSize(ctx context.Context, deploymentID string, increment int64) error
 	DeviceCountByDeployment(ctx context.Context, id string) (int, error)
 	UpdateDeploymentsWithArtifactName(
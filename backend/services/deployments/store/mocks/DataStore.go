@@ -50,6 +50,24 @@ func (_m *DataStore) AbortDeviceDeployments(ctx context.Context, deploymentID st
 	return r0
 }
 
+// AddDeviceToDeployment provides a mock function with given fields: ctx, deploymentID, deviceID
+func (_m *DataStore) AddDeviceToDeployment(ctx context.Context, deploymentID string, deviceID string) error {
+	ret := _m.Called(ctx, deploymentID, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeviceToDeployment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, deploymentID, deviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AggregateDeviceDeploymentByStatus provides a mock function with given fields: ctx, id
 func (_m *DataStore) AggregateDeviceDeploymentByStatus(ctx context.Context, id string) (model.Stats, error) {
 	ret := _m.Called(ctx, id)
@@ -116,6 +134,24 @@ func (_m *DataStore) DecommissionDeviceDeployments(ctx context.Context, deviceId
 	return r0
 }
 
+// DecrementDeploymentPhaseDeviceCount provides a mock function with given fields: ctx, deploymentID, phaseID
+func (_m *DataStore) DecrementDeploymentPhaseDeviceCount(ctx context.Context, deploymentID string, phaseID string) error {
+	ret := _m.Called(ctx, deploymentID, phaseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementDeploymentPhaseDeviceCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, deploymentID, phaseID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDeployment provides a mock function with given fields: ctx, id
 func (_m *DataStore) DeleteDeployment(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -206,6 +242,52 @@ func (_m *DataStore) DeleteReleasesByNames(ctx context.Context, names []string)
 	return r0
 }
 
+// DeleteVerificationKey provides a mock function with given fields: ctx, id
+func (_m *DataStore) DeleteVerificationKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerificationKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeltaExists provides a mock function with given fields: ctx, id
+func (_m *DataStore) DeltaExists(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeltaExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceCountByDeployment provides a mock function with given fields: ctx, id
 func (_m *DataStore) DeviceCountByDeployment(ctx context.Context, id string) (int, error) {
 	ret := _m.Called(ctx, id)
@@ -346,6 +428,36 @@ func (_m *DataStore) Exists(ctx context.Context, id string) (bool, error) {
 	return r0, r1
 }
 
+// FindDelta provides a mock function with given fields: ctx, sourceImageID, targetImageID
+func (_m *DataStore) FindDelta(ctx context.Context, sourceImageID string, targetImageID string) (*model.Delta, error) {
+	ret := _m.Called(ctx, sourceImageID, targetImageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelta")
+	}
+
+	var r0 *model.Delta
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Delta, error)); ok {
+		return rf(ctx, sourceImageID, targetImageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Delta); ok {
+		r0 = rf(ctx, sourceImageID, targetImageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Delta)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, sourceImageID, targetImageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindDeploymentByID provides a mock function with given fields: ctx, id
 func (_m *DataStore) FindDeploymentByID(ctx context.Context, id string) (*model.Deployment, error) {
 	ret := _m.Called(ctx, id)
@@ -450,6 +562,66 @@ func (_m *DataStore) FindDeployments(ctx context.Context, query model.Query) ([]
 	return r0, r1, r2
 }
 
+// FindDevicesForDeployment provides a mock function with given fields: ctx, query
+func (_m *DataStore) FindDevicesForDeployment(ctx context.Context, query store.ListQuery) (store.Iterator[model.DeviceDeployment], error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDevicesForDeployment")
+	}
+
+	var r0 store.Iterator[model.DeviceDeployment]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQuery) (store.Iterator[model.DeviceDeployment], error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, store.ListQuery) store.Iterator[model.DeviceDeployment]); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator[model.DeviceDeployment])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, store.ListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindImageByChecksum provides a mock function with given fields: ctx, checksum
+func (_m *DataStore) FindImageByChecksum(ctx context.Context, checksum string) (*model.Image, error) {
+	ret := _m.Called(ctx, checksum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindImageByChecksum")
+	}
+
+	var r0 *model.Image
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Image, error)); ok {
+		return rf(ctx, checksum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Image); ok {
+		r0 = rf(ctx, checksum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Image)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, checksum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindImageByID provides a mock function with given fields: ctx, id
 func (_m *DataStore) FindImageByID(ctx context.Context, id string) (*model.Image, error) {
 	ret := _m.Called(ctx, id)
@@ -630,6 +802,66 @@ func (_m *DataStore) FindUnfinishedByID(ctx context.Context, id string) (*model.
 	return r0, r1
 }
 
+// FindUnusedImages provides a mock function with given fields: ctx, modifiedBefore
+func (_m *DataStore) FindUnusedImages(ctx context.Context, modifiedBefore time.Time) (store.Iterator[model.Image], error) {
+	ret := _m.Called(ctx, modifiedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnusedImages")
+	}
+
+	var r0 store.Iterator[model.Image]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (store.Iterator[model.Image], error)); ok {
+		return rf(ctx, modifiedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) store.Iterator[model.Image]); ok {
+		r0 = rf(ctx, modifiedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Iterator[model.Image])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, modifiedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUploadLink provides a mock function with given fields: ctx, id
+func (_m *DataStore) FindUploadLink(ctx context.Context, id string) (*model.UploadLink, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUploadLink")
+	}
+
+	var r0 *model.UploadLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UploadLink, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UploadLink); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UploadLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUploadLinks provides a mock function with given fields: ctx, expired
 func (_m *DataStore) FindUploadLinks(ctx context.Context, expired time.Time) (store.Iterator[model.UploadLink], error) {
 	ret := _m.Called(ctx, expired)
@@ -690,6 +922,36 @@ func (_m *DataStore) GetActiveDeploymentIDsByArtifactNames(ctx context.Context,
 	return r0, r1
 }
 
+// GetArtifactVerificationSettings provides a mock function with given fields: ctx
+func (_m *DataStore) GetArtifactVerificationSettings(ctx context.Context) (*model.ArtifactVerificationSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtifactVerificationSettings")
+	}
+
+	var r0 *model.ArtifactVerificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.ArtifactVerificationSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.ArtifactVerificationSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ArtifactVerificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeviceDeployment provides a mock function with given fields: ctx, deploymentID, deviceID, includeDeleted
 func (_m *DataStore) GetDeviceDeployment(ctx context.Context, deploymentID string, deviceID string, includeDeleted bool) (*model.DeviceDeployment, error) {
 	ret := _m.Called(ctx, deploymentID, deviceID, includeDeleted)
@@ -884,6 +1146,36 @@ func (_m *DataStore) GetDevicesListForDeployment(ctx context.Context, query stor
 	return r0, r1, r2
 }
 
+// GetDownloadSettings provides a mock function with given fields: ctx
+func (_m *DataStore) GetDownloadSettings(ctx context.Context) (*model.DownloadSettings, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDownloadSettings")
+	}
+
+	var r0 *model.DownloadSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.DownloadSettings, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.DownloadSettings); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DownloadSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastDeviceDeploymentStatus provides a mock function with given fields: ctx, devicesIds
 func (_m *DataStore) GetLastDeviceDeploymentStatus(ctx context.Context, devicesIds []string) ([]model.DeviceDeploymentLastStatus, error) {
 	ret := _m.Called(ctx, devicesIds)
@@ -1237,6 +1529,24 @@ func (_m *DataStore) IncrementDeploymentDeviceCount(ctx context.Context, deploym
 	return r0
 }
 
+// IncrementDeploymentPhaseDeviceCount provides a mock function with given fields: ctx, deploymentID, phaseID, maxDevices
+func (_m *DataStore) IncrementDeploymentPhaseDeviceCount(ctx context.Context, deploymentID string, phaseID string, maxDevices int) error {
+	ret := _m.Called(ctx, deploymentID, phaseID, maxDevices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementDeploymentPhaseDeviceCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, deploymentID, phaseID, maxDevices)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // IncrementDeploymentTotalSize provides a mock function with given fields: ctx, deploymentID, increment
 func (_m *DataStore) IncrementDeploymentTotalSize(ctx context.Context, deploymentID string, increment int64) error {
 	ret := _m.Called(ctx, deploymentID, increment)
@@ -1255,6 +1565,42 @@ func (_m *DataStore) IncrementDeploymentTotalSize(ctx context.Context, deploymen
 	return r0
 }
 
+// IncrementDeviceDeploymentAttempts provides a mock function with given fields: ctx, id, inc
+func (_m *DataStore) IncrementDeviceDeploymentAttempts(ctx context.Context, id string, inc uint) error {
+	ret := _m.Called(ctx, id, inc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementDeviceDeploymentAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, id, inc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertDelta provides a mock function with given fields: ctx, delta
+func (_m *DataStore) InsertDelta(ctx context.Context, delta *model.Delta) error {
+	ret := _m.Called(ctx, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDelta")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Delta) error); ok {
+		r0 = rf(ctx, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertDeployment provides a mock function with given fields: ctx, deployment
 func (_m *DataStore) InsertDeployment(ctx context.Context, deployment *model.Deployment) error {
 	ret := _m.Called(ctx, deployment)
@@ -1352,6 +1698,24 @@ func (_m *DataStore) InsertUploadIntent(ctx context.Context, link *model.UploadL
 	return r0
 }
 
+// InsertVerificationKey provides a mock function with given fields: ctx, key
+func (_m *DataStore) InsertVerificationKey(ctx context.Context, key *model.VerificationKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertVerificationKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VerificationKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // IsArtifactUnique provides a mock function with given fields: ctx, artifactName, deviceTypesCompatible
 func (_m *DataStore) IsArtifactUnique(ctx context.Context, artifactName string, deviceTypesCompatible []string) (bool, error) {
 	ret := _m.Called(ctx, artifactName, deviceTypesCompatible)
@@ -1544,6 +1908,36 @@ func (_m *DataStore) ListSoftwareTags(ctx context.Context, filter *model.Softwar
 	return r0, r1
 }
 
+// ListVerificationKeys provides a mock function with given fields: ctx
+func (_m *DataStore) ListVerificationKeys(ctx context.Context) ([]model.VerificationKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVerificationKeys")
+	}
+
+	var r0 []model.VerificationKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.VerificationKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.VerificationKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.VerificationKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Ping provides a mock function with given fields: ctx
 func (_m *DataStore) Ping(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -1598,6 +1992,60 @@ func (_m *DataStore) ReplaceReleaseTags(ctx context.Context, releaseName string,
 	return r0
 }
 
+// RetryDelta provides a mock function with given fields: ctx, id, attempts
+func (_m *DataStore) RetryDelta(ctx context.Context, id string, attempts int) error {
+	ret := _m.Called(ctx, id, attempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDelta")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, id, attempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryUploadIntent provides a mock function with given fields: ctx, id, retries
+func (_m *DataStore) RetryUploadIntent(ctx context.Context, id string, retries uint) error {
+	ret := _m.Called(ctx, id, retries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryUploadIntent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, id, retries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveDeviceDeploymentIllegalTransition provides a mock function with given fields: ctx, ID, transition
+func (_m *DataStore) SaveDeviceDeploymentIllegalTransition(ctx context.Context, ID string, transition model.DeviceDeploymentTransition) error {
+	ret := _m.Called(ctx, ID, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeviceDeploymentIllegalTransition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeviceDeploymentTransition) error); ok {
+		r0 = rf(ctx, ID, transition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveDeviceDeploymentLog provides a mock function with given fields: ctx, log
 func (_m *DataStore) SaveDeviceDeploymentLog(ctx context.Context, log model.DeploymentLog) error {
 	ret := _m.Called(ctx, log)
@@ -1616,6 +2064,24 @@ func (_m *DataStore) SaveDeviceDeploymentLog(ctx context.Context, log model.Depl
 	return r0
 }
 
+// SaveDeviceDeploymentObject provides a mock function with given fields: ctx, ID, object
+func (_m *DataStore) SaveDeviceDeploymentObject(ctx context.Context, ID string, object *model.DeviceDeploymentObject) error {
+	ret := _m.Called(ctx, ID, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeviceDeploymentObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.DeviceDeploymentObject) error); ok {
+		r0 = rf(ctx, ID, object)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveDeviceDeploymentRequest provides a mock function with given fields: ctx, ID, request
 func (_m *DataStore) SaveDeviceDeploymentRequest(ctx context.Context, ID string, request *model.DeploymentNextRequest) error {
 	ret := _m.Called(ctx, ID, request)
@@ -1670,6 +2136,24 @@ func (_m *DataStore) SaveUpdateTypes(ctx context.Context, updateTypes []string)
 	return r0
 }
 
+// SetArtifactVerificationSettings provides a mock function with given fields: ctx, settings
+func (_m *DataStore) SetArtifactVerificationSettings(ctx context.Context, settings *model.ArtifactVerificationSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArtifactVerificationSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ArtifactVerificationSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDeploymentDeviceCount provides a mock function with given fields: ctx, deploymentID, count
 func (_m *DataStore) SetDeploymentDeviceCount(ctx context.Context, deploymentID string, count int) error {
 	ret := _m.Called(ctx, deploymentID, count)
@@ -1706,6 +2190,24 @@ func (_m *DataStore) SetDeploymentStatus(ctx context.Context, id string, status
 	return r0
 }
 
+// SetDownloadSettings provides a mock function with given fields: ctx, settings
+func (_m *DataStore) SetDownloadSettings(ctx context.Context, settings *model.DownloadSettings) error {
+	ret := _m.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDownloadSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DownloadSettings) error); ok {
+		r0 = rf(ctx, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetStorageSettings provides a mock function with given fields: ctx, storageSettings
 func (_m *DataStore) SetStorageSettings(ctx context.Context, storageSettings *model.StorageSettings) error {
 	ret := _m.Called(ctx, storageSettings)
@@ -1752,6 +2254,42 @@ func (_m *DataStore) Update(ctx context.Context, image *model.Image) (bool, erro
 	return r0, r1
 }
 
+// UpdateDeltaStatus provides a mock function with given fields: ctx, id, update
+func (_m *DataStore) UpdateDeltaStatus(ctx context.Context, id string, update model.DeltaStatusUpdate) error {
+	ret := _m.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeltaStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeltaStatusUpdate) error); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeploymentStatus provides a mock function with given fields: ctx, id, from, to, now
+func (_m *DataStore) UpdateDeploymentStatus(ctx context.Context, id string, from model.DeploymentStatus, to model.DeploymentStatus, now time.Time) error {
+	ret := _m.Called(ctx, id, from, to, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeploymentStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.DeploymentStatus, model.DeploymentStatus, time.Time) error); ok {
+		r0 = rf(ctx, id, from, to, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDeploymentsWithArtifactName provides a mock function with given fields: ctx, artifactName, artifactIDs
 func (_m *DataStore) UpdateDeploymentsWithArtifactName(ctx context.Context, artifactName string, artifactIDs []string) error {
 	ret := _m.Called(ctx, artifactName, artifactIDs)
@@ -1918,6 +2456,24 @@ func (_m *DataStore) UpdateStatsInc(ctx context.Context, id string, stateFrom mo
 	return r0, r1
 }
 
+// UpdateUploadIntentMetadata provides a mock function with given fields: ctx, id, metadata
+func (_m *DataStore) UpdateUploadIntentMetadata(ctx context.Context, id string, metadata *model.DirectUploadMetadata) error {
+	ret := _m.Called(ctx, id, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUploadIntentMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.DirectUploadMetadata) error); ok {
+		r0 = rf(ctx, id, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUploadIntentStatus provides a mock function with given fields: ctx, id, from, to
 func (_m *DataStore) UpdateUploadIntentStatus(ctx context.Context, id string, from model.LinkStatus, to model.LinkStatus) error {
 	ret := _m.Called(ctx, id, from, to)
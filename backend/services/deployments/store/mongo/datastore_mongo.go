@@ -14,8 +14,11 @@
 package mongo
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"math"
 	"regexp"
 	"sort"
@@ -51,11 +54,17 @@ const (
 	CollectionUploadIntents        = "uploads"
 	CollectionReleases             = "releases"
 	CollectionUpdateTypes          = "update_types"
+	CollectionDeltas               = "deltas"
+	CollectionVerificationKeys     = "verification_keys"
 )
 
 const DefaultDocumentLimit = 20
 const maxCountDocuments = int64(10000)
 
+// maxIllegalTransitions is the number of rejected status transitions
+// recorded for each device deployment
+const maxIllegalTransitions = 10
+
 // Internal status codes from
 // https://github.com/mongodb/mongo/blob/4.4/src/mongo/base/error_codes.yml
 const (
@@ -110,6 +119,15 @@ var (
 	// Indexes 1.2.17
 	IndexNameDeploymentName = "deployment_name"
 
+	// Indexes 1.2.18
+	IndexNameDeltaSourceTarget = "delta_source_target"
+
+	// Indexes 1.2.19
+	IndexNameImageChecksum = "image_checksum"
+
+	// Indexes 1.2.20
+	IndexNameDeploymentLogExpireAt = "deployment_log_expire_at"
+
 	StorageIndexes = mongo.IndexModel{
 		// NOTE: Keys should be bson.D as element
 		//       order matters!
@@ -292,6 +310,36 @@ var (
 		Options: mopts.Index().
 			SetName(IndexNameDeploymentName),
 	}
+
+	// 1.2.18
+	IndexDeltaSourceTarget = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyDeltaSourceImageId, Value: 1},
+			{Key: StorageKeyDeltaTargetImageId, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameDeltaSourceTarget).
+			SetUnique(true),
+	}
+
+	// 1.2.19
+	IndexImageChecksum = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyImageChecksum, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameImageChecksum).
+			SetSparse(true).
+			SetUnique(true),
+	}
+	IndexDeploymentLogExpireAt = mongo.IndexModel{
+		Keys: bson.D{
+			{Key: StorageKeyDeviceDeploymentLogExpireAt, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(IndexNameDeploymentLogExpireAt).
+			SetExpireAfterSeconds(0),
+	}
 )
 
 // Errors
@@ -301,6 +349,7 @@ var (
 	ErrImagesStorageInvalidName         = errors.New("Invalid name")
 	ErrImagesStorageInvalidDeviceType   = errors.New("Invalid device type")
 	ErrImagesStorageInvalidImage        = errors.New("Invalid image")
+	ErrImagesStorageInvalidChecksum     = errors.New("Invalid checksum")
 
 	ErrStorageInvalidDeviceDeployment = errors.New("Invalid device deployment")
 
@@ -319,6 +368,15 @@ var (
 	ErrConflictingDeployment = errors.New(
 		"an active deployment with the same parameter already exists",
 	)
+	ErrDeploymentPhaseFull = errors.New(
+		"the deployment phase does not admit more devices",
+	)
+	ErrConflictingDelta = errors.New(
+		"a delta artifact between the same images already exists",
+	)
+	ErrConflictingChecksum = errors.New(
+		"an artifact with the same checksum already exists",
+	)
 )
 
 // Database keys
@@ -327,11 +385,15 @@ const (
 	StorageKeyId       = "_id"
 	StorageKeyTenantId = "tenant_id"
 
+	StorageKeyUploadLinkMetadata = "metadata"
+	StorageKeyUploadLinkRetries  = "retries"
+
 	StorageKeyImageProvides    = "meta_artifact.provides"
 	StorageKeyImageProvidesIdx = "meta_artifact.provides_idx"
 	StorageKeyImageDepends     = "meta_artifact.depends"
 	StorageKeyImageDependsIdx  = "meta_artifact.depends_idx"
 	StorageKeyImageSize        = "size"
+	StorageKeyImageChecksum    = "checksum"
 	StorageKeyImageDeviceTypes = "meta_artifact.device_types_compatible"
 	StorageKeyImageName        = "meta_artifact.name"
 	StorageKeyUpdateType       = "meta_artifact.updates.typeinfo.type"
@@ -364,6 +426,8 @@ const (
 		StorageKeyImageProvidesIdx
 
 	StorageKeyDeviceDeploymentLogMessages = "messages"
+	StorageKeyDeviceDeploymentLogData     = "data"
+	StorageKeyDeviceDeploymentLogExpireAt = "expire_at"
 
 	StorageKeyDeviceDeploymentAssignedImage   = "image"
 	StorageKeyDeviceDeploymentAssignedImageId = StorageKeyDeviceDeploymentAssignedImage +
@@ -380,7 +444,11 @@ const (
 	StorageKeyDeviceDeploymentIsLogAvailable = "log"
 	StorageKeyDeviceDeploymentArtifact       = "image"
 	StorageKeyDeviceDeploymentRequest        = "request"
+	StorageKeyDeviceDeploymentObject         = "object"
 	StorageKeyDeviceDeploymentDeleted        = "deleted"
+	StorageKeyDeviceDeploymentAttempts       = "attempts"
+
+	StorageKeyDeviceDeploymentIllegalTransitions = "illegal_transitions"
 
 	StorageKeyDeploymentName                = "deploymentconstructor.name"
 	StorageKeyDeploymentArtifactName        = "deploymentconstructor.artifactname"
@@ -390,6 +458,7 @@ const (
 	StorageKeyDeploymentStatus              = "status"
 	StorageKeyDeploymentCreated             = "created"
 	StorageKeyDeploymentDeviceList          = "device_list"
+	StorageKeyDeploymentContinuous          = "deploymentconstructor.continuous"
 	StorageKeyDeploymentStatsCreated        = "created"
 	StorageKeyDeploymentFinished            = "finished"
 	StorageKeyDeploymentArtifacts           = "artifacts"
@@ -397,6 +466,16 @@ const (
 	StorageKeyDeploymentMaxDevices          = "max_devices"
 	StorageKeyDeploymentType                = "type"
 	StorageKeyDeploymentTotalSize           = "statistics.total_size"
+	StorageKeyDeploymentPhases              = "phases"
+	StorageKeyDeploymentPhaseId             = "id"
+	StorageKeyDeploymentPhaseDeviceCount    = "device_count"
+
+	StorageKeyDeltaSourceImageId = "source_image_id"
+	StorageKeyDeltaTargetImageId = "target_image_id"
+	StorageKeyDeltaStatus        = "status"
+	StorageKeyDeltaSize          = "size"
+	StorageKeyDeltaModified      = "modified"
+	StorageKeyDeltaAttempts      = "attempts"
 
 	StorageKeyStorageSettingsDefaultID      = "settings"
 	StorageKeyStorageSettingsBucket         = "bucket"
@@ -409,6 +488,9 @@ const (
 	StorageKeyStorageSettingsForcePathStyle = "force_path_style"
 	StorageKeyStorageSettingsUseAccelerate  = "use_accelerate"
 
+	StorageKeyArtifactVerificationSettingsID = "artifact_verification"
+	StorageKeyDownloadSettingsID             = "download"
+
 	StorageKeyStorageReleaseUpdateTypes = "update_types"
 
 	ArtifactDependsDeviceType = "device_type"
@@ -798,6 +880,47 @@ func (db *DataStoreMongo) Exists(ctx context.Context, id string) (bool, error) {
 	return true, nil
 }
 
+// FindUnusedImages returns the images last modified before the given time
+// which are not referenced by any deployment
+func (db *DataStoreMongo) FindUnusedImages(
+	ctx context.Context,
+	modifiedBefore time.Time,
+) (store.Iterator[model.Image], error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collImg := database.Collection(CollectionImages)
+
+	const keyDeployments = "deployments"
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{
+			Key:   StorageKeyImageModified,
+			Value: bson.D{{Key: "$lt", Value: modifiedBefore}},
+		}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: CollectionDeployments},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: StorageKeyDeploymentArtifacts},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$limit", Value: 1}},
+				bson.D{{Key: "$project", Value: bson.D{{Key: "_id", Value: 1}}}},
+			}},
+			{Key: "as", Value: keyDeployments},
+		}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: keyDeployments, Value: bson.D{{Key: "$size", Value: 0}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: keyDeployments, Value: 0},
+			{Key: StorageKeyImageDependsIdx, Value: 0},
+			{Key: StorageKeyImageProvidesIdx, Value: 0},
+		}}},
+	}
+	cur, err := collImg.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	return IteratorFromCursor[model.Image](cur), nil
+}
+
 // Update provided Image
 // Return false if not found
 func (db *DataStoreMongo) Update(ctx context.Context,
@@ -864,6 +987,136 @@ func (db *DataStoreMongo) ImageByNameAndDeviceType(ctx context.Context,
 	return &image, nil
 }
 
+// InsertDelta stores a new delta artifact; returns ErrConflictingDelta if a
+// delta between the same images already exists.
+func (db *DataStoreMongo) InsertDelta(ctx context.Context, delta *model.Delta) error {
+	if delta == nil {
+		return ErrStorageInvalidInput
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDeltas := database.Collection(CollectionDeltas)
+
+	if _, err := collDeltas.InsertOne(ctx, delta); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrConflictingDelta
+		}
+		return err
+	}
+	return nil
+}
+
+// FindDelta finds the delta artifact from the source to the target image,
+// returns nil if not found
+func (db *DataStoreMongo) FindDelta(ctx context.Context,
+	sourceImageID, targetImageID string) (*model.Delta, error) {
+
+	if len(sourceImageID) == 0 || len(targetImageID) == 0 {
+		return nil, ErrStorageInvalidID
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDeltas := database.Collection(CollectionDeltas)
+
+	query := bson.M{
+		StorageKeyDeltaSourceImageId: sourceImageID,
+		StorageKeyDeltaTargetImageId: targetImageID,
+	}
+	var delta model.Delta
+	if err := collDeltas.FindOne(ctx, query).Decode(&delta); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delta, nil
+}
+
+// DeltaExists checks if a delta artifact with the given ID exists
+func (db *DataStoreMongo) DeltaExists(ctx context.Context, id string) (bool, error) {
+	if len(id) == 0 {
+		return false, ErrStorageInvalidID
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDeltas := database.Collection(CollectionDeltas)
+
+	count, err := collDeltas.CountDocuments(ctx, bson.M{"_id": id},
+		mopts.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateDeltaStatus sets the outcome of the generation of a pending delta
+// artifact; returns ErrStorageNotFound if there is no such pending delta.
+func (db *DataStoreMongo) UpdateDeltaStatus(
+	ctx context.Context,
+	id string,
+	update model.DeltaStatusUpdate,
+) error {
+	if len(id) == 0 {
+		return ErrStorageInvalidID
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDeltas := database.Collection(CollectionDeltas)
+
+	query := bson.M{
+		StorageKeyId:          id,
+		StorageKeyDeltaStatus: model.DeltaStatusPending,
+	}
+	set := bson.M{
+		StorageKeyDeltaStatus:   update.Status,
+		StorageKeyDeltaModified: time.Now(),
+	}
+	if update.Size > 0 {
+		set[StorageKeyDeltaSize] = update.Size
+	}
+	res, err := collDeltas.UpdateOne(ctx, query, bson.M{"$set": set})
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
+// RetryDelta schedules the failed delta generation again, unless another
+// request already did; returns ErrStorageNotFound if there is no such
+// failed delta attempted the given number of times.
+func (db *DataStoreMongo) RetryDelta(ctx context.Context, id string, attempts int) error {
+	if len(id) == 0 {
+		return ErrStorageInvalidID
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDeltas := database.Collection(CollectionDeltas)
+
+	query := bson.M{
+		StorageKeyId:            id,
+		StorageKeyDeltaStatus:   model.DeltaStatusFailed,
+		StorageKeyDeltaAttempts: attempts,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			StorageKeyDeltaStatus:   model.DeltaStatusPending,
+			StorageKeyDeltaModified: time.Now(),
+		},
+		"$inc": bson.M{
+			StorageKeyDeltaAttempts: 1,
+		},
+	}
+	res, err := collDeltas.UpdateOne(ctx, query, update)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
 // ImageByIdsAndDeviceType finds image with id from ids and target device type
 func (db *DataStoreMongo) ImageByIdsAndDeviceType(ctx context.Context,
 	ids []string, deviceType string) (*model.Image, error) {
@@ -950,6 +1203,16 @@ func newDependsConflictError(mgoErr mongo.WriteError) *model.ConflictError {
 	return conflictErr
 }
 
+// isChecksumConflict returns true if the duplicate key error was caused by
+// the image checksum index.
+func isChecksumConflict(mgoErr mongo.WriteError) bool {
+	if raw, ok := mgoErr.Raw.Lookup("keyValue").DocumentOK(); ok {
+		_, err := raw.LookupErr(StorageKeyImageChecksum)
+		return err == nil
+	}
+	return strings.Contains(mgoErr.Message, IndexNameImageChecksum)
+}
+
 // Insert persists object
 func (db *DataStoreMongo) InsertImage(ctx context.Context, image *model.Image) error {
 
@@ -975,6 +1238,9 @@ func (db *DataStoreMongo) InsertImage(ctx context.Context, image *model.Image) e
 				if !mongo.IsDuplicateKeyError(wErr) {
 					continue
 				}
+				if isChecksumConflict(wErr) {
+					return ErrConflictingChecksum
+				}
 				return newDependsConflictError(wErr)
 			}
 		}
@@ -1032,6 +1298,103 @@ func (db *DataStoreMongo) UpdateUploadIntentStatus(
 	return nil
 }
 
+// FindUploadLink returns the upload link with the given artifact ID,
+// store.ErrNotFound if there is none.
+func (db *DataStoreMongo) FindUploadLink(
+	ctx context.Context,
+	id string,
+) (*model.UploadLink, error) {
+	collUploads := db.client.
+		Database(DatabaseName).
+		Collection(CollectionUploadIntents)
+	q := bson.D{
+		{Key: "_id", Value: id},
+	}
+	if idty := identity.FromContext(ctx); idty != nil {
+		q = append(q, bson.E{
+			Key:   StorageKeyTenantId,
+			Value: idty.Tenant,
+		})
+	}
+	var link model.UploadLink
+	err := collUploads.FindOne(ctx, q).Decode(&link)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// UpdateUploadIntentMetadata saves the metadata of a pending upload to
+// process it with.
+func (db *DataStoreMongo) UpdateUploadIntentMetadata(
+	ctx context.Context,
+	id string,
+	metadata *model.DirectUploadMetadata,
+) error {
+	collUploads := db.client.
+		Database(DatabaseName).
+		Collection(CollectionUploadIntents)
+	q := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "status", Value: model.LinkStatusPending},
+	}
+	if idty := identity.FromContext(ctx); idty != nil {
+		q = append(q, bson.E{
+			Key:   StorageKeyTenantId,
+			Value: idty.Tenant,
+		})
+	}
+	res, err := collUploads.UpdateOne(ctx, q, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: StorageKeyUploadLinkMetadata, Value: metadata},
+		}},
+	})
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// RetryUploadIntent increments the retry counter of an upload in processing
+// if it still equals retries, so that only one caller resumes the processing;
+// returns store.ErrNotFound otherwise.
+func (db *DataStoreMongo) RetryUploadIntent(
+	ctx context.Context,
+	id string,
+	retries uint,
+) error {
+	collUploads := db.client.
+		Database(DatabaseName).
+		Collection(CollectionUploadIntents)
+	var qRetries interface{} = retries
+	if retries == 0 {
+		qRetries = bson.D{{Key: "$in", Value: bson.A{0, nil}}}
+	}
+	q := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "status", Value: model.LinkStatusProcessing},
+		{Key: StorageKeyUploadLinkRetries, Value: qRetries},
+	}
+	res, err := collUploads.UpdateOne(ctx, q, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "updated_ts", Value: time.Now()},
+		}},
+		{Key: "$inc", Value: bson.D{
+			{Key: StorageKeyUploadLinkRetries, Value: 1},
+		}},
+	})
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
 func (db *DataStoreMongo) FindUploadLinks(
 	ctx context.Context,
 	expiredAt time.Time,
@@ -1086,6 +1449,36 @@ func (db *DataStoreMongo) FindImageByID(ctx context.Context,
 	return &image, nil
 }
 
+// FindImageByChecksum returns an image with the given artifact checksum,
+// nil if there is none.
+func (db *DataStoreMongo) FindImageByChecksum(ctx context.Context,
+	checksum string) (*model.Image, error) {
+
+	if len(checksum) == 0 {
+		return nil, ErrImagesStorageInvalidChecksum
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collImg := database.Collection(CollectionImages)
+	projection := bson.M{
+		StorageKeyImageDependsIdx:  0,
+		StorageKeyImageProvidesIdx: 0,
+	}
+	findOptions := mopts.FindOne()
+	findOptions.SetProjection(projection)
+
+	var image model.Image
+	if err := collImg.FindOne(ctx, bson.M{StorageKeyImageChecksum: checksum}, findOptions).
+		Decode(&image); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
 // IsArtifactUnique checks if there is no artifact with the same artifactName
 // supporting one of the device types from deviceTypesCompatible list.
 // Returns true, nil if artifact is unique;
@@ -1411,6 +1804,43 @@ func (db *DataStoreMongo) DeleteImagesByNames(ctx context.Context, names []strin
 }
 
 // device deployment log
+
+// deploymentLogDocument is the deployment log as stored in the database:
+// the messages are stored as gzip compressed JSON, while the logs saved
+// before the compression was introduced keep the plain messages
+type deploymentLogDocument struct {
+	DeviceID     string             `bson:"deviceid"`
+	DeploymentID string             `bson:"deploymentid"`
+	Messages     []model.LogMessage `bson:"messages,omitempty"`
+	Data         []byte             `bson:"data,omitempty"`
+	ExpireAt     *time.Time         `bson:"expire_at,omitempty"`
+}
+
+func compressLogMessages(messages []model.LogMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(messages); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompressLogMessages(data []byte) ([]model.LogMessage, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var messages []model.LogMessage
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
 func (db *DataStoreMongo) SaveDeviceDeploymentLog(ctx context.Context,
 	log model.DeploymentLog) error {
 
@@ -1418,6 +1848,11 @@ func (db *DataStoreMongo) SaveDeviceDeploymentLog(ctx context.Context,
 		return err
 	}
 
+	data, err := compressLogMessages(log.Messages)
+	if err != nil {
+		return errors.Wrap(err, "failed to compress the deployment log")
+	}
+
 	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
 	collLogs := database.Collection(CollectionDeviceDeploymentLogs)
 
@@ -1430,10 +1865,20 @@ func (db *DataStoreMongo) SaveDeviceDeploymentLog(ctx context.Context,
 
 	// update log messages
 	// if the deployment log is already present than messages will be overwritten
+	set := bson.M{
+		StorageKeyDeviceDeploymentLogData: data,
+	}
+	unset := bson.M{
+		StorageKeyDeviceDeploymentLogMessages: "",
+	}
+	if log.ExpireAt != nil {
+		set[StorageKeyDeviceDeploymentLogExpireAt] = *log.ExpireAt
+	} else {
+		unset[StorageKeyDeviceDeploymentLogExpireAt] = ""
+	}
 	update := bson.D{
-		{Key: "$set", Value: bson.M{
-			StorageKeyDeviceDeploymentLogMessages: log.Messages,
-		}},
+		{Key: "$set", Value: set},
+		{Key: "$unset", Value: unset},
 	}
 	updateOptions := mopts.UpdateOne()
 	updateOptions.SetUpsert(true)
@@ -1456,15 +1901,29 @@ func (db *DataStoreMongo) GetDeviceDeploymentLog(ctx context.Context,
 		StorageKeyDeviceDeploymentDeploymentID: deploymentID,
 	}
 
-	var depl model.DeploymentLog
-	if err := collLogs.FindOne(ctx, query).Decode(&depl); err != nil {
+	var doc deploymentLogDocument
+	if err := collLogs.FindOne(ctx, query).Decode(&doc); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return &depl, nil
+	depl := &model.DeploymentLog{
+		DeviceID:     doc.DeviceID,
+		DeploymentID: doc.DeploymentID,
+		Messages:     doc.Messages,
+		ExpireAt:     doc.ExpireAt,
+	}
+	if doc.Data != nil {
+		messages, err := decompressLogMessages(doc.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress the deployment log")
+		}
+		depl.Messages = messages
+	}
+
+	return depl, nil
 }
 
 // device deployments
@@ -1699,12 +2158,37 @@ func (db *DataStoreMongo) UpdateDeviceDeploymentStatus(
 	return old.Status, nil
 }
 
-func (db *DataStoreMongo) UpdateDeviceDeploymentLogAvailability(ctx context.Context,
-	deviceID string, deploymentID string, log bool) error {
+// IncrementDeviceDeploymentAttempts increments the attempts counter of the
+// device deployment
+func (db *DataStoreMongo) IncrementDeviceDeploymentAttempts(
+	ctx context.Context,
+	id string,
+	inc uint,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
 
-	// Verify ID formatting
-	if len(deviceID) == 0 ||
-		len(deploymentID) == 0 {
+	update := bson.M{
+		"$inc": bson.M{
+			StorageKeyDeviceDeploymentAttempts: inc,
+		},
+	}
+
+	res, err := collDevs.UpdateOne(ctx, bson.M{StorageKeyId: id}, update)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) UpdateDeviceDeploymentLogAvailability(ctx context.Context,
+	deviceID string, deploymentID string, log bool) error {
+
+	// Verify ID formatting
+	if len(deviceID) == 0 ||
+		len(deploymentID) == 0 {
 		return ErrStorageInvalidID
 	}
 
@@ -1757,6 +2241,58 @@ func (db *DataStoreMongo) SaveDeviceDeploymentRequest(
 	return nil
 }
 
+// SaveDeviceDeploymentObject saves the object served to the device
+func (db *DataStoreMongo) SaveDeviceDeploymentObject(
+	ctx context.Context,
+	ID string,
+	object *model.DeviceDeploymentObject,
+) error {
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
+
+	res, err := collDevs.UpdateOne(
+		ctx,
+		bson.D{{Key: StorageKeyId, Value: ID}},
+		bson.D{{Key: "$set", Value: bson.M{StorageKeyDeviceDeploymentObject: object}}},
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
+// SaveDeviceDeploymentIllegalTransition records a status transition
+// rejected for the device deployment, keeping the latest ones only
+func (db *DataStoreMongo) SaveDeviceDeploymentIllegalTransition(
+	ctx context.Context,
+	ID string,
+	transition model.DeviceDeploymentTransition,
+) error {
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
+
+	res, err := collDevs.UpdateOne(
+		ctx,
+		bson.D{{Key: StorageKeyId, Value: ID}},
+		bson.D{{Key: "$push", Value: bson.M{
+			StorageKeyDeviceDeploymentIllegalTransitions: bson.M{
+				"$each":  []model.DeviceDeploymentTransition{transition},
+				"$slice": -maxIllegalTransitions,
+			},
+		}}},
+	)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
 // AssignArtifact assigns artifact to the device deployment
 func (db *DataStoreMongo) AssignArtifact(
 	ctx context.Context,
@@ -1879,13 +2415,9 @@ func (db *DataStoreMongo) GetDeviceStatusesForDeployment(ctx context.Context,
 	return statuses, nil
 }
 
-func (db *DataStoreMongo) GetDevicesListForDeployment(ctx context.Context,
-	q store.ListQuery) ([]model.DeviceDeployment, int, error) {
-
-	statuses := []model.DeviceDeployment{}
-	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
-	collDevs := database.Collection(CollectionDevices)
-
+// devicesListForDeploymentQuery returns the query selecting the devices of
+// the deployment, optionally filtered by status
+func devicesListForDeploymentQuery(q store.ListQuery) (bson.D, error) {
 	query := bson.D{
 		{Key: StorageKeyDeviceDeploymentDeploymentID, Value: q.DeploymentID},
 		{Key: StorageKeyDeviceDeploymentDeleted, Value: bson.D{
@@ -1931,7 +2463,7 @@ func (db *DataStoreMongo) GetDevicesListForDeployment(ctx context.Context,
 			var status model.DeviceDeploymentStatus
 			err := status.UnmarshalText([]byte(*q.Status))
 			if err != nil {
-				return nil, -1, errors.Wrap(err, "invalid status query")
+				return nil, errors.Wrap(err, "invalid status query")
 			}
 			query = append(query, bson.E{
 				Key: "status", Value: status,
@@ -1939,6 +2471,21 @@ func (db *DataStoreMongo) GetDevicesListForDeployment(ctx context.Context,
 		}
 	}
 
+	return query, nil
+}
+
+func (db *DataStoreMongo) GetDevicesListForDeployment(ctx context.Context,
+	q store.ListQuery) ([]model.DeviceDeployment, int, error) {
+
+	statuses := []model.DeviceDeployment{}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
+
+	query, err := devicesListForDeploymentQuery(q)
+	if err != nil {
+		return nil, -1, err
+	}
+
 	options := mopts.Find()
 	sortFieldQuery := bson.D{
 		{Key: StorageKeyDeviceDeploymentStatus, Value: 1},
@@ -1974,6 +2521,32 @@ func (db *DataStoreMongo) GetDevicesListForDeployment(ctx context.Context,
 	return statuses, int(count), nil
 }
 
+// FindDevicesForDeployment returns an iterator over the devices of the
+// deployment, sorted like GetDevicesListForDeployment; skip and limit of
+// the query are ignored
+func (db *DataStoreMongo) FindDevicesForDeployment(ctx context.Context,
+	q store.ListQuery) (store.Iterator[model.DeviceDeployment], error) {
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDevs := database.Collection(CollectionDevices)
+
+	query, err := devicesListForDeploymentQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	options := mopts.Find().
+		SetSort(bson.D{
+			{Key: StorageKeyDeviceDeploymentStatus, Value: 1},
+			{Key: StorageKeyDeviceDeploymentDeviceId, Value: 1},
+		})
+	cur, err := collDevs.Find(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+	return IteratorFromCursor[model.DeviceDeployment](cur), nil
+}
+
 func (db *DataStoreMongo) GetDeviceDeploymentsForDevice(ctx context.Context,
 	q store.ListQueryDeviceDeployments) ([]model.DeviceDeployment, int, error) {
 
@@ -2441,7 +3014,10 @@ func (db *DataStoreMongo) FindDeploymentStatsByIDs(
 		},
 	}
 	statsProjection := mopts.Find()
-	statsProjection.SetProjection(bson.M{"stats": 1})
+	statsProjection.SetProjection(bson.M{
+		"stats":                    1,
+		StorageKeyDeploymentStatus: 1,
+	})
 
 	results, err := collDpl.Find(
 		ctx,
@@ -2517,6 +3093,79 @@ func (db *DataStoreMongo) IncrementDeploymentDeviceCount(
 	return err
 }
 
+// IncrementDeploymentPhaseDeviceCount increments the device count of the
+// given deployment phase, unless the phase already admitted maxDevices devices,
+// in which case ErrDeploymentPhaseFull is returned.
+func (db *DataStoreMongo) IncrementDeploymentPhaseDeviceCount(
+	ctx context.Context,
+	deploymentID string,
+	phaseID string,
+	maxDevices int,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeployments)
+
+	filter := bson.M{
+		"_id": deploymentID,
+		StorageKeyDeploymentPhases: bson.M{
+			"$elemMatch": bson.M{
+				StorageKeyDeploymentPhaseId: phaseID,
+				StorageKeyDeploymentPhaseDeviceCount: bson.M{
+					"$lt": maxDevices,
+				},
+			},
+		},
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			StorageKeyDeploymentPhases + ".$." +
+				StorageKeyDeploymentPhaseDeviceCount: 1,
+		},
+	}
+
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrDeploymentPhaseFull
+	}
+	return nil
+}
+
+// DecrementDeploymentPhaseDeviceCount releases a device admitted to the given
+// deployment phase, so that another device can take its place.
+func (db *DataStoreMongo) DecrementDeploymentPhaseDeviceCount(
+	ctx context.Context,
+	deploymentID string,
+	phaseID string,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeployments)
+
+	filter := bson.M{
+		"_id": deploymentID,
+		StorageKeyDeploymentPhases: bson.M{
+			"$elemMatch": bson.M{
+				StorageKeyDeploymentPhaseId: phaseID,
+				StorageKeyDeploymentPhaseDeviceCount: bson.M{
+					"$gt": 0,
+				},
+			},
+		},
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			StorageKeyDeploymentPhases + ".$." +
+				StorageKeyDeploymentPhaseDeviceCount: -1,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
 func (db *DataStoreMongo) SetDeploymentDeviceCount(
 	ctx context.Context,
 	deploymentID string,
@@ -2784,6 +3433,8 @@ func (db *DataStoreMongo) buildDeploymentsQuery(
 			status = model.DeploymentStatusPending
 		} else if match.Status == model.StatusQueryInProgress {
 			status = model.DeploymentStatusInProgress
+		} else if match.Status == model.StatusQueryPaused {
+			status = model.DeploymentStatusPaused
 		} else {
 			status = model.DeploymentStatusFinished
 		}
@@ -2886,7 +3537,9 @@ func (db *DataStoreMongo) FindNewerActiveDeployments(ctx context.Context,
 }
 
 // FindNewerActiveDeployment finds active deployments which were created
-// after createdAfter where deviceID is part of the device list.
+// after createdAfter where deviceID is part of the device list, or which are
+// continuous. The device list of the result contains deviceID only if the
+// device is part of it.
 func (db *DataStoreMongo) FindNewerActiveDeployment(ctx context.Context,
 	createdAfter *time.Time, deviceID string) (*model.Deployment, error) {
 
@@ -2896,14 +3549,19 @@ func (db *DataStoreMongo) FindNewerActiveDeployment(ctx context.Context,
 	findQuery := bson.D{
 		{Key: StorageKeyDeploymentActive, Value: true},
 		{Key: StorageKeyDeploymentCreated, Value: bson.M{"$gt": createdAfter}},
-		{Key: StorageKeyDeploymentDeviceList, Value: deviceID},
+		{Key: "$or", Value: bson.A{
+			bson.M{StorageKeyDeploymentDeviceList: deviceID},
+			bson.M{StorageKeyDeploymentContinuous: true},
+		}},
 	}
 	findOptions := mopts.FindOne().
 		SetSort(bson.D{{Key: StorageKeyDeploymentCreated, Value: 1}}).
 		SetProjection(bson.M{
 			// Discard information we don't need
 			StorageKeyDeploymentConstructorChecksum: 0,
-			StorageKeyDeploymentDeviceList:          0,
+			StorageKeyDeploymentDeviceList: bson.M{
+				"$elemMatch": bson.M{"$eq": deviceID},
+			},
 		})
 
 	var deployment = new(model.Deployment)
@@ -2919,6 +3577,39 @@ func (db *DataStoreMongo) FindNewerActiveDeployment(ctx context.Context,
 	return deployment, nil
 }
 
+// AddDeviceToDeployment adds the device to the device list of a continuous
+// deployment, increasing the number of devices it targets; it does nothing
+// if the device is already part of the deployment.
+func (db *DataStoreMongo) AddDeviceToDeployment(
+	ctx context.Context,
+	deploymentID string,
+	deviceID string,
+) error {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionDeployments)
+
+	filter := bson.M{
+		"_id":                          deploymentID,
+		StorageKeyDeploymentDeviceList: bson.M{"$ne": deviceID},
+	}
+	update := bson.M{
+		"$push": bson.M{
+			StorageKeyDeploymentDeviceList: deviceID,
+		},
+		"$inc": bson.M{
+			StorageKeyDeploymentMaxDevices: 1,
+			StorageKeyDeploymentStats + "." +
+				model.DeviceDeploymentStatusPendingStr: 1,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, "failed to add the device to the deployment")
+	}
+	return nil
+}
+
 // SetDeploymentStatus simply sets the status field
 // optionally sets 'finished time' if deployment is indeed finished
 func (db *DataStoreMongo) SetDeploymentStatus(
@@ -2934,31 +3625,60 @@ func (db *DataStoreMongo) SetDeploymentStatus(
 	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
 	collDpl := database.Collection(CollectionDeployments)
 
-	var update bson.M
+	res, err := collDpl.UpdateOne(ctx, bson.M{"_id": id}, deploymentStatusUpdate(status, now))
+
+	if res != nil && res.MatchedCount == 0 {
+		return ErrStorageInvalidID
+	}
+
+	return err
+}
+
+// UpdateDeploymentStatus sets the status field like SetDeploymentStatus,
+// but only if the current status of the deployment is the given one;
+// returns ErrStorageNotFound otherwise.
+func (db *DataStoreMongo) UpdateDeploymentStatus(
+	ctx context.Context,
+	id string,
+	from, to model.DeploymentStatus,
+	now time.Time,
+) error {
+	if len(id) == 0 {
+		return ErrStorageInvalidID
+	}
+
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collDpl := database.Collection(CollectionDeployments)
+
+	filter := bson.M{
+		"_id":                      id,
+		StorageKeyDeploymentStatus: from,
+	}
+	res, err := collDpl.UpdateOne(ctx, filter, deploymentStatusUpdate(to, now))
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
+func deploymentStatusUpdate(status model.DeploymentStatus, now time.Time) bson.M {
 	if status == model.DeploymentStatusFinished {
-		update = bson.M{
+		return bson.M{
 			"$set": bson.M{
 				StorageKeyDeploymentActive:   false,
 				StorageKeyDeploymentStatus:   status,
 				StorageKeyDeploymentFinished: &now,
 			},
 		}
-	} else {
-		update = bson.M{
-			"$set": bson.M{
-				StorageKeyDeploymentActive: true,
-				StorageKeyDeploymentStatus: status,
-			},
-		}
 	}
-
-	res, err := collDpl.UpdateOne(ctx, bson.M{"_id": id}, update)
-
-	if res != nil && res.MatchedCount == 0 {
-		return ErrStorageInvalidID
+	return bson.M{
+		"$set": bson.M{
+			StorageKeyDeploymentActive: true,
+			StorageKeyDeploymentStatus: status,
+		},
 	}
-
-	return err
 }
 
 // ExistUnfinishedByArtifactId checks if there is an active deployment that uses
@@ -3089,6 +3809,136 @@ func (db *DataStoreMongo) SetStorageSettings(
 	return err
 }
 
+// ListVerificationKeys returns the tenant's artifact verification keys
+func (db *DataStoreMongo) ListVerificationKeys(
+	ctx context.Context,
+) ([]model.VerificationKey, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionVerificationKeys)
+
+	findOpts := mopts.Find().
+		SetSort(bson.D{{Key: StorageKeyId, Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	keys := []model.VerificationKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (db *DataStoreMongo) InsertVerificationKey(
+	ctx context.Context,
+	key *model.VerificationKey,
+) error {
+	if key == nil {
+		return ErrStorageInvalidInput
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionVerificationKeys)
+
+	_, err := collection.InsertOne(ctx, key)
+	return err
+}
+
+// DeleteVerificationKey removes the verification key; returns
+// ErrStorageNotFound if there is no such key.
+func (db *DataStoreMongo) DeleteVerificationKey(ctx context.Context, id string) error {
+	if len(id) == 0 {
+		return ErrStorageInvalidID
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionVerificationKeys)
+
+	res, err := collection.DeleteOne(ctx, bson.M{StorageKeyId: id})
+	if err != nil {
+		return err
+	} else if res.DeletedCount == 0 {
+		return ErrStorageNotFound
+	}
+	return nil
+}
+
+// GetArtifactVerificationSettings returns the tenant's artifact verification
+// settings, stored next to the storage settings; returns nil if not set.
+func (db *DataStoreMongo) GetArtifactVerificationSettings(
+	ctx context.Context,
+) (*model.ArtifactVerificationSettings, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	settings := new(model.ArtifactVerificationSettings)
+	query := bson.M{
+		"_id": StorageKeyArtifactVerificationSettingsID,
+	}
+	if err := collection.FindOne(ctx, query).Decode(settings); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (db *DataStoreMongo) SetArtifactVerificationSettings(
+	ctx context.Context,
+	settings *model.ArtifactVerificationSettings,
+) error {
+	if settings == nil {
+		return ErrStorageInvalidInput
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	filter := bson.M{
+		"_id": StorageKeyArtifactVerificationSettingsID,
+	}
+	_, err := collection.ReplaceOne(ctx, filter, settings,
+		mopts.Replace().SetUpsert(true))
+	return err
+}
+
+// GetDownloadSettings returns the tenant's download link settings, stored
+// next to the storage settings; returns nil if not set.
+func (db *DataStoreMongo) GetDownloadSettings(
+	ctx context.Context,
+) (*model.DownloadSettings, error) {
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	settings := new(model.DownloadSettings)
+	query := bson.M{
+		"_id": StorageKeyDownloadSettingsID,
+	}
+	if err := collection.FindOne(ctx, query).Decode(settings); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (db *DataStoreMongo) SetDownloadSettings(
+	ctx context.Context,
+	settings *model.DownloadSettings,
+) error {
+	if settings == nil {
+		return ErrStorageInvalidInput
+	}
+	database := db.client.Database(mstore.DbFromContext(ctx, DatabaseName))
+	collection := database.Collection(CollectionStorageSettings)
+
+	filter := bson.M{
+		"_id": StorageKeyDownloadSettingsID,
+	}
+	_, err := collection.ReplaceOne(ctx, filter, settings,
+		mopts.Replace().SetUpsert(true))
+	return err
+}
+
 func (db *DataStoreMongo) UpdateDeploymentsWithArtifactName(
 	ctx context.Context,
 	artifactName string,
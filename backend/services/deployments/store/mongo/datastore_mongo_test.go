@@ -265,6 +265,82 @@ func TestUpdateUploadIntentStatus(t *testing.T) {
 	})
 }
 
+func TestUploadIntentProcessing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestUploadIntentProcessing in short mode.")
+	}
+	db.Wipe()
+
+	const (
+		artifactID = "00000000-0000-0000-0000-000000000000"
+		tenantID   = "123456789012345678901234"
+	)
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenantID,
+	})
+	ds := NewDataStoreMongoWithClient(db.Client())
+
+	_, err := ds.FindUploadLink(ctx, artifactID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	err = ds.InsertUploadIntent(ctx, &model.UploadLink{
+		ArtifactID: artifactID,
+		Link: model.Link{
+			Expire: time.Now().Add(time.Minute),
+		},
+		IssuedAt:   time.Now(),
+		Status:     model.LinkStatusPending,
+		SkipVerify: true,
+		UploadID:   "upload-id",
+	})
+	assert.NoError(t, err)
+
+	// links are looked up within the tenant
+	_, err = ds.FindUploadLink(context.Background(), artifactID)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	metadata := &model.DirectUploadMetadata{
+		Size:    123,
+		Updates: []model.Update{{}},
+	}
+	err = ds.UpdateUploadIntentMetadata(ctx, artifactID, metadata)
+	assert.NoError(t, err)
+
+	// only links in processing are retried
+	err = ds.RetryUploadIntent(ctx, artifactID, 0)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	err = ds.UpdateUploadIntentStatus(ctx, artifactID,
+		model.LinkStatusPending, model.LinkStatusProcessing)
+	assert.NoError(t, err)
+
+	// the metadata can't change once processing
+	err = ds.UpdateUploadIntentMetadata(ctx, artifactID, metadata)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+
+	err = ds.RetryUploadIntent(ctx, artifactID, 0)
+	assert.NoError(t, err)
+	// the retry was already claimed
+	err = ds.RetryUploadIntent(ctx, artifactID, 0)
+	assert.ErrorIs(t, err, store.ErrNotFound)
+	err = ds.RetryUploadIntent(ctx, artifactID, 1)
+	assert.NoError(t, err)
+
+	link, err := ds.FindUploadLink(ctx, artifactID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.LinkStatusProcessing, link.Status)
+		assert.Equal(t, uint(2), link.Retries)
+		assert.True(t, link.SkipVerify)
+		assert.Equal(t, "upload-id", link.UploadID)
+		if assert.NotNil(t, link.Metadata) {
+			assert.Equal(t, metadata.Size, link.Metadata.Size)
+			assert.Len(t, link.Metadata.Updates, 1)
+		}
+		assert.Equal(t, tenantID, link.TenantID)
+	}
+}
+
 func TestFindNewerActiveDeployments(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestFindNewerActiveDeployments in short mode.")
@@ -587,6 +663,54 @@ func TestSetDeploymentDeviceCount(t *testing.T) {
 
 }
 
+func TestIncrementDeploymentPhaseDeviceCount(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestIncrementDeploymentPhaseDeviceCount in short mode.")
+	}
+
+	now := time.Now()
+	deployment := &model.Deployment{
+		Id:      "d50eda0d-2cea-4de1-8d42-9cd3e7e86711",
+		Created: &now,
+		DeploymentConstructor: &model.DeploymentConstructor{
+			Name:         "name",
+			ArtifactName: "artifact",
+			Devices:      []string{"device-1", "device-2", "device-3"},
+		},
+		Phases: []model.DeploymentPhase{
+			{Id: "phase-1", StartTs: &now, MaxDevices: 1},
+			{Id: "phase-2", StartTs: &now, MaxDevices: 2},
+		},
+	}
+
+	ctx := context.Background()
+	ds := NewDataStoreMongoWithClient(db.Client())
+
+	err := ds.InsertDeployment(ctx, deployment)
+	assert.NoError(t, err)
+
+	err = ds.IncrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-1", 1)
+	assert.NoError(t, err)
+	err = ds.IncrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-1", 1)
+	assert.Equal(t, ErrDeploymentPhaseFull, err)
+	err = ds.IncrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-2", 2)
+	assert.NoError(t, err)
+	err = ds.IncrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-3", 2)
+	assert.Equal(t, ErrDeploymentPhaseFull, err)
+	err = ds.IncrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-2", 2)
+	assert.NoError(t, err)
+	err = ds.DecrementDeploymentPhaseDeviceCount(ctx, deployment.Id, "phase-2")
+	assert.NoError(t, err)
+
+	dep, err := ds.FindDeploymentByID(ctx, deployment.Id)
+	assert.NoError(t, err)
+	if assert.NotNil(t, dep) && assert.Len(t, dep.Phases, 2) {
+		assert.Equal(t, 1, dep.Phases[0].DeviceCount)
+		assert.Equal(t, 1, dep.Phases[1].DeviceCount)
+		assert.Equal(t, 2, dep.Phases[1].MaxDevices)
+	}
+}
+
 func TestSetStorageSettings(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestSetStorageSettings in short mode.")
@@ -607,6 +731,28 @@ func TestSetStorageSettings(t *testing.T) {
 				Token:  "token",
 			},
 		},
+		"ok, locations": {
+			settings: &model.StorageSettings{
+				Region: "region",
+				Key:    "secretkey",
+				Secret: "secret",
+				Bucket: "bucket",
+				LocationAttribute: &model.StorageLocationAttribute{
+					Scope: "inventory",
+					Name:  "region",
+				},
+				Locations: []model.StorageLocation{{
+					Name:   "apac",
+					Values: []string{"ap-southeast", "ap-northeast"},
+					Settings: model.StorageSettings{
+						Region: "ap-southeast-1",
+						Key:    "secretkey",
+						Secret: "secret",
+						Bucket: "bucket-apac",
+					},
+				}},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -624,6 +770,58 @@ func TestSetStorageSettings(t *testing.T) {
 	}
 }
 
+func TestDownloadSettings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDownloadSettings in short mode.")
+	}
+
+	testCases := map[string]struct {
+		tenant string
+	}{
+		"ok": {},
+		"ok, tenant": {
+			tenant: "foo",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db.Wipe()
+			ds := NewDataStoreMongoWithClient(db.Client())
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = identity.WithContext(ctx, &identity.Identity{
+					Tenant: tc.tenant,
+				})
+			}
+
+			settings, err := ds.GetDownloadSettings(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, settings)
+
+			expected := &model.DownloadSettings{
+				MinBandwidth: 64 * 1024,
+				Groups: []model.GroupDownloadSettings{{
+					Group:        "cellular",
+					MinBandwidth: 8 * 1024,
+				}},
+			}
+			err = ds.SetDownloadSettings(ctx, expected)
+			assert.NoError(t, err)
+
+			settings, err = ds.GetDownloadSettings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, settings)
+
+			// the storage settings are kept apart
+			storageSettings, err := ds.GetStorageSettings(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, storageSettings)
+		})
+	}
+}
+
 func TestSortDeployments(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping TestSortDeployments in short mode.")
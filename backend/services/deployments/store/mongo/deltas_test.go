@@ -0,0 +1,117 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	ctxstore "github.com/mendersoftware/mender-server/pkg/store"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func TestDeltas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDeltas in short mode.")
+	}
+
+	testCases := map[string]struct {
+		tenant string
+	}{
+		"ok": {},
+		"ok, tenant": {
+			tenant: "foo",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db.Wipe()
+			store := NewDataStoreMongoWithClient(db.Client())
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = identity.WithContext(ctx, &identity.Identity{
+					Tenant: tc.tenant,
+				})
+			}
+			err := store.EnsureIndexes(ctxstore.DbFromContext(ctx, DatabaseName),
+				CollectionDeltas, IndexDeltaSourceTarget)
+			assert.NoError(t, err)
+
+			delta, err := store.FindDelta(ctx, "source", "target")
+			assert.NoError(t, err)
+			assert.Nil(t, delta)
+
+			newDelta := model.NewDelta("source", "target")
+			exists, err := store.DeltaExists(ctx, newDelta.Id)
+			assert.NoError(t, err)
+			assert.False(t, exists)
+
+			err = store.InsertDelta(ctx, newDelta)
+			assert.NoError(t, err)
+
+			exists, err = store.DeltaExists(ctx, newDelta.Id)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+
+			err = store.InsertDelta(ctx, model.NewDelta("source", "target"))
+			assert.ErrorIs(t, err, ErrConflictingDelta)
+
+			err = store.UpdateDeltaStatus(ctx, newDelta.Id, model.DeltaStatusUpdate{
+				Status: model.DeltaStatusReady,
+				Size:   123,
+			})
+			assert.NoError(t, err)
+
+			delta, err = store.FindDelta(ctx, "source", "target")
+			assert.NoError(t, err)
+			if assert.NotNil(t, delta) {
+				assert.Equal(t, newDelta.Id, delta.Id)
+				assert.Equal(t, model.DeltaStatusReady, delta.Status)
+				assert.Equal(t, int64(123), delta.Size)
+			}
+
+			// only pending deltas can be updated
+			err = store.UpdateDeltaStatus(ctx, newDelta.Id, model.DeltaStatusUpdate{
+				Status: model.DeltaStatusFailed,
+			})
+			assert.ErrorIs(t, err, ErrStorageNotFound)
+
+			// failed deltas are generated again
+			failedDelta := model.NewDelta("source", "other")
+			err = store.InsertDelta(ctx, failedDelta)
+			assert.NoError(t, err)
+			err = store.RetryDelta(ctx, failedDelta.Id, 1)
+			assert.ErrorIs(t, err, ErrStorageNotFound)
+			err = store.UpdateDeltaStatus(ctx, failedDelta.Id, model.DeltaStatusUpdate{
+				Status: model.DeltaStatusFailed,
+			})
+			assert.NoError(t, err)
+			err = store.RetryDelta(ctx, failedDelta.Id, 1)
+			assert.NoError(t, err)
+			delta, err = store.FindDelta(ctx, "source", "other")
+			assert.NoError(t, err)
+			if assert.NotNil(t, delta) {
+				assert.Equal(t, model.DeltaStatusPending, delta.Status)
+				assert.Equal(t, 2, delta.Attempts)
+			}
+		})
+	}
+}
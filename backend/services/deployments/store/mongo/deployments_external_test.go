@@ -1683,3 +1683,128 @@ func TestDeploymentSetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentUpdateStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestDeploymentUpdateStatus in short mode.")
+	}
+
+	const id = "a108ae14-bb4e-455f-9b40-2ef4bab97bb7"
+	now := time.Now().UTC()
+	testCases := map[string]struct {
+		status   model.DeploymentStatus
+		from, to model.DeploymentStatus
+
+		err error
+	}{
+		"ok": {
+			status: model.DeploymentStatusInProgress,
+			from:   model.DeploymentStatusInProgress,
+			to:     model.DeploymentStatusPaused,
+		},
+		"ok, finished": {
+			status: model.DeploymentStatusPaused,
+			from:   model.DeploymentStatusPaused,
+			to:     model.DeploymentStatusFinished,
+		},
+		"error, status changed": {
+			status: model.DeploymentStatusPaused,
+			from:   model.DeploymentStatusPending,
+			to:     model.DeploymentStatusInProgress,
+			err:    ErrStorageNotFound,
+		},
+	}
+
+	client := db.Client()
+	store := NewDataStoreMongoWithClient(client)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db.Wipe()
+
+			ctx := context.Background()
+			collDep := client.Database(DatabaseName).
+				Collection(CollectionDeployments)
+			_, err := collDep.InsertOne(ctx, &model.Deployment{
+				Id:     id,
+				Status: tc.status,
+			})
+			assert.NoError(t, err)
+
+			err = store.UpdateDeploymentStatus(ctx, id, tc.from, tc.to, now)
+
+			var deployment *model.Deployment
+			assert.NoError(t, collDep.FindOne(ctx, bson.M{"_id": id}).Decode(&deployment))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, tc.status, deployment.Status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.to, deployment.Status)
+				assert.Equal(t, tc.to != model.DeploymentStatusFinished, deployment.Active)
+			}
+		})
+	}
+}
+
+func TestContinuousDeployment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestContinuousDeployment in short mode.")
+	}
+
+	db.Wipe()
+	store := NewDataStoreMongoWithClient(db.Client())
+	ctx := context.Background()
+
+	created := time.Now().UTC().Add(-time.Hour)
+	static, err := model.NewDeploymentFromConstructor(&model.DeploymentConstructor{
+		Name:         "static",
+		ArtifactName: "foo",
+	})
+	assert.NoError(t, err)
+	static.Created = &created
+	static.Active = true
+	static.DeviceList = []string{"other"}
+	static.MaxDevices = 1
+	assert.NoError(t, store.InsertDeployment(ctx, static))
+
+	continuous, err := model.NewDeploymentFromConstructor(&model.DeploymentConstructor{
+		Name:         "continuous",
+		ArtifactName: "foo",
+		Continuous:   true,
+	})
+	assert.NoError(t, err)
+	continuous.Active = true
+	continuous.DeviceList = []string{"other"}
+	continuous.MaxDevices = 1
+	continuous.Stats[model.DeviceDeploymentStatusPendingStr] = 1
+	assert.NoError(t, store.InsertDeployment(ctx, continuous))
+
+	// the device is not part of the device list of the continuous deployment
+	deployment, err := store.FindNewerActiveDeployment(ctx, &time.Time{}, "device")
+	assert.NoError(t, err)
+	if assert.NotNil(t, deployment) {
+		assert.Equal(t, continuous.Id, deployment.Id)
+		assert.True(t, deployment.IsContinuous())
+		assert.Empty(t, deployment.DeviceList)
+	}
+
+	err = store.AddDeviceToDeployment(ctx, continuous.Id, "device")
+	assert.NoError(t, err)
+	// adding the device again is a no-op
+	err = store.AddDeviceToDeployment(ctx, continuous.Id, "device")
+	assert.NoError(t, err)
+
+	deployment, err = store.FindNewerActiveDeployment(ctx, &time.Time{}, "device")
+	assert.NoError(t, err)
+	if assert.NotNil(t, deployment) {
+		assert.Equal(t, []string{"device"}, deployment.DeviceList)
+	}
+
+	deployment, err = store.FindDeploymentByID(ctx, continuous.Id)
+	assert.NoError(t, err)
+	if assert.NotNil(t, deployment) {
+		assert.Equal(t, 2, deployment.MaxDevices)
+		assert.Equal(t, 2, deployment.Stats[model.DeviceDeploymentStatusPendingStr])
+	}
+}
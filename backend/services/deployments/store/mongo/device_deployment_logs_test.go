@@ -135,7 +135,7 @@ func TestSaveDeviceDeploymentLog(t *testing.T) {
 			assert.NoError(t, err)
 
 			// no errors, so we should be able to find the log in DB
-			var dlog model.DeploymentLog
+			var dlog deploymentLogDocument
 			collDepLogs := client.Database(ctxstore.
 				DbFromContext(ctx, DatabaseName)).
 				Collection(CollectionDeviceDeploymentLogs)
@@ -148,10 +148,15 @@ func TestSaveDeviceDeploymentLog(t *testing.T) {
 
 			assert.NoError(t, err)
 
+			// the messages are stored compressed
+			assert.Nil(t, dlog.Messages)
+			messages, err := decompressLogMessages(dlog.Data)
+			assert.NoError(t, err)
+
 			// message timestamp is a pointer, so we cannot use assert.EqualValues()
 			// or reflect.DeepEqual() as both will choke on *time.Time pointing to
 			// different, but value-equal instances, just compare if length is ok for now
-			assert.Len(t, dlog.Messages, len(testCase.InputDeviceDeploymentLog.Messages))
+			assert.Len(t, messages, len(testCase.InputDeviceDeploymentLog.Messages))
 
 			if testCase.InputTenant != "" {
 				// logs were saved to tenant's DB, double check
@@ -300,3 +305,71 @@ func TestGetDeviceDeploymentLog(t *testing.T) {
 	}
 	db.Wipe()
 }
+
+func TestDeviceDeploymentLogCompatibility(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping TestDeviceDeploymentLogCompatibility in short mode.")
+	}
+
+	messages := []model.LogMessage{
+		{
+			Level:     "notice",
+			Message:   "foo",
+			Timestamp: parseTime(t, "2006-01-02T15:04:05Z"),
+		},
+	}
+
+	// Make sure we start test with empty database
+	db.Wipe()
+
+	client := db.Client()
+	store := NewDataStoreMongoWithClient(client)
+	ctx := context.Background()
+
+	// logs saved before the compression keep the plain messages
+	collDepLogs := client.Database(DatabaseName).
+		Collection(CollectionDeviceDeploymentLogs)
+	_, err := collDepLogs.InsertOne(ctx, bson.M{
+		StorageKeyDeviceDeploymentDeviceId:     "123",
+		StorageKeyDeviceDeploymentDeploymentID: "30b3e62c-9ec2-4312-a7fa-cff24cc7397a",
+		StorageKeyDeviceDeploymentLogMessages:  messages,
+	})
+	assert.NoError(t, err)
+
+	dlog, err := store.GetDeviceDeploymentLog(ctx,
+		"123", "30b3e62c-9ec2-4312-a7fa-cff24cc7397a")
+	assert.NoError(t, err)
+	if assert.NotNil(t, dlog) && assert.Len(t, dlog.Messages, 1) {
+		assert.Equal(t, "foo", dlog.Messages[0].Message)
+		assert.Nil(t, dlog.ExpireAt)
+	}
+
+	// saving the log again compresses the messages and sets the expiry
+	expireAt := time.Now().Add(time.Hour).UTC().Round(time.Millisecond)
+	err = store.SaveDeviceDeploymentLog(ctx, model.DeploymentLog{
+		DeviceID:     "123",
+		DeploymentID: "30b3e62c-9ec2-4312-a7fa-cff24cc7397a",
+		Messages:     messages,
+		ExpireAt:     &expireAt,
+	})
+	assert.NoError(t, err)
+
+	var doc deploymentLogDocument
+	err = collDepLogs.FindOne(ctx, bson.M{
+		StorageKeyDeviceDeploymentDeviceId: "123",
+	}).Decode(&doc)
+	assert.NoError(t, err)
+	assert.Nil(t, doc.Messages)
+	assert.NotEmpty(t, doc.Data)
+
+	dlog, err = store.GetDeviceDeploymentLog(ctx,
+		"123", "30b3e62c-9ec2-4312-a7fa-cff24cc7397a")
+	assert.NoError(t, err)
+	if assert.NotNil(t, dlog) && assert.Len(t, dlog.Messages, 1) {
+		assert.Equal(t, "foo", dlog.Messages[0].Message)
+		if assert.NotNil(t, dlog.ExpireAt) {
+			assert.True(t, expireAt.Equal(*dlog.ExpireAt))
+		}
+	}
+}
@@ -1358,6 +1358,76 @@ func TestDecommissionDeviceDeployments(t *testing.T) {
 	}
 }
 
+func TestFindDevicesForDeployment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestFindDevicesForDeployment in short mode.")
+	}
+
+	const deploymentID = "30b3e62c-9ec2-4312-a7fa-cff24cc7397a"
+
+	// more devices than the default page size
+	input := make([]interface{}, DefaultDocumentLimit+5)
+	for i := range input {
+		dd := model.NewDeviceDeployment(fmt.Sprintf("device%04d", i), deploymentID)
+		if i%2 == 0 {
+			dd.Status = model.DeviceDeploymentStatusSuccess
+		}
+		input[i] = dd
+	}
+	input = append(input,
+		model.NewDeviceDeployment("device", "30b3e62c-9ec2-4312-a7fa-cff24cc7397b"))
+
+	db.Wipe()
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "acme",
+	})
+	client := db.Client()
+	ds := NewDataStoreMongoWithClient(client)
+	_, err := client.Database(ctxstore.DbFromContext(ctx, DbName)).
+		Collection(CollectionDevices).
+		InsertMany(ctx, input)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	readAll := func(q store.ListQuery) []model.DeviceDeployment {
+		it, err := ds.FindDevicesForDeployment(ctx, q)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer it.Close(ctx)
+		var res []model.DeviceDeployment
+		for {
+			next, err := it.Next(ctx)
+			assert.NoError(t, err)
+			if !next {
+				break
+			}
+			var dd model.DeviceDeployment
+			assert.NoError(t, it.Decode(&dd))
+			res = append(res, dd)
+		}
+		return res
+	}
+
+	res := readAll(store.ListQuery{DeploymentID: deploymentID, Limit: 1})
+	assert.Len(t, res, DefaultDocumentLimit+5)
+
+	status := model.DeviceDeploymentStatusSuccessStr
+	res = readAll(store.ListQuery{DeploymentID: deploymentID, Status: &status})
+	if assert.Len(t, res, (DefaultDocumentLimit+6)/2) {
+		assert.Equal(t, "device0000", res[0].DeviceId)
+		for _, dd := range res {
+			assert.Equal(t, model.DeviceDeploymentStatusSuccess, dd.Status)
+		}
+	}
+
+	status = "foobar"
+	_, err = ds.FindDevicesForDeployment(ctx,
+		store.ListQuery{DeploymentID: deploymentID, Status: &status})
+	assert.ErrorContains(t, err, "invalid status query")
+}
+
 func TestSaveDeviceDeploymentRequest(t *testing.T) {
 
 	if testing.Short() {
@@ -1440,3 +1510,149 @@ func TestSaveDeviceDeploymentRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveDeviceDeploymentIllegalTransition(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping TestSaveDeviceDeploymentIllegalTransition in short mode.")
+	}
+
+	dd := model.NewDeviceDeployment("456", "30b3e62c-9ec2-4312-a7fa-cff24cc7397a")
+
+	// Make sure we start test with empty database
+	db.Wipe()
+	client := db.Client()
+	store := NewDataStoreMongoWithClient(client)
+
+	ctx := context.Background()
+	err := store.SaveDeviceDeploymentIllegalTransition(ctx, dd.Id,
+		model.DeviceDeploymentTransition{})
+	assert.EqualError(t, err, ErrStorageNotFound.Error())
+
+	err = store.InsertMany(ctx, dd)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	for i := 0; i < maxIllegalTransitions+2; i++ {
+		err = store.SaveDeviceDeploymentIllegalTransition(ctx, dd.Id,
+			model.DeviceDeploymentTransition{
+				From:      model.DeviceDeploymentStatusSuccess,
+				To:        model.DeviceDeploymentStatusDownloading,
+				Timestamp: now.Add(time.Duration(i) * time.Second),
+			})
+		assert.NoError(t, err)
+	}
+
+	var deployment *model.DeviceDeployment
+	collDevs := client.Database(ctxstore.
+		DbFromContext(ctx, DatabaseName)).
+		Collection(CollectionDevices)
+	err = collDevs.FindOne(ctx, bson.M{StorageKeyId: dd.Id}).Decode(&deployment)
+	assert.NoError(t, err)
+	if assert.Len(t, deployment.IllegalTransitions, maxIllegalTransitions) {
+		assert.True(t, now.Add((maxIllegalTransitions+1)*time.Second).Equal(
+			deployment.IllegalTransitions[maxIllegalTransitions-1].Timestamp))
+	}
+}
+
+func TestSaveDeviceDeploymentObject(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping TestSaveDeviceDeploymentObject in short mode.")
+	}
+
+	dd := model.NewDeviceDeployment("456", "30b3e62c-9ec2-4312-a7fa-cff24cc7397a")
+	object := &model.DeviceDeploymentObject{ID: "delta", Size: 123}
+
+	// Make sure we start test with empty database
+	db.Wipe()
+	client := db.Client()
+	store := NewDataStoreMongoWithClient(client)
+
+	ctx := context.Background()
+	err := store.SaveDeviceDeploymentObject(ctx, dd.Id, object)
+	assert.EqualError(t, err, ErrStorageNotFound.Error())
+
+	err = store.InsertMany(ctx, dd)
+	assert.NoError(t, err)
+
+	err = store.SaveDeviceDeploymentObject(ctx, dd.Id, object)
+	assert.NoError(t, err)
+
+	var deployment *model.DeviceDeployment
+	collDevs := client.Database(ctxstore.
+		DbFromContext(ctx, DatabaseName)).
+		Collection(CollectionDevices)
+	err = collDevs.FindOne(ctx, bson.M{StorageKeyId: dd.Id}).Decode(&deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, object, deployment.Object)
+}
+
+func TestIncrementDeviceDeploymentAttempts(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping TestIncrementDeviceDeploymentAttempts in short mode.")
+	}
+
+	dd := model.NewDeviceDeployment("456", "30b3e62c-9ec2-4312-a7fa-cff24cc7397a")
+	dd.Retries = 2
+
+	testCases := map[string]struct {
+		deviceDeployments []*model.DeviceDeployment
+		tenant            string
+
+		err error
+	}{
+		"ok": {
+			deviceDeployments: []*model.DeviceDeployment{dd},
+		},
+		"ok, tenant": {
+			deviceDeployments: []*model.DeviceDeployment{dd},
+			tenant:            "foo",
+		},
+		"no device deployments": {
+			err: ErrStorageNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(fmt.Sprintf("test case %s", name), func(t *testing.T) {
+
+			// Make sure we start test with empty database
+			db.Wipe()
+			client := db.Client()
+			store := NewDataStoreMongoWithClient(client)
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = identity.WithContext(ctx, &identity.Identity{
+					Tenant: tc.tenant,
+				})
+			}
+
+			err := store.InsertMany(ctx, tc.deviceDeployments...)
+			assert.NoError(t, err)
+
+			err = store.IncrementDeviceDeploymentAttempts(ctx, dd.Id, 1)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+				err = store.IncrementDeviceDeploymentAttempts(ctx, dd.Id, 1)
+				assert.NoError(t, err)
+
+				var deployment *model.DeviceDeployment
+				collDevs := client.Database(ctxstore.
+					DbFromContext(ctx, DatabaseName)).
+					Collection(CollectionDevices)
+				query := bson.M{
+					StorageKeyId: dd.Id,
+				}
+				err := collDevs.FindOne(ctx, query).Decode(&deployment)
+				assert.NoError(t, err)
+				assert.Equal(t, uint(2), deployment.Attempts)
+				assert.Equal(t, uint(2), deployment.Retries)
+			}
+		})
+	}
+}
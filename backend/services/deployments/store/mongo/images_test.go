@@ -17,12 +17,14 @@ package mongo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/mendersoftware/mender-server/pkg/identity"
+	mstore "github.com/mendersoftware/mender-server/pkg/store"
 
 	"github.com/mendersoftware/mender-server/services/deployments/model"
 )
@@ -736,3 +738,150 @@ func TestListV2Images(t *testing.T) {
 		})
 	}
 }
+
+func TestFindImageByChecksum(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestFindImageByChecksum in short mode.")
+	}
+
+	const checksum = "4355a46b19d348dc2f57c046f8ef63d4538ebb936000f3c9ee954a27460dd865"
+
+	testCases := map[string]struct {
+		tenant string
+	}{
+		"ok": {},
+		"ok, tenant": {
+			tenant: "foo",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db.Wipe()
+			store := NewDataStoreMongoWithClient(db.Client())
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = identity.WithContext(ctx, &identity.Identity{
+					Tenant: tc.tenant,
+				})
+			}
+
+			err := store.EnsureIndexes(mstore.DbFromContext(ctx, DatabaseName),
+				CollectionImages, IndexImageChecksum)
+			assert.NoError(t, err)
+
+			_, err = store.FindImageByChecksum(ctx, "")
+			assert.ErrorIs(t, err, ErrImagesStorageInvalidChecksum)
+
+			image, err := store.FindImageByChecksum(ctx, checksum)
+			assert.NoError(t, err)
+			assert.Nil(t, image)
+
+			newImage := &model.Image{
+				Id: uuid.NewString(),
+				ArtifactMeta: &model.ArtifactMeta{
+					Name:                  "App1 v1.0",
+					DeviceTypesCompatible: []string{"foo"},
+					Updates:               []model.Update{},
+				},
+				Checksum: checksum,
+			}
+			err = store.InsertImage(ctx, newImage)
+			assert.NoError(t, err)
+
+			image, err = store.FindImageByChecksum(ctx, checksum)
+			assert.NoError(t, err)
+			if assert.NotNil(t, image) {
+				assert.Equal(t, newImage.Id, image.Id)
+				assert.Equal(t, checksum, image.Checksum)
+			}
+
+			// concurrent uploads of the same artifact are rejected by the index
+			duplicateMeta := *newImage.ArtifactMeta
+			duplicateMeta.Name = "App1 v1.1"
+			duplicate := *newImage
+			duplicate.Id = uuid.NewString()
+			duplicate.ArtifactMeta = &duplicateMeta
+			err = store.InsertImage(ctx, &duplicate)
+			assert.ErrorIs(t, err, ErrConflictingChecksum)
+
+			// checksums are looked up within the tenant
+			otherCtx := identity.WithContext(context.Background(),
+				&identity.Identity{Tenant: "bar"})
+			image, err = store.FindImageByChecksum(otherCtx, checksum)
+			assert.NoError(t, err)
+			assert.Nil(t, image)
+		})
+	}
+}
+
+func TestFindUnusedImages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestFindUnusedImages in short mode.")
+	}
+
+	db.Wipe()
+	store := NewDataStoreMongoWithClient(db.Client())
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "foo",
+	})
+
+	old := time.Now().Add(-time.Hour * 48)
+	recent := time.Now()
+	newImage := func(modified *time.Time) *model.Image {
+		return &model.Image{
+			Id: uuid.NewString(),
+			ArtifactMeta: &model.ArtifactMeta{
+				Name:                  "App1 v1.0",
+				DeviceTypesCompatible: []string{"foo"},
+				Updates:               []model.Update{},
+			},
+			Modified: modified,
+		}
+	}
+	unused := newImage(&old)
+	used := newImage(&old)
+	images := []*model.Image{unused, used, newImage(&recent)}
+	for _, image := range images {
+		err := store.InsertImage(ctx, image)
+		assert.NoError(t, err)
+	}
+	err := store.InsertDeployment(ctx, &model.Deployment{
+		Id:        uuid.NewString(),
+		Created:   &recent,
+		Artifacts: []string{used.Id},
+		DeploymentConstructor: &model.DeploymentConstructor{
+			Name:         "foo",
+			ArtifactName: "App1 v1.0",
+			Devices:      []string{"device-1"},
+		},
+	})
+	assert.NoError(t, err)
+
+	it, err := store.FindUnusedImages(ctx, time.Now().Add(-time.Hour*24))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer it.Close(ctx)
+	var found []string
+	for {
+		next, err := it.Next(ctx)
+		if !assert.NoError(t, err) || !next {
+			break
+		}
+		var image model.Image
+		assert.NoError(t, it.Decode(&image))
+		found = append(found, image.Id)
+	}
+	assert.Equal(t, []string{unused.Id}, found)
+
+	// images are looked up within the tenant
+	it, err = store.FindUnusedImages(context.Background(), time.Now())
+	if assert.NoError(t, err) {
+		next, err := it.Next(ctx)
+		assert.NoError(t, err)
+		assert.False(t, next)
+		it.Close(ctx)
+	}
+}
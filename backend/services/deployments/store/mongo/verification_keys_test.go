@@ -0,0 +1,96 @@
+// Copyright 2026 Northern.tech AS
+//
+//    Licensed under the Apache License, Version 2.0 (the "License");
+//    you may not use this file except in compliance with the License.
+//    You may obtain a copy of the License at
+//
+//        http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS,
+//    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//    See the License for the specific language governing permissions and
+//    limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deployments/model"
+)
+
+func TestVerificationKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestVerificationKeys in short mode.")
+	}
+
+	testCases := map[string]struct {
+		tenant string
+	}{
+		"ok": {},
+		"ok, tenant": {
+			tenant: "foo",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db.Wipe()
+			store := NewDataStoreMongoWithClient(db.Client())
+
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = identity.WithContext(ctx, &identity.Identity{
+					Tenant: tc.tenant,
+				})
+			}
+
+			keys, err := store.ListVerificationKeys(ctx)
+			assert.NoError(t, err)
+			assert.Empty(t, keys)
+
+			key := &model.VerificationKey{
+				Id:        "key",
+				Name:      "release",
+				PublicKey: "-----BEGIN PUBLIC KEY-----",
+				Type:      model.VerificationKeyTypeEd25519,
+				Created:   time.Now().UTC().Truncate(time.Millisecond),
+			}
+			err = store.InsertVerificationKey(ctx, key)
+			assert.NoError(t, err)
+
+			keys, err = store.ListVerificationKeys(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.VerificationKey{*key}, keys)
+
+			err = store.DeleteVerificationKey(ctx, "key")
+			assert.NoError(t, err)
+			err = store.DeleteVerificationKey(ctx, "key")
+			assert.ErrorIs(t, err, ErrStorageNotFound)
+
+			settings, err := store.GetArtifactVerificationSettings(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, settings)
+
+			expected := &model.ArtifactVerificationSettings{RequireSignature: true}
+			err = store.SetArtifactVerificationSettings(ctx, expected)
+			assert.NoError(t, err)
+
+			settings, err = store.GetArtifactVerificationSettings(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, settings)
+
+			// the storage settings are kept apart
+			storageSettings, err := store.GetStorageSettings(ctx)
+			assert.NoError(t, err)
+			assert.Nil(t, storageSettings)
+		})
+	}
+}
@@ -31,6 +31,11 @@ func (l ListQuery) Validate() error {
 	if l.Limit <= 0 {
 		return errors.New("limit: must be a positive integer")
 	}
+	return l.ValidateFilter()
+}
+
+// ValidateFilter validates the query ignoring the paging parameters
+func (l ListQuery) ValidateFilter() error {
 	if l.DeploymentID == "" {
 		return errors.New("deployment_id: cannot be blank")
 	}
@@ -102,3 +102,25 @@ func (p *RESTView) RenderDeploymentLog(c *gin.Context, dlog model.DeploymentLog)
 		}
 	}
 }
+
+// RenderDeploymentLogDownload renders the raw text of the log messages as
+// a file attachment.
+func (p *RESTView) RenderDeploymentLogDownload(
+	c *gin.Context,
+	dlog model.DeploymentLog,
+	filename string,
+) {
+	h := c.Writer
+
+	h.Header().Set("Content-Type", "text/plain")
+	h.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", filename))
+	h.WriteHeader(http.StatusOK)
+
+	for _, m := range dlog.Messages {
+		_, _ = h.Write([]byte(m.Message))
+		if !strings.HasSuffix(m.Message, "\n") {
+			_, _ = h.Write([]byte("\n"))
+		}
+	}
+}
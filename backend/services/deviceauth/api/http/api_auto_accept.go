@@ -0,0 +1,77 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+)
+
+func (i *DevAuthApiHandlers) GetAutoAcceptRulesHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	rules, err := i.app.GetAutoAcceptRules(ctx)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (i *DevAuthApiHandlers) PostAutoAcceptRuleHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.AutoAcceptRuleReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode auto-accept rule request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		err = errors.Wrap(err, "invalid auto-accept rule request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rule, err := i.app.AddAutoAcceptRule(ctx, &req)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+	c.Header("Location", "auto_accept_rules/"+rule.Id)
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (i *DevAuthApiHandlers) DeleteAutoAcceptRuleHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := i.app.DeleteAutoAcceptRule(ctx, c.Param("id"))
+	switch err {
+	case nil:
+		c.Status(http.StatusNoContent)
+	case devauth.ErrAutoAcceptRuleNotFound:
+		rest.RenderError(c, http.StatusNotFound, err)
+	default:
+		rest.RenderInternalError(c, err)
+	}
+}
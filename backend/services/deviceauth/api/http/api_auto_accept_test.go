@@ -0,0 +1,170 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	mtest "github.com/mendersoftware/mender-server/services/deviceauth/utils/testing"
+)
+
+func TestApiV2PostAutoAcceptRule(t *testing.T) {
+	t.Parallel()
+
+	rule := &model.AutoAcceptRule{
+		Id:   "0b5c12a0-d0d2-44d5-8d41-b2fe5cbe5d73",
+		Name: "factory",
+		IdData: []model.IdDataMatcher{
+			{Attribute: "mac", Prefix: "00:1a:2b"},
+		},
+		EnrollmentSecret: true,
+	}
+	body, _ := json.Marshal(rule)
+
+	testCases := map[string]struct {
+		req interface{}
+
+		appCalled bool
+		appErr    error
+
+		code int
+		body string
+	}{
+		"ok": {
+			req: map[string]interface{}{
+				"name": "factory",
+				"id_data": []map[string]string{
+					{"attribute": "mac", "prefix": "00:1a:2b"},
+				},
+				"enrollment_secret": "0123456789abcdef",
+			},
+			appCalled: true,
+			code:      http.StatusCreated,
+			body:      string(body),
+		},
+		"error, no criteria": {
+			req: map[string]interface{}{
+				"name": "factory",
+			},
+			code: http.StatusBadRequest,
+			body: RestError("invalid auto-accept rule request: " +
+				"id_data: cannot be blank without enrollment_secret."),
+		},
+		"error, malformed": {
+			req:  []string{"garbage"},
+			code: http.StatusBadRequest,
+			body: RestError("failed to decode auto-accept rule request: " +
+				"json: cannot unmarshal array into Go value of type " +
+				"model.AutoAcceptRuleReq"),
+		},
+		"error, internal": {
+			req: map[string]interface{}{
+				"enrollment_secret": "0123456789abcdef",
+			},
+			appCalled: true,
+			appErr:    errors.New("connection refused"),
+			code:      http.StatusInternalServerError,
+			body:      RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			if tc.appCalled {
+				res := rule
+				if tc.appErr != nil {
+					res = nil
+				}
+				da.On("AddAutoAcceptRule",
+					mtest.ContextMatcher(),
+					mock.MatchedBy(func(req *model.AutoAcceptRuleReq) bool {
+						return req.EnrollmentSecret == "0123456789abcdef"
+					})).
+					Return(res, tc.appErr)
+			}
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost/api/management/v2/devauth/auto_accept_rules",
+				Body:   tc.req,
+				Auth:   true,
+			})
+			apih := makeMockApiHandler(t, da, nil)
+			rsp := runTestRequest(t, apih, req, tc.code, tc.body)
+			if tc.code == http.StatusCreated {
+				assert.Equal(t, "auto_accept_rules/"+rule.Id,
+					rsp.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestApiV2DeleteAutoAcceptRule(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			code: http.StatusNoContent,
+		},
+		"error, not found": {
+			appErr: devauth.ErrAutoAcceptRuleNotFound,
+			code:   http.StatusNotFound,
+			body:   RestError(devauth.ErrAutoAcceptRuleNotFound.Error()),
+		},
+		"error, internal": {
+			appErr: errors.New("connection refused"),
+			code:   http.StatusInternalServerError,
+			body:   RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			da.On("DeleteAutoAcceptRule", mtest.ContextMatcher(), "foo").
+				Return(tc.appErr)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path:   "http://localhost/api/management/v2/devauth/auto_accept_rules/foo",
+				Auth:   true,
+			})
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, tc.body)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+	mredis "github.com/mendersoftware/mender-server/pkg/redis"
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+const (
+	contentTypeCSV    = "text/csv"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// bulkItemResult is the outcome of a single item of a bulk request; the
+// status is the HTTP status code the item would have had as a single
+// request.
+type bulkItemResult struct {
+	Item      int    `json:"item"`
+	AuthSetID string `json:"auth_set_id,omitempty"`
+	DeviceID  string `json:"device_id,omitempty"`
+	Status    int    `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newBulkItemResult(
+	ctx context.Context,
+	item int,
+	res model.BulkResult,
+	successStatus int,
+) bulkItemResult {
+	result := bulkItemResult{
+		Item:      item,
+		AuthSetID: res.AuthSetID,
+		DeviceID:  res.DeviceID,
+		Status:    successStatus,
+	}
+	if res.Err == nil {
+		return result
+	}
+	switch err := res.Err; {
+	case err == store.ErrAuthSetNotFound, err == store.ErrDevNotFound:
+		result.Status = http.StatusNotFound
+	case err == devauth.ErrDevAuthBadRequest:
+		result.Status = http.StatusBadRequest
+	case err == devauth.ErrDeviceExists:
+		result.Status = http.StatusConflict
+	case err == devauth.ErrMaxDeviceCountReached:
+		result.Status = http.StatusUnprocessableEntity
+	case mredis.IsUnavailableErr(err):
+		result.Status = http.StatusServiceUnavailable
+		result.Error = "service unavailable"
+		log.FromContext(ctx).Error(err)
+		return result
+	default:
+		result.Status = http.StatusInternalServerError
+		result.Error = "internal error"
+		log.FromContext(ctx).Error(err)
+		return result
+	}
+	result.Error = res.Err.Error()
+	return result
+}
+
+func (i *DevAuthApiHandlers) UpdateAuthSetsStatusHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.AuthSetsStatusReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode status request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := i.app.SetAuthSetsStatus(ctx, &req)
+	switch err {
+	case nil:
+	case devauth.ErrBulkTooManyItems:
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	default:
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	rsp := make([]bulkItemResult, len(results))
+	for item, res := range results {
+		rsp[item] = newBulkItemResult(ctx, item, res, http.StatusNoContent)
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (i *DevAuthApiHandlers) PreauthorizeDevicesHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var (
+		reqs []*preAuthReq
+		errs []error
+		err  error
+	)
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	switch mediaType {
+	case contentTypeCSV:
+		reqs, errs, err = parsePreAuthReqsCSV(c.Request.Body)
+	case contentTypeNDJSON:
+		reqs, errs, err = parsePreAuthReqsNDJSON(c.Request.Body)
+	default:
+		rest.RenderError(c,
+			http.StatusUnsupportedMediaType,
+			errors.Errorf(
+				"Content-Type '%s' not supported",
+				c.GetHeader("Content-Type"),
+			))
+		return
+	}
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode preauth requests")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	} else if len(reqs) == 0 {
+		rest.RenderError(c, http.StatusBadRequest, errors.New("empty request body"))
+		return
+	} else if len(reqs) > model.BulkMaxItems {
+		rest.RenderError(c, http.StatusBadRequest, devauth.ErrBulkTooManyItems)
+		return
+	}
+
+	rsp := make([]bulkItemResult, len(reqs))
+	items := make([]int, 0, len(reqs))
+	dbReqs := make([]*model.PreAuthReq, 0, len(reqs))
+	for item, req := range reqs {
+		if errs[item] != nil {
+			rsp[item] = bulkItemResult{
+				Item:   item,
+				Status: http.StatusBadRequest,
+				Error:  errs[item].Error(),
+			}
+			continue
+		}
+		dbReq, err := req.getDbModel()
+		if err != nil {
+			rest.RenderInternalError(c, err)
+			return
+		}
+		items = append(items, item)
+		dbReqs = append(dbReqs, dbReq)
+	}
+
+	if len(dbReqs) > 0 {
+		results, err := i.app.PreauthorizeDevices(ctx, dbReqs)
+		if err != nil {
+			rest.RenderInternalError(c, err)
+			return
+		}
+		for j, res := range results {
+			rsp[items[j]] = newBulkItemResult(ctx, items[j], res, http.StatusCreated)
+		}
+	}
+	c.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mtest "github.com/mendersoftware/mender-server/services/deviceauth/utils/testing"
+)
+
+func TestApiV2UpdateAuthSetsStatus(t *testing.T) {
+	t.Parallel()
+
+	const (
+		authSetID = "5b7b5f6e-4e0d-4d8b-9d3e-d0a8c54f4b0b"
+		deviceID  = "3f3b34b6-53f9-4d6f-8e20-9f1b1d4e0c8a"
+	)
+
+	testCases := map[string]struct {
+		req interface{}
+
+		appCalled  bool
+		appResults []model.BulkResult
+		appErr     error
+
+		code int
+		body interface{}
+	}{
+		"ok": {
+			req: map[string]interface{}{
+				"action":       model.AuthSetActionAccept,
+				"auth_set_ids": []string{authSetID, "missing", "limited", "broken"},
+			},
+			appCalled: true,
+			appResults: []model.BulkResult{
+				{AuthSetID: authSetID, DeviceID: deviceID},
+				{AuthSetID: "missing", Err: store.ErrAuthSetNotFound},
+				{AuthSetID: "limited", DeviceID: deviceID,
+					Err: devauth.ErrMaxDeviceCountReached},
+				{AuthSetID: "broken", DeviceID: deviceID,
+					Err: errors.New("connection refused")},
+			},
+			code: http.StatusOK,
+			body: []bulkItemResult{{
+				Item:      0,
+				AuthSetID: authSetID,
+				DeviceID:  deviceID,
+				Status:    http.StatusNoContent,
+			}, {
+				Item:      1,
+				AuthSetID: "missing",
+				Status:    http.StatusNotFound,
+				Error:     store.ErrAuthSetNotFound.Error(),
+			}, {
+				Item:      2,
+				AuthSetID: "limited",
+				DeviceID:  deviceID,
+				Status:    http.StatusUnprocessableEntity,
+				Error:     devauth.ErrMaxDeviceCountReached.Error(),
+			}, {
+				Item:      3,
+				AuthSetID: "broken",
+				DeviceID:  deviceID,
+				Status:    http.StatusInternalServerError,
+				Error:     "internal error",
+			}},
+		},
+		"ok, filter": {
+			req: map[string]interface{}{
+				"action": model.AuthSetActionDismiss,
+				"filter": map[string]interface{}{
+					"status": model.DevStatusRejected,
+				},
+			},
+			appCalled:  true,
+			appResults: []model.BulkResult{},
+			code:       http.StatusOK,
+			body:       []bulkItemResult{},
+		},
+		"error, ids and filter": {
+			req: map[string]interface{}{
+				"action":       model.AuthSetActionReject,
+				"auth_set_ids": []string{authSetID},
+				"filter": map[string]interface{}{
+					"status": model.DevStatusPending,
+				},
+			},
+			code: http.StatusBadRequest,
+			body: RestError("exactly one of auth_set_ids or filter is required"),
+		},
+		"error, invalid action": {
+			req: map[string]interface{}{
+				"action":       "decommission",
+				"auth_set_ids": []string{authSetID},
+			},
+			code: http.StatusBadRequest,
+			body: RestError("action: must be a valid value."),
+		},
+		"error, too many devices": {
+			req: map[string]interface{}{
+				"action": model.AuthSetActionReject,
+				"filter": map[string]interface{}{
+					"status": model.DevStatusPending,
+				},
+			},
+			appCalled: true,
+			appErr:    devauth.ErrBulkTooManyItems,
+			code:      http.StatusBadRequest,
+			body:      RestError(devauth.ErrBulkTooManyItems.Error()),
+		},
+		"error, internal": {
+			req: map[string]interface{}{
+				"action":       model.AuthSetActionReject,
+				"auth_set_ids": []string{authSetID},
+			},
+			appCalled: true,
+			appErr:    errors.New("workflows failed"),
+			code:      http.StatusInternalServerError,
+			body:      RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			if tc.appCalled {
+				da.On("SetAuthSetsStatus",
+					mtest.ContextMatcher(),
+					mock.AnythingOfType("*model.AuthSetsStatusReq")).
+					Return(tc.appResults, tc.appErr)
+			}
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost/api/management/v2/devauth/auth_sets/status",
+				Body:   tc.req,
+				Auth:   true,
+			})
+			body, ok := tc.body.(string)
+			if !ok {
+				b, _ := json.Marshal(tc.body)
+				body = string(b)
+			}
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, body)
+		})
+	}
+}
+
+func TestApiV2PreauthorizeDevices(t *testing.T) {
+	t.Parallel()
+
+	pubkeyStr := mtest.LoadPubKeyStr("testdata/public.pem")
+
+	csvBody := func(rows ...[]string) string {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.WriteAll(rows)
+		return buf.String()
+	}
+	ndjsonBody := func(docs ...interface{}) string {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, doc := range docs {
+			_ = enc.Encode(doc)
+		}
+		return buf.String()
+	}
+
+	testCases := map[string]struct {
+		contentType string
+		body        string
+
+		appReqs    int
+		appResults []model.BulkResult
+		appErr     error
+
+		code int
+		rsp  interface{}
+	}{
+		"ok, csv": {
+			contentType: "text/csv; charset=utf-8",
+			body: csvBody(
+				[]string{"mac", "sku", "pubkey"},
+				[]string{"00:1a:2b:3c:4d:5e", "gateway", pubkeyStr},
+				[]string{"00:1a:2b:3c:4d:5f", "", "garbage"},
+				[]string{"00:1a:2b:3c:4d:60", "sensor", pubkeyStr},
+			),
+			appReqs: 2,
+			appResults: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1"},
+				{AuthSetID: "aset-2", DeviceID: "device-0",
+					Err: devauth.ErrDeviceExists},
+			},
+			code: http.StatusOK,
+			rsp: []bulkItemResult{{
+				Item:      0,
+				AuthSetID: "aset-1",
+				DeviceID:  "device-1",
+				Status:    http.StatusCreated,
+			}, {
+				Item:   1,
+				Status: http.StatusBadRequest,
+				Error:  "cannot decode public key",
+			}, {
+				Item:      2,
+				AuthSetID: "aset-2",
+				DeviceID:  "device-0",
+				Status:    http.StatusConflict,
+				Error:     devauth.ErrDeviceExists.Error(),
+			}},
+		},
+		"ok, ndjson": {
+			contentType: "application/x-ndjson",
+			body: ndjsonBody(
+				map[string]interface{}{
+					"identity_data": map[string]string{"mac": "00:1a:2b:3c:4d:5e"},
+					"pubkey":        pubkeyStr,
+					"force":         true,
+				},
+				map[string]interface{}{
+					"pubkey": pubkeyStr,
+				},
+			),
+			appReqs: 1,
+			appResults: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1"},
+			},
+			code: http.StatusOK,
+			rsp: []bulkItemResult{{
+				Item:      0,
+				AuthSetID: "aset-1",
+				DeviceID:  "device-1",
+				Status:    http.StatusCreated,
+			}, {
+				Item:   1,
+				Status: http.StatusBadRequest,
+				Error:  "identity_data: cannot be blank.",
+			}},
+		},
+		"error, csv without pubkey column": {
+			contentType: "text/csv",
+			body: csvBody(
+				[]string{"mac"},
+				[]string{"00:1a:2b:3c:4d:5e"},
+			),
+			code: http.StatusBadRequest,
+			rsp: RestError("failed to decode preauth requests: " +
+				`missing column "pubkey"`),
+		},
+		"error, ndjson syntax": {
+			contentType: "application/x-ndjson",
+			body:        "{\"pubkey\": \n",
+			code:        http.StatusBadRequest,
+			rsp:         RestError("failed to decode preauth requests: unexpected EOF"),
+		},
+		"error, empty": {
+			contentType: "application/x-ndjson",
+			code:        http.StatusBadRequest,
+			rsp:         RestError("empty request body"),
+		},
+		"error, content type": {
+			contentType: "application/json",
+			body:        "[]",
+			code:        http.StatusUnsupportedMediaType,
+			rsp:         RestError("Content-Type 'application/json' not supported"),
+		},
+		"error, internal": {
+			contentType: "text/csv",
+			body: csvBody(
+				[]string{"pubkey", "mac"},
+				[]string{pubkeyStr, "00:1a:2b:3c:4d:5e"},
+			),
+			appReqs: 1,
+			appErr:  errors.New("workflows failed"),
+			code:    http.StatusInternalServerError,
+			rsp:     RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			if tc.appReqs > 0 {
+				da.On("PreauthorizeDevices",
+					mtest.ContextMatcher(),
+					mock.MatchedBy(func(reqs []*model.PreAuthReq) bool {
+						for _, req := range reqs {
+							if req.DeviceId == "" || req.AuthSetId == "" ||
+								req.PubKey != pubkeyStr {
+								return false
+							}
+						}
+						return len(reqs) == tc.appReqs
+					})).
+					Return(tc.appResults, tc.appErr)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost/api/management/v2/devauth/devices/preauthorize",
+				bytes.NewBufferString(tc.body))
+			req.Header.Set("Authorization", rtest.DEFAULT_AUTH)
+			req.Header.Set("Content-Type", tc.contentType)
+
+			body, ok := tc.rsp.(string)
+			if !ok {
+				b, _ := json.Marshal(tc.rsp)
+				body = string(b)
+			}
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, body)
+		})
+	}
+}
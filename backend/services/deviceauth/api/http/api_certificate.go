@@ -0,0 +1,169 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/rest.utils"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+)
+
+// clientCertificateChain returns the peer certificates of the mutual TLS
+// connection, or the client certificate chain forwarded by a trusted proxy
+// terminating the connection.
+func (i *DevAuthApiHandlers) clientCertificateChain(
+	r *http.Request,
+) ([]*x509.Certificate, error) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates, nil
+	}
+	chain := r.Header.Get(HdrClientCert)
+	if chain == "" {
+		return nil, errors.New("missing client certificate")
+	}
+	if !i.isClientCertProxy(r) {
+		return nil, errors.New("client certificate forwarded by an untrusted proxy")
+	}
+	return utils.ParseCertificateChain(chain)
+}
+
+// isClientCertProxy reports whether the request comes from a proxy trusted
+// to forward the client certificates.
+func (i *DevAuthApiHandlers) isClientCertProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range i.clientCertProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *DevAuthApiHandlers) SubmitCertificateAuthRequestHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	chain, err := i.clientCertificateChain(c.Request)
+	if err != nil {
+		err = errors.Wrap(err, "invalid auth request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// the request is signed with the key of the certificate, proving
+	// the device holds the key
+	body, err := utils.ReadBodyRaw(c.Request)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode auth request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if !json.Valid(body) {
+		rest.RenderError(c, http.StatusBadRequest,
+			errors.New("failed to decode auth request: invalid JSON"),
+		)
+		return
+	}
+	signature := c.GetHeader(HdrAuthReqSign)
+	if signature == "" {
+		rest.RenderError(c, http.StatusBadRequest,
+			errors.New("missing request signature header"),
+		)
+		return
+	}
+	err = utils.VerifyAuthReqSign(signature, chain[0].PublicKey, body)
+	if err != nil {
+		rest.RenderErrorWithMessage(c,
+			http.StatusUnauthorized,
+			errors.Cause(err),
+			"signature verification failed",
+		)
+		return
+	}
+
+	tokens, err := i.app.SubmitCertificateAuthRequest(ctx, chain)
+	renderAuthToken(c, tokens, err)
+}
+
+func (i *DevAuthApiHandlers) GetCertificateAuthoritiesHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	cas, err := i.app.GetCertificateAuthorities(ctx)
+	if err != nil {
+		rest.RenderInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cas)
+}
+
+func (i *DevAuthApiHandlers) PostCertificateAuthorityHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CertificateAuthorityReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode certificate authority request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		err = errors.Wrap(err, "invalid certificate authority request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	ca, err := i.app.AddCertificateAuthority(ctx, &req)
+	switch errors.Cause(err) {
+	case nil:
+		c.Header("Location", "certificate_authorities/"+ca.Id)
+		c.JSON(http.StatusCreated, ca)
+	case devauth.ErrCertificateAuthorityProof:
+		rest.RenderError(c, http.StatusBadRequest, err)
+	case devauth.ErrCertificateAuthorityExists:
+		rest.RenderError(c, http.StatusConflict, err)
+	default:
+		rest.RenderInternalError(c, err)
+	}
+}
+
+func (i *DevAuthApiHandlers) DeleteCertificateAuthorityHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := i.app.DeleteCertificateAuthority(ctx, c.Param("id"))
+	switch err {
+	case nil:
+		c.Status(http.StatusNoContent)
+	case devauth.ErrCertificateAuthorityNotFound:
+		rest.RenderError(c, http.StatusNotFound, err)
+	default:
+		rest.RenderInternalError(c, err)
+	}
+}
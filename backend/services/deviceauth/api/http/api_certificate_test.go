@@ -0,0 +1,448 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package http
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	rtest "github.com/mendersoftware/mender-server/pkg/testing/rest"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth"
+	"github.com/mendersoftware/mender-server/services/deviceauth/devauth/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	mtest "github.com/mendersoftware/mender-server/services/deviceauth/utils/testing"
+)
+
+func newTestCertificate(t *testing.T, cn string, isCA bool) *x509.Certificate {
+	cert, _ := newTestCertificateKey(t, cn, isCA)
+	return cert
+}
+
+func newTestCertificateKey(
+	t *testing.T,
+	cn string,
+	isCA bool,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func encodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	}))
+}
+
+func TestApiDevAuthSubmitCertificateAuthReq(t *testing.T) {
+	t.Parallel()
+
+	cert, key := newTestCertificateKey(t, "device-0001", false)
+	_, otherKey := newTestCertificateKey(t, "device-0002", false)
+	certMatcher := mock.MatchedBy(func(chain []*x509.Certificate) bool {
+		return len(chain) == 1 && chain[0].Equal(cert)
+	})
+	_, proxy, _ := net.ParseCIDR("10.0.0.0/8")
+	body := []byte(`{}`)
+
+	testCases := map[string]struct {
+		header     string
+		remoteAddr string
+		tls        bool
+		body       []byte
+		signer     *ecdsa.PrivateKey
+		signature  string
+
+		token  string
+		appErr error
+
+		code int
+		resp string
+	}{
+		"ok": {
+			header: url.QueryEscape(encodeCertificate(cert)),
+			token:  "dummytoken",
+			code:   http.StatusOK,
+			resp:   "dummytoken",
+		},
+		"ok, tls peer certificate": {
+			tls:   true,
+			token: "dummytoken",
+			code:  http.StatusOK,
+			resp:  "dummytoken",
+		},
+		"error, untrusted proxy": {
+			header:     url.QueryEscape(encodeCertificate(cert)),
+			remoteAddr: "192.0.2.1:1234",
+			code:       http.StatusBadRequest,
+			resp: RestError("invalid auth request: " +
+				"client certificate forwarded by an untrusted proxy"),
+		},
+		"error, missing certificate": {
+			code: http.StatusBadRequest,
+			resp: RestError("invalid auth request: missing client certificate"),
+		},
+		"error, invalid certificate": {
+			header: "Z2FyYmFnZQ==",
+			code:   http.StatusBadRequest,
+			resp: RestError("invalid auth request: cannot decode certificate: " +
+				"x509: malformed certificate"),
+		},
+		"error, invalid body": {
+			tls:  true,
+			body: []byte("garbage"),
+			code: http.StatusBadRequest,
+			resp: RestError("failed to decode auth request: invalid JSON"),
+		},
+		"error, missing signature": {
+			tls:       true,
+			signature: "-",
+			code:      http.StatusBadRequest,
+			resp:      RestError("missing request signature header"),
+		},
+		"error, signed with another key": {
+			tls:    true,
+			signer: otherKey,
+			code:   http.StatusUnauthorized,
+			resp:   RestError("signature verification failed"),
+		},
+		"error, unauthorized": {
+			header: url.QueryEscape(encodeCertificate(cert)),
+			appErr: devauth.MakeErrDevAuthUnauthorized(
+				errors.New("certificate is not issued by a registered certificate authority"),
+			),
+			code: http.StatusUnauthorized,
+			resp: RestError("certificate is not issued by a registered certificate authority"),
+		},
+		"error, bad request": {
+			header: url.QueryEscape(encodeCertificate(cert)),
+			appErr: devauth.MakeErrDevAuthBadRequest(
+				errors.New(`certificate subject is missing the "CN" attribute`),
+			),
+			code: http.StatusBadRequest,
+			resp: RestError(`certificate subject is missing the "CN" attribute`),
+		},
+		"error, device limit": {
+			header: url.QueryEscape(encodeCertificate(cert)),
+			appErr: devauth.ErrMaxDeviceCountReached,
+			code:   http.StatusUnauthorized,
+			resp:   RestError("unauthorized"),
+		},
+		"error, internal": {
+			header: url.QueryEscape(encodeCertificate(cert)),
+			appErr: errors.New("connection refused"),
+			code:   http.StatusInternalServerError,
+			resp:   RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.body == nil {
+				tc.body = body
+			}
+			if tc.remoteAddr == "" {
+				tc.remoteAddr = "10.1.2.3:1234"
+			}
+			if tc.signer == nil {
+				tc.signer = key
+			}
+
+			da := mocks.NewApp(t)
+			if tc.token != "" || tc.appErr != nil {
+				da.On("SubmitCertificateAuthRequest",
+					mtest.ContextMatcher(),
+					certMatcher).
+					Return(model.DeviceTokens{Token: tc.token}, tc.appErr)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost,
+				"http://localhost/api/devices/v1/authentication/auth_requests/certificate",
+				bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = tc.remoteAddr
+			switch tc.signature {
+			case "":
+				req.Header.Set(HdrAuthReqSign,
+					string(mtest.AuthReqSign(tc.body, tc.signer, t)))
+			case "-":
+			default:
+				req.Header.Set(HdrAuthReqSign, tc.signature)
+			}
+			if tc.header != "" {
+				req.Header.Set(HdrClientCert, tc.header)
+			}
+			if tc.tls {
+				req.TLS = &tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{cert},
+				}
+			}
+			apih := NewRouter(da, nil, SetClientCertProxies([]*net.IPNet{proxy}))
+			runTestRequest(t, apih, req, tc.code, tc.resp)
+		})
+	}
+}
+
+func TestApiV2PostCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	cert := newTestCertificate(t, "Factory CA", true)
+	ca := &model.CertificateAuthority{
+		Id:                 "0b5c12a0-d0d2-44d5-8d41-b2fe5cbe5d73",
+		Name:               "factory",
+		Certificate:        encodeCertificate(cert),
+		Subject:            "CN=Factory CA",
+		IdentityAttributes: model.DefaultCertIdentityAttributes,
+	}
+	body, _ := json.Marshal(ca)
+
+	testCases := map[string]struct {
+		req interface{}
+
+		appCalled bool
+		appErr    error
+
+		code int
+		body string
+	}{
+		"ok": {
+			req: map[string]interface{}{
+				"name":        "factory",
+				"certificate": encodeCertificate(cert),
+				"signature":   "c2lnbmF0dXJl",
+			},
+			appCalled: true,
+			code:      http.StatusCreated,
+			body:      string(body),
+		},
+		"error, not a certificate authority": {
+			req: map[string]interface{}{
+				"certificate": encodeCertificate(newTestCertificate(t, "device", false)),
+				"signature":   "c2lnbmF0dXJl",
+			},
+			code: http.StatusBadRequest,
+			body: RestError("invalid certificate authority request: " +
+				"certificate is not a certificate authority"),
+		},
+		"error, malformed": {
+			req:  []string{"garbage"},
+			code: http.StatusBadRequest,
+			body: RestError("failed to decode certificate authority request: " +
+				"json: cannot unmarshal array into Go value of type " +
+				"model.CertificateAuthorityReq"),
+		},
+		"error, missing signature": {
+			req: map[string]interface{}{
+				"certificate": encodeCertificate(cert),
+			},
+			code: http.StatusBadRequest,
+			body: RestError("invalid certificate authority request: " +
+				"signature: cannot be blank."),
+		},
+		"error, no proof of possession": {
+			req: map[string]interface{}{
+				"certificate": encodeCertificate(cert),
+				"signature":   "c2lnbmF0dXJl",
+			},
+			appCalled: true,
+			appErr: errors.Wrap(devauth.ErrCertificateAuthorityProof,
+				"verification failed"),
+			code: http.StatusBadRequest,
+			body: RestError("verification failed: " +
+				devauth.ErrCertificateAuthorityProof.Error()),
+		},
+		"error, exists": {
+			req: map[string]interface{}{
+				"certificate": encodeCertificate(cert),
+				"signature":   "c2lnbmF0dXJl",
+			},
+			appCalled: true,
+			appErr:    devauth.ErrCertificateAuthorityExists,
+			code:      http.StatusConflict,
+			body:      RestError(devauth.ErrCertificateAuthorityExists.Error()),
+		},
+		"error, internal": {
+			req: map[string]interface{}{
+				"certificate": encodeCertificate(cert),
+				"signature":   "c2lnbmF0dXJl",
+			},
+			appCalled: true,
+			appErr:    errors.New("connection refused"),
+			code:      http.StatusInternalServerError,
+			body:      RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			if tc.appCalled {
+				res := ca
+				if tc.appErr != nil {
+					res = nil
+				}
+				da.On("AddCertificateAuthority",
+					mtest.ContextMatcher(),
+					mock.MatchedBy(func(req *model.CertificateAuthorityReq) bool {
+						return req.Cert != nil && req.Cert.Equal(cert)
+					})).
+					Return(res, tc.appErr)
+			}
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost/api/management/v2/devauth/certificate_authorities",
+				Body:   tc.req,
+				Auth:   true,
+			})
+			apih := makeMockApiHandler(t, da, nil)
+			rsp := runTestRequest(t, apih, req, tc.code, tc.body)
+			if tc.code == http.StatusCreated {
+				assert.Equal(t, "certificate_authorities/"+ca.Id,
+					rsp.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestApiV2GetCertificateAuthorities(t *testing.T) {
+	t.Parallel()
+
+	cas := []model.CertificateAuthority{{
+		Id:          "0b5c12a0-d0d2-44d5-8d41-b2fe5cbe5d73",
+		Name:        "factory",
+		Fingerprint: "fingerprint",
+	}}
+	body, _ := json.Marshal(cas)
+
+	testCases := map[string]struct {
+		cas    []model.CertificateAuthority
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			cas:  cas,
+			code: http.StatusOK,
+			body: string(body),
+		},
+		"error, internal": {
+			appErr: errors.New("connection refused"),
+			code:   http.StatusInternalServerError,
+			body:   RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			da.On("GetCertificateAuthorities", mtest.ContextMatcher()).
+				Return(tc.cas, tc.appErr)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodGet,
+				Path:   "http://localhost/api/management/v2/devauth/certificate_authorities",
+				Auth:   true,
+			})
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, tc.body)
+		})
+	}
+}
+
+func TestApiV2DeleteCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		appErr error
+
+		code int
+		body string
+	}{
+		"ok": {
+			code: http.StatusNoContent,
+		},
+		"error, not found": {
+			appErr: devauth.ErrCertificateAuthorityNotFound,
+			code:   http.StatusNotFound,
+			body:   RestError(devauth.ErrCertificateAuthorityNotFound.Error()),
+		},
+		"error, internal": {
+			appErr: errors.New("connection refused"),
+			code:   http.StatusInternalServerError,
+			body:   RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			da := mocks.NewApp(t)
+			da.On("DeleteCertificateAuthority", mtest.ContextMatcher(), "foo").
+				Return(tc.appErr)
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodDelete,
+				Path:   "http://localhost/api/management/v2/devauth/certificate_authorities/foo",
+				Auth:   true,
+			})
+			apih := makeMockApiHandler(t, da, nil)
+			runTestRequest(t, apih, req, tc.code, tc.body)
+		})
+	}
+}
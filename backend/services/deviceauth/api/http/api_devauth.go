@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -47,9 +48,10 @@ var (
 )
 
 type DevAuthApiHandlers struct {
-	app         devauth.App
-	db          store.DataStore
-	rateLimiter gin.HandlerFunc
+	app               devauth.App
+	db                store.DataStore
+	rateLimiter       gin.HandlerFunc
+	clientCertProxies []*net.IPNet
 }
 
 type DevAuthApiStatus struct {
@@ -67,9 +69,10 @@ func NewDevAuthApiHandlers(
 	}
 
 	return &DevAuthApiHandlers{
-		app:         devAuth,
-		db:          db,
-		rateLimiter: cfg.AuthVerifyRatelimits,
+		app:               devAuth,
+		db:                db,
+		rateLimiter:       cfg.AuthVerifyRatelimits,
+		clientCertProxies: cfg.ClientCertProxies,
 	}
 }
 
@@ -138,7 +141,53 @@ func (i *DevAuthApiHandlers) SubmitAuthRequestHandler(c *gin.Context) {
 		return
 	}
 
-	token, err := i.app.SubmitAuthRequest(ctx, &authreq)
+	tokens, err := i.app.SubmitAuthRequest(ctx, &authreq)
+	renderAuthToken(c, tokens, err)
+}
+
+func (i *DevAuthApiHandlers) RefreshTokenHandler(c *gin.Context) {
+	var req model.RefreshTokenReq
+
+	ctx := c.Request.Context()
+
+	// the raw body is needed to verify the signature
+	body, err := utils.ReadBodyRaw(c.Request)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode refresh token request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode refresh token request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = req.Validate()
+	if err != nil {
+		err = errors.Wrap(err, "invalid refresh token request")
+		rest.RenderError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	req.Signature = c.GetHeader(HdrAuthReqSign)
+	if req.Signature == "" {
+		rest.RenderError(c, http.StatusBadRequest,
+			errors.New("missing request signature header"),
+		)
+		return
+	}
+	req.Raw = body
+
+	tokens, err := i.app.RefreshDeviceToken(ctx, &req)
+	renderAuthToken(c, tokens, err)
+}
+
+// renderAuthToken renders the token issued for an auth request, passing
+// the refresh token in a header, or the auth request error
+func renderAuthToken(c *gin.Context, tokens model.DeviceTokens, err error) {
 	if err != nil {
 		switch {
 		case devauth.IsErrDevAuthUnauthorized(err):
@@ -161,8 +210,11 @@ func (i *DevAuthApiHandlers) SubmitAuthRequestHandler(c *gin.Context) {
 
 	switch err {
 	case nil:
+		if tokens.RefreshToken != "" {
+			c.Header(HdrRefreshToken, tokens.RefreshToken)
+		}
 		c.Header("Content-Type", "application/jwt")
-		_, _ = c.Writer.Write([]byte(token))
+		_, _ = c.Writer.Write([]byte(tokens.Token))
 		return
 	case devauth.ErrDevIdAuthIdMismatch, devauth.ErrMaxDeviceCountReached:
 		// error is always set to unauthorized, client does not need to
@@ -607,6 +659,11 @@ func (i *DevAuthApiHandlers) DeleteTokensHandler(c *gin.Context) {
 	}
 }
 
+func (i *DevAuthApiHandlers) GetJWKSHandler(c *gin.Context) {
+	jwks := i.app.GetJWKS(c.Request.Context())
+	c.JSON(http.StatusOK, jwks)
+}
+
 func (i *DevAuthApiHandlers) GetAuthSetStatusHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
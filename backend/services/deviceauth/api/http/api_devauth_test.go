@@ -342,11 +342,11 @@ func TestApiDevAuthSubmitAuthReq(t *testing.T) {
 				mtest.ContextMatcher(),
 				mock.AnythingOfType("*model.AuthReq")).
 				Return(
-					func(_ context.Context, r *model.AuthReq) string {
+					func(_ context.Context, r *model.AuthReq) model.DeviceTokens {
 						if tc.devAuthErr != nil {
-							return ""
+							return model.DeviceTokens{}
 						}
-						return tc.devAuthToken
+						return model.DeviceTokens{Token: tc.devAuthToken}
 					},
 					tc.devAuthErr)
 
@@ -361,6 +361,107 @@ func TestApiDevAuthSubmitAuthReq(t *testing.T) {
 	}
 }
 
+func TestApiDevAuthRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	privkey := mtest.LoadPrivKey("testdata/private.pem")
+	body := map[string]interface{}{"refresh_token": "aset-1.secret"}
+
+	testCases := map[string]struct {
+		body      interface{}
+		signature string
+
+		tokens model.DeviceTokens
+		appErr error
+
+		code int
+		resp string
+	}{
+		"ok": {
+			body: body,
+			tokens: model.DeviceTokens{
+				Token:        "token",
+				RefreshToken: "aset-1.newsecret",
+			},
+			code: http.StatusOK,
+			resp: "token",
+		},
+		"error, empty body": {
+			signature: "dontcare",
+			code:      http.StatusBadRequest,
+			resp: RestError(
+				"failed to decode refresh token request: empty request body"),
+		},
+		"error, no refresh token": {
+			body:      map[string]interface{}{},
+			signature: "dontcare",
+			code:      http.StatusBadRequest,
+			resp: RestError(
+				"invalid refresh token request: refresh_token: cannot be blank."),
+		},
+		"error, no signature": {
+			body:      body,
+			signature: "-",
+			code:      http.StatusBadRequest,
+			resp:      RestError("missing request signature header"),
+		},
+		"error, unauthorized": {
+			body:   body,
+			appErr: devauth.MakeErrDevAuthUnauthorized(devauth.ErrInvalidRefreshToken),
+			code:   http.StatusUnauthorized,
+			resp:   RestError(devauth.ErrInvalidRefreshToken.Error()),
+		},
+		"error, internal": {
+			body:   body,
+			appErr: errors.New("connection refused"),
+			code:   http.StatusInternalServerError,
+			resp:   RestError("internal error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := rtest.MakeTestRequest(&rtest.TestRequest{
+				Method: http.MethodPost,
+				Path:   "http://localhost/api/devices/v1/authentication/tokens/refresh",
+				Body:   tc.body,
+			})
+			switch tc.signature {
+			case "":
+				b, _ := json.Marshal(tc.body)
+				req.Header.Set(HdrAuthReqSign, string(mtest.AuthReqSign(b, privkey, t)))
+			case "-":
+			default:
+				req.Header.Set(HdrAuthReqSign, tc.signature)
+			}
+
+			da := mocks.NewApp(t)
+			if tc.code == http.StatusOK || tc.appErr != nil {
+				da.On("RefreshDeviceToken",
+					mtest.ContextMatcher(),
+					mock.MatchedBy(func(r *model.RefreshTokenReq) bool {
+						return r.RefreshToken == "aset-1.secret" &&
+							r.Signature == req.Header.Get(HdrAuthReqSign) &&
+							len(r.Raw) > 0
+					})).
+					Return(tc.tokens, tc.appErr)
+			}
+
+			apih := makeMockApiHandler(t, da, nil)
+			recorded := runTestRequest(t, apih, req, tc.code, tc.resp)
+			assert.Equal(t, tc.tokens.RefreshToken,
+				recorded.Result().Header.Get(HdrRefreshToken))
+			if tc.code == http.StatusOK {
+				assert.Equal(t, "application/jwt",
+					recorded.Result().Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
 // Custom checker for the Location header in a preauth response
 type DevicePreauthReturnID struct {
 	mt.JSONResponse
@@ -1924,6 +2025,31 @@ func TestApiDeleteTokens(t *testing.T) {
 	}
 }
 
+func TestApiGetJWKS(t *testing.T) {
+	t.Parallel()
+
+	jwks := &jwt.JWKS{Keys: []jwt.JWK{{
+		KeyType:   "OKP",
+		KeyID:     "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+		Algorithm: "EdDSA",
+		Use:       "sig",
+		Curve:     "Ed25519",
+		X:         "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}}}
+
+	da := &mocks.App{}
+	da.On("GetJWKS", mtest.ContextMatcher()).Return(jwks)
+
+	req := rtest.MakeTestRequest(&rtest.TestRequest{
+		Method: "GET",
+		Path:   "http://localhost" + apiUrlInternalV1 + uriJWKS,
+	})
+
+	apih := makeMockApiHandler(t, da, nil)
+	recorded := runSimpleTestRequest(t, apih, req)
+	mt.CheckHTTPResponse(t, mt.NewJSONResponse(http.StatusOK, nil, jwks), recorded)
+}
+
 func TestApiDevAuthGetTenantDeviceStatus(t *testing.T) {
 	t.Parallel()
 
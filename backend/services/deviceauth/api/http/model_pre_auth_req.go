@@ -14,8 +14,10 @@
 package http
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"io"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
@@ -98,3 +100,77 @@ func (r *preAuthReq) getDbModel() (*model.PreAuthReq, error) {
 		PubKey:    r.PubKey,
 	}, nil
 }
+
+// csvColumnPubKey is the column of the preauthorization CSV holding the
+// public key; the other columns are the identity attributes.
+const csvColumnPubKey = "pubkey"
+
+// parsePreAuthReqsCSV parses the preauthorization requests from the CSV
+// rows; the errors of the rows failing validation are returned in errs.
+func parsePreAuthReqsCSV(source io.Reader) (reqs []*preAuthReq, errs []error, err error) {
+	r := csv.NewReader(source)
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("empty request body")
+	} else if err != nil {
+		return nil, nil, err
+	}
+	keyColumn := -1
+	seen := make(map[string]struct{}, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		header[i] = name
+		if name == "" {
+			return nil, nil, errors.Errorf("column %d: empty column name", i+1)
+		} else if _, ok := seen[name]; ok {
+			return nil, nil, errors.Errorf("duplicate column %q", name)
+		}
+		seen[name] = struct{}{}
+		if name == csvColumnPubKey {
+			keyColumn = i
+		}
+	}
+	if keyColumn < 0 {
+		return nil, nil, errors.Errorf("missing column %q", csvColumnPubKey)
+	}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		req := &preAuthReq{
+			IdData: make(map[string]interface{}, len(record)-1),
+		}
+		for i, value := range record {
+			if i == keyColumn {
+				req.PubKey = value
+			} else if value != "" {
+				req.IdData[header[i]] = value
+			}
+		}
+		reqs = append(reqs, req)
+		errs = append(errs, req.validate())
+	}
+	return reqs, errs, nil
+}
+
+// parsePreAuthReqsNDJSON parses the preauthorization requests from the
+// newline delimited JSON documents; the errors of the documents failing
+// validation are returned in errs.
+func parsePreAuthReqsNDJSON(source io.Reader) (reqs []*preAuthReq, errs []error, err error) {
+	jd := json.NewDecoder(source)
+	for {
+		var req preAuthReq
+		err := jd.Decode(&req)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+		reqs = append(reqs, &req)
+		errs = append(errs, req.validate())
+	}
+	return reqs, errs, nil
+}
@@ -14,6 +14,7 @@
 package http
 
 import (
+	"net"
 	"net/http"
 	"strings"
 
@@ -32,12 +33,15 @@ import (
 const (
 	apiUrlDevicesV1 = "/api/devices/v1/authentication"
 	uriAuthReqs     = "/auth_requests"
+	uriAuthReqsCert = "/auth_requests/certificate"
+	uriTokenRefresh = "/tokens/refresh"
 
 	// internal API
 	apiUrlInternalV1      = "/api/internal/v1/devauth"
 	uriAlive              = "/alive"
 	uriHealth             = "/health"
 	uriTokenVerify        = "/tokens/verify"
+	uriJWKS               = "/jwks"
 	uriTenantLimit        = "/tenant/:id/limits/:name"
 	uriTokens             = "/tokens"
 	uriTenants            = "/tenants"
@@ -54,10 +58,21 @@ const (
 	v2uriDevice              = "/devices/:id"
 	v2uriDeviceAuthSet       = "/devices/:id/auth/:aid"
 	v2uriDeviceAuthSetStatus = "/devices/:id/auth/:aid/status"
+	v2uriDevicesPreauthorize = "/devices/preauthorize"
+	v2uriAuthSetsStatus      = "/auth_sets/status"
 	v2uriToken               = "/tokens/:id"
 	v2uriDevicesLimit        = "/limits/:name"
+	v2uriCAs                 = "/certificate_authorities"
+	v2uriCA                  = "/certificate_authorities/:id"
+	v2uriAutoAcceptRules     = "/auto_accept_rules"
+	v2uriAutoAcceptRule      = "/auto_accept_rules/:id"
 
 	HdrAuthReqSign = "X-MEN-Signature"
+	// HdrRefreshToken carries the refresh token issued to the device
+	HdrRefreshToken = "X-MEN-Refresh-Token"
+	// HdrClientCert carries the client certificate chain of the devices
+	// authenticating with mutual TLS, forwarded by a trusted proxy
+	HdrClientCert = "X-Forwarded-Tls-Client-Cert"
 )
 
 type HttpOptionsGenerator func(methods []string) gin.HandlerFunc
@@ -104,6 +119,9 @@ func AutogenOptionsRoutes(router *gin.Engine, gen HttpOptionsGenerator) {
 type Config struct {
 	AuthVerifyRatelimits gin.HandlerFunc
 	MaxRequestSize       int64
+	// ClientCertProxies are the networks of the proxies trusted to
+	// forward the client certificates of the devices
+	ClientCertProxies []*net.IPNet
 }
 
 type Option func(c *Config)
@@ -114,6 +132,12 @@ func SetMaxRequestSize(size int64) Option {
 	}
 }
 
+func SetClientCertProxies(proxies []*net.IPNet) Option {
+	return func(c *Config) {
+		c.ClientCertProxies = proxies
+	}
+}
+
 func ConfigAuthVerifyRatelimits(handler gin.HandlerFunc) Option {
 	return func(c *Config) {
 		c.AuthVerifyRatelimits = handler
@@ -142,7 +166,9 @@ func NewRouter(app devauth.App, db store.DataStore, options ...Option) http.Hand
 
 	// Devices API
 	devicesAPIs.Group(".").Use(contenttype.CheckJSON()).
-		POST(uriAuthReqs, d.SubmitAuthRequestHandler)
+		POST(uriAuthReqs, d.SubmitAuthRequestHandler).
+		POST(uriAuthReqsCert, d.SubmitCertificateAuthRequestHandler).
+		POST(uriTokenRefresh, d.RefreshTokenHandler)
 
 	// API v2
 	mgmtAPIV2.GET(v2uriDevicesCount, d.GetDevicesCountHandler)
@@ -150,13 +176,21 @@ func NewRouter(app devauth.App, db store.DataStore, options ...Option) http.Hand
 	mgmtAPIV2.GET(v2uriDevice, d.GetDeviceV2Handler)
 	mgmtAPIV2.GET(v2uriDeviceAuthSetStatus, d.GetAuthSetStatusHandler)
 	mgmtAPIV2.GET(v2uriDevicesLimit, d.GetLimitHandler)
+	mgmtAPIV2.GET(v2uriCAs, d.GetCertificateAuthoritiesHandler)
+	mgmtAPIV2.GET(v2uriAutoAcceptRules, d.GetAutoAcceptRulesHandler)
 	mgmtAPIV2.DELETE(v2uriDevice, d.DecommissionDeviceHandler)
 	mgmtAPIV2.DELETE(v2uriDeviceAuthSet, d.DeleteDeviceAuthSetHandler)
 	mgmtAPIV2.DELETE(v2uriToken, d.DeleteTokenHandler)
+	mgmtAPIV2.DELETE(v2uriCA, d.DeleteCertificateAuthorityHandler)
+	mgmtAPIV2.DELETE(v2uriAutoAcceptRule, d.DeleteAutoAcceptRuleHandler)
+	mgmtAPIV2.POST(v2uriDevicesPreauthorize, d.PreauthorizeDevicesHandler)
 	mgmtAPIV2.Group(".").Use(contenttype.CheckJSON()).
 		POST(v2uriDevices, d.PostDevicesV2Handler).
 		PUT(v2uriDeviceAuthSetStatus, d.UpdateDeviceStatusHandler).
-		POST(v2uriDevicesSearch, d.SearchDevicesV2Handler)
+		POST(v2uriDevicesSearch, d.SearchDevicesV2Handler).
+		POST(v2uriCAs, d.PostCertificateAuthorityHandler).
+		POST(v2uriAutoAcceptRules, d.PostAutoAcceptRuleHandler).
+		POST(v2uriAuthSetsStatus, d.UpdateAuthSetsStatusHandler)
 
 	// automatically add Option routes for public endpoints
 	AutogenOptionsRoutes(router, AllowHeaderOptionsGenerator)
@@ -171,6 +205,7 @@ func NewRouter(app devauth.App, db store.DataStore, options ...Option) http.Hand
 		GET(uriTokenVerify, d.VerifyTokenHandler).
 		POST(uriTokenVerify, d.VerifyTokenHandler)
 	intrnlAPIV1.DELETE(uriTokens, d.DeleteTokensHandler)
+	intrnlAPIV1.GET(uriJWKS, d.GetJWKSHandler)
 	intrnlAPIV1.PUT(uriTenantLimit, d.PutTenantLimitHandler)
 	intrnlAPIV1.GET(uriTenantLimit, d.GetTenantLimitHandler)
 	intrnlAPIV1.DELETE(uriTenantLimit, d.DeleteTenantLimitHandler)
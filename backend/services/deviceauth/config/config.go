@@ -67,12 +67,24 @@ const (
 	SettingServerFallbackPrivKeyPath        = "server_fallback_priv_key_path"
 	SettingServerFallbackPrivKeyPathDefault = ""
 
+	SettingServerPrivKeyDir        = "server_priv_key_dir"
+	SettingServerPrivKeyDirDefault = ""
+
+	SettingServerPrivKeySigning        = "server_priv_key_signing"
+	SettingServerPrivKeySigningDefault = ""
+
 	SettingJWTIssuer        = "jwt_issuer"
 	SettingJWTIssuerDefault = "Mender"
 
 	SettingJWTExpirationTimeout        = "jwt_exp_timeout"
 	SettingJWTExpirationTimeoutDefault = "604800" //one week
 
+	SettingJWTRefreshExpirationTimeout        = "jwt_refresh_exp_timeout"
+	SettingJWTRefreshExpirationTimeoutDefault = "0" // disabled
+
+	SettingJWTShortExpirationTimeout        = "jwt_short_exp_timeout"
+	SettingJWTShortExpirationTimeoutDefault = "900" // 15 minutes
+
 	SettingRedisConnectionString        = "redis_connection_string"
 	SettingRedisConnectionStringDefault = ""
 
@@ -89,6 +101,9 @@ const (
 	SettingMaxRequestSizeDefault = 1024 * 1024 // 1 MiB
 
 	SettingLegacyProvisionDevice = "legacy_provision_device"
+
+	// Networks of the proxies trusted to forward the client certificates
+	SettingClientCertTrustedProxies = "client_cert_trusted_proxies"
 )
 
 var (
@@ -101,8 +116,13 @@ var (
 		{Key: SettingOrchestratorAddr, Value: SettingOrchestratorAddrDefault},
 		{Key: SettingServerPrivKeyPath, Value: SettingServerPrivKeyPathDefault},
 		{Key: SettingServerFallbackPrivKeyPath, Value: SettingServerFallbackPrivKeyPathDefault},
+		{Key: SettingServerPrivKeyDir, Value: SettingServerPrivKeyDirDefault},
+		{Key: SettingServerPrivKeySigning, Value: SettingServerPrivKeySigningDefault},
 		{Key: SettingJWTIssuer, Value: SettingJWTIssuerDefault},
 		{Key: SettingJWTExpirationTimeout, Value: SettingJWTExpirationTimeoutDefault},
+		{Key: SettingJWTRefreshExpirationTimeout,
+			Value: SettingJWTRefreshExpirationTimeoutDefault},
+		{Key: SettingJWTShortExpirationTimeout, Value: SettingJWTShortExpirationTimeoutDefault},
 		{Key: SettingDbSSL, Value: SettingDbSSLDefault},
 		{Key: SettingDbSSLSkipVerify, Value: SettingDbSSLSkipVerifyDefault},
 		{Key: SettingRedisLimitsExpSec, Value: SettingRedisLimitsExpSecDefault},
@@ -0,0 +1,116 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/oid"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+var ErrAutoAcceptRuleNotFound = errors.New("auto-accept rule not found")
+
+func (d *DevAuth) AddAutoAcceptRule(
+	ctx context.Context,
+	req *model.AutoAcceptRuleReq,
+) (*model.AutoAcceptRule, error) {
+	rule := model.NewAutoAcceptRule(oid.NewUUIDv4().String(), req)
+	if err := d.db.AddAutoAcceptRule(ctx, *rule); err != nil {
+		return nil, errors.Wrap(err, "failed to add auto-accept rule")
+	}
+	return rule, nil
+}
+
+func (d *DevAuth) GetAutoAcceptRules(ctx context.Context) ([]model.AutoAcceptRule, error) {
+	rules, err := d.db.GetAutoAcceptRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list auto-accept rules")
+	}
+	return rules, nil
+}
+
+func (d *DevAuth) DeleteAutoAcceptRule(ctx context.Context, id string) error {
+	err := d.db.DeleteAutoAcceptRule(ctx, id)
+	switch err {
+	case nil:
+		return nil
+	case store.ErrAutoAcceptRuleNotFound:
+		return ErrAutoAcceptRuleNotFound
+	default:
+		return errors.Wrap(err, "failed to delete auto-accept rule")
+	}
+}
+
+// autoAcceptAuthSet accepts the pending auth set if it matches any of the
+// auto-accept rules. Only devices neither accepted nor rejected are
+// accepted, so that a matching identity can't take over an accepted device
+// and a device rejected by the user can't get accepted with a new key; the
+// devices exceeding the device limit stay pending. Returns nil if the auth
+// set was not accepted.
+func (d *DevAuth) autoAcceptAuthSet(
+	ctx context.Context,
+	r *model.AuthReq,
+	dev *model.Device,
+	aset *model.AuthSet,
+) (*model.AuthSet, error) {
+	l := log.FromContext(ctx)
+
+	if aset.Status != model.DevStatusPending {
+		return nil, nil
+	}
+	switch dev.Status {
+	case model.DevStatusAccepted, model.DevStatusRejected:
+		return nil, nil
+	}
+	rules, err := d.db.GetAutoAcceptRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch auto-accept rules")
+	}
+	var match *model.AutoAcceptRule
+	for i := range rules {
+		if rules[i].Match(aset.IdDataStruct, r.EnrollmentSecret) {
+			match = &rules[i]
+			break
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+
+	rejected, err := d.isDeviceRejected(ctx, dev.Id)
+	if err != nil {
+		return nil, err
+	} else if rejected {
+		l.Infof("device %s matches the auto-accept rule %s "+
+			"but was rejected", dev.Id, match.Id)
+		return nil, nil
+	}
+
+	aset, err = d.acceptAuthSet(ctx, aset)
+	if err == ErrMaxDeviceCountReached {
+		l.Warnf("device %s matches the auto-accept rule %s "+
+			"but the device limit is reached", dev.Id, match.Id)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	l.Infof("device %s accepted by the auto-accept rule %s", dev.Id, match.Id)
+	return aset, nil
+}
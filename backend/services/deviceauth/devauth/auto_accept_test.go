@@ -0,0 +1,231 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceauth/store/mocks"
+)
+
+func TestDevAuthAutoAcceptAuthSet(t *testing.T) {
+	t.Parallel()
+
+	const (
+		devID  = "c39b2a4f-cc83-4d6b-a5ee-3b7a8ef1f7b6"
+		authID = "1a5dfa69-3e56-4b31-8a1c-5dd0a6b0e35e"
+		secret = "0123456789abcdef"
+	)
+	idData := map[string]interface{}{"mac": "00:1a:2b:3c:4d:5e"}
+	macRule := model.NewAutoAcceptRule("mac", &model.AutoAcceptRuleReq{
+		IdData: []model.IdDataMatcher{{Attribute: "mac", Prefix: "00:1A:2B"}},
+	})
+	secretRule := model.NewAutoAcceptRule("secret", &model.AutoAcceptRuleReq{
+		EnrollmentSecret: secret,
+	})
+
+	testCases := map[string]struct {
+		req    model.AuthReq
+		device *model.Device
+		status string
+
+		rules    []model.AutoAcceptRule
+		rulesErr error
+		match    bool
+		authSets []model.AuthSet
+		setsErr  error
+		limit    int64
+
+		accepted bool
+		err      error
+	}{
+		"ok, accepted by id data": {
+			device:   &model.Device{Id: devID, Status: model.DevStatusPending},
+			status:   model.DevStatusPending,
+			rules:    []model.AutoAcceptRule{*secretRule, *macRule},
+			match:    true,
+			limit:    model.LimitUnlimited,
+			accepted: true,
+		},
+		"ok, accepted by enrollment secret": {
+			req:      model.AuthReq{EnrollmentSecret: secret},
+			device:   &model.Device{Id: devID, Status: model.DevStatusNoAuth},
+			status:   model.DevStatusPending,
+			rules:    []model.AutoAcceptRule{*secretRule},
+			match:    true,
+			limit:    model.LimitUnlimited,
+			accepted: true,
+		},
+		"ok, no matching rule": {
+			req:    model.AuthReq{EnrollmentSecret: "fedcba9876543210"},
+			device: &model.Device{Id: devID, Status: model.DevStatusPending},
+			status: model.DevStatusPending,
+			rules:  []model.AutoAcceptRule{*secretRule},
+		},
+		"ok, device limit reached": {
+			device: &model.Device{Id: devID, Status: model.DevStatusPending},
+			status: model.DevStatusPending,
+			rules:  []model.AutoAcceptRule{*macRule},
+			match:  true,
+			limit:  0,
+		},
+		"ok, device already accepted": {
+			device: &model.Device{Id: devID, Status: model.DevStatusAccepted},
+			status: model.DevStatusPending,
+		},
+		"ok, device rejected": {
+			device: &model.Device{Id: devID, Status: model.DevStatusRejected},
+			status: model.DevStatusPending,
+		},
+		"ok, device rejected before with another key": {
+			device: &model.Device{Id: devID, Status: model.DevStatusPending},
+			status: model.DevStatusPending,
+			rules:  []model.AutoAcceptRule{*macRule},
+			match:  true,
+			authSets: []model.AuthSet{
+				{Id: "rejected", DeviceId: devID, Status: model.DevStatusRejected},
+				{Id: authID, DeviceId: devID, Status: model.DevStatusPending},
+			},
+		},
+		"ok, auth set rejected": {
+			device: &model.Device{Id: devID, Status: model.DevStatusRejected},
+			status: model.DevStatusRejected,
+		},
+		"error, fetching rules": {
+			device:   &model.Device{Id: devID, Status: model.DevStatusPending},
+			status:   model.DevStatusPending,
+			rulesErr: errors.New("connection refused"),
+			err:      errors.New("failed to fetch auto-accept rules: connection refused"),
+		},
+		"error, fetching auth sets": {
+			device:  &model.Device{Id: devID, Status: model.DevStatusPending},
+			status:  model.DevStatusPending,
+			rules:   []model.AutoAcceptRule{*macRule},
+			match:   true,
+			setsErr: errors.New("connection refused"),
+			err:     errors.New("failed to fetch device auth sets: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+			aset := &model.AuthSet{
+				Id:           authID,
+				DeviceId:     devID,
+				IdDataStruct: idData,
+				Status:       tc.status,
+			}
+
+			if tc.rules != nil || tc.rulesErr != nil {
+				db.On("GetAutoAcceptRules", ctx).Return(tc.rules, tc.rulesErr)
+			}
+			if tc.match {
+				db.On("GetAuthSetsForDevice", ctx, devID).Return(tc.authSets, tc.setsErr)
+			}
+			if tc.match && tc.setsErr == nil && tc.authSets == nil {
+				db.On("GetDeviceById", ctx, devID).Return(tc.device, nil)
+				db.On("GetLimit", ctx, model.LimitMaxDevicesCount).
+					Return(&model.Limit{Value: tc.limit}, nil)
+			}
+			if tc.accepted {
+				db.On("RejectAuthSetsForDevice", ctx, devID, authID).Return(nil)
+				db.On("UpdateAuthSetById", ctx, authID,
+					model.AuthSetUpdate{Status: model.DevStatusAccepted}).
+					Return(nil)
+				db.On("UpdateDeviceWithRevision", ctx, devID, uint(0),
+					mock.MatchedBy(func(u model.DeviceUpdate) bool {
+						return u.Status == model.DevStatusAccepted
+					})).
+					Return(nil)
+				req := client.ApiStartWorkflowRequest{ApiService: co}
+				co.EXPECT().
+					StartWorkflow(ctx, "provision_device").
+					Return(req).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+			}
+
+			devauth := NewDevAuth(db, co, nil, nil, Config{})
+			res, err := devauth.autoAcceptAuthSet(ctx, &tc.req, tc.device, aset)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			if tc.accepted {
+				if assert.NotNil(t, res) {
+					assert.Equal(t, model.DevStatusAccepted, res.Status)
+				}
+			} else {
+				assert.Nil(t, res)
+			}
+		})
+	}
+}
+
+func TestDevAuthDeleteAutoAcceptRule(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deleteErr error
+
+		err error
+	}{
+		"ok": {},
+		"error, not found": {
+			deleteErr: store.ErrAutoAcceptRuleNotFound,
+			err:       ErrAutoAcceptRuleNotFound,
+		},
+		"error, internal": {
+			deleteErr: errors.New("connection refused"),
+			err:       errors.New("failed to delete auto-accept rule: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			db.On("DeleteAutoAcceptRule", ctx, "id").Return(tc.deleteErr)
+
+			devauth := NewDevAuth(db, nil, nil, nil, Config{})
+			err := devauth.DeleteAutoAcceptRule(ctx, "id")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
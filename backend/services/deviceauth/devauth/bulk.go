@@ -0,0 +1,255 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/requestid"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+// statusEventsBatchSize is the maximum number of devices per status update
+// workflow.
+const statusEventsBatchSize = 500
+
+var ErrBulkTooManyItems = errors.Errorf(
+	"bulk request exceeds the maximum of %d items", model.BulkMaxItems,
+)
+
+// statusEvents collects the device status updates propagated to the
+// inventory, by device status.
+type statusEvents map[string][]client.DeviceAuthEvent
+
+func (e statusEvents) add(status string, event client.DeviceAuthEvent) {
+	e[status] = append(e[status], event)
+}
+
+// emitStatusEvent collects the event, or publishes it right away if events
+// is nil.
+func (d *DevAuth) emitStatusEvent(
+	ctx context.Context,
+	events statusEvents,
+	status string,
+	event client.DeviceAuthEvent,
+) error {
+	if events != nil {
+		events.add(status, event)
+		return nil
+	}
+	return d.startUpdateDeviceStatus(ctx, status, []client.DeviceAuthEvent{event})
+}
+
+func (d *DevAuth) startUpdateDeviceStatus(
+	ctx context.Context,
+	status string,
+	devices []client.DeviceAuthEvent,
+) error {
+	tenantId := ""
+	idData := identity.FromContext(ctx)
+	if idData != nil {
+		tenantId = idData.Tenant
+	}
+	//nolint:bodyclose
+	_, _, err := d.cOrch.StartWorkflow(ctx, "update_device_status").
+		RequestBody(map[string]any{
+			"request_id":    requestid.FromContext(ctx),
+			"devices":       devices,
+			"tenant_id":     tenantId,
+			"device_status": status,
+		}).Execute()
+	return err
+}
+
+// publishStatusEvents starts a status update workflow for every device
+// status and batch of devices; it returns the error of every device whose
+// status update could not be published.
+func (d *DevAuth) publishStatusEvents(
+	ctx context.Context,
+	events statusEvents,
+) map[string]error {
+	statuses := make([]string, 0, len(events))
+	for status := range events {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	var errs map[string]error
+	for _, status := range statuses {
+		devices := events[status]
+		for start := 0; start < len(devices); start += statusEventsBatchSize {
+			end := min(start+statusEventsBatchSize, len(devices))
+			err := d.startUpdateDeviceStatus(ctx, status, devices[start:end])
+			if err == nil {
+				continue
+			}
+			// the other batches are published all the same, the
+			// changes to the devices were applied already
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			err = errors.Wrap(err, "update device status job error")
+			for _, dev := range devices[start:end] {
+				errs[dev.Id] = err
+			}
+		}
+	}
+	return errs
+}
+
+// setPublishErrors sets the result of the items whose device status update
+// could not be published, unless the item failed already.
+func setPublishErrors(results []model.BulkResult, errs map[string]error) {
+	for i := range results {
+		if err, ok := errs[results[i].DeviceID]; ok && results[i].Err == nil {
+			results[i].Err = err
+		}
+	}
+}
+
+// SetAuthSetsStatus applies the action to the listed auth sets, or to the
+// auth sets of the devices matching the filter, returning the result of
+// every auth set.
+func (d *DevAuth) SetAuthSetsStatus(
+	ctx context.Context,
+	req *model.AuthSetsStatusReq,
+) ([]model.BulkResult, error) {
+	var (
+		asets   []*model.AuthSet
+		results []model.BulkResult
+		err     error
+	)
+	if req.Filter != nil {
+		asets, err = d.filterAuthSets(ctx, req)
+		results = make([]model.BulkResult, len(asets))
+	} else {
+		asets, results, err = d.getAuthSets(ctx, req.AuthSetIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := statusEvents{}
+	for i, aset := range asets {
+		if aset == nil {
+			continue
+		}
+		results[i].AuthSetID = aset.Id
+		results[i].DeviceID = aset.DeviceId
+		if req.Action == model.AuthSetActionDismiss {
+			results[i].Err = d.dismissAuthSet(ctx, aset.DeviceId, aset, events)
+		} else {
+			results[i].Err = d.setAuthSetStatus(ctx, aset, req.Status(), events)
+		}
+	}
+	setPublishErrors(results, d.publishStatusEvents(ctx, events))
+	return results, nil
+}
+
+// getAuthSets looks up the auth sets by ID; the results of the auth sets
+// which do not exist are set to store.ErrAuthSetNotFound.
+func (d *DevAuth) getAuthSets(
+	ctx context.Context,
+	ids []string,
+) ([]*model.AuthSet, []model.BulkResult, error) {
+	if len(ids) > model.BulkMaxItems {
+		return nil, nil, ErrBulkTooManyItems
+	}
+	asets := make([]*model.AuthSet, len(ids))
+	results := make([]model.BulkResult, len(ids))
+	for i, id := range ids {
+		aset, err := d.db.GetAuthSetById(ctx, id)
+		switch err {
+		case nil:
+			asets[i] = aset
+		case store.ErrAuthSetNotFound:
+			results[i] = model.BulkResult{AuthSetID: id, Err: err}
+		default:
+			return nil, nil, errors.Wrap(err, "db get auth set error")
+		}
+	}
+	return asets, results, nil
+}
+
+// filterAuthSets returns the auth sets determining the status of the
+// devices matching the filter; accepting a device accepts its most recent
+// auth set only.
+func (d *DevAuth) filterAuthSets(
+	ctx context.Context,
+	req *model.AuthSetsStatusReq,
+) ([]*model.AuthSet, error) {
+	devs, err := d.db.GetDevices(ctx, 0, model.BulkMaxItems+1, *req.Filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch devices")
+	} else if len(devs) > model.BulkMaxItems {
+		return nil, ErrBulkTooManyItems
+	}
+	var asets []*model.AuthSet
+	for _, dev := range devs {
+		devAsets, err := d.db.GetAuthSetsForDevice(ctx, dev.Id)
+		if err != nil && err != store.ErrAuthSetNotFound {
+			return nil, errors.Wrap(err, "db get auth sets error")
+		}
+		var latest *model.AuthSet
+		for i := range devAsets {
+			aset := &devAsets[i]
+			if aset.Status != dev.Status {
+				continue
+			}
+			if req.Action != model.AuthSetActionAccept {
+				asets = append(asets, aset)
+			} else if latest == nil || (aset.Timestamp != nil &&
+				(latest.Timestamp == nil || aset.Timestamp.After(*latest.Timestamp))) {
+				latest = aset
+			}
+		}
+		if latest != nil {
+			asets = append(asets, latest)
+		}
+	}
+	if len(asets) > model.BulkMaxItems {
+		return nil, ErrBulkTooManyItems
+	}
+	return asets, nil
+}
+
+// PreauthorizeDevices preauthorizes the devices, returning the result of
+// every request.
+func (d *DevAuth) PreauthorizeDevices(
+	ctx context.Context,
+	reqs []*model.PreAuthReq,
+) ([]model.BulkResult, error) {
+	if len(reqs) > model.BulkMaxItems {
+		return nil, ErrBulkTooManyItems
+	}
+	events := statusEvents{}
+	results := make([]model.BulkResult, len(reqs))
+	for i, req := range reqs {
+		results[i].AuthSetID = req.AuthSetId
+		results[i].DeviceID = req.DeviceId
+		dev, err := d.preauthorizeDevice(ctx, req, events)
+		if dev != nil {
+			results[i].DeviceID = dev.Id
+		}
+		results[i].Err = err
+	}
+	setPublishErrors(results, d.publishStatusEvents(ctx, events))
+	return results, nil
+}
@@ -0,0 +1,342 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceauth/store/mocks"
+)
+
+func TestDevAuthSetAuthSetsStatus(t *testing.T) {
+	t.Parallel()
+
+	devices := []model.Device{
+		{Id: "device-1", Status: model.DevStatusPending, Provisioned: true},
+		{Id: "device-2", Status: model.DevStatusPending, Provisioned: true},
+	}
+
+	testCases := map[string]struct {
+		workflowErr error
+
+		results []model.BulkResult
+	}{
+		"ok": {
+			results: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1"},
+				{AuthSetID: "missing", Err: store.ErrAuthSetNotFound},
+				{AuthSetID: "aset-2", DeviceID: "device-2"},
+			},
+		},
+		"error, workflow": {
+			workflowErr: errors.New("workflows failed"),
+			// the devices were accepted, but their status was not
+			// propagated
+			results: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1", Err: errors.New(
+					"update device status job error: workflows failed")},
+				{AuthSetID: "missing", Err: store.ErrAuthSetNotFound},
+				{AuthSetID: "aset-2", DeviceID: "device-2", Err: errors.New(
+					"update device status job error: workflows failed")},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+
+			db.On("GetAuthSetById", ctx, "missing").
+				Return(nil, store.ErrAuthSetNotFound)
+			for i := range devices {
+				dev := devices[i]
+				asetID := "aset-" + dev.Id[len("device-"):]
+				db.On("GetAuthSetById", ctx, asetID).
+					Return(&model.AuthSet{
+						Id:       asetID,
+						DeviceId: dev.Id,
+						Status:   model.DevStatusPending,
+					}, nil)
+				db.On("GetDeviceById", ctx, dev.Id).Return(&dev, nil)
+				db.On("RejectAuthSetsForDevice", ctx, dev.Id, asetID).Return(nil)
+				db.On("UpdateAuthSetById", ctx, asetID,
+					model.AuthSetUpdate{Status: model.DevStatusAccepted}).
+					Return(nil)
+				db.On("UpdateDeviceWithRevision", ctx, dev.Id, uint(0),
+					mock.MatchedBy(func(u model.DeviceUpdate) bool {
+						return u.Status == model.DevStatusAccepted
+					})).
+					Return(nil)
+			}
+			db.On("GetLimit", ctx, model.LimitMaxDevicesCount).
+				Return(&model.Limit{Value: model.LimitUnlimited}, nil)
+
+			// the status updates of the devices are propagated at once
+			req := client.ApiStartWorkflowRequest{ApiService: co}
+			co.EXPECT().
+				StartWorkflow(ctx, "update_device_status").
+				Return(req).
+				Once()
+			co.EXPECT().
+				StartWorkflowExecute(mock.Anything).
+				Return(nil, mockResponseOK, tc.workflowErr).
+				Once()
+
+			devauth := NewDevAuth(db, co, nil, nil, Config{})
+			results, err := devauth.SetAuthSetsStatus(ctx, &model.AuthSetsStatusReq{
+				Action:     model.AuthSetActionAccept,
+				AuthSetIDs: []string{"aset-1", "missing", "aset-2"},
+			})
+			assert.NoError(t, err)
+			assertBulkResults(t, tc.results, results)
+		})
+	}
+}
+
+func TestDevAuthSetAuthSetsStatusFilter(t *testing.T) {
+	t.Parallel()
+
+	const devID = "device-1"
+	now := time.Now()
+	older := now.Add(-time.Hour)
+
+	testCases := map[string]struct {
+		action  string
+		devices []model.Device
+		asets   []model.AuthSet
+
+		results []model.BulkResult
+		err     error
+	}{
+		"ok, accept the latest auth set": {
+			action: model.AuthSetActionAccept,
+			devices: []model.Device{
+				{Id: devID, Status: model.DevStatusRejected, Provisioned: true},
+			},
+			asets: []model.AuthSet{
+				{Id: "aset-old", DeviceId: devID, Status: model.DevStatusRejected,
+					Timestamp: &older},
+				{Id: "aset-new", DeviceId: devID, Status: model.DevStatusRejected,
+					Timestamp: &now},
+				{Id: "aset-pending", DeviceId: devID, Status: model.DevStatusPending,
+					Timestamp: &now},
+			},
+			results: []model.BulkResult{
+				{AuthSetID: "aset-new", DeviceID: devID},
+			},
+		},
+		"ok, dismiss preauthorized device": {
+			action: model.AuthSetActionDismiss,
+			devices: []model.Device{
+				{Id: devID, Status: model.DevStatusPreauth},
+			},
+			asets: []model.AuthSet{
+				{Id: "aset-preauth", DeviceId: devID, Status: model.DevStatusPreauth},
+			},
+			results: []model.BulkResult{
+				{AuthSetID: "aset-preauth", DeviceID: devID},
+			},
+		},
+		"error, too many devices": {
+			action:  model.AuthSetActionReject,
+			devices: make([]model.Device, model.BulkMaxItems+1),
+			err:     ErrBulkTooManyItems,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+			filter := model.DeviceFilter{Status: []string{tc.devices[0].Status}}
+
+			db.On("GetDevices", ctx, uint(0), uint(model.BulkMaxItems+1), filter).
+				Return(tc.devices, nil)
+			req := client.ApiStartWorkflowRequest{ApiService: co}
+			switch tc.action {
+			case model.AuthSetActionAccept:
+				db.On("GetAuthSetsForDevice", ctx, devID).Return(tc.asets, nil)
+				db.On("GetDeviceById", ctx, devID).Return(&tc.devices[0], nil)
+				db.On("GetLimit", ctx, model.LimitMaxDevicesCount).
+					Return(&model.Limit{Value: model.LimitUnlimited}, nil)
+				db.On("RejectAuthSetsForDevice", ctx, devID, "aset-new").Return(nil)
+				db.On("UpdateAuthSetById", ctx, "aset-new",
+					model.AuthSetUpdate{Status: model.DevStatusAccepted}).
+					Return(nil)
+				db.On("UpdateDeviceWithRevision", ctx, devID, uint(0),
+					mock.AnythingOfType("model.DeviceUpdate")).
+					Return(nil)
+				co.EXPECT().
+					StartWorkflow(ctx, "update_device_status").
+					Return(req).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+
+			case model.AuthSetActionDismiss:
+				db.On("GetAuthSetsForDevice", ctx, devID).Return(tc.asets, nil)
+				db.On("DeleteAuthSetForDevice", ctx, devID, "aset-preauth").
+					Return(nil)
+				db.On("GetDeviceStatus", ctx, devID).
+					Return("", store.ErrAuthSetNotFound)
+				db.On("DeleteDevice", ctx, devID).Return(nil)
+				co.EXPECT().
+					StartWorkflow(ctx, "update_device_status").
+					Return(req).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+			}
+
+			devauth := NewDevAuth(db, co, nil, nil, Config{})
+			results, err := devauth.SetAuthSetsStatus(ctx, &model.AuthSetsStatusReq{
+				Action: tc.action,
+				Filter: &filter,
+			})
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestDevAuthPreauthorizeDevices(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		workflowErr error
+
+		results []model.BulkResult
+	}{
+		"ok": {
+			results: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1"},
+				{AuthSetID: "aset-2", DeviceID: "device-0", Err: ErrDeviceExists},
+			},
+		},
+		"error, workflow": {
+			workflowErr: errors.New("workflows failed"),
+			// the device was preauthorized, but its status was not
+			// propagated
+			results: []model.BulkResult{
+				{AuthSetID: "aset-1", DeviceID: "device-1", Err: errors.New(
+					"update device status job error: workflows failed")},
+				{AuthSetID: "aset-2", DeviceID: "device-0", Err: ErrDeviceExists},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+
+			reqs := []*model.PreAuthReq{{
+				DeviceId:  "device-1",
+				AuthSetId: "aset-1",
+				IdData:    `{"mac":"00:1a:2b:3c:4d:5e"}`,
+				PubKey:    "pubkey-1",
+			}, {
+				DeviceId:  "device-2",
+				AuthSetId: "aset-2",
+				IdData:    `{"mac":"00:1a:2b:3c:4d:5f"}`,
+				PubKey:    "pubkey-2",
+			}}
+			_, existingSha256, _ := parseIdData(reqs[1].IdData)
+
+			db.On("AddDevice", ctx, mock.MatchedBy(func(d model.Device) bool {
+				return d.Id == "device-1"
+			})).Return(nil)
+			db.On("AddAuthSet", ctx, mock.MatchedBy(func(a model.AuthSet) bool {
+				return a.Id == "aset-1" && a.Status == model.DevStatusPreauth
+			})).Return(nil)
+			db.On("AddDevice", ctx, mock.MatchedBy(func(d model.Device) bool {
+				return d.Id == "device-2"
+			})).Return(store.ErrObjectExists)
+			db.On("GetDeviceByIdentityDataHash", ctx, existingSha256).
+				Return(&model.Device{Id: "device-0", Status: model.DevStatusAccepted}, nil)
+
+			// the identity is set per device, the status once for all devices
+			req := client.ApiStartWorkflowRequest{ApiService: co}
+			co.EXPECT().
+				StartWorkflow(ctx, "update_device_inventory").
+				Return(req).
+				Once()
+			co.EXPECT().
+				StartWorkflow(ctx, "update_device_status").
+				Return(req).
+				Once()
+			co.EXPECT().
+				StartWorkflowExecute(mock.Anything).
+				Return(nil, mockResponseOK, nil).
+				Once()
+			co.EXPECT().
+				StartWorkflowExecute(mock.Anything).
+				Return(nil, mockResponseOK, tc.workflowErr).
+				Once()
+
+			devauth := NewDevAuth(db, co, nil, nil, Config{})
+			results, err := devauth.PreauthorizeDevices(ctx, reqs)
+			assert.NoError(t, err)
+			assertBulkResults(t, tc.results, results)
+		})
+	}
+}
+
+// assertBulkResults compares the bulk results, and the errors by message.
+func assertBulkResults(t *testing.T, expected, actual []model.BulkResult) {
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.Equal(t, expected[i].AuthSetID, actual[i].AuthSetID)
+		assert.Equal(t, expected[i].DeviceID, actual[i].DeviceID)
+		if expected[i].Err == nil {
+			assert.NoError(t, actual[i].Err)
+		} else {
+			assert.EqualError(t, actual[i].Err, expected[i].Err.Error())
+		}
+	}
+}
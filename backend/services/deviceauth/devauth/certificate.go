@@ -0,0 +1,220 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	"github.com/mendersoftware/mender-server/pkg/log"
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/oid"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+)
+
+var (
+	ErrCertificateAuthorityExists   = errors.New("certificate authority already exists")
+	ErrCertificateAuthorityNotFound = errors.New("certificate authority not found")
+	ErrCertificateAuthorityProof    = errors.New(
+		"signature does not prove possession of the certificate authority key",
+	)
+
+	errCertificateNotTrusted = errors.New(
+		"certificate is not issued by a registered certificate authority",
+	)
+	errCertificateAmbiguous = errors.New(
+		"certificate authority is registered by more than one tenant",
+	)
+)
+
+func (d *DevAuth) AddCertificateAuthority(
+	ctx context.Context,
+	req *model.CertificateAuthorityReq,
+) (*model.CertificateAuthority, error) {
+	// the registration is bound to the tenant, so that the proof can't
+	// be replayed by another tenant
+	var tenantID string
+	if id := identity.FromContext(ctx); id != nil {
+		tenantID = id.Tenant
+	}
+	err := utils.VerifyAuthReqSign(
+		req.Signature, req.Cert.PublicKey, req.ProofOfPossession(tenantID),
+	)
+	if err != nil {
+		return nil, errors.Wrap(ErrCertificateAuthorityProof, err.Error())
+	}
+
+	ca := model.NewCertificateAuthority(oid.NewUUIDv4().String(), req)
+	err = d.db.AddCertificateAuthority(ctx, *ca)
+	switch err {
+	case nil:
+		return ca, nil
+	case store.ErrObjectExists:
+		return nil, ErrCertificateAuthorityExists
+	default:
+		return nil, errors.Wrap(err, "failed to add certificate authority")
+	}
+}
+
+func (d *DevAuth) GetCertificateAuthorities(
+	ctx context.Context,
+) ([]model.CertificateAuthority, error) {
+	cas, err := d.db.GetCertificateAuthorities(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list certificate authorities")
+	}
+	return cas, nil
+}
+
+// DeleteCertificateAuthority stops accepting new auth requests from the
+// devices with certificates issued by the authority; the devices already
+// accepted keep their auth sets.
+func (d *DevAuth) DeleteCertificateAuthority(ctx context.Context, id string) error {
+	err := d.db.DeleteCertificateAuthority(ctx, id)
+	switch err {
+	case nil:
+		return nil
+	case store.ErrCertificateAuthorityNotFound:
+		return ErrCertificateAuthorityNotFound
+	default:
+		return errors.Wrap(err, "failed to delete certificate authority")
+	}
+}
+
+// verifyDeviceCertificate verifies the device certificate chain against
+// the registered certificate authorities, returning the authority issuing
+// the device certificate. The authority determines the tenant of the
+// device, so the certificate is rejected if the authorities of more than
+// one tenant verify it.
+func (d *DevAuth) verifyDeviceCertificate(
+	ctx context.Context,
+	chain []*x509.Certificate,
+) (*model.CertificateAuthority, error) {
+	l := log.FromContext(ctx)
+
+	issuers := make([][]byte, len(chain))
+	intermediates := x509.NewCertPool()
+	for i, cert := range chain {
+		issuers[i] = model.CertSubjectSha256(cert.RawIssuer)
+		if i > 0 {
+			intermediates.AddCert(cert)
+		}
+	}
+	cas, err := d.db.FindCertificateAuthorities(ctx, issuers...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look up certificate authorities")
+	}
+	var issuer *model.CertificateAuthority
+	for i := range cas {
+		caCert, err := model.ParseCertificate(cas[i].Certificate)
+		if err != nil {
+			l.Errorf("invalid certificate authority %s: %s", cas[i].Id, err)
+			continue
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(caCert)
+		_, err = chain[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if err != nil {
+			l.Warnf("device certificate %q not verified by the certificate authority %s: %s",
+				chain[0].Subject.String(), cas[i].Id, err)
+			continue
+		}
+		if issuer == nil {
+			issuer = &cas[i]
+		} else if issuer.TenantID != cas[i].TenantID {
+			l.Warnf("device certificate %q verified by the certificate authorities "+
+				"%s and %s of different tenants",
+				chain[0].Subject.String(), issuer.Id, cas[i].Id)
+			return nil, MakeErrDevAuthUnauthorized(errCertificateAmbiguous)
+		}
+	}
+	if issuer == nil {
+		return nil, MakeErrDevAuthUnauthorized(errCertificateNotTrusted)
+	}
+	return issuer, nil
+}
+
+// SubmitCertificateAuthRequest authenticates a device presenting a client
+// certificate issued by a registered certificate authority. The identity
+// data of the device is derived from the certificate subject, and the
+// device is accepted without being preauthorized.
+func (d *DevAuth) SubmitCertificateAuthRequest(
+	ctx context.Context,
+	chain []*x509.Certificate,
+) (model.DeviceTokens, error) {
+	if len(chain) == 0 {
+		return model.DeviceTokens{}, MakeErrDevAuthBadRequest(
+			errors.New("missing client certificate"),
+		)
+	}
+	// the certificate authority determines the tenant of the device
+	ctx = identity.WithContext(ctx, nil)
+	ca, err := d.verifyDeviceCertificate(ctx, chain)
+	if err != nil {
+		return model.DeviceTokens{}, err
+	}
+	if ca.TenantID != "" {
+		ctx = identity.WithContext(ctx, &identity.Identity{
+			Tenant: ca.TenantID,
+		})
+	}
+
+	idData, err := ca.IdentityData(chain[0])
+	if err != nil {
+		return model.DeviceTokens{}, MakeErrDevAuthBadRequest(err)
+	}
+	pubKey, err := utils.SerializePubKey(chain[0].PublicKey)
+	if err != nil {
+		return model.DeviceTokens{}, MakeErrDevAuthBadRequest(err)
+	}
+	r := &model.AuthReq{
+		IdData: idData,
+		PubKey: pubKey,
+	}
+	if err := r.Validate(); err != nil {
+		return model.DeviceTokens{}, MakeErrDevAuthBadRequest(err)
+	}
+
+	authSet, err := d.processAuthRequest(ctx, r)
+	if err != nil {
+		return model.DeviceTokens{}, err
+	}
+	// the certificate vouches for new auth sets, but the user rejecting
+	// the device takes precedence
+	switch authSet.Status {
+	case model.DevStatusPending, model.DevStatusPreauth:
+		rejected, err := d.isDeviceRejected(ctx, authSet.DeviceId)
+		if err != nil {
+			return model.DeviceTokens{}, err
+		} else if rejected {
+			log.FromContext(ctx).Infof("device %s presents a trusted certificate "+
+				"but was rejected", authSet.DeviceId)
+			return model.DeviceTokens{}, ErrDevAuthUnauthorized
+		}
+		authSet, err = d.acceptAuthSet(ctx, authSet)
+		if err != nil {
+			return model.DeviceTokens{}, err
+		}
+	}
+	return d.issueDeviceToken(ctx, authSet)
+}
@@ -0,0 +1,476 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/jwt"
+	mjwt "github.com/mendersoftware/mender-server/services/deviceauth/jwt/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceauth/store/mocks"
+	mtesting "github.com/mendersoftware/mender-server/services/deviceauth/utils/testing"
+)
+
+type testCertificate struct {
+	*x509.Certificate
+	key crypto.Signer
+}
+
+func newTestCertificate(
+	t *testing.T,
+	subject pkix.Name,
+	issuer *testCertificate,
+) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  issuer == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	parent, parentKey := template, crypto.Signer(key)
+	if issuer != nil {
+		parent, parentKey = issuer.Certificate, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCertificate{Certificate: cert, key: key}
+}
+
+// sign signs the content with the key of the certificate the same way the
+// devices sign the auth requests.
+func (cert *testCertificate) sign(t *testing.T, content []byte) string {
+	digest := sha256.Sum256(content)
+	sig, err := cert.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func (cert *testCertificate) authority(
+	tenantID string,
+	attributes ...string,
+) model.CertificateAuthority {
+	return model.CertificateAuthority{
+		Id: "ca-" + cert.Subject.CommonName,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})),
+		SubjectSha256:      model.CertSubjectSha256(cert.RawSubject),
+		IdentityAttributes: attributes,
+		TenantID:           tenantID,
+	}
+}
+
+func TestDevAuthSubmitCertificateAuthRequest(t *testing.T) {
+	t.Parallel()
+
+	rootCA := newTestCertificate(t, pkix.Name{CommonName: "root"}, nil)
+	otherCA := newTestCertificate(t, pkix.Name{CommonName: "root"}, nil)
+	leaf := newTestCertificate(t, pkix.Name{CommonName: "device-0001"}, rootCA)
+
+	const (
+		tenantID = "tenant"
+		idData   = `{"CN":"device-0001"}`
+		token    = "dummytoken"
+	)
+	_, idDataSha256, err := parseIdData(idData)
+	require.NoError(t, err)
+	devID := "c39b2a4f-cc83-4d6b-a5ee-3b7a8ef1f7b6"
+	authID := "1a5dfa69-3e56-4b31-8a1c-5dd0a6b0e35e"
+
+	testCases := map[string]struct {
+		chain []*x509.Certificate
+
+		cas     []model.CertificateAuthority
+		findErr error
+
+		authSet   *model.AuthSet
+		device    *model.Device
+		authSets  []model.AuthSet
+		limit     int64
+		workflows []string
+
+		res string
+		err string
+	}{
+		"ok, new auth set is accepted": {
+			chain:     []*x509.Certificate{leaf.Certificate},
+			cas:       []model.CertificateAuthority{rootCA.authority(tenantID)},
+			device:    &model.Device{Id: devID, Status: model.DevStatusPending},
+			limit:     model.LimitUnlimited,
+			workflows: []string{"provision_device"},
+			res:       token,
+		},
+		"ok, auth set already accepted": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas: []model.CertificateAuthority{
+				otherCA.authority("other"),
+				rootCA.authority(tenantID),
+			},
+			authSet: &model.AuthSet{
+				Id:       authID,
+				DeviceId: devID,
+				Status:   model.DevStatusAccepted,
+			},
+			device: &model.Device{Id: devID, Status: model.DevStatusAccepted},
+			res:    token,
+		},
+		"error, auth set rejected": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas:   []model.CertificateAuthority{rootCA.authority(tenantID)},
+			authSet: &model.AuthSet{
+				Id:       authID,
+				DeviceId: devID,
+				Status:   model.DevStatusRejected,
+			},
+			device: &model.Device{Id: devID, Status: model.DevStatusRejected},
+			err:    ErrDevAuthUnauthorized.Error(),
+		},
+		"error, device rejected before with another key": {
+			chain:  []*x509.Certificate{leaf.Certificate},
+			cas:    []model.CertificateAuthority{rootCA.authority(tenantID)},
+			device: &model.Device{Id: devID, Status: model.DevStatusPending},
+			authSets: []model.AuthSet{
+				{Id: "rejected", DeviceId: devID, Status: model.DevStatusRejected},
+				{Id: authID, DeviceId: devID, Status: model.DevStatusPending},
+			},
+			err: ErrDevAuthUnauthorized.Error(),
+		},
+		"error, preauthorized device rejected before": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas:   []model.CertificateAuthority{rootCA.authority(tenantID)},
+			authSet: &model.AuthSet{
+				Id:       authID,
+				DeviceId: devID,
+				Status:   model.DevStatusPreauth,
+			},
+			device: &model.Device{Id: devID, Status: model.DevStatusPreauth},
+			authSets: []model.AuthSet{
+				{Id: "rejected", DeviceId: devID, Status: model.DevStatusRejected},
+				{Id: authID, DeviceId: devID, Status: model.DevStatusPreauth},
+			},
+			err: ErrDevAuthUnauthorized.Error(),
+		},
+		"error, device limit reached": {
+			chain:  []*x509.Certificate{leaf.Certificate},
+			cas:    []model.CertificateAuthority{rootCA.authority(tenantID)},
+			device: &model.Device{Id: devID, Status: model.DevStatusPending},
+			limit:  0,
+			err:    ErrMaxDeviceCountReached.Error(),
+		},
+		"error, no certificate": {
+			err: "dev auth: bad request: missing client certificate",
+		},
+		"error, unknown authority": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas:   []model.CertificateAuthority{otherCA.authority(tenantID)},
+			err:   "dev auth: unauthorized: " + errCertificateNotTrusted.Error(),
+		},
+		"error, authority of more than one tenant": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas: []model.CertificateAuthority{
+				rootCA.authority(tenantID),
+				rootCA.authority("other"),
+			},
+			err: "dev auth: unauthorized: " + errCertificateAmbiguous.Error(),
+		},
+		"error, no authority": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			err:   "dev auth: unauthorized: " + errCertificateNotTrusted.Error(),
+		},
+		"error, missing identity attribute": {
+			chain: []*x509.Certificate{leaf.Certificate},
+			cas: []model.CertificateAuthority{
+				rootCA.authority(tenantID, model.CertSubjectSerialNumber),
+			},
+			err: `dev auth: bad request: certificate subject is missing ` +
+				`the "serialNumber" attribute`,
+		},
+		"error, looking up authorities": {
+			chain:   []*x509.Certificate{leaf.Certificate},
+			findErr: errors.New("connection refused"),
+			err:     "failed to look up certificate authorities: connection refused",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tenantMatcher := mock.MatchedBy(func(ctx context.Context) bool {
+				id := identity.FromContext(ctx)
+				return id != nil && id.Tenant == tenantID
+			})
+			db := mstore.NewDataStore(t)
+			jwth := mjwt.NewHandler(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+
+			if len(tc.chain) > 0 {
+				db.On("FindCertificateAuthorities",
+					mtesting.ContextMatcher(),
+					model.CertSubjectSha256(leaf.RawIssuer)).
+					Return(tc.cas, tc.findErr)
+			}
+			if tc.device != nil {
+				db.On("AddDevice", tenantMatcher,
+					mock.AnythingOfType("model.Device")).
+					Return(store.ErrObjectExists)
+				db.On("GetDeviceByIdentityDataHash", tenantMatcher, idDataSha256).
+					Return(tc.device, nil)
+				if tc.authSet == nil {
+					db.On("AddAuthSet", tenantMatcher,
+						mock.MatchedBy(func(aset model.AuthSet) bool {
+							return aset.IdData == idData &&
+								aset.Status == model.DevStatusPending
+						})).
+						Return(nil)
+				} else {
+					db.On("AddAuthSet", tenantMatcher,
+						mock.AnythingOfType("model.AuthSet")).
+						Return(store.ErrObjectExists)
+					db.On("GetAuthSetByIdDataHashKey", tenantMatcher,
+						idDataSha256, mock.AnythingOfType("string")).
+						Return(tc.authSet, nil)
+				}
+			}
+			if tc.authSet == nil && tc.device != nil {
+				db.On("GetAutoAcceptRules", tenantMatcher).
+					Return([]model.AutoAcceptRule{}, nil)
+			}
+			rejected := false
+			for _, aset := range tc.authSets {
+				rejected = rejected || aset.Status == model.DevStatusRejected
+			}
+			if tc.device != nil &&
+				(tc.authSet == nil || tc.authSet.Status == model.DevStatusPreauth) {
+				db.On("GetAuthSetsForDevice", tenantMatcher, devID).
+					Return(tc.authSets, nil)
+				if !rejected {
+					db.On("GetDeviceById", tenantMatcher, devID).Return(tc.device, nil)
+					db.On("GetLimit", tenantMatcher, model.LimitMaxDevicesCount).
+						Return(&model.Limit{Value: tc.limit}, nil)
+				}
+			}
+			for _, name := range tc.workflows {
+				db.On("RejectAuthSetsForDevice", tenantMatcher, devID,
+					mock.AnythingOfType("string")).
+					Return(nil)
+				db.On("UpdateAuthSetById", tenantMatcher,
+					mock.AnythingOfType("string"),
+					model.AuthSetUpdate{Status: model.DevStatusAccepted}).
+					Return(nil)
+				db.On("UpdateDeviceWithRevision", tenantMatcher, devID, uint(0),
+					mock.MatchedBy(func(u model.DeviceUpdate) bool {
+						return u.Status == model.DevStatusAccepted
+					})).
+					Return(nil)
+				req := client.ApiStartWorkflowRequest{ApiService: co}
+				co.EXPECT().
+					StartWorkflow(tenantMatcher, name).
+					Return(req).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+			}
+			if tc.res != "" {
+				jwth.On("ToJWT", mock.MatchedBy(func(token *jwt.Token) bool {
+					return token.Claims.Tenant == tenantID &&
+						token.Claims.Subject.String() == devID
+				})).Return(token, nil)
+				db.On("AddToken", tenantMatcher,
+					mock.AnythingOfType("*jwt.Token")).
+					Return(nil)
+				db.On("UpdateDevice", tenantMatcher, devID,
+					mock.AnythingOfType("model.DeviceUpdate")).
+					Return(nil)
+				co.EXPECT().
+					StartWorkflow(tenantMatcher, "update_device_inventory").
+					Return(client.ApiStartWorkflowRequest{ApiService: co}).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+			}
+
+			devauth := NewDevAuth(db, co, nil, jwth, Config{})
+			res, err := devauth.SubmitCertificateAuthRequest(context.Background(), tc.chain)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.res, res.Token)
+		})
+	}
+}
+
+func TestDevAuthAddCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	const tenantID = "tenant"
+	rootCA := newTestCertificate(t, pkix.Name{CommonName: "root"}, nil)
+	otherCA := newTestCertificate(t, pkix.Name{CommonName: "root"}, nil)
+
+	testCases := map[string]struct {
+		signer   *testCertificate
+		signedBy string
+		invalid  bool
+
+		addErr error
+
+		err error
+	}{
+		"ok": {},
+		"error, exists": {
+			addErr: store.ErrObjectExists,
+			err:    ErrCertificateAuthorityExists,
+		},
+		"error, internal": {
+			addErr: errors.New("connection refused"),
+			err:    errors.New("failed to add certificate authority: connection refused"),
+		},
+		"error, not signed with the authority key": {
+			signer:  otherCA,
+			invalid: true,
+			err: errors.New("verification failed: " +
+				ErrCertificateAuthorityProof.Error()),
+		},
+		"error, signed for another tenant": {
+			signedBy: "other",
+			invalid:  true,
+			err: errors.New("verification failed: " +
+				ErrCertificateAuthorityProof.Error()),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := identity.WithContext(context.Background(), &identity.Identity{
+				Tenant: tenantID,
+			})
+			req := &model.CertificateAuthorityReq{
+				Name: "factory",
+				Certificate: string(pem.EncodeToMemory(&pem.Block{
+					Type:  "CERTIFICATE",
+					Bytes: rootCA.Raw,
+				})),
+				Signature: "c2lnbmF0dXJl",
+			}
+			require.NoError(t, req.Validate())
+			signer, signedBy := rootCA, tenantID
+			if tc.signer != nil {
+				signer = tc.signer
+			}
+			if tc.signedBy != "" {
+				signedBy = tc.signedBy
+			}
+			req.Signature = signer.sign(t, req.ProofOfPossession(signedBy))
+
+			db := mstore.NewDataStore(t)
+			if !tc.invalid {
+				db.On("AddCertificateAuthority", ctx,
+					mock.MatchedBy(func(ca model.CertificateAuthority) bool {
+						return ca.Id != "" && ca.Name == req.Name &&
+							ca.Certificate == req.Certificate
+					})).
+					Return(tc.addErr)
+			}
+
+			devauth := NewDevAuth(db, nil, nil, nil, Config{})
+			ca, err := devauth.AddCertificateAuthority(ctx, req)
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+				assert.Nil(t, ca)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "CN=root", ca.Subject)
+		})
+	}
+}
+
+func TestDevAuthDeleteCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		deleteErr error
+
+		err error
+	}{
+		"ok": {},
+		"error, not found": {
+			deleteErr: store.ErrCertificateAuthorityNotFound,
+			err:       ErrCertificateAuthorityNotFound,
+		},
+		"error, internal": {
+			deleteErr: errors.New("connection refused"),
+			err:       errors.New("failed to delete certificate authority: connection refused"),
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := mstore.NewDataStore(t)
+			db.On("DeleteCertificateAuthority", ctx, "id").Return(tc.deleteErr)
+
+			devauth := NewDevAuth(db, nil, nil, nil, Config{})
+			err := devauth.DeleteCertificateAuthority(ctx, "id")
+			if tc.err != nil {
+				assert.EqualError(t, err, tc.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
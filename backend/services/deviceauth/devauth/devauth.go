@@ -16,6 +16,7 @@ package devauth
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -81,7 +82,15 @@ func MakeErrDevAuthBadRequest(e error) error {
 //go:generate ../../../utils/mockgen.sh
 type App interface {
 	HealthCheck(ctx context.Context) error
-	SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (string, error)
+	SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (model.DeviceTokens, error)
+	SubmitCertificateAuthRequest(
+		ctx context.Context,
+		chain []*x509.Certificate,
+	) (model.DeviceTokens, error)
+	RefreshDeviceToken(
+		ctx context.Context,
+		req *model.RefreshTokenReq,
+	) (model.DeviceTokens, error)
 
 	GetDevices(
 		ctx context.Context,
@@ -100,10 +109,19 @@ type App interface {
 		status string,
 	) error
 	PreauthorizeDevice(ctx context.Context, req *model.PreAuthReq) (*model.Device, error)
+	SetAuthSetsStatus(
+		ctx context.Context,
+		req *model.AuthSetsStatusReq,
+	) ([]model.BulkResult, error)
+	PreauthorizeDevices(
+		ctx context.Context,
+		reqs []*model.PreAuthReq,
+	) ([]model.BulkResult, error)
 
 	RevokeToken(ctx context.Context, tokenID string) error
 	VerifyToken(ctx context.Context, token string) error
 	DeleteTokens(ctx context.Context, tenantID, deviceID string) error
+	GetJWKS(ctx context.Context) *jwt.JWKS
 
 	SetTenantLimit(ctx context.Context, tenant_id string, limit model.Limit) error
 	DeleteTenantLimit(ctx context.Context, tenant_id string, limit string) error
@@ -115,6 +133,20 @@ type App interface {
 
 	GetTenantDeviceStatus(ctx context.Context, tenantId, deviceId string) (*model.Status, error)
 	UpdateDevice(ctx context.Context, deviceID string, update model.DeviceUpdate) error
+
+	AddCertificateAuthority(
+		ctx context.Context,
+		req *model.CertificateAuthorityReq,
+	) (*model.CertificateAuthority, error)
+	GetCertificateAuthorities(ctx context.Context) ([]model.CertificateAuthority, error)
+	DeleteCertificateAuthority(ctx context.Context, id string) error
+
+	AddAutoAcceptRule(
+		ctx context.Context,
+		req *model.AutoAcceptRuleReq,
+	) (*model.AutoAcceptRule, error)
+	GetAutoAcceptRules(ctx context.Context) ([]model.AutoAcceptRule, error)
+	DeleteAutoAcceptRule(ctx context.Context, id string) error
 }
 
 type DevAuth struct {
@@ -134,6 +166,10 @@ type Config struct {
 	Issuer string
 	// token expiration time
 	ExpirationTime int64
+	// refresh token expiration time, enables the refresh tokens if set
+	RefreshExpirationTime int64
+	// token expiration time when the refresh tokens are enabled
+	ShortExpirationTime int64
 	// Default tenant token to use when the client supplies none. Can be
 	// empty
 	DefaultTenantToken string
@@ -279,9 +315,10 @@ func (d *DevAuth) signToken() jwt.SignFunc {
 	}
 }
 
-func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (string, error) {
-	l := log.FromContext(ctx)
-
+func (d *DevAuth) SubmitAuthRequest(
+	ctx context.Context,
+	r *model.AuthReq,
+) (model.DeviceTokens, error) {
 	var err error
 
 	ctx = identity.WithContext(ctx, nil)
@@ -289,26 +326,41 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 	// first, try to handle preauthorization
 	authSet, err := d.processPreAuthRequest(ctx, r)
 	if err != nil {
-		return "", err
+		return model.DeviceTokens{}, err
 	}
 
 	// if not a preauth request, process with regular auth request handling
 	if authSet == nil {
 		authSet, err = d.processAuthRequest(ctx, r)
 		if err != nil {
-			return "", err
+			return model.DeviceTokens{}, err
 		}
 	}
 
+	return d.issueDeviceToken(ctx, authSet)
+}
+
+// issueDeviceToken issues a token, and a refresh token if enabled, for the
+// accepted auth set; the device is unauthorized in any other status.
+func (d *DevAuth) issueDeviceToken(
+	ctx context.Context,
+	authSet *model.AuthSet,
+) (model.DeviceTokens, error) {
+	l := log.FromContext(ctx)
+
 	// request was already present in DB, check its status
 	if authSet.Status == model.DevStatusAccepted {
 		jti := oid.FromString(authSet.Id)
 		if jti.String() == "" {
-			return "", ErrInvalidAuthSetID
+			return model.DeviceTokens{}, ErrInvalidAuthSetID
 		}
 		sub := oid.FromString(authSet.DeviceId)
 		if sub.String() == "" {
-			return "", ErrInvalidDeviceID
+			return model.DeviceTokens{}, ErrInvalidDeviceID
+		}
+		expiration := d.config.ExpirationTime
+		if d.config.RefreshExpirationTime > 0 {
+			expiration = d.config.ShortExpirationTime
 		}
 		now := time.Now()
 		token := &jwt.Token{Claims: jwt.Claims{
@@ -316,12 +368,14 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 			Subject: sub,
 			Issuer:  d.config.Issuer,
 			ExpiresAt: jwt.Time{
-				Time: now.Add(time.Second *
-					time.Duration(d.config.ExpirationTime)),
+				Time: now.Add(time.Second * time.Duration(expiration)),
 			},
 			IssuedAt: jwt.Time{Time: now},
 			Device:   true,
 		}}
+		if id := identity.FromContext(ctx); id != nil {
+			token.Claims.Tenant = id.Tenant
+		}
 
 		token.Claims.Plan = plan.PlanEnterprise
 		token.Addons = addons.AllAddonsEnabled
@@ -329,24 +383,49 @@ func (d *DevAuth) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (stri
 		// sign and encode as JWT
 		raw, err := token.MarshalJWT(d.signToken())
 		if err != nil {
-			return "", errors.Wrap(err, "generate token error")
+			return model.DeviceTokens{}, errors.Wrap(err, "generate token error")
 		}
 
 		if err := d.db.AddToken(ctx, token); err != nil {
-			return "", errors.Wrap(err, "add token error")
+			return model.DeviceTokens{}, errors.Wrap(err, "add token error")
+		}
+		tokens := model.DeviceTokens{Token: string(raw)}
+		if d.config.RefreshExpirationTime > 0 {
+			tokens.RefreshToken, err = d.issueRefreshToken(ctx, authSet, now)
+			if err != nil {
+				return model.DeviceTokens{}, err
+			}
 		}
 
 		l.Infof("Token %s assigned to device %s",
 			token.Claims.ID, token.Claims.Subject)
 		d.updateCheckInTime(ctx, authSet.DeviceId, token.Claims.Tenant, nil)
-		return string(raw), nil
+		return tokens, nil
 	}
 
 	// no token, return device unauthorized
-	return "", ErrDevAuthUnauthorized
+	return model.DeviceTokens{}, ErrDevAuthUnauthorized
+}
+
+// isDeviceRejected reports whether the user rejected any of the auth sets of
+// the device. A new auth set turns the status of a rejected device pending,
+// so the auth sets are checked instead of the device status.
+func (d *DevAuth) isDeviceRejected(ctx context.Context, deviceID string) (bool, error) {
+	asets, err := d.db.GetAuthSetsForDevice(ctx, deviceID)
+	if err != nil && err != store.ErrAuthSetNotFound {
+		return false, errors.Wrap(err, "failed to fetch device auth sets")
+	}
+	for i := range asets {
+		if asets[i].Status == model.DevStatusRejected {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
-func (d *DevAuth) handlePreAuthDevice(
+// acceptAuthSet accepts the auth set of a device authenticating with
+// preauthorized or otherwise trusted credentials.
+func (d *DevAuth) acceptAuthSet(
 	ctx context.Context,
 	aset *model.AuthSet,
 ) (*model.AuthSet, error) {
@@ -424,7 +503,7 @@ func (d *DevAuth) processPreAuthRequest(
 	if aset.Status != model.DevStatusPreauth {
 		return nil, nil
 	}
-	return d.handlePreAuthDevice(ctx, aset)
+	return d.acceptAuthSet(ctx, aset)
 }
 
 func (d *DevAuth) aggregateDeviceStatus(ctx context.Context, deviceID string) (string, error) {
@@ -469,6 +548,19 @@ func (d *DevAuth) updateDeviceStatus(
 	authSet *model.AuthSet,
 	device *model.Device,
 	status string,
+) error {
+	return d.updateDeviceStatusEvents(ctx, authSet, device, status, nil)
+}
+
+// updateDeviceStatusEvents updates the device status; the status update
+// propagated to the inventory is collected in events, or published right
+// away if events is nil.
+func (d *DevAuth) updateDeviceStatusEvents(
+	ctx context.Context,
+	authSet *model.AuthSet,
+	device *model.Device,
+	status string,
+	events statusEvents,
 ) error {
 	if device.Status == status {
 		return nil // No-op
@@ -529,21 +621,11 @@ func (d *DevAuth) updateDeviceStatus(
 			}
 		}
 
-		//nolint:bodyclose
-		_, _, err := d.cOrch.StartWorkflow(ctx, "update_device_status").
-			RequestBody(map[string]any{
-				"request_id": requestid.FromContext(ctx),
-				"devices": []client.DeviceAuthEvent{
-					updateDeviceStatusEvent(authSet, device, status),
-				},
-				"tenant_id":     tenantId,
-				"device_status": status,
-			}).Execute()
-
+		err := d.emitStatusEvent(ctx, events, status,
+			updateDeviceStatusEvent(authSet, device, status))
 		if err != nil {
 			return errors.Wrap(err, "update device status job error")
 		}
-
 	}
 
 	return nil
@@ -591,6 +673,16 @@ func (d *DevAuth) processAuthRequest(
 	} else if err != nil {
 		return nil, err
 	}
+	if areq.IdDataStruct == nil {
+		areq.IdDataStruct = idDataStruct
+	}
+
+	accepted, err := d.autoAcceptAuthSet(ctx, r, dev, areq)
+	if err != nil {
+		return nil, err
+	} else if accepted != nil {
+		return accepted, nil
+	}
 
 	if model.DeviceStatusFromAuthSetStatuses(areq.Status, dev.Status) == dev.Status {
 		// Device status will not change, we're done
@@ -787,6 +879,17 @@ func (d *DevAuth) DeleteAuthSet(ctx context.Context, devID string, authId string
 		return errors.Wrap(err, "db get auth set error")
 	}
 
+	return d.dismissAuthSet(ctx, devID, authSet, nil)
+}
+
+// dismissAuthSet deletes the auth set and updates the resulting device
+// status; see updateDeviceStatusEvents for the handling of events.
+func (d *DevAuth) dismissAuthSet(
+	ctx context.Context,
+	devID string,
+	authSet *model.AuthSet,
+	events statusEvents,
+) error {
 	if err := d.deleteAuthSet(ctx, authSet); err != nil {
 		return err
 	}
@@ -806,7 +909,7 @@ func (d *DevAuth) DeleteAuthSet(ctx context.Context, devID string, authId string
 	// special value "decommissioned", which will cause the deletion of the
 	// device from the inventory service's database.
 	if authSet.Status == model.DevStatusPreauth && newStatus == model.DevStatusNoAuth {
-		err = d.deletePreauthDevice(ctx, authSet.DeviceId)
+		err = d.deletePreauthDevice(ctx, authSet.DeviceId, events)
 		if err != nil {
 			return errors.Wrap(err, "failed to delete preauthorized device")
 		}
@@ -820,10 +923,19 @@ func (d *DevAuth) DeleteAuthSet(ctx context.Context, devID string, authId string
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
 
-	return d.updateDeviceStatus(ctx, authSet, dev, newStatus)
+	return d.updateDeviceStatusEvents(ctx, authSet, dev, newStatus, events)
 }
 
-func (d *DevAuth) deletePreauthDevice(ctx context.Context, devId string) error {
+func (d *DevAuth) deletePreauthDevice(
+	ctx context.Context,
+	devId string,
+	events statusEvents,
+) error {
+	if events != nil {
+		events.add("decommissioned", client.DeviceAuthEvent{Id: devId})
+		return d.db.DeleteDevice(ctx, devId)
+	}
+
 	tenantId := ""
 	idData := identity.FromContext(ctx)
 	if idData != nil {
@@ -933,12 +1045,24 @@ func (d *DevAuth) SetAuthSetStatus(
 		return ErrDevIdAuthIdMismatch
 	}
 
+	return d.setAuthSetStatus(ctx, aset, status, nil)
+}
+
+// setAuthSetStatus updates the status of the auth set and the resulting
+// device status; see updateDeviceStatusEvents for the handling of events.
+func (d *DevAuth) setAuthSetStatus(
+	ctx context.Context,
+	aset *model.AuthSet,
+	status string,
+	events statusEvents,
+) error {
+	deviceID := aset.DeviceId
 	if aset.Status == status {
 		// No-op
 		return nil
 	}
 	// Validate status transition
-	err = model.ValidateStatusTransition(aset.Status, status)
+	err := model.ValidateStatusTransition(aset.Status, status)
 	if err != nil {
 		return ErrDevAuthBadRequest
 	}
@@ -980,11 +1104,7 @@ func (d *DevAuth) SetAuthSetStatus(
 			return err
 		}
 	}
-	err = d.updateDeviceStatus(ctx, aset, device, status)
-	if err != nil {
-		return err
-	}
-	return nil
+	return d.updateDeviceStatusEvents(ctx, aset, device, status, events)
 }
 
 func parseIdData(idData string) (map[string]interface{}, []byte, error) {
@@ -1010,6 +1130,16 @@ func parseIdData(idData string) (map[string]interface{}, []byte, error) {
 func (d *DevAuth) PreauthorizeDevice(
 	ctx context.Context,
 	req *model.PreAuthReq,
+) (*model.Device, error) {
+	return d.preauthorizeDevice(ctx, req, nil)
+}
+
+// preauthorizeDevice adds the preauthorized device; see
+// updateDeviceStatusEvents for the handling of events.
+func (d *DevAuth) preauthorizeDevice(
+	ctx context.Context,
+	req *model.PreAuthReq,
+	events statusEvents,
 ) (*model.Device, error) {
 	// try add device, if a device with the given id_data exists -
 	// the unique index on id_data will prevent it (conflict)
@@ -1091,16 +1221,8 @@ func (d *DevAuth) PreauthorizeDevice(
 		if err := d.setDeviceIdentity(ctx, dev, tenantId); err != nil {
 			return nil, err
 		}
-		//nolint:bodyclose
-		_, _, err = d.cOrch.StartWorkflow(ctx, "update_device_status").
-			RequestBody(map[string]any{
-				"request_id": requestid.FromContext(ctx),
-				"devices": []client.DeviceAuthEvent{
-					updateDeviceStatusEvent(&authset, dev, dev.Status),
-				},
-				"tenant_id":     tenantId,
-				"device_status": dev.Status,
-			}).Execute()
+		err = d.emitStatusEvent(ctx, events, dev.Status,
+			updateDeviceStatusEvent(&authset, dev, dev.Status))
 		if err != nil {
 			return nil, errors.Wrap(err, "update device status job error")
 		}
@@ -1389,6 +1511,24 @@ func (d *DevAuth) GetTenantLimit(
 	return d.GetLimit(ctx, name)
 }
 
+// GetJWKS returns the public keys accepted for the device tokens, the
+// signing keys first.
+func (d *DevAuth) GetJWKS(ctx context.Context) *jwt.JWKS {
+	keys := d.jwt.PublicKeys()
+	if d.jwtFallback != nil {
+		seen := make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			seen[key.KeyID] = struct{}{}
+		}
+		for _, key := range d.jwtFallback.PublicKeys() {
+			if _, ok := seen[key.KeyID]; !ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return &jwt.JWKS{Keys: keys}
+}
+
 func (d *DevAuth) WithJWTFallbackHandler(handler jwt.Handler) *DevAuth {
 	d.jwtFallback = handler
 	return d
@@ -550,6 +550,8 @@ func TestDevAuthSubmitAuthRequest(t *testing.T) {
 				mock.AnythingOfType("model.DeviceUpdate")).Return(nil)
 			db.On("GetDeviceById", ctxMatcher,
 				mock.AnythingOfType("string")).Return(&model.Device{}, nil)
+			db.On("GetAutoAcceptRules", ctxMatcher).
+				Return([]model.AutoAcceptRule{}, nil)
 
 			jwth := mjwt.Handler{}
 			jwth.On("ToJWT",
@@ -596,7 +598,7 @@ func TestDevAuthSubmitAuthRequest(t *testing.T) {
 			res, err := devauth.SubmitAuthRequest(ctx, &tc.inReq)
 
 			t.Logf("error: %v", err)
-			assert.Equal(t, tc.res, res)
+			assert.Equal(t, tc.res, res.Token)
 			if tc.err != nil {
 				assert.EqualError(t, err, tc.err.Error())
 			} else {
@@ -912,7 +914,7 @@ func TestDevAuthSubmitAuthRequestPreauth(t *testing.T) {
 			res, err := devauth.SubmitAuthRequest(context.Background(), &inReq)
 
 			// verify
-			assert.Equal(t, tc.res, res)
+			assert.Equal(t, tc.res, res.Token)
 			if tc.err != nil {
 				assert.EqualError(t, err, tc.err.Error())
 			} else {
@@ -2083,6 +2085,54 @@ func TestDevAuthVerifyToken(t *testing.T) {
 	}
 }
 
+func TestDevAuthGetJWKS(t *testing.T) {
+	t.Parallel()
+
+	newKey := jwt.JWK{KeyType: "OKP", KeyID: "new", Algorithm: "EdDSA", Use: "sig"}
+	oldKey := jwt.JWK{KeyType: "RSA", KeyID: "old", Algorithm: "RS256", Use: "sig"}
+
+	testCases := map[string]struct {
+		keys         []jwt.JWK
+		fallbackKeys []jwt.JWK
+
+		jwks *jwt.JWKS
+	}{
+		"ok": {
+			keys: []jwt.JWK{newKey, oldKey},
+			jwks: &jwt.JWKS{Keys: []jwt.JWK{newKey, oldKey}},
+		},
+		"ok, with fallback": {
+			keys:         []jwt.JWK{newKey},
+			fallbackKeys: []jwt.JWK{oldKey},
+			jwks:         &jwt.JWKS{Keys: []jwt.JWK{newKey, oldKey}},
+		},
+		"ok, fallback key listed twice": {
+			keys:         []jwt.JWK{newKey, oldKey},
+			fallbackKeys: []jwt.JWK{oldKey},
+			jwks:         &jwt.JWKS{Keys: []jwt.JWK{newKey, oldKey}},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ja := mjwt.NewHandler(t)
+			ja.On("PublicKeys").Return(tc.keys)
+			devauth := NewDevAuth(nil, nil, nil, ja, Config{})
+			if tc.fallbackKeys != nil {
+				jaFallback := mjwt.NewHandler(t)
+				jaFallback.On("PublicKeys").Return(tc.fallbackKeys)
+				devauth = devauth.WithJWTFallbackHandler(jaFallback)
+			}
+
+			jwks := devauth.GetJWKS(context.Background())
+			assert.Equal(t, tc.jwks, jwks)
+		})
+	}
+}
+
 func TestDevAuthVerifyTokenWithCache(t *testing.T) {
 	t.Parallel()
 
@@ -19,8 +19,12 @@ package mocks
 import (
 	context "context"
 
+	x509 "crypto/x509"
+
 	mock "github.com/stretchr/testify/mock"
 
+	jwt "github.com/mendersoftware/mender-server/services/deviceauth/jwt"
+
 	model "github.com/mendersoftware/mender-server/services/deviceauth/model"
 )
 
@@ -29,6 +33,66 @@ type App struct {
 	mock.Mock
 }
 
+// AddAutoAcceptRule provides a mock function with given fields: ctx, req
+func (_m *App) AddAutoAcceptRule(ctx context.Context, req *model.AutoAcceptRuleReq) (*model.AutoAcceptRule, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAutoAcceptRule")
+	}
+
+	var r0 *model.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AutoAcceptRuleReq) (*model.AutoAcceptRule, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AutoAcceptRuleReq) *model.AutoAcceptRule); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AutoAcceptRuleReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddCertificateAuthority provides a mock function with given fields: ctx, req
+func (_m *App) AddCertificateAuthority(ctx context.Context, req *model.CertificateAuthorityReq) (*model.CertificateAuthority, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCertificateAuthority")
+	}
+
+	var r0 *model.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CertificateAuthorityReq) (*model.CertificateAuthority, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CertificateAuthorityReq) *model.CertificateAuthority); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CertificateAuthorityReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DecommissionDevice provides a mock function with given fields: ctx, dev_id
 func (_m *App) DecommissionDevice(ctx context.Context, dev_id string) error {
 	ret := _m.Called(ctx, dev_id)
@@ -65,6 +129,42 @@ func (_m *App) DeleteAuthSet(ctx context.Context, dev_id string, auth_id string)
 	return r0
 }
 
+// DeleteAutoAcceptRule provides a mock function with given fields: ctx, id
+func (_m *App) DeleteAutoAcceptRule(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAutoAcceptRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCertificateAuthority provides a mock function with given fields: ctx, id
+func (_m *App) DeleteCertificateAuthority(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCertificateAuthority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, dev_id
 func (_m *App) DeleteDevice(ctx context.Context, dev_id string) error {
 	ret := _m.Called(ctx, dev_id)
@@ -119,6 +219,66 @@ func (_m *App) DeleteTokens(ctx context.Context, tenantID string, deviceID strin
 	return r0
 }
 
+// GetAutoAcceptRules provides a mock function with given fields: ctx
+func (_m *App) GetAutoAcceptRules(ctx context.Context) ([]model.AutoAcceptRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAutoAcceptRules")
+	}
+
+	var r0 []model.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.AutoAcceptRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.AutoAcceptRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCertificateAuthorities provides a mock function with given fields: ctx
+func (_m *App) GetCertificateAuthorities(ctx context.Context) ([]model.CertificateAuthority, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAuthorities")
+	}
+
+	var r0 []model.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.CertificateAuthority, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.CertificateAuthority); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevCountByStatus provides a mock function with given fields: ctx, status
 func (_m *App) GetDevCountByStatus(ctx context.Context, status string) (int, error) {
 	ret := _m.Called(ctx, status)
@@ -207,6 +367,26 @@ func (_m *App) GetDevices(ctx context.Context, skip uint, limit uint, filter mod
 	return r0, r1
 }
 
+// GetJWKS provides a mock function with given fields: ctx
+func (_m *App) GetJWKS(ctx context.Context) *jwt.JWKS {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJWKS")
+	}
+
+	var r0 *jwt.JWKS
+	if rf, ok := ret.Get(0).(func(context.Context) *jwt.JWKS); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.JWKS)
+		}
+	}
+
+	return r0
+}
+
 // GetLimit provides a mock function with given fields: ctx, name
 func (_m *App) GetLimit(ctx context.Context, name string) (*model.Limit, error) {
 	ret := _m.Called(ctx, name)
@@ -345,6 +525,64 @@ func (_m *App) PreauthorizeDevice(ctx context.Context, req *model.PreAuthReq) (*
 	return r0, r1
 }
 
+// PreauthorizeDevices provides a mock function with given fields: ctx, reqs
+func (_m *App) PreauthorizeDevices(ctx context.Context, reqs []*model.PreAuthReq) ([]model.BulkResult, error) {
+	ret := _m.Called(ctx, reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreauthorizeDevices")
+	}
+
+	var r0 []model.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.PreAuthReq) ([]model.BulkResult, error)); ok {
+		return rf(ctx, reqs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.PreAuthReq) []model.BulkResult); ok {
+		r0 = rf(ctx, reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*model.PreAuthReq) error); ok {
+		r1 = rf(ctx, reqs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshDeviceToken provides a mock function with given fields: ctx, req
+func (_m *App) RefreshDeviceToken(ctx context.Context, req *model.RefreshTokenReq) (model.DeviceTokens, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshDeviceToken")
+	}
+
+	var r0 model.DeviceTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RefreshTokenReq) (model.DeviceTokens, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RefreshTokenReq) model.DeviceTokens); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(model.DeviceTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.RefreshTokenReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RevokeToken provides a mock function with given fields: ctx, tokenID
 func (_m *App) RevokeToken(ctx context.Context, tokenID string) error {
 	ret := _m.Called(ctx, tokenID)
@@ -381,6 +619,36 @@ func (_m *App) SetAuthSetStatus(ctx context.Context, deviceID string, authID str
 	return r0
 }
 
+// SetAuthSetsStatus provides a mock function with given fields: ctx, req
+func (_m *App) SetAuthSetsStatus(ctx context.Context, req *model.AuthSetsStatusReq) ([]model.BulkResult, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAuthSetsStatus")
+	}
+
+	var r0 []model.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthSetsStatusReq) ([]model.BulkResult, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthSetsStatusReq) []model.BulkResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AuthSetsStatusReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetTenantLimit provides a mock function with given fields: ctx, tenant_id, limit
 func (_m *App) SetTenantLimit(ctx context.Context, tenant_id string, limit model.Limit) error {
 	ret := _m.Called(ctx, tenant_id, limit)
@@ -400,22 +668,22 @@ func (_m *App) SetTenantLimit(ctx context.Context, tenant_id string, limit model
 }
 
 // SubmitAuthRequest provides a mock function with given fields: ctx, r
-func (_m *App) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (string, error) {
+func (_m *App) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (model.DeviceTokens, error) {
 	ret := _m.Called(ctx, r)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SubmitAuthRequest")
 	}
 
-	var r0 string
+	var r0 model.DeviceTokens
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthReq) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthReq) (model.DeviceTokens, error)); ok {
 		return rf(ctx, r)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthReq) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AuthReq) model.DeviceTokens); ok {
 		r0 = rf(ctx, r)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(model.DeviceTokens)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *model.AuthReq) error); ok {
@@ -427,6 +695,34 @@ func (_m *App) SubmitAuthRequest(ctx context.Context, r *model.AuthReq) (string,
 	return r0, r1
 }
 
+// SubmitCertificateAuthRequest provides a mock function with given fields: ctx, chain
+func (_m *App) SubmitCertificateAuthRequest(ctx context.Context, chain []*x509.Certificate) (model.DeviceTokens, error) {
+	ret := _m.Called(ctx, chain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitCertificateAuthRequest")
+	}
+
+	var r0 model.DeviceTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*x509.Certificate) (model.DeviceTokens, error)); ok {
+		return rf(ctx, chain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*x509.Certificate) model.DeviceTokens); ok {
+		r0 = rf(ctx, chain)
+	} else {
+		r0 = ret.Get(0).(model.DeviceTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*x509.Certificate) error); ok {
+		r1 = rf(ctx, chain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDevice provides a mock function with given fields: ctx, deviceID, update
 func (_m *App) UpdateDevice(ctx context.Context, deviceID string, update model.DeviceUpdate) error {
 	ret := _m.Called(ctx, deviceID, update)
@@ -0,0 +1,124 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+)
+
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// issueRefreshToken issues the refresh token of the auth set, replacing
+// the previous one.
+func (d *DevAuth) issueRefreshToken(
+	ctx context.Context,
+	authSet *model.AuthSet,
+	now time.Time,
+) (string, error) {
+	expiresAt := now.Add(time.Second * time.Duration(d.config.RefreshExpirationTime))
+	refresh, token, err := model.NewRefreshToken(authSet, expiresAt)
+	if err != nil {
+		return "", err
+	}
+	if err := d.db.AddRefreshToken(ctx, *refresh); err != nil {
+		return "", errors.Wrap(err, "add refresh token error")
+	}
+	return token, nil
+}
+
+// RefreshDeviceToken renews the device token with the refresh token, which
+// can be used once. The request must be signed with the key of the auth
+// set the refresh token was issued to, and the auth set must still be
+// accepted.
+func (d *DevAuth) RefreshDeviceToken(
+	ctx context.Context,
+	req *model.RefreshTokenReq,
+) (model.DeviceTokens, error) {
+	unauthorized := MakeErrDevAuthUnauthorized(ErrInvalidRefreshToken)
+	if d.config.RefreshExpirationTime <= 0 {
+		return model.DeviceTokens{}, unauthorized
+	}
+	authSetID, secretSha256, err := model.ParseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return model.DeviceTokens{}, unauthorized
+	}
+
+	ctx = identity.WithContext(ctx, nil)
+	refresh, err := d.db.GetRefreshToken(ctx, authSetID)
+	switch err {
+	case nil:
+	case store.ErrRefreshTokenNotFound:
+		return model.DeviceTokens{}, unauthorized
+	default:
+		return model.DeviceTokens{}, errors.Wrap(err, "db get refresh token error")
+	}
+	if !refresh.Match(secretSha256, time.Now()) {
+		return model.DeviceTokens{}, unauthorized
+	}
+	if refresh.TenantID != "" {
+		ctx = identity.WithContext(ctx, &identity.Identity{
+			Tenant: refresh.TenantID,
+		})
+	}
+
+	authSet, err := d.db.GetAuthSetById(ctx, refresh.AuthSetID)
+	switch err {
+	case nil:
+	case store.ErrAuthSetNotFound:
+		return model.DeviceTokens{}, unauthorized
+	default:
+		return model.DeviceTokens{}, errors.Wrap(err, "db get auth set error")
+	}
+	key, err := utils.ParsePubKey(authSet.PubKey)
+	if err != nil {
+		return model.DeviceTokens{}, errors.Wrap(err, "failed to parse device key")
+	}
+	if err := utils.VerifyAuthReqSign(req.Signature, key, req.Raw); err != nil {
+		return model.DeviceTokens{}, MakeErrDevAuthUnauthorized(
+			errors.New("signature verification failed"),
+		)
+	}
+
+	dev, err := d.db.GetDeviceById(ctx, authSet.DeviceId)
+	switch {
+	case err == nil:
+	case errors.Is(err, store.ErrDevNotFound):
+		return model.DeviceTokens{}, unauthorized
+	default:
+		return model.DeviceTokens{}, errors.Wrap(err, "db get device error")
+	}
+	if dev.Decommissioning {
+		return model.DeviceTokens{}, unauthorized
+	}
+
+	err = d.db.DeleteRefreshToken(ctx, authSetID, secretSha256)
+	switch err {
+	case nil:
+	case store.ErrRefreshTokenNotFound:
+		// used by a concurrent request
+		return model.DeviceTokens{}, unauthorized
+	default:
+		return model.DeviceTokens{}, errors.Wrap(err, "db delete refresh token error")
+	}
+	return d.issueDeviceToken(ctx, authSet)
+}
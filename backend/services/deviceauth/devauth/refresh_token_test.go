@@ -0,0 +1,256 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package devauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/api/client"
+	oas_mocks "github.com/mendersoftware/mender-server/pkg/api/client/mocks"
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/jwt"
+	mjwt "github.com/mendersoftware/mender-server/services/deviceauth/jwt/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+	mstore "github.com/mendersoftware/mender-server/services/deviceauth/store/mocks"
+	"github.com/mendersoftware/mender-server/services/deviceauth/utils"
+	mtesting "github.com/mendersoftware/mender-server/services/deviceauth/utils/testing"
+)
+
+func TestDevAuthRefreshDeviceToken(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tenantID = "tenant"
+		devID    = "c39b2a4f-cc83-4d6b-a5ee-3b7a8ef1f7b6"
+		authID   = "1a5dfa69-3e56-4b31-8a1c-5dd0a6b0e35e"
+		token    = "dummytoken"
+	)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubKey, err := utils.SerializePubKey(pub)
+	require.NoError(t, err)
+
+	aset := &model.AuthSet{
+		Id:       authID,
+		DeviceId: devID,
+		PubKey:   pubKey,
+		Status:   model.DevStatusAccepted,
+	}
+	refresh, refreshToken, err := model.NewRefreshToken(aset, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	refresh.TenantID = tenantID
+	_, secretSha256, err := model.ParseRefreshToken(refreshToken)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		config Config
+
+		refreshToken string
+		signer       ed25519.PrivateKey
+
+		refresh         *model.RefreshToken
+		getErr          error
+		status          string
+		decommissioning bool
+		deleteErr       error
+
+		err string
+	}{
+		"ok": {
+			refresh: refresh,
+		},
+		"error, auth set rejected": {
+			refresh: refresh,
+			status:  model.DevStatusRejected,
+
+			err: ErrDevAuthUnauthorized.Error(),
+		},
+		"error, refresh tokens disabled": {
+			config: Config{ExpirationTime: 3600},
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, malformed refresh token": {
+			refreshToken: "malformed",
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, refresh token not found": {
+			getErr: store.ErrRefreshTokenNotFound,
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, refresh token expired": {
+			refresh: &model.RefreshToken{
+				AuthSetID:    authID,
+				DeviceID:     devID,
+				SecretSha256: secretSha256,
+				ExpiresAt:    time.Now().Add(-time.Minute),
+				TenantID:     tenantID,
+			},
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, wrong secret": {
+			refreshToken: authID + ".wrongsecret",
+			refresh:      refresh,
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, signed with another key": {
+			refresh: refresh,
+			signer:  otherPriv,
+
+			err: "dev auth: unauthorized: signature verification failed",
+		},
+		"error, device decommissioning": {
+			refresh:         refresh,
+			decommissioning: true,
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, refresh token used concurrently": {
+			refresh:   refresh,
+			deleteErr: store.ErrRefreshTokenNotFound,
+
+			err: "dev auth: unauthorized: invalid refresh token",
+		},
+		"error, db get refresh token": {
+			getErr: errors.New("connection refused"),
+
+			err: "db get refresh token error: connection refused",
+		},
+		"error, db delete refresh token": {
+			refresh:   refresh,
+			deleteErr: errors.New("connection refused"),
+
+			err: "db delete refresh token error: connection refused",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.config == (Config{}) {
+				tc.config = Config{
+					ExpirationTime:        3600,
+					RefreshExpirationTime: 86400,
+					ShortExpirationTime:   900,
+				}
+			}
+			if tc.refreshToken == "" {
+				tc.refreshToken = refreshToken
+			}
+			if tc.signer == nil {
+				tc.signer = priv
+			}
+			if tc.status == "" {
+				tc.status = model.DevStatusAccepted
+			}
+			tenantMatcher := mock.MatchedBy(func(ctx context.Context) bool {
+				id := identity.FromContext(ctx)
+				return id != nil && id.Tenant == tenantID
+			})
+
+			raw := []byte(`{"refresh_token":"` + tc.refreshToken + `"}`)
+			req := &model.RefreshTokenReq{
+				RefreshToken: tc.refreshToken,
+				Raw:          raw,
+				Signature:    string(mtesting.AuthReqSign(raw, tc.signer, t)),
+			}
+
+			db := mstore.NewDataStore(t)
+			jwth := mjwt.NewHandler(t)
+			co := oas_mocks.NewMockWorkflowsOtherAPI(t)
+
+			reqAuthSetID, _, parseErr := model.ParseRefreshToken(tc.refreshToken)
+			if tc.config.RefreshExpirationTime > 0 && parseErr == nil {
+				db.On("GetRefreshToken", mtesting.ContextMatcher(), reqAuthSetID).
+					Return(tc.refresh, tc.getErr)
+			}
+			if tc.refresh != nil && tc.refresh.Match(secretSha256, time.Now()) &&
+				tc.refreshToken == refreshToken {
+				authSet := *aset
+				authSet.Status = tc.status
+				db.On("GetAuthSetById", tenantMatcher, authID).
+					Return(&authSet, nil)
+				if tc.signer.Equal(priv) {
+					db.On("GetDeviceById", tenantMatcher, devID).
+						Return(&model.Device{
+							Id:              devID,
+							Decommissioning: tc.decommissioning,
+						}, nil)
+				}
+				if tc.signer.Equal(priv) && !tc.decommissioning {
+					db.On("DeleteRefreshToken", tenantMatcher, authID, secretSha256).
+						Return(tc.deleteErr)
+				}
+			}
+			if tc.err == "" {
+				jwth.On("ToJWT", mock.MatchedBy(func(token *jwt.Token) bool {
+					expiresIn := time.Until(token.Claims.ExpiresAt.Time)
+					return token.Claims.Tenant == tenantID &&
+						token.Claims.Subject.String() == devID &&
+						expiresIn <= 900*time.Second
+				})).Return(token, nil)
+				db.On("AddToken", tenantMatcher,
+					mock.AnythingOfType("*jwt.Token")).
+					Return(nil)
+				db.On("AddRefreshToken", tenantMatcher,
+					mock.MatchedBy(func(r model.RefreshToken) bool {
+						return r.AuthSetID == authID &&
+							!r.Match(secretSha256, time.Now())
+					})).
+					Return(nil)
+				db.On("UpdateDevice", tenantMatcher, devID,
+					mock.AnythingOfType("model.DeviceUpdate")).
+					Return(nil)
+				co.EXPECT().
+					StartWorkflow(tenantMatcher, "update_device_inventory").
+					Return(client.ApiStartWorkflowRequest{ApiService: co}).
+					Once()
+				co.EXPECT().
+					StartWorkflowExecute(mock.Anything).
+					Return(nil, mockResponseOK, nil).
+					Once()
+			}
+
+			devauth := NewDevAuth(db, co, nil, jwth, tc.config)
+			res, err := devauth.RefreshDeviceToken(context.Background(), req)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, token, res.Token)
+			assert.NotEmpty(t, res.RefreshToken)
+			assert.NotEqual(t, tc.refreshToken, res.RefreshToken)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+const (
+	headerKeyID = "kid"
+
+	jwkKeyTypeRSA   = "RSA"
+	jwkKeyTypeOKP   = "OKP"
+	jwkCurveEd25519 = "Ed25519"
+	jwkUseSignature = "sig"
+)
+
+// JWK is the public part of a token signing key in the JSON Web Key
+// format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+
+	// RSA public key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519 public key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517) publishing the keys verifying
+// the tokens.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWKRSA(alg string, pub *rsa.PublicKey) JWK {
+	jwk := JWK{
+		KeyType:   jwkKeyTypeRSA,
+		Algorithm: alg,
+		Use:       jwkUseSignature,
+		N:         base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E: base64.RawURLEncoding.EncodeToString(
+			big.NewInt(int64(pub.E)).Bytes(),
+		),
+	}
+	jwk.KeyID = jwkThumbprint(map[string]string{
+		"e":   jwk.E,
+		"kty": jwk.KeyType,
+		"n":   jwk.N,
+	})
+	return jwk
+}
+
+func newJWKEd25519(alg string, pub ed25519.PublicKey) JWK {
+	jwk := JWK{
+		KeyType:   jwkKeyTypeOKP,
+		Algorithm: alg,
+		Use:       jwkUseSignature,
+		Curve:     jwkCurveEd25519,
+		X:         base64.RawURLEncoding.EncodeToString(pub),
+	}
+	jwk.KeyID = jwkThumbprint(map[string]string{
+		"crv": jwk.Curve,
+		"kty": jwk.KeyType,
+		"x":   jwk.X,
+	})
+	return jwk
+}
+
+// jwkThumbprint computes the JWK thumbprint (RFC 7638) from the required
+// members of the key, used as the key ID.
+func jwkThumbprint(members map[string]string) string {
+	// encoding/json sorts the map keys, as required by the RFC
+	b, _ := json.Marshal(members)
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -47,9 +47,21 @@ type Handler interface {
 	// ErrTokenExpired when the token is valid but expired
 	// ErrTokenInvalid when the token is invalid (malformed, missing required claims, etc.)
 	Validate(string) error
+	// PublicKeys returns the public keys verifying the tokens.
+	PublicKeys() []JWK
+}
+
+// keyHandler is a Handler backed by a single key
+type keyHandler interface {
+	Handler
+	KeyID() string
 }
 
 func NewJWTHandler(privateKeyPath string) (Handler, error) {
+	return newKeyHandler(privateKeyPath)
+}
+
+func newKeyHandler(privateKeyPath string) (keyHandler, error) {
 	priv, err := os.ReadFile(privateKeyPath)
 	block, _ := pem.Decode(priv)
 	if block == nil {
@@ -23,17 +23,21 @@ import (
 // JWTHandlerEd25519 is an Ed25519-specific JWTHandler
 type JWTHandlerEd25519 struct {
 	privKey *ed25519.PrivateKey
+	jwk     JWK
 }
 
 func NewJWTHandlerEd25519(privKey *ed25519.PrivateKey) *JWTHandlerEd25519 {
 	return &JWTHandlerEd25519{
 		privKey: privKey,
+		jwk: newJWKEd25519(jwt.SigningMethodEdDSA.Alg(),
+			privKey.Public().(ed25519.PublicKey)),
 	}
 }
 
 func (j *JWTHandlerEd25519) ToJWT(token *Token) (string, error) {
 	//generate
 	jt := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &token.Claims)
+	jt.Header[headerKeyID] = j.jwk.KeyID
 
 	//sign
 	data, err := jt.SignedString(j.privKey)
@@ -79,3 +83,12 @@ func (j *JWTHandlerEd25519) Validate(tokstr string) error {
 
 	return nil
 }
+
+// KeyID returns the ID of the signing key, set in the token header.
+func (j *JWTHandlerEd25519) KeyID() string {
+	return j.jwk.KeyID
+}
+
+func (j *JWTHandlerEd25519) PublicKeys() []JWK {
+	return []JWK{j.jwk}
+}
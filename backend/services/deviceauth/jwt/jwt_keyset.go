@@ -0,0 +1,123 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package jwt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+const keyFileExt = ".pem"
+
+// JWTHandlerKeySet signs the tokens with one of a set of keys, and accepts
+// the tokens signed with any of them. The key ID in the token header picks
+// the key validating the token.
+type JWTHandlerKeySet struct {
+	// keys ordered from the oldest to the newest
+	keys     []keyHandler
+	keysByID map[string]keyHandler
+	signing  keyHandler
+}
+
+// NewJWTHandlerKeySet loads the private keys (*.pem) from keyDir. The key
+// file signingKey signs the tokens; if not set, the newest key does. The
+// keys are ordered by file name: the last one is the newest; naming the key
+// files after their creation date keeps the order.
+//
+// Setting the signing key lets the replicas of the service rotate the keys
+// without a synchronized restart: the new key is published to all the
+// replicas first, and signs the tokens once all of them accept it.
+func NewJWTHandlerKeySet(keyDir, signingKey string) (*JWTHandlerKeySet, error) {
+	entries, err := os.ReadDir(keyDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the key directory")
+	}
+	j := &JWTHandlerKeySet{
+		keysByID: make(map[string]keyHandler, len(entries)),
+	}
+	// entries are sorted by file name
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keyFileExt) {
+			continue
+		}
+		handler, err := newKeyHandler(filepath.Join(keyDir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load key %s", entry.Name())
+		}
+		if _, ok := j.keysByID[handler.KeyID()]; ok {
+			return nil, errors.Errorf("duplicate key %s", entry.Name())
+		}
+		j.keys = append(j.keys, handler)
+		j.keysByID[handler.KeyID()] = handler
+		if entry.Name() == signingKey {
+			j.signing = handler
+		}
+	}
+	if len(j.keys) == 0 {
+		return nil, errors.Errorf("no private keys found in %s", keyDir)
+	}
+	if signingKey == "" {
+		j.signing = j.keys[len(j.keys)-1]
+	} else if j.signing == nil {
+		return nil, errors.Errorf("signing key %s not found in %s", signingKey, keyDir)
+	}
+	return j, nil
+}
+
+func (j *JWTHandlerKeySet) signingKey() keyHandler {
+	return j.signing
+}
+
+func (j *JWTHandlerKeySet) ToJWT(token *Token) (string, error) {
+	return j.signingKey().ToJWT(token)
+}
+
+func (j *JWTHandlerKeySet) FromJWT(tokstr string) (*Token, error) {
+	return j.signingKey().FromJWT(tokstr)
+}
+
+func (j *JWTHandlerKeySet) Validate(tokstr string) error {
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	jwttoken, _, err := parser.ParseUnverified(tokstr, &Claims{})
+	if err != nil {
+		return ErrTokenInvalid
+	}
+	if kid, ok := jwttoken.Header[headerKeyID].(string); ok {
+		handler, ok := j.keysByID[kid]
+		if !ok {
+			return ErrTokenInvalid
+		}
+		return handler.Validate(tokstr)
+	}
+	// tokens issued before the key IDs were introduced: try all the keys
+	for i := len(j.keys) - 1; i >= 0; i-- {
+		err = j.keys[i].Validate(tokstr)
+		if err == nil {
+			break
+		}
+	}
+	return err
+}
+
+func (j *JWTHandlerKeySet) PublicKeys() []JWK {
+	jwks := make([]JWK, 0, len(j.keys))
+	for i := len(j.keys) - 1; i >= 0; i-- {
+		jwks = append(jwks, j.keys[i].PublicKeys()...)
+	}
+	return jwks
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/oid"
+)
+
+func TestNewJWTHandlerKeySet(t *testing.T) {
+	t.Parallel()
+
+	copyKey := func(t *testing.T, dir, src, dst string) {
+		data, err := os.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dst), data, 0600))
+	}
+
+	testCases := map[string]struct {
+		keys       map[string]string
+		signingKey string
+
+		keyCount int
+		err      string
+	}{
+		"ok": {
+			keys: map[string]string{
+				"2025-04-01.pem": "./testdata/rsa.pem",
+				"2026-10-01.pem": "./testdata/ed25519.pem",
+				"README":         "./testdata/dsa.pem",
+			},
+			keyCount: 2,
+		},
+		"ok, signing key": {
+			keys: map[string]string{
+				"2025-04-01.pem": "./testdata/rsa.pem",
+				"2026-10-01.pem": "./testdata/ed25519.pem",
+			},
+			signingKey: "2025-04-01.pem",
+			keyCount:   2,
+		},
+		"error, signing key not found": {
+			keys: map[string]string{
+				"2025-04-01.pem": "./testdata/rsa.pem",
+			},
+			signingKey: "2026-10-01.pem",
+			err:        "signing key 2026-10-01.pem not found in ",
+		},
+		"error, no keys": {
+			keys: map[string]string{},
+			err:  "no private keys found in ",
+		},
+		"error, unsupported key": {
+			keys: map[string]string{
+				"2025-04-01.pem": "./testdata/dsa.pem",
+			},
+			err: "failed to load key 2025-04-01.pem: unsupported server private key type",
+		},
+		"error, duplicate key": {
+			keys: map[string]string{
+				"2025-04-01.pem": "./testdata/rsa.pem",
+				"2026-10-01.pem": "./testdata/rsa.pem",
+			},
+			err: "duplicate key 2026-10-01.pem",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for dst, src := range tc.keys {
+				copyKey(t, dir, src, dst)
+			}
+			handler, err := NewJWTHandlerKeySet(dir, tc.signingKey)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, handler.PublicKeys(), tc.keyCount)
+		})
+	}
+
+	_, err := NewJWTHandlerKeySet("./testdata/doesnotexist", "")
+	assert.ErrorContains(t, err, "failed to read the key directory")
+}
+
+func TestJWTHandlerKeySet(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewJWTHandlerKeySet("./testdata/keyset", "")
+	require.NoError(t, err)
+
+	oldKey := NewJWTHandlerRS256(loadRSAPrivKey("./testdata/rsa.pem", t))
+	newKey, err := NewJWTHandler("./testdata/ed25519.pem")
+	require.NoError(t, err)
+	otherKey, err := NewJWTHandler("./testdata/rsa_pkcs8.pem")
+	require.NoError(t, err)
+
+	token := &Token{Claims: Claims{
+		ID:        oid.NewUUIDv5("someid"),
+		Subject:   oid.NewUUIDv5("foo"),
+		Issuer:    "Mender",
+		ExpiresAt: Time{Time: time.Now().Add(time.Hour)},
+		Device:    true,
+	}}
+
+	// the newest key signs the tokens
+	raw, err := handler.ToJWT(token)
+	require.NoError(t, err)
+	assert.NoError(t, newKey.Validate(raw))
+	parsed, err := handler.FromJWT(raw)
+	require.NoError(t, err)
+	assert.Equal(t, token.Claims.Subject, parsed.Claims.Subject)
+	assert.NoError(t, handler.Validate(raw))
+
+	// tokens signed with the older key are still valid
+	raw, err = oldKey.ToJWT(token)
+	require.NoError(t, err)
+	assert.NoError(t, handler.Validate(raw))
+
+	// tokens without key ID are validated against all the keys
+	raw, err = legacyToken(oldKey, token)
+	require.NoError(t, err)
+	assert.NoError(t, handler.Validate(raw))
+
+	// tokens signed with an unknown key are rejected
+	raw, err = otherKey.ToJWT(token)
+	require.NoError(t, err)
+	assert.ErrorIs(t, handler.Validate(raw), ErrTokenInvalid)
+
+	raw, err = legacyToken(otherKey.(*JWTHandlerRS256), token)
+	require.NoError(t, err)
+	assert.ErrorIs(t, handler.Validate(raw), ErrTokenInvalid)
+
+	assert.ErrorIs(t, handler.Validate("garbage"), ErrTokenInvalid)
+
+	// the newest key is published first
+	assert.Equal(t,
+		append(newKey.PublicKeys(), oldKey.PublicKeys()...),
+		handler.PublicKeys())
+}
+
+func TestJWTHandlerKeySetSigningKey(t *testing.T) {
+	t.Parallel()
+
+	// the new key is staged: accepted, but not signing yet
+	handler, err := NewJWTHandlerKeySet("./testdata/keyset", "2025-04-01.pem")
+	require.NoError(t, err)
+
+	oldKey := NewJWTHandlerRS256(loadRSAPrivKey("./testdata/rsa.pem", t))
+	newKey, err := NewJWTHandler("./testdata/ed25519.pem")
+	require.NoError(t, err)
+
+	token := &Token{Claims: Claims{
+		ID:        oid.NewUUIDv5("someid"),
+		Subject:   oid.NewUUIDv5("foo"),
+		Issuer:    "Mender",
+		ExpiresAt: Time{Time: time.Now().Add(time.Hour)},
+		Device:    true,
+	}}
+
+	raw, err := handler.ToJWT(token)
+	require.NoError(t, err)
+	assert.NoError(t, oldKey.Validate(raw))
+	parsed, err := handler.FromJWT(raw)
+	require.NoError(t, err)
+	assert.Equal(t, token.Claims.Subject, parsed.Claims.Subject)
+
+	// tokens signed by the replicas already signing with the new key
+	raw, err = newKey.ToJWT(token)
+	require.NoError(t, err)
+	assert.NoError(t, handler.Validate(raw))
+	assert.Len(t, handler.PublicKeys(), 2)
+}
+
+// legacyToken signs the token without the key ID in the header.
+func legacyToken(key *JWTHandlerRS256, token *Token) (string, error) {
+	return jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, &token.Claims).
+		SignedString(key.privKey)
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	t.Parallel()
+
+	// RFC 8037, appendix A.3
+	pub, err := base64.RawURLEncoding.DecodeString(
+		"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	require.NoError(t, err)
+
+	jwk := newJWKEd25519("EdDSA", ed25519.PublicKey(pub))
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", jwk.KeyID)
+	assert.Equal(t, "OKP", jwk.KeyType)
+	assert.Equal(t, "Ed25519", jwk.Curve)
+}
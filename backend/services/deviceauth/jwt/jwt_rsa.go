@@ -23,17 +23,20 @@ import (
 // JWTHandlerRS256 is an RS256-specific JWTHandler
 type JWTHandlerRS256 struct {
 	privKey *rsa.PrivateKey
+	jwk     JWK
 }
 
 func NewJWTHandlerRS256(privKey *rsa.PrivateKey) *JWTHandlerRS256 {
 	return &JWTHandlerRS256{
 		privKey: privKey,
+		jwk:     newJWKRSA(jwt.SigningMethodRS256.Alg(), &privKey.PublicKey),
 	}
 }
 
 func (j *JWTHandlerRS256) ToJWT(token *Token) (string, error) {
 	//generate
 	jt := jwt.NewWithClaims(jwt.SigningMethodRS256, &token.Claims)
+	jt.Header[headerKeyID] = j.jwk.KeyID
 
 	//sign
 	data, err := jt.SignedString(j.privKey)
@@ -79,3 +82,12 @@ func (j *JWTHandlerRS256) Validate(tokstr string) error {
 
 	return nil
 }
+
+// KeyID returns the ID of the signing key, set in the token header.
+func (j *JWTHandlerRS256) KeyID() string {
+	return j.jwk.KeyID
+}
+
+func (j *JWTHandlerRS256) PublicKeys() []JWK {
+	return []JWK{j.jwk}
+}
@@ -56,6 +56,26 @@ func (_m *Handler) FromJWT(_a0 string) (*jwt.Token, error) {
 	return r0, r1
 }
 
+// PublicKeys provides a mock function with no fields
+func (_m *Handler) PublicKeys() []jwt.JWK {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 []jwt.JWK
+	if rf, ok := ret.Get(0).(func() []jwt.JWK); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]jwt.JWK)
+		}
+	}
+
+	return r0
+}
+
 // ToJWT provides a mock function with given fields: t
 func (_m *Handler) ToJWT(t *jwt.Token) (string, error) {
 	ret := _m.Called(t)
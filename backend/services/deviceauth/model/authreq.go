@@ -26,6 +26,8 @@ type AuthReq struct {
 	IdData      string `json:"id_data" bson:"id_data"`
 	TenantToken string `json:"tenant_token" bson:"tenant_token"`
 	PubKey      string `json:"pubkey"`
+	// EnrollmentSecret is the secret of the auto-accept rules
+	EnrollmentSecret string `json:"enrollment_secret,omitempty" bson:"-"`
 
 	//helpers, not serialized
 	PubKeyStruct crypto.PublicKey `json:"-" bson:"-"`
@@ -0,0 +1,153 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	enrollmentSecretMinLength = 16
+	enrollmentSecretMaxLength = 1024
+)
+
+// IdDataMatcher matches the values of an identity data attribute, either
+// by (case-insensitive) prefix, e.g. the OUI of a MAC address, or by regular
+// expression.
+type IdDataMatcher struct {
+	Attribute string `json:"attribute" bson:"attribute"`
+	Prefix    string `json:"prefix,omitempty" bson:"prefix,omitempty"`
+	Regex     string `json:"regex,omitempty" bson:"regex,omitempty"`
+}
+
+func ruleRegex(value interface{}) error {
+	expr, _ := value.(string)
+	if _, err := regexp.Compile(expr); err != nil {
+		return errors.New("must be a valid regular expression")
+	}
+	return nil
+}
+
+func (m IdDataMatcher) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.Attribute, validation.Required),
+		validation.Field(&m.Prefix,
+			validation.When(m.Regex == "", validation.Required).
+				Else(validation.Empty.Error("cannot be combined with regex"))),
+		validation.Field(&m.Regex, validation.By(ruleRegex)),
+	)
+}
+
+func (m IdDataMatcher) matchValue(value string) bool {
+	if m.Regex != "" {
+		match, _ := regexp.MatchString(m.Regex, value)
+		return match
+	}
+	return len(value) >= len(m.Prefix) &&
+		strings.EqualFold(value[:len(m.Prefix)], m.Prefix)
+}
+
+// Match returns true if any of the values of the attribute matches.
+func (m IdDataMatcher) Match(idData map[string]interface{}) bool {
+	switch value := idData[m.Attribute].(type) {
+	case string:
+		return m.matchValue(value)
+	case []interface{}:
+		for _, v := range value {
+			if s, ok := v.(string); ok && m.matchValue(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AutoAcceptRuleReq creates a rule accepting the matching devices without
+// an admin's approval.
+type AutoAcceptRuleReq struct {
+	Name string `json:"name"`
+	// IdData lists the matchers the identity data must all match
+	IdData []IdDataMatcher `json:"id_data,omitempty"`
+	// EnrollmentSecret is the shared secret the devices present in
+	// the auth request
+	EnrollmentSecret string `json:"enrollment_secret,omitempty"`
+}
+
+func (r *AutoAcceptRuleReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Length(0, 1024)),
+		validation.Field(&r.IdData,
+			validation.When(r.EnrollmentSecret == "", validation.Required.Error(
+				"cannot be blank without enrollment_secret",
+			))),
+		validation.Field(&r.EnrollmentSecret, validation.Length(
+			enrollmentSecretMinLength,
+			enrollmentSecretMaxLength,
+		)),
+	)
+}
+
+type AutoAcceptRule struct {
+	Id     string          `json:"id" bson:"_id"`
+	Name   string          `json:"name" bson:"name,omitempty"`
+	IdData []IdDataMatcher `json:"id_data" bson:"id_data"`
+	// EnrollmentSecret is set if the rule requires the enrollment secret
+	EnrollmentSecret       bool      `json:"enrollment_secret" bson:"enrollment_secret"`
+	EnrollmentSecretSha256 []byte    `json:"-" bson:"enrollment_secret_sha256,omitempty"`
+	CreatedTs              time.Time `json:"created_ts" bson:"created_ts"`
+	TenantID               string    `json:"-" bson:"tenant_id"`
+}
+
+func NewAutoAcceptRule(id string, req *AutoAcceptRuleReq) *AutoAcceptRule {
+	rule := &AutoAcceptRule{
+		Id:        id,
+		Name:      req.Name,
+		IdData:    req.IdData,
+		CreatedTs: time.Now().UTC(),
+	}
+	if rule.IdData == nil {
+		rule.IdData = []IdDataMatcher{}
+	}
+	if req.EnrollmentSecret != "" {
+		hash := sha256.Sum256([]byte(req.EnrollmentSecret))
+		rule.EnrollmentSecret = true
+		rule.EnrollmentSecretSha256 = hash[:]
+	}
+	return rule
+}
+
+// Match returns true if the identity data matches all the matchers of
+// the rule and the enrollment secret, if the rule requires one, is valid.
+func (rule *AutoAcceptRule) Match(idData map[string]interface{}, secret string) bool {
+	if rule.EnrollmentSecret {
+		hash := sha256.Sum256([]byte(secret))
+		if secret == "" ||
+			subtle.ConstantTimeCompare(hash[:], rule.EnrollmentSecretSha256) != 1 {
+			return false
+		}
+	}
+	for _, matcher := range rule.IdData {
+		if !matcher.Match(idData) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoAcceptRuleReqValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		req AutoAcceptRuleReq
+
+		err string
+	}{
+		"ok, id data": {
+			req: AutoAcceptRuleReq{
+				Name: "factory",
+				IdData: []IdDataMatcher{
+					{Attribute: "mac", Prefix: "00:1a:2b"},
+					{Attribute: "serial", Regex: "^SN-[0-9]{8}$"},
+				},
+			},
+		},
+		"ok, enrollment secret": {
+			req: AutoAcceptRuleReq{
+				EnrollmentSecret: "0123456789abcdef",
+			},
+		},
+		"error, no criteria": {
+			req: AutoAcceptRuleReq{Name: "everything"},
+			err: "id_data: cannot be blank without enrollment_secret.",
+		},
+		"error, short secret": {
+			req: AutoAcceptRuleReq{
+				EnrollmentSecret: "secret",
+			},
+			err: "enrollment_secret: the length must be between 16 and 1024.",
+		},
+		"error, missing attribute": {
+			req: AutoAcceptRuleReq{
+				IdData: []IdDataMatcher{{Prefix: "00:1a:2b"}},
+			},
+			err: "id_data: (0: (attribute: cannot be blank.).).",
+		},
+		"error, missing pattern": {
+			req: AutoAcceptRuleReq{
+				IdData: []IdDataMatcher{{Attribute: "mac"}},
+			},
+			err: "id_data: (0: (prefix: cannot be blank.).).",
+		},
+		"error, prefix and regex": {
+			req: AutoAcceptRuleReq{
+				IdData: []IdDataMatcher{{Attribute: "mac", Prefix: "00", Regex: "^00"}},
+			},
+			err: "id_data: (0: (prefix: cannot be combined with regex.).).",
+		},
+		"error, invalid regex": {
+			req: AutoAcceptRuleReq{
+				IdData: []IdDataMatcher{{Attribute: "serial", Regex: "SN-("}},
+			},
+			err: "id_data: (0: (regex: must be a valid regular expression.).).",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.req.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAutoAcceptRuleMatch(t *testing.T) {
+	t.Parallel()
+
+	const secret = "0123456789abcdef"
+	idData := map[string]interface{}{
+		"mac":    "00:1A:2B:3C:4D:5E",
+		"serial": "SN-00001234",
+		"sku":    []interface{}{"board-a", "board-b"},
+	}
+
+	testCases := map[string]struct {
+		req    AutoAcceptRuleReq
+		secret string
+
+		match bool
+	}{
+		"ok, prefix": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "mac", Prefix: "00:1a:2b"},
+			}},
+			match: true,
+		},
+		"ok, regex and prefix": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "mac", Prefix: "00:1a:2b"},
+				{Attribute: "serial", Regex: "^SN-[0-9]{8}$"},
+			}},
+			match: true,
+		},
+		"ok, multi-valued attribute": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "sku", Prefix: "board-b"},
+			}},
+			match: true,
+		},
+		"ok, enrollment secret": {
+			req:    AutoAcceptRuleReq{EnrollmentSecret: secret},
+			secret: secret,
+			match:  true,
+		},
+		"ok, enrollment secret and id data": {
+			req: AutoAcceptRuleReq{
+				EnrollmentSecret: secret,
+				IdData: []IdDataMatcher{
+					{Attribute: "serial", Regex: "^SN-"},
+				},
+			},
+			secret: secret,
+			match:  true,
+		},
+		"no match, one matcher fails": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "mac", Prefix: "00:1a:2b"},
+				{Attribute: "serial", Regex: "^XX-"},
+			}},
+		},
+		"no match, missing attribute": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "imei", Regex: ".*"},
+			}},
+		},
+		"no match, prefix longer than value": {
+			req: AutoAcceptRuleReq{IdData: []IdDataMatcher{
+				{Attribute: "mac", Prefix: "00:1A:2B:3C:4D:5E:6F"},
+			}},
+		},
+		"no match, wrong secret": {
+			req:    AutoAcceptRuleReq{EnrollmentSecret: secret},
+			secret: "fedcba9876543210",
+		},
+		"no match, no secret": {
+			req: AutoAcceptRuleReq{
+				EnrollmentSecret: secret,
+				IdData: []IdDataMatcher{
+					{Attribute: "mac", Prefix: "00:1a:2b"},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rule := NewAutoAcceptRule("id", &tc.req)
+			assert.Equal(t, tc.req.EnrollmentSecret != "", rule.EnrollmentSecret)
+			assert.Equal(t, tc.match, rule.Match(idData, tc.secret))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// Actions of the bulk auth set status changes.
+const (
+	AuthSetActionAccept  = "accept"
+	AuthSetActionReject  = "reject"
+	AuthSetActionDismiss = "dismiss"
+)
+
+// BulkMaxItems is the maximum number of items processed by a bulk request.
+const BulkMaxItems = 1000
+
+// AuthSetsStatusReq changes the status of a list of auth sets, or of the
+// auth sets of every device matching the filter.
+type AuthSetsStatusReq struct {
+	Action     string        `json:"action"`
+	AuthSetIDs []string      `json:"auth_set_ids,omitempty"`
+	Filter     *DeviceFilter `json:"filter,omitempty"`
+}
+
+func (r AuthSetsStatusReq) Validate() error {
+	err := validation.ValidateStruct(&r,
+		validation.Field(&r.Action, validation.Required, validation.In(
+			AuthSetActionAccept,
+			AuthSetActionReject,
+			AuthSetActionDismiss,
+		)),
+		validation.Field(&r.AuthSetIDs,
+			validation.Length(0, BulkMaxItems),
+			validation.Each(validation.Required)),
+	)
+	if err != nil {
+		return err
+	}
+	if (len(r.AuthSetIDs) > 0) == (r.Filter != nil) {
+		return errors.New("exactly one of auth_set_ids or filter is required")
+	}
+	if r.Filter != nil {
+		if len(r.Filter.Status) == 0 {
+			return errors.New("filter: status: cannot be blank")
+		}
+		if err := r.Filter.Validate(); err != nil {
+			return errors.Wrap(err, "filter")
+		}
+	}
+	return nil
+}
+
+// Status returns the auth set status the action results in, or an empty
+// string for the actions deleting the auth sets.
+func (r AuthSetsStatusReq) Status() string {
+	switch r.Action {
+	case AuthSetActionAccept:
+		return DevStatusAccepted
+	case AuthSetActionReject:
+		return DevStatusRejected
+	}
+	return ""
+}
+
+// BulkResult is the outcome of a single item of a bulk request.
+type BulkResult struct {
+	AuthSetID string
+	DeviceID  string
+	Err       error
+}
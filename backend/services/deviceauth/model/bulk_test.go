@@ -0,0 +1,98 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthSetsStatusReqValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		req AuthSetsStatusReq
+
+		err string
+	}{
+		"ok, auth set ids": {
+			req: AuthSetsStatusReq{
+				Action:     AuthSetActionAccept,
+				AuthSetIDs: []string{"aset-1", "aset-2"},
+			},
+		},
+		"ok, filter": {
+			req: AuthSetsStatusReq{
+				Action: AuthSetActionDismiss,
+				Filter: &DeviceFilter{Status: []string{DevStatusRejected}},
+			},
+		},
+		"error, no action": {
+			req: AuthSetsStatusReq{
+				AuthSetIDs: []string{"aset-1"},
+			},
+			err: "action: cannot be blank.",
+		},
+		"error, no auth sets": {
+			req: AuthSetsStatusReq{
+				Action: AuthSetActionReject,
+			},
+			err: "exactly one of auth_set_ids or filter is required",
+		},
+		"error, empty auth set id": {
+			req: AuthSetsStatusReq{
+				Action:     AuthSetActionReject,
+				AuthSetIDs: []string{""},
+			},
+			err: "auth_set_ids: (0: cannot be blank.).",
+		},
+		"error, too many auth sets": {
+			req: AuthSetsStatusReq{
+				Action:     AuthSetActionReject,
+				AuthSetIDs: make([]string, BulkMaxItems+1),
+			},
+			err: "auth_set_ids: the length must be no more than 1000.",
+		},
+		"error, filter without status": {
+			req: AuthSetsStatusReq{
+				Action: AuthSetActionAccept,
+				Filter: &DeviceFilter{IDs: []string{"device-1"}},
+			},
+			err: "filter: status: cannot be blank",
+		},
+		"error, filter with invalid status": {
+			req: AuthSetsStatusReq{
+				Action: AuthSetActionAccept,
+				Filter: &DeviceFilter{Status: []string{"decommissioned"}},
+			},
+			err: "filter: filter status must be one of: " +
+				"accepted, pending, rejected, preauthorized or noauth",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.req.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// Certificate subject attributes the device identity can be derived from.
+const (
+	CertSubjectCommonName         = "CN"
+	CertSubjectSerialNumber       = "serialNumber"
+	CertSubjectOrganization       = "O"
+	CertSubjectOrganizationalUnit = "OU"
+	CertSubjectCountry            = "C"
+	CertSubjectProvince           = "ST"
+	CertSubjectLocality           = "L"
+
+	certBlockType = "CERTIFICATE"
+)
+
+var (
+	certSubjectAttributes = map[string]func(pkix.Name) []string{
+		CertSubjectCommonName: func(n pkix.Name) []string {
+			return []string{n.CommonName}
+		},
+		CertSubjectSerialNumber: func(n pkix.Name) []string {
+			return []string{n.SerialNumber}
+		},
+		CertSubjectOrganization:       func(n pkix.Name) []string { return n.Organization },
+		CertSubjectOrganizationalUnit: func(n pkix.Name) []string { return n.OrganizationalUnit },
+		CertSubjectCountry:            func(n pkix.Name) []string { return n.Country },
+		CertSubjectProvince:           func(n pkix.Name) []string { return n.Province },
+		CertSubjectLocality:           func(n pkix.Name) []string { return n.Locality },
+	}
+
+	DefaultCertIdentityAttributes = []string{CertSubjectCommonName}
+)
+
+// CertificateAuthorityReq registers a CA issuing the device certificates.
+type CertificateAuthorityReq struct {
+	Name string `json:"name"`
+	// PEM encoded CA certificate
+	Certificate string `json:"certificate"`
+	// IdentityAttributes are the subject attributes of the device
+	// certificates making up the device identity data.
+	IdentityAttributes []string `json:"identity_attributes,omitempty"`
+	// Signature is the base64 encoded signature of the proof of
+	// possession message made with the CA key.
+	Signature string `json:"signature"`
+
+	//helpers, not serialized
+	Cert *x509.Certificate `json:"-"`
+}
+
+func ruleIdentityAttributes(value interface{}) error {
+	attrs, _ := value.([]string)
+	seen := make(map[string]struct{}, len(attrs))
+	for _, attr := range attrs {
+		if _, ok := certSubjectAttributes[attr]; !ok {
+			return errors.Errorf("unsupported subject attribute %q", attr)
+		}
+		if _, ok := seen[attr]; ok {
+			return errors.Errorf("duplicate subject attribute %q", attr)
+		}
+		seen[attr] = struct{}{}
+	}
+	return nil
+}
+
+func (r *CertificateAuthorityReq) Validate() error {
+	err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Length(0, 1024)),
+		validation.Field(&r.Certificate, validation.Required),
+		validation.Field(&r.Signature, validation.Required),
+		validation.Field(&r.IdentityAttributes,
+			validation.By(ruleIdentityAttributes)),
+	)
+	if err != nil {
+		return err
+	}
+
+	cert, err := ParseCertificate(r.Certificate)
+	if err != nil {
+		return err
+	}
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return errors.New("certificate is not a certificate authority")
+	}
+	r.Cert = cert
+
+	if len(r.IdentityAttributes) == 0 {
+		r.IdentityAttributes = DefaultCertIdentityAttributes
+	}
+	return nil
+}
+
+// ProofOfPossession returns the message the CA key signs to prove the
+// tenant registering the certificate authority holds the key: the tenant ID
+// and the hex encoded SHA256 fingerprint of the certificate, separated by a
+// newline.
+func (r *CertificateAuthorityReq) ProofOfPossession(tenantID string) []byte {
+	return []byte(tenantID + "\n" + CertFingerprint(r.Cert))
+}
+
+// CertFingerprint returns the hex encoded SHA256 hash of the certificate.
+func CertFingerprint(cert *x509.Certificate) string {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// ParseCertificate parses a single PEM encoded certificate
+func ParseCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != certBlockType {
+		return nil, errors.New("cannot decode certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode certificate")
+	}
+	return cert, nil
+}
+
+// CertSubjectSha256 returns the hash of the raw subject (or issuer) name,
+// used to look up the authority issuing a certificate.
+func CertSubjectSha256(rawName []byte) []byte {
+	hash := sha256.Sum256(rawName)
+	return hash[:]
+}
+
+type CertificateAuthority struct {
+	Id                 string    `json:"id" bson:"_id"`
+	Name               string    `json:"name" bson:"name,omitempty"`
+	Certificate        string    `json:"certificate" bson:"certificate"`
+	Subject            string    `json:"subject" bson:"subject"`
+	SubjectSha256      []byte    `json:"-" bson:"subject_sha256"`
+	Fingerprint        string    `json:"fingerprint" bson:"fingerprint"`
+	IdentityAttributes []string  `json:"identity_attributes" bson:"identity_attributes"`
+	NotAfter           time.Time `json:"not_after" bson:"not_after"`
+	CreatedTs          time.Time `json:"created_ts" bson:"created_ts"`
+	TenantID           string    `json:"-" bson:"tenant_id"`
+}
+
+func NewCertificateAuthority(id string, req *CertificateAuthorityReq) *CertificateAuthority {
+	return &CertificateAuthority{
+		Id:   id,
+		Name: req.Name,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{
+			Type:  certBlockType,
+			Bytes: req.Cert.Raw,
+		})),
+		Subject:            req.Cert.Subject.String(),
+		SubjectSha256:      CertSubjectSha256(req.Cert.RawSubject),
+		Fingerprint:        CertFingerprint(req.Cert),
+		IdentityAttributes: req.IdentityAttributes,
+		NotAfter:           req.Cert.NotAfter.UTC(),
+		CreatedTs:          time.Now().UTC(),
+	}
+}
+
+// IdentityData composes the identity data of the device from the subject
+// attributes of the device certificate.
+func (ca *CertificateAuthority) IdentityData(cert *x509.Certificate) (string, error) {
+	attrs := ca.IdentityAttributes
+	if len(attrs) == 0 {
+		attrs = DefaultCertIdentityAttributes
+	}
+	idData := make(map[string]interface{}, len(attrs))
+	for _, attr := range attrs {
+		getter, ok := certSubjectAttributes[attr]
+		if !ok {
+			return "", errors.Errorf("unsupported subject attribute %q", attr)
+		}
+		var values []string
+		for _, value := range getter(cert.Subject) {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+		switch len(values) {
+		case 0:
+			return "", errors.Errorf(
+				"certificate subject is missing the %q attribute", attr,
+			)
+		case 1:
+			idData[attr] = values[0]
+		default:
+			idData[attr] = values
+		}
+	}
+	b, err := json.Marshal(idData)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode identity data")
+	}
+	return string(b), nil
+}
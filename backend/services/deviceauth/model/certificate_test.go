@@ -0,0 +1,213 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(
+	t *testing.T,
+	subject pkix.Name,
+	isCA bool,
+) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func encodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	}))
+}
+
+func TestCertificateAuthorityReqValidate(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCertificate(t, pkix.Name{CommonName: "Factory CA"}, true)
+	leaf := newTestCertificate(t, pkix.Name{CommonName: "device"}, false)
+
+	testCases := map[string]struct {
+		req CertificateAuthorityReq
+
+		attributes []string
+		err        string
+	}{
+		"ok, default attributes": {
+			req: CertificateAuthorityReq{
+				Name:        "factory",
+				Certificate: encodeCertificate(ca),
+				Signature:   "c2lnbmF0dXJl",
+			},
+			attributes: DefaultCertIdentityAttributes,
+		},
+		"ok": {
+			req: CertificateAuthorityReq{
+				Certificate:        encodeCertificate(ca),
+				IdentityAttributes: []string{CertSubjectSerialNumber, CertSubjectOrganization},
+				Signature:          "c2lnbmF0dXJl",
+			},
+			attributes: []string{CertSubjectSerialNumber, CertSubjectOrganization},
+		},
+		"error, missing certificate": {
+			req: CertificateAuthorityReq{Signature: "c2lnbmF0dXJl"},
+			err: "certificate: cannot be blank.",
+		},
+		"error, missing signature": {
+			req: CertificateAuthorityReq{
+				Certificate: encodeCertificate(ca),
+			},
+			err: "signature: cannot be blank.",
+		},
+		"error, not a certificate": {
+			req: CertificateAuthorityReq{
+				Certificate: "-----BEGIN PUBLIC KEY-----\nMAo=\n-----END PUBLIC KEY-----\n",
+				Signature:   "c2lnbmF0dXJl",
+			},
+			err: "cannot decode certificate",
+		},
+		"error, not a certificate authority": {
+			req: CertificateAuthorityReq{
+				Certificate: encodeCertificate(leaf),
+				Signature:   "c2lnbmF0dXJl",
+			},
+			err: "certificate is not a certificate authority",
+		},
+		"error, unsupported attribute": {
+			req: CertificateAuthorityReq{
+				Certificate:        encodeCertificate(ca),
+				IdentityAttributes: []string{"emailAddress"},
+				Signature:          "c2lnbmF0dXJl",
+			},
+			err: `identity_attributes: unsupported subject attribute "emailAddress".`,
+		},
+		"error, duplicate attribute": {
+			req: CertificateAuthorityReq{
+				Certificate:        encodeCertificate(ca),
+				IdentityAttributes: []string{CertSubjectCommonName, CertSubjectCommonName},
+				Signature:          "c2lnbmF0dXJl",
+			},
+			err: `identity_attributes: duplicate subject attribute "CN".`,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.req.Validate()
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.attributes, tc.req.IdentityAttributes)
+			assert.Equal(t, ca.Raw, tc.req.Cert.Raw)
+		})
+	}
+}
+
+func TestCertificateAuthorityIdentityData(t *testing.T) {
+	t.Parallel()
+
+	leaf := newTestCertificate(t, pkix.Name{
+		CommonName:         "device-0001",
+		SerialNumber:       "SN0001",
+		OrganizationalUnit: []string{"line-1", "line-2"},
+	}, false)
+
+	testCases := map[string]struct {
+		attributes []string
+
+		idData string
+		err    string
+	}{
+		"ok, default attributes": {
+			idData: `{"CN":"device-0001"}`,
+		},
+		"ok": {
+			attributes: []string{CertSubjectSerialNumber, CertSubjectCommonName},
+			idData:     `{"CN":"device-0001","serialNumber":"SN0001"}`,
+		},
+		"ok, multi-valued attribute": {
+			attributes: []string{CertSubjectOrganizationalUnit},
+			idData:     `{"OU":["line-1","line-2"]}`,
+		},
+		"error, missing attribute": {
+			attributes: []string{CertSubjectCommonName, CertSubjectOrganization},
+			err:        `certificate subject is missing the "O" attribute`,
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ca := &CertificateAuthority{IdentityAttributes: tc.attributes}
+			idData, err := ca.IdentityData(leaf)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.idData, idData)
+		})
+	}
+}
+
+func TestNewCertificateAuthority(t *testing.T) {
+	t.Parallel()
+
+	cert := newTestCertificate(t, pkix.Name{CommonName: "Factory CA"}, true)
+	req := &CertificateAuthorityReq{
+		Name:        "factory",
+		Certificate: encodeCertificate(cert),
+		Signature:   "c2lnbmF0dXJl",
+	}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, []byte("tenant\n"+CertFingerprint(cert)), req.ProofOfPossession("tenant"))
+
+	ca := NewCertificateAuthority("id", req)
+	assert.Equal(t, "id", ca.Id)
+	assert.Equal(t, "factory", ca.Name)
+	assert.Equal(t, "CN=Factory CA", ca.Subject)
+	assert.Equal(t, CertSubjectSha256(cert.RawSubject), ca.SubjectSha256)
+	assert.Len(t, ca.Fingerprint, 64)
+	assert.Equal(t, CertFingerprint(cert), ca.Fingerprint)
+	assert.Equal(t, req.Certificate, ca.Certificate)
+	assert.Equal(t, DefaultCertIdentityAttributes, ca.IdentityAttributes)
+}
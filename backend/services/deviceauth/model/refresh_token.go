@@ -0,0 +1,99 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+const refreshTokenSecretSize = 32
+
+// DeviceTokens are the credentials issued to an authenticated device; the
+// refresh token is only issued when refresh tokens are enabled.
+type DeviceTokens struct {
+	Token        string
+	RefreshToken string
+}
+
+// RefreshToken renews the device token of the auth set, and thus of the
+// device key, it was issued to. A device holds at most one refresh token per
+// auth set, and only the hash of the secret is stored.
+type RefreshToken struct {
+	AuthSetID    string    `bson:"_id"`
+	DeviceID     string    `bson:"device_id"`
+	SecretSha256 []byte    `bson:"secret_sha256"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+	CreatedTs    time.Time `bson:"created_ts"`
+	TenantID     string    `bson:"tenant_id"`
+}
+
+// NewRefreshToken generates a refresh token for the auth set, returning the
+// token presented by the device along with the record to store.
+func NewRefreshToken(aset *AuthSet, expiresAt time.Time) (*RefreshToken, string, error) {
+	secret := make([]byte, refreshTokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate refresh token")
+	}
+	encSecret := base64.RawURLEncoding.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(encSecret))
+	return &RefreshToken{
+		AuthSetID:    aset.Id,
+		DeviceID:     aset.DeviceId,
+		SecretSha256: hash[:],
+		ExpiresAt:    expiresAt.UTC(),
+		CreatedTs:    time.Now().UTC(),
+	}, aset.Id + "." + encSecret, nil
+}
+
+// ParseRefreshToken splits the refresh token presented by the device into
+// the auth set ID and the hash of the secret.
+func ParseRefreshToken(token string) (authSetID string, secretSha256 []byte, err error) {
+	authSetID, secret, ok := strings.Cut(token, ".")
+	if !ok || authSetID == "" || secret == "" {
+		return "", nil, errors.New("malformed refresh token")
+	}
+	hash := sha256.Sum256([]byte(secret))
+	return authSetID, hash[:], nil
+}
+
+// Match reports whether the refresh token has the secret and has not
+// expired yet.
+func (t *RefreshToken) Match(secretSha256 []byte, now time.Time) bool {
+	return subtle.ConstantTimeCompare(t.SecretSha256, secretSha256) == 1 &&
+		now.Before(t.ExpiresAt)
+}
+
+// RefreshTokenReq renews the device token; the request is signed with the
+// device key.
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token"`
+
+	//helpers, not serialized
+	Raw       []byte `json:"-"`
+	Signature string `json:"-"`
+}
+
+func (r *RefreshTokenReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.RefreshToken, validation.Required),
+	)
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	aset := &AuthSet{Id: "aset-1", DeviceId: "device-1"}
+	expiresAt := time.Now().Add(time.Hour)
+	refresh, token, err := NewRefreshToken(aset, expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, "aset-1", refresh.AuthSetID)
+	assert.Equal(t, "device-1", refresh.DeviceID)
+	assert.True(t, strings.HasPrefix(token, "aset-1."))
+
+	authSetID, secretSha256, err := ParseRefreshToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "aset-1", authSetID)
+	assert.True(t, refresh.Match(secretSha256, time.Now()))
+	assert.False(t, refresh.Match(secretSha256, expiresAt.Add(time.Second)))
+
+	_, otherToken, err := NewRefreshToken(aset, expiresAt)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, otherToken)
+	_, otherSecretSha256, err := ParseRefreshToken(otherToken)
+	require.NoError(t, err)
+	assert.False(t, refresh.Match(otherSecretSha256, time.Now()))
+}
+
+func TestParseRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		token string
+
+		authSetID string
+		err       string
+	}{
+		"ok": {
+			token:     "aset-1.secret",
+			authSetID: "aset-1",
+		},
+		"error, no separator": {
+			token: "aset-1",
+			err:   "malformed refresh token",
+		},
+		"error, no auth set": {
+			token: ".secret",
+			err:   "malformed refresh token",
+		},
+		"error, no secret": {
+			token: "aset-1.",
+			err:   "malformed refresh token",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			authSetID, secretSha256, err := ParseRefreshToken(tc.token)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.authSetID, authSetID)
+			assert.Len(t, secretSha256, 32)
+		})
+	}
+}
+
+func TestRefreshTokenReqValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&RefreshTokenReq{RefreshToken: "aset-1.secret"}).Validate())
+	assert.EqualError(t, (&RefreshTokenReq{}).Validate(),
+		"refresh_token: cannot be blank.")
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -58,9 +59,15 @@ func RunServer(c config.Reader) error {
 		return errors.Wrap(err, "database connection failed")
 	}
 
-	jwtHandler, err := jwt.NewJWTHandler(
-		c.GetString(dconfig.SettingServerPrivKeyPath),
-	)
+	var jwtHandler jwt.Handler
+	if keyDir := c.GetString(dconfig.SettingServerPrivKeyDir); keyDir != "" {
+		jwtHandler, err = jwt.NewJWTHandlerKeySet(keyDir,
+			c.GetString(dconfig.SettingServerPrivKeySigning))
+	} else {
+		jwtHandler, err = jwt.NewJWTHandler(
+			c.GetString(dconfig.SettingServerPrivKeyPath),
+		)
+	}
 	var jwtFallbackHandler jwt.Handler
 	fallback := c.GetString(dconfig.SettingServerFallbackPrivKeyPath)
 	if err == nil && fallback != "" {
@@ -94,8 +101,11 @@ func RunServer(c config.Reader) error {
 		inv.DeviceInventoryInternalAPIAPI,
 		jwtHandler,
 		devauth.Config{
-			Issuer:               c.GetString(dconfig.SettingJWTIssuer),
-			ExpirationTime:       int64(c.GetInt(dconfig.SettingJWTExpirationTimeout)),
+			Issuer:         c.GetString(dconfig.SettingJWTIssuer),
+			ExpirationTime: int64(c.GetInt(dconfig.SettingJWTExpirationTimeout)),
+			RefreshExpirationTime: int64(
+				c.GetInt(dconfig.SettingJWTRefreshExpirationTimeout)),
+			ShortExpirationTime:  int64(c.GetInt(dconfig.SettingJWTShortExpirationTimeout)),
 			LegacyProvisionEvent: c.GetBool(dconfig.SettingLegacyProvisionDevice),
 		})
 
@@ -127,6 +137,17 @@ func RunServer(c config.Reader) error {
 		return errors.New("ratelimits: redis is required but disabled")
 	}
 
+	var clientCertProxies []*net.IPNet
+	for _, cidr := range c.GetStringSlice(dconfig.SettingClientCertTrustedProxies) {
+		_, proxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid setting %s",
+				dconfig.SettingClientCertTrustedProxies)
+		}
+		clientCertProxies = append(clientCertProxies, proxy)
+	}
+	apiOptions = append(apiOptions, api_http.SetClientCertProxies(clientCertProxies))
+
 	apiOptions = append(apiOptions, api_http.SetMaxRequestSize(
 		int64(c.GetInt(dconfig.SettingMaxRequestSize)),
 	))
@@ -38,6 +38,12 @@ var (
 	ErrObjectExists = errors.New("object exists")
 	// device status unknown
 	ErrDevStatusBroken = errors.New("cannot qualify device status")
+	// certificate authority not found
+	ErrCertificateAuthorityNotFound = errors.New("certificate authority not found")
+	// auto-accept rule not found
+	ErrAutoAcceptRuleNotFound = errors.New("auto-accept rule not found")
+	// refresh token not found
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 )
 
 const (
@@ -159,6 +165,47 @@ type DataStore interface {
 	// gets device status
 	GetDeviceStatus(ctx context.Context, dev_id string) (string, error)
 
+	// adds a (tenant's) certificate authority issuing device certificates
+	// returns ErrObjectExists if the certificate is already registered
+	AddCertificateAuthority(ctx context.Context, ca model.CertificateAuthority) error
+
+	// lists the (tenant's) certificate authorities
+	GetCertificateAuthorities(ctx context.Context) ([]model.CertificateAuthority, error)
+
+	// deletes a (tenant's) certificate authority
+	// returns ErrCertificateAuthorityNotFound if not found
+	DeleteCertificateAuthority(ctx context.Context, id string) error
+
+	// finds the certificate authorities by subject hash, looking up the
+	// candidate issuers of a device certificate; the authorities of all
+	// tenants are searched unless the context has a tenant
+	FindCertificateAuthorities(
+		ctx context.Context,
+		subjectSha256 ...[]byte,
+	) ([]model.CertificateAuthority, error)
+
+	// adds a (tenant's) rule auto-accepting the matching devices
+	AddAutoAcceptRule(ctx context.Context, rule model.AutoAcceptRule) error
+
+	// lists the (tenant's) auto-accept rules
+	GetAutoAcceptRules(ctx context.Context) ([]model.AutoAcceptRule, error)
+
+	// deletes a (tenant's) auto-accept rule
+	// returns ErrAutoAcceptRuleNotFound if not found
+	DeleteAutoAcceptRule(ctx context.Context, id string) error
+
+	// stores the refresh token, replacing the previous refresh token of
+	// the auth set
+	AddRefreshToken(ctx context.Context, token model.RefreshToken) error
+
+	// gets the refresh token of the auth set of any tenant
+	// returns ErrRefreshTokenNotFound if not found
+	GetRefreshToken(ctx context.Context, authSetID string) (*model.RefreshToken, error)
+
+	// deletes the refresh token of the auth set, if it has the secret
+	// returns ErrRefreshTokenNotFound if not found
+	DeleteRefreshToken(ctx context.Context, authSetID string, secretSha256 []byte) error
+
 	MigrateTenant(ctx context.Context, version string, tenant string) error
 	WithAutomigrate() DataStore
 	//call this one if you really know what you are doing. This is supposed to be called only
@@ -54,6 +54,42 @@ func (_m *DataStore) AddAuthSet(ctx context.Context, set model.AuthSet) error {
 	return r0
 }
 
+// AddAutoAcceptRule provides a mock function with given fields: ctx, rule
+func (_m *DataStore) AddAutoAcceptRule(ctx context.Context, rule model.AutoAcceptRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAutoAcceptRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AutoAcceptRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddCertificateAuthority provides a mock function with given fields: ctx, ca
+func (_m *DataStore) AddCertificateAuthority(ctx context.Context, ca model.CertificateAuthority) error {
+	ret := _m.Called(ctx, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCertificateAuthority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CertificateAuthority) error); ok {
+		r0 = rf(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddDevice provides a mock function with given fields: ctx, d
 func (_m *DataStore) AddDevice(ctx context.Context, d model.Device) error {
 	ret := _m.Called(ctx, d)
@@ -72,6 +108,24 @@ func (_m *DataStore) AddDevice(ctx context.Context, d model.Device) error {
 	return r0
 }
 
+// AddRefreshToken provides a mock function with given fields: ctx, token
+func (_m *DataStore) AddRefreshToken(ctx context.Context, token model.RefreshToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddToken provides a mock function with given fields: ctx, t
 func (_m *DataStore) AddToken(ctx context.Context, t *jwt.Token) error {
 	ret := _m.Called(ctx, t)
@@ -126,6 +180,42 @@ func (_m *DataStore) DeleteAuthSetsForDevice(ctx context.Context, devid string)
 	return r0
 }
 
+// DeleteAutoAcceptRule provides a mock function with given fields: ctx, id
+func (_m *DataStore) DeleteAutoAcceptRule(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAutoAcceptRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCertificateAuthority provides a mock function with given fields: ctx, id
+func (_m *DataStore) DeleteCertificateAuthority(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCertificateAuthority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, id
 func (_m *DataStore) DeleteDevice(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -162,6 +252,24 @@ func (_m *DataStore) DeleteLimit(ctx context.Context, lim string) error {
 	return r0
 }
 
+// DeleteRefreshToken provides a mock function with given fields: ctx, authSetID, secretSha256
+func (_m *DataStore) DeleteRefreshToken(ctx context.Context, authSetID string, secretSha256 []byte) error {
+	ret := _m.Called(ctx, authSetID, secretSha256)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, authSetID, secretSha256)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteToken provides a mock function with given fields: ctx, jti
 func (_m *DataStore) DeleteToken(ctx context.Context, jti oid.ObjectID) error {
 	ret := _m.Called(ctx, jti)
@@ -216,6 +324,43 @@ func (_m *DataStore) DeleteTokens(ctx context.Context) error {
 	return r0
 }
 
+// FindCertificateAuthorities provides a mock function with given fields: ctx, subjectSha256
+func (_m *DataStore) FindCertificateAuthorities(ctx context.Context, subjectSha256 ...[]byte) ([]model.CertificateAuthority, error) {
+	_va := make([]interface{}, len(subjectSha256))
+	for _i := range subjectSha256 {
+		_va[_i] = subjectSha256[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCertificateAuthorities")
+	}
+
+	var r0 []model.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...[]byte) ([]model.CertificateAuthority, error)); ok {
+		return rf(ctx, subjectSha256...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...[]byte) []model.CertificateAuthority); ok {
+		r0 = rf(ctx, subjectSha256...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...[]byte) error); ok {
+		r1 = rf(ctx, subjectSha256...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ForEachTenant provides a mock function with given fields: parentCtx, opFunc
 func (_m *DataStore) ForEachTenant(parentCtx context.Context, opFunc store.MapFunc) error {
 	ret := _m.Called(parentCtx, opFunc)
@@ -354,6 +499,66 @@ func (_m *DataStore) GetAuthSetsForDevice(ctx context.Context, devid string) ([]
 	return r0, r1
 }
 
+// GetAutoAcceptRules provides a mock function with given fields: ctx
+func (_m *DataStore) GetAutoAcceptRules(ctx context.Context) ([]model.AutoAcceptRule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAutoAcceptRules")
+	}
+
+	var r0 []model.AutoAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.AutoAcceptRule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.AutoAcceptRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AutoAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCertificateAuthorities provides a mock function with given fields: ctx
+func (_m *DataStore) GetCertificateAuthorities(ctx context.Context) ([]model.CertificateAuthority, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAuthorities")
+	}
+
+	var r0 []model.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.CertificateAuthority, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.CertificateAuthority); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevCountByStatus provides a mock function with given fields: ctx, status
 func (_m *DataStore) GetDevCountByStatus(ctx context.Context, status string) (int, error) {
 	ret := _m.Called(ctx, status)
@@ -530,6 +735,36 @@ func (_m *DataStore) GetLimit(ctx context.Context, name string) (*model.Limit, e
 	return r0, r1
 }
 
+// GetRefreshToken provides a mock function with given fields: ctx, authSetID
+func (_m *DataStore) GetRefreshToken(ctx context.Context, authSetID string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, authSetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshToken")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.RefreshToken, error)); ok {
+		return rf(ctx, authSetID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, authSetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authSetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetToken provides a mock function with given fields: ctx, jti
 func (_m *DataStore) GetToken(ctx context.Context, jti oid.ObjectID) (*jwt.Token, error) {
 	ret := _m.Called(ctx, jti)
@@ -42,12 +42,15 @@ import (
 )
 
 const (
-	DbVersion     = "2.0.2"
-	DbName        = "deviceauth"
-	DbDevicesColl = "devices"
-	DbAuthSetColl = "auth_sets"
-	DbTokensColl  = "tokens"
-	DbLimitsColl  = "limits"
+	DbVersion                    = "2.3.0"
+	DbName                       = "deviceauth"
+	DbDevicesColl                = "devices"
+	DbAuthSetColl                = "auth_sets"
+	DbTokensColl                 = "tokens"
+	DbLimitsColl                 = "limits"
+	DbCertificateAuthoritiesColl = "certificate_authorities"
+	DbAutoAcceptRulesColl        = "auto_accept_rules"
+	DbRefreshTokensColl          = "refresh_tokens"
 
 	DbKeyDeviceRevision = "revision"
 	dbFieldID           = "_id"
@@ -61,6 +64,11 @@ const (
 	dbFieldName         = "name"
 	dbFieldValue        = "value"
 	dbFieldSubject      = "sub"
+	dbFieldFingerprint  = "fingerprint"
+	dbFieldSubjectSha   = "subject_sha256"
+	dbFieldCreatedTs    = "created_ts"
+	dbFieldExpiresAt    = "expires_at"
+	dbFieldSecretSha    = "secret_sha256"
 )
 
 var (
@@ -595,6 +603,18 @@ func (db *DataStoreMongo) MigrateTenant(ctx context.Context, database, version s
 			ds:  db,
 			ctx: ctx,
 		},
+		&migration_2_1_0{
+			ds:  db,
+			ctx: ctx,
+		},
+		&migration_2_2_0{
+			ds:  db,
+			ctx: ctx,
+		},
+		&migration_2_3_0{
+			ds:  db,
+			ctx: ctx,
+		},
 	}
 
 	ver, err := migrate.NewVersion(version)
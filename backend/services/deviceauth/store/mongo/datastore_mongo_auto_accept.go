@@ -0,0 +1,77 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	mongostore "github.com/mendersoftware/mender-server/pkg/mongo/v2"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func (db *DataStoreMongo) AddAutoAcceptRule(
+	ctx context.Context,
+	rule model.AutoAcceptRule,
+) error {
+	c := db.client.Database(DbName).Collection(DbAutoAcceptRulesColl)
+
+	rule.TenantID = ""
+	if id := identity.FromContext(ctx); id != nil {
+		rule.TenantID = id.Tenant
+	}
+	if _, err := c.InsertOne(ctx, rule); err != nil {
+		return errors.Wrap(err, "failed to store auto-accept rule")
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) GetAutoAcceptRules(
+	ctx context.Context,
+) ([]model.AutoAcceptRule, error) {
+	c := db.client.Database(DbName).Collection(DbAutoAcceptRulesColl)
+
+	cur, err := c.Find(ctx,
+		mongostore.WithTenantID(ctx, bson.D{}),
+		mopts.Find().SetSort(bson.D{{Key: dbFieldCreatedTs, Value: 1}}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch auto-accept rules")
+	}
+	rules := []model.AutoAcceptRule{}
+	if err := cur.All(ctx, &rules); err != nil {
+		return nil, errors.Wrap(err, "failed to decode auto-accept rules")
+	}
+	return rules, nil
+}
+
+func (db *DataStoreMongo) DeleteAutoAcceptRule(ctx context.Context, id string) error {
+	c := db.client.Database(DbName).Collection(DbAutoAcceptRulesColl)
+
+	res, err := c.DeleteOne(ctx, mongostore.WithTenantID(ctx, bson.D{
+		{Key: dbFieldID, Value: id},
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to delete auto-accept rule")
+	} else if res.DeletedCount == 0 {
+		return store.ErrAutoAcceptRuleNotFound
+	}
+	return nil
+}
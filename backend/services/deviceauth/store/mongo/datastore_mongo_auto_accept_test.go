@@ -0,0 +1,83 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func TestStoreAutoAcceptRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreAutoAcceptRules in short mode.")
+	}
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenant,
+	})
+	ctxOther := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "other-" + tenant,
+	})
+	db := getDb(ctx)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	rule1 := model.AutoAcceptRule{
+		Id:   "rule1",
+		Name: "factory",
+		IdData: []model.IdDataMatcher{
+			{Attribute: "mac", Prefix: "00:1a:2b"},
+		},
+		CreatedTs: now,
+	}
+	rule2 := model.AutoAcceptRule{
+		Id:                     "rule2",
+		IdData:                 []model.IdDataMatcher{},
+		EnrollmentSecret:       true,
+		EnrollmentSecretSha256: []byte("secret-hash"),
+		CreatedTs:              now.Add(time.Second),
+	}
+	rule3 := rule1
+	rule3.Id = "rule3"
+
+	require.NoError(t, db.AddAutoAcceptRule(ctx, rule2))
+	require.NoError(t, db.AddAutoAcceptRule(ctx, rule1))
+	require.NoError(t, db.AddAutoAcceptRule(ctxOther, rule3))
+
+	rule1.TenantID = tenant
+	rule2.TenantID = tenant
+
+	rules, err := db.GetAutoAcceptRules(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AutoAcceptRule{rule1, rule2}, rules)
+
+	// tenants can't delete each other's rules
+	err = db.DeleteAutoAcceptRule(ctxOther, rule1.Id)
+	assert.Equal(t, store.ErrAutoAcceptRuleNotFound, err)
+
+	err = db.DeleteAutoAcceptRule(ctx, rule1.Id)
+	assert.NoError(t, err)
+
+	rules, err = db.GetAutoAcceptRules(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AutoAcceptRule{rule2}, rules)
+}
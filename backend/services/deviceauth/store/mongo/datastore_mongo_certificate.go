@@ -0,0 +1,104 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+	mongostore "github.com/mendersoftware/mender-server/pkg/mongo/v2"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func (db *DataStoreMongo) AddCertificateAuthority(
+	ctx context.Context,
+	ca model.CertificateAuthority,
+) error {
+	c := db.client.Database(DbName).Collection(DbCertificateAuthoritiesColl)
+
+	ca.TenantID = ""
+	if id := identity.FromContext(ctx); id != nil {
+		ca.TenantID = id.Tenant
+	}
+	if _, err := c.InsertOne(ctx, ca); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrObjectExists
+		}
+		return errors.Wrap(err, "failed to store certificate authority")
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) GetCertificateAuthorities(
+	ctx context.Context,
+) ([]model.CertificateAuthority, error) {
+	c := db.client.Database(DbName).Collection(DbCertificateAuthoritiesColl)
+
+	cur, err := c.Find(ctx,
+		mongostore.WithTenantID(ctx, bson.D{}),
+		mopts.Find().SetSort(bson.D{{Key: dbFieldCreatedTs, Value: 1}}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch certificate authorities")
+	}
+	cas := []model.CertificateAuthority{}
+	if err := cur.All(ctx, &cas); err != nil {
+		return nil, errors.Wrap(err, "failed to decode certificate authorities")
+	}
+	return cas, nil
+}
+
+func (db *DataStoreMongo) DeleteCertificateAuthority(ctx context.Context, id string) error {
+	c := db.client.Database(DbName).Collection(DbCertificateAuthoritiesColl)
+
+	res, err := c.DeleteOne(ctx, mongostore.WithTenantID(ctx, bson.D{
+		{Key: dbFieldID, Value: id},
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to delete certificate authority")
+	} else if res.DeletedCount == 0 {
+		return store.ErrCertificateAuthorityNotFound
+	}
+	return nil
+}
+
+func (db *DataStoreMongo) FindCertificateAuthorities(
+	ctx context.Context,
+	subjectSha256 ...[]byte,
+) ([]model.CertificateAuthority, error) {
+	c := db.client.Database(DbName).Collection(DbCertificateAuthoritiesColl)
+
+	filter := bson.D{
+		{Key: dbFieldSubjectSha, Value: bson.D{{Key: "$in", Value: subjectSha256}}},
+	}
+	if id := identity.FromContext(ctx); id != nil {
+		filter = mongostore.WithTenantID(ctx, filter)
+	}
+	cur, err := c.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch certificate authorities")
+	}
+	cas := []model.CertificateAuthority{}
+	if err := cur.All(ctx, &cas); err != nil {
+		return nil, errors.Wrap(err, "failed to decode certificate authorities")
+	}
+	return cas, nil
+}
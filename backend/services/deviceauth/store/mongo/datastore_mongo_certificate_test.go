@@ -0,0 +1,115 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func TestStoreCertificateAuthorities(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreCertificateAuthorities in short mode.")
+	}
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenant,
+	})
+	ctxOther := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: "other-" + tenant,
+	})
+	db := getDb(ctx)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ca1 := model.CertificateAuthority{
+		Id:                 "ca1",
+		Name:               "factory",
+		Certificate:        "certificate-1",
+		Subject:            "CN=Factory CA",
+		SubjectSha256:      []byte("subject-1"),
+		Fingerprint:        "fingerprint-1",
+		IdentityAttributes: []string{model.CertSubjectCommonName},
+		NotAfter:           now.Add(time.Hour),
+		CreatedTs:          now,
+	}
+	ca2 := ca1
+	ca2.Id = "ca2"
+	ca2.Certificate = "certificate-2"
+	ca2.Fingerprint = "fingerprint-2"
+	ca2.CreatedTs = now.Add(time.Second)
+	ca3 := ca1
+	ca3.Id = "ca3"
+	ca3.SubjectSha256 = []byte("subject-3")
+	ca3.Fingerprint = "fingerprint-3"
+
+	require.NoError(t, db.AddCertificateAuthority(ctx, ca1))
+	require.NoError(t, db.AddCertificateAuthority(ctx, ca2))
+	require.NoError(t, db.AddCertificateAuthority(ctxOther, ca3))
+
+	// the certificate is registered only once
+	dup := ca1
+	dup.Id = "dup"
+	err := db.AddCertificateAuthority(ctx, dup)
+	assert.Equal(t, store.ErrObjectExists, err)
+	owned := ca1
+	owned.Id = "owned"
+	owned.Fingerprint = ca3.Fingerprint
+	err = db.AddCertificateAuthority(ctx, owned)
+	assert.Equal(t, store.ErrObjectExists, err)
+	shared := ca1
+	shared.Id = "shared"
+	shared.SubjectSha256 = []byte("subject-4")
+	shared.Fingerprint = "fingerprint-4"
+	shared.CreatedTs = now.Add(2 * time.Second)
+	require.NoError(t, db.AddCertificateAuthority(ctx, shared))
+
+	ca1.TenantID = tenant
+	ca2.TenantID = tenant
+	ca3.TenantID = "other-" + tenant
+	shared.TenantID = tenant
+
+	cas, err := db.GetCertificateAuthorities(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CertificateAuthority{ca1, ca2, shared}, cas)
+
+	cas, err = db.FindCertificateAuthorities(context.Background(),
+		[]byte("subject-3"), []byte("subject-4"))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []model.CertificateAuthority{ca3, shared}, cas)
+
+	cas, err = db.FindCertificateAuthorities(ctxOther,
+		[]byte("subject-3"), []byte("subject-4"))
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CertificateAuthority{ca3}, cas)
+
+	// tenants can't delete each other's authorities
+	err = db.DeleteCertificateAuthority(ctxOther, ca1.Id)
+	assert.Equal(t, store.ErrCertificateAuthorityNotFound, err)
+
+	err = db.DeleteCertificateAuthority(ctx, ca1.Id)
+	assert.NoError(t, err)
+
+	cas, err = db.GetCertificateAuthorities(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CertificateAuthority{ca2, shared}, cas)
+}
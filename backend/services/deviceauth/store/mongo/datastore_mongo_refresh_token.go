@@ -0,0 +1,87 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func (db *DataStoreMongo) AddRefreshToken(
+	ctx context.Context,
+	token model.RefreshToken,
+) error {
+	c := db.client.Database(DbName).Collection(DbRefreshTokensColl)
+
+	token.TenantID = ""
+	if id := identity.FromContext(ctx); id != nil {
+		token.TenantID = id.Tenant
+	}
+	_, err := c.ReplaceOne(ctx,
+		bson.D{{Key: dbFieldID, Value: token.AuthSetID}},
+		token,
+		mopts.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to store refresh token")
+	}
+	return nil
+}
+
+// GetRefreshToken looks up the refresh token regardless of the tenant:
+// the device presenting it is not authenticated yet.
+func (db *DataStoreMongo) GetRefreshToken(
+	ctx context.Context,
+	authSetID string,
+) (*model.RefreshToken, error) {
+	c := db.client.Database(DbName).Collection(DbRefreshTokensColl)
+
+	var token model.RefreshToken
+	err := c.FindOne(ctx, bson.D{{Key: dbFieldID, Value: authSetID}}).
+		Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return nil, store.ErrRefreshTokenNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch refresh token")
+	}
+	return &token, nil
+}
+
+func (db *DataStoreMongo) DeleteRefreshToken(
+	ctx context.Context,
+	authSetID string,
+	secretSha256 []byte,
+) error {
+	c := db.client.Database(DbName).Collection(DbRefreshTokensColl)
+
+	res, err := c.DeleteOne(ctx, bson.D{
+		{Key: dbFieldID, Value: authSetID},
+		{Key: dbFieldSecretSha, Value: secretSha256},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to delete refresh token")
+	} else if res.DeletedCount == 0 {
+		return store.ErrRefreshTokenNotFound
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mendersoftware/mender-server/pkg/identity"
+
+	"github.com/mendersoftware/mender-server/services/deviceauth/model"
+	"github.com/mendersoftware/mender-server/services/deviceauth/store"
+)
+
+func TestStoreRefreshTokens(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestStoreRefreshTokens in short mode.")
+	}
+
+	ctx := identity.WithContext(context.Background(), &identity.Identity{
+		Tenant: tenant,
+	})
+	db := getDb(ctx)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	token := model.RefreshToken{
+		AuthSetID:    "aset1",
+		DeviceID:     "device1",
+		SecretSha256: []byte("secret-hash"),
+		ExpiresAt:    now.Add(time.Hour),
+		CreatedTs:    now,
+	}
+	require.NoError(t, db.AddRefreshToken(ctx, token))
+
+	// a new token replaces the previous token of the auth set
+	rotated := token
+	rotated.SecretSha256 = []byte("rotated-hash")
+	require.NoError(t, db.AddRefreshToken(ctx, rotated))
+	rotated.TenantID = tenant
+
+	// the refresh token is found without the tenant
+	res, err := db.GetRefreshToken(context.Background(), token.AuthSetID)
+	assert.NoError(t, err)
+	assert.Equal(t, &rotated, res)
+
+	err = db.DeleteRefreshToken(ctx, token.AuthSetID, token.SecretSha256)
+	assert.Equal(t, store.ErrRefreshTokenNotFound, err)
+
+	err = db.DeleteRefreshToken(ctx, token.AuthSetID, rotated.SecretSha256)
+	assert.NoError(t, err)
+
+	_, err = db.GetRefreshToken(ctx, token.AuthSetID)
+	assert.Equal(t, store.ErrRefreshTokenNotFound, err)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/pkg/errors"
+
+	mongostore "github.com/mendersoftware/mender-server/pkg/mongo/v2"
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/migrate"
+)
+
+var DbCertificateAuthoritiesCollectionIndices = []mongo.IndexModel{
+	{
+		// a certificate authority belongs to a single tenant, so that
+		// the tenant of the devices it issues certificates to is unique
+		Keys: bson.D{
+			{Key: dbFieldFingerprint, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(dbFieldFingerprint).
+			SetUnique(true),
+	},
+	{
+		Keys: bson.D{
+			{Key: dbFieldSubjectSha, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(dbFieldSubjectSha),
+	},
+	{
+		Keys: bson.D{
+			{Key: mongostore.FieldTenantID, Value: 1},
+			{Key: dbFieldCreatedTs, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(strings.Join([]string{
+				mongostore.FieldTenantID,
+				dbFieldCreatedTs,
+			}, "_")),
+	},
+}
+
+type migration_2_1_0 struct {
+	ds  *DataStoreMongo
+	ctx context.Context
+}
+
+// Up creates the indexes of the certificate authorities collection
+func (m *migration_2_1_0) Up(from migrate.Version) error {
+	_, err := m.ds.client.
+		Database(DbName).
+		Collection(DbCertificateAuthoritiesColl).
+		Indexes().
+		CreateMany(m.ctx, DbCertificateAuthoritiesCollectionIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to create certificate authorities indexes")
+	}
+	return nil
+}
+
+func (m *migration_2_1_0) Version() migrate.Version {
+	return migrate.MakeVersion(2, 1, 0)
+}
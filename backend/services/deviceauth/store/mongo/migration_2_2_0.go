@@ -0,0 +1,63 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/pkg/errors"
+
+	mongostore "github.com/mendersoftware/mender-server/pkg/mongo/v2"
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/migrate"
+)
+
+var DbAutoAcceptRulesCollectionIndices = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: mongostore.FieldTenantID, Value: 1},
+			{Key: dbFieldCreatedTs, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(strings.Join([]string{
+				mongostore.FieldTenantID,
+				dbFieldCreatedTs,
+			}, "_")),
+	},
+}
+
+type migration_2_2_0 struct {
+	ds  *DataStoreMongo
+	ctx context.Context
+}
+
+func (m *migration_2_2_0) Up(from migrate.Version) error {
+	_, err := m.ds.client.
+		Database(DbName).
+		Collection(DbAutoAcceptRulesColl).
+		Indexes().
+		CreateMany(m.ctx, DbAutoAcceptRulesCollectionIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to create auto-accept rules indexes")
+	}
+	return nil
+}
+
+func (m *migration_2_2_0) Version() migrate.Version {
+	return migrate.MakeVersion(2, 2, 0)
+}
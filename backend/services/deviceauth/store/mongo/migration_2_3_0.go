@@ -0,0 +1,59 @@
+// Copyright 2026 Northern.tech AS
+//
+//	Licensed under the Apache License, Version 2.0 (the "License");
+//	you may not use this file except in compliance with the License.
+//	You may obtain a copy of the License at
+//
+//	    http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS,
+//	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//	See the License for the specific language governing permissions and
+//	limitations under the License.
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	mopts "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/pkg/errors"
+
+	"github.com/mendersoftware/mender-server/pkg/mongo/v2/migrate"
+)
+
+var DbRefreshTokensCollectionIndices = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: dbFieldExpiresAt, Value: 1},
+		},
+		Options: mopts.Index().
+			SetName(dbFieldExpiresAt).
+			SetExpireAfterSeconds(0),
+	},
+}
+
+type migration_2_3_0 struct {
+	ds  *DataStoreMongo
+	ctx context.Context
+}
+
+// Up creates the index expiring the refresh tokens
+func (m *migration_2_3_0) Up(from migrate.Version) error {
+	_, err := m.ds.client.
+		Database(DbName).
+		Collection(DbRefreshTokensColl).
+		Indexes().
+		CreateMany(m.ctx, DbRefreshTokensCollectionIndices)
+	if err != nil {
+		return errors.Wrap(err, "failed to create refresh tokens indexes")
+	}
+	return nil
+}
+
+func (m *migration_2_3_0) Version() migrate.Version {
+	return migrate.MakeVersion(2, 3, 0)
+}
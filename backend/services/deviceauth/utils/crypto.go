@@ -25,6 +25,8 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"math/big"
+	"net/url"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -129,3 +131,41 @@ func SerializePubKey(key crypto.PublicKey) (string, error) {
 
 	return string(out), nil
 }
+
+// ParseCertificateChain parses the client certificate chain forwarded by
+// the API gateway: a comma separated, URL escaped list of either PEM
+// encoded certificates or base64 encoded DER certificates, starting with
+// the leaf certificate.
+func ParseCertificateChain(chain string) ([]*x509.Certificate, error) {
+	unescaped, err := url.QueryUnescape(chain)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode certificate chain")
+	}
+	var certs []*x509.Certificate
+	for _, part := range strings.Split(unescaped, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var der []byte
+		if block, _ := pem.Decode([]byte(part)); block != nil {
+			der = block.Bytes
+		} else {
+			der, err = base64.StdEncoding.DecodeString(
+				strings.Join(strings.Fields(part), ""),
+			)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot decode certificate")
+			}
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot decode certificate")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("empty certificate chain")
+	}
+	return certs, nil
+}
@@ -17,10 +17,19 @@ package utils
 import (
 	"crypto"
 	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -301,3 +310,77 @@ func TestSerializePubKey(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCertificateChain(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	newCert := func(cn string) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			panic(err)
+		}
+		cert, _ := x509.ParseCertificate(der)
+		return cert
+	}
+	leaf := newCert("device")
+	intermediate := newCert("intermediate")
+	encodePEM := func(cert *x509.Certificate) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+
+	testCases := map[string]struct {
+		chain string
+
+		subjects []string
+		err      string
+	}{
+		"ok, escaped PEM": {
+			chain:    url.QueryEscape(encodePEM(leaf) + "," + encodePEM(intermediate)),
+			subjects: []string{"device", "intermediate"},
+		},
+		"ok, base64 DER": {
+			chain: url.QueryEscape(base64.StdEncoding.EncodeToString(leaf.Raw) + "," +
+				base64.StdEncoding.EncodeToString(intermediate.Raw)),
+			subjects: []string{"device", "intermediate"},
+		},
+		"error, empty": {
+			chain: ",",
+			err:   "empty certificate chain",
+		},
+		"error, bad encoding": {
+			chain: "!!!!",
+			err:   "cannot decode certificate: illegal base64 data at input byte 0",
+		},
+		"error, bad certificate": {
+			chain: base64.StdEncoding.EncodeToString([]byte("garbage")),
+			err:   "cannot decode certificate: x509: malformed certificate",
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			certs, err := ParseCertificateChain(tc.chain)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			subjects := make([]string, len(certs))
+			for i, cert := range certs {
+				subjects[i] = cert.Subject.CommonName
+			}
+			assert.Equal(t, tc.subjects, subjects)
+		})
+	}
+}